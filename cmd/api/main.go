@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/shnaki/studytrack-api/internal/controller"
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
 	"github.com/shnaki/studytrack-api/internal/repository/config"
 	"github.com/shnaki/studytrack-api/internal/repository/postgres"
 	"github.com/shnaki/studytrack-api/internal/usecase"
@@ -31,6 +32,12 @@ func run() error {
 	slog.SetDefault(logger)
 
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.AuthSecret == config.DefaultAuthSecret {
+		logger.Warn("AUTH_SECRET is not set; access tokens are signed with the public development key")
+	}
 
 	// Run migrations
 	logger.Info("running migrations")
@@ -53,17 +60,82 @@ func run() error {
 	studyLogRepo := postgres.NewStudyLogRepository(pool)
 	goalRepo := postgres.NewGoalRepository(pool)
 	noteRepo := postgres.NewNoteRepository(pool)
+	patRepo := postgres.NewPersonalAccessTokenRepository(pool)
+	sessionRepo := postgres.NewSessionRepository(pool)
+	mfaRepo := postgres.NewMFAChallengeRepository(pool)
+	timerRepo := postgres.NewTimerRepository(pool)
+	pomodoroRepo := postgres.NewPomodoroRepository(pool)
+	activityTypeRepo := postgres.NewActivityTypeRepository(pool)
+	noteLinkRepo := postgres.NewNoteLinkRepository(pool)
+	milestoneRepo := postgres.NewMilestoneRepository(pool)
+	memberRepo := postgres.NewProjectMemberRepository(pool)
+
+	// Auth
+	hasher := auth.NewBcryptHasher(0)
+	tokens := auth.NewTokenManager(cfg.AuthSecret, cfg.AccessTokenTTL)
+	opaqueTokens := auth.NewOpaqueTokenGenerator()
+	totp := auth.NewTOTPProvider("StudyTrack")
 
 	// Usecases
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, userRepo, milestoneRepo, goalRepo, tokens)
+	studyLogUsecase := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, activityTypeRepo, noteLinkRepo, memberRepo)
 	usecases := &controller.Usecases{
-		User:     usecase.NewUserUsecase(userRepo),
-		Project:  usecase.NewProjectUsecase(projectRepo, userRepo),
-		StudyLog: usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo),
-		Goal:     usecase.NewGoalUsecase(goalRepo, userRepo, projectRepo),
-		Stats:    usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo),
-		Note:     usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo),
+		Auth:                usecase.NewAuthUsecase(userRepo, patRepo, sessionRepo, mfaRepo, hasher, tokens, opaqueTokens, totp, cfg.RefreshTokenTTL),
+		User:                usecase.NewUserUsecase(userRepo, hasher, tokens),
+		Project:             projectUsecase,
+		StudyLog:            studyLogUsecase,
+		StudyLogImport:      usecase.NewStudyLogImportUsecase(studyLogRepo, userRepo, projectUsecase),
+		ActivityType:        usecase.NewActivityTypeUsecase(activityTypeRepo, userRepo),
+		Milestone:           usecase.NewMilestoneUsecase(milestoneRepo, projectRepo, userRepo),
+		ProjectMember:       usecase.NewProjectMemberUsecase(memberRepo, projectRepo, userRepo, studyLogRepo),
+		ProjectClone:        usecase.NewProjectCloneUsecase(projectRepo, memberRepo, userRepo, noteRepo, goalRepo, milestoneRepo),
+		Timer:               usecase.NewTimerUsecase(timerRepo, userRepo, projectRepo, studyLogUsecase, memberRepo),
+		Pomodoro:            usecase.NewPomodoroUsecase(pomodoroRepo, userRepo, projectRepo, studyLogRepo, memberRepo),
+		Goal:                usecase.NewGoalUsecase(goalRepo, userRepo, projectRepo),
+		Stats:               usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo, userRepo, activityTypeRepo, milestoneRepo, memberRepo),
+		Note:                usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo, studyLogRepo, noteLinkRepo, memberRepo),
+		PersonalAccessToken: usecase.NewPersonalAccessTokenUsecase(patRepo, userRepo, opaqueTokens),
+		Session:             usecase.NewSessionUsecase(sessionRepo, userRepo),
+		TwoFactor:           usecase.NewTwoFactorUsecase(userRepo, totp, opaqueTokens),
+		Takeout:             usecase.NewTakeoutUsecase(userRepo, projectRepo, studyLogRepo, goalRepo, noteRepo, activityTypeRepo, noteLinkRepo, milestoneRepo),
+		Trash:               usecase.NewTrashUsecase(projectRepo, noteRepo, studyLogRepo, userRepo, cfg.TrashRetention),
 	}
 
+	// Background jobs
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	go runEvery(jobCtx, time.Minute, func(ctx context.Context) {
+		stopped, discarded, err := usecases.Timer.StopExpiredTimers(ctx)
+		if err != nil {
+			logger.Error("failed to stop expired timers", "error", err)
+		}
+		for _, timer := range discarded {
+			logger.Warn("discarded an expired timer that could not be recorded",
+				"user_id", timer.UserID, "project_id", timer.ProjectID, "reason", timer.Reason)
+		}
+		if stopped > 0 {
+			logger.Info("stopped expired timers", "count", stopped)
+		}
+	})
+	go runEvery(jobCtx, time.Minute, func(ctx context.Context) {
+		advanced, err := usecases.Pomodoro.AdvancePomodoros(ctx)
+		if err != nil {
+			logger.Error("failed to advance pomodoro sessions", "error", err)
+		}
+		if advanced > 0 {
+			logger.Info("advanced pomodoro sessions", "count", advanced)
+		}
+	})
+	go runEvery(jobCtx, time.Hour, func(ctx context.Context) {
+		purged, err := usecases.Trash.PurgeTrash(ctx)
+		if err != nil {
+			logger.Error("failed to purge trash", "error", err)
+		}
+		if purged > 0 {
+			logger.Info("purged trash", "count", purged)
+		}
+	})
+
 	// Router
 	router := controller.NewRouter(usecases, cfg.CORSOrigins, logger)
 
@@ -97,3 +169,17 @@ func run() error {
 
 	return nil
 }
+
+// runEvery calls fn every interval until ctx is cancelled.
+func runEvery(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+-- ログイン用の認証情報を users に追加
+ALTER TABLE users ADD COLUMN email VARCHAR(254);
+ALTER TABLE users ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
+
+-- 既存ユーザーにはログイン不可のダミーアドレスを割り当てる（password_hash が空のためログインできない）
+UPDATE users SET email = id::text || '@users.invalid' WHERE email IS NULL;
+
+ALTER TABLE users ALTER COLUMN email SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
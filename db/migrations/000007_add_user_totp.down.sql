@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_code_hashes;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,5 @@
+-- TOTP による二要素認証（任意）。リカバリーコードはハッシュのみ保存する
+ALTER TABLE users ADD COLUMN totp_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS default_session_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE users DROP COLUMN IF EXISTS week_start;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
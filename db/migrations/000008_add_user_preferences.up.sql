@@ -0,0 +1,5 @@
+-- ユーザー設定（タイムゾーン、週の開始曜日 0=日曜〜6=土曜、ロケール、デフォルトの学習時間）
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN week_start SMALLINT NOT NULL DEFAULT 1 CHECK (week_start BETWEEN 0 AND 6);
+ALTER TABLE users ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT 'en';
+ALTER TABLE users ADD COLUMN default_session_minutes INTEGER NOT NULL DEFAULT 60 CHECK (default_session_minutes BETWEEN 1 AND 1440);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_study_logs_user_import_key;
+ALTER TABLE study_logs DROP COLUMN IF EXISTS import_key;
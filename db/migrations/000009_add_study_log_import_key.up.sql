@@ -0,0 +1,3 @@
+-- CSV インポートで取り込んだ学習記録の重複判定キー（外部ID または内容のハッシュ）
+ALTER TABLE study_logs ADD COLUMN import_key TEXT;
+CREATE UNIQUE INDEX idx_study_logs_user_import_key ON study_logs(user_id, import_key) WHERE import_key IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS study_log_revisions;
+ALTER TABLE study_logs DROP COLUMN IF EXISTS updated_at;
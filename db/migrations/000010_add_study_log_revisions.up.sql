@@ -0,0 +1,18 @@
+-- 学習記録の編集履歴。編集のたびに編集前の値を1件保存する
+ALTER TABLE study_logs ADD COLUMN updated_at TIMESTAMPTZ;
+UPDATE study_logs SET updated_at = created_at;
+ALTER TABLE study_logs ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE study_logs ALTER COLUMN updated_at SET DEFAULT NOW();
+
+CREATE TABLE study_log_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    study_log_id UUID NOT NULL REFERENCES study_logs(id) ON DELETE CASCADE,
+    edited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    project_id UUID NOT NULL,
+    studied_at TIMESTAMPTZ NOT NULL,
+    minutes INTEGER NOT NULL,
+    note TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_study_log_revisions_study_log ON study_log_revisions(study_log_id, created_at);
@@ -0,0 +1,12 @@
+-- 計測中のタイマー（ユーザーごとに1つ）。resumed_at は一時停止中は NULL、elapsed_seconds は resumed_at より前に経過した秒数
+CREATE TABLE timers (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    resumed_at TIMESTAMPTZ,
+    elapsed_seconds INTEGER NOT NULL DEFAULT 0 CHECK (elapsed_seconds >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_timers_resumed_at ON timers(resumed_at) WHERE resumed_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pomodoro_sessions;
+ALTER TABLE study_logs DROP COLUMN IF EXISTS pomodoros;
@@ -0,0 +1,16 @@
+-- ポモドーロセッション（ユーザーごとに1つ）。完了した作業時間は pomodoros を付けて学習記録に保存する
+ALTER TABLE study_logs ADD COLUMN pomodoros INTEGER NOT NULL DEFAULT 0 CHECK (pomodoros >= 0);
+
+CREATE TABLE pomodoro_sessions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    work_minutes INTEGER NOT NULL CHECK (work_minutes BETWEEN 1 AND 180),
+    break_minutes INTEGER NOT NULL CHECK (break_minutes BETWEEN 1 AND 60),
+    cycles INTEGER NOT NULL CHECK (cycles BETWEEN 1 AND 24),
+    phase VARCHAR(10) NOT NULL CHECK (phase IN ('work', 'break')),
+    phase_started_at TIMESTAMPTZ NOT NULL,
+    completed_pomodoros INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_notes_project_created_at;
+DROP INDEX IF EXISTS idx_notes_project_updated_at;
+CREATE INDEX idx_notes_project ON notes(project_id);
+
+DROP INDEX IF EXISTS idx_goals_user_created_at;
+
+DROP INDEX IF EXISTS idx_projects_user_created_at;
+
+DROP INDEX IF EXISTS idx_study_logs_user_studied_at;
+CREATE INDEX idx_study_logs_user_studied_at ON study_logs(user_id, studied_at);
@@ -0,0 +1,11 @@
+-- 一覧のキーセットページネーション用に、並び順の列と id を含むインデックスを作成
+DROP INDEX idx_study_logs_user_studied_at;
+CREATE INDEX idx_study_logs_user_studied_at ON study_logs(user_id, studied_at, id);
+
+CREATE INDEX idx_projects_user_created_at ON projects(user_id, created_at, id);
+
+CREATE INDEX idx_goals_user_created_at ON goals(user_id, created_at, id);
+
+DROP INDEX idx_notes_project;
+CREATE INDEX idx_notes_project_updated_at ON notes(project_id, updated_at, id);
+CREATE INDEX idx_notes_project_created_at ON notes(project_id, created_at, id);
@@ -0,0 +1,4 @@
+ALTER TABLE study_log_revisions DROP COLUMN IF EXISTS activity_type_id;
+DROP INDEX IF EXISTS idx_study_logs_activity_type;
+ALTER TABLE study_logs DROP COLUMN IF EXISTS activity_type_id;
+DROP TABLE IF EXISTS activity_types;
@@ -0,0 +1,14 @@
+-- 学習の種類（読書・演習・講義・復習など）のユーザーごとの一覧。学習記録には任意で1つ付ける
+CREATE TABLE activity_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+
+ALTER TABLE study_logs ADD COLUMN activity_type_id UUID REFERENCES activity_types(id) ON DELETE SET NULL;
+CREATE INDEX idx_study_logs_activity_type ON study_logs(activity_type_id);
+
+ALTER TABLE study_log_revisions ADD COLUMN activity_type_id UUID REFERENCES activity_types(id) ON DELETE SET NULL;
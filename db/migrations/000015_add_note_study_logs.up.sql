@@ -0,0 +1,9 @@
+-- ノートと、そのノートを書いた学習記録の多対多の紐付け
+CREATE TABLE note_study_logs (
+    note_id UUID NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    study_log_id UUID NOT NULL REFERENCES study_logs(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (note_id, study_log_id)
+);
+
+CREATE INDEX idx_note_study_logs_study_log ON note_study_logs(study_log_id);
@@ -0,0 +1,18 @@
+DELETE FROM projects WHERE deleted_at IS NOT NULL;
+DELETE FROM notes WHERE deleted_at IS NOT NULL;
+DELETE FROM study_logs WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_study_logs_user_import_key;
+CREATE UNIQUE INDEX idx_study_logs_user_import_key ON study_logs(user_id, import_key) WHERE import_key IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_projects_user_name;
+ALTER TABLE projects ADD CONSTRAINT projects_user_id_name_key UNIQUE (user_id, name);
+
+DROP INDEX IF EXISTS idx_study_logs_deleted_at;
+DROP INDEX IF EXISTS idx_notes_deleted_at;
+DROP INDEX IF EXISTS idx_projects_deleted_at;
+
+ALTER TABLE goals DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE study_logs DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE notes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
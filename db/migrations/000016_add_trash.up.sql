@@ -0,0 +1,16 @@
+-- プロジェクト・ノート・学習記録の論理削除（ゴミ箱）。プロジェクトと一緒にゴミ箱へ移した子は、プロジェクトと同じ deleted_at を持つ
+ALTER TABLE projects ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE notes ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE study_logs ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE goals ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_projects_deleted_at ON projects(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_notes_deleted_at ON notes(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_study_logs_deleted_at ON study_logs(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- ゴミ箱にあるプロジェクト・学習記録と同じ名前・インポートキーで作り直せるようにする
+ALTER TABLE projects DROP CONSTRAINT projects_user_id_name_key;
+CREATE UNIQUE INDEX idx_projects_user_name ON projects(user_id, name) WHERE deleted_at IS NULL;
+
+DROP INDEX idx_study_logs_user_import_key;
+CREATE UNIQUE INDEX idx_study_logs_user_import_key ON study_logs(user_id, import_key) WHERE import_key IS NOT NULL AND deleted_at IS NULL;
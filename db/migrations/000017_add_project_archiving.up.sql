@@ -0,0 +1,2 @@
+-- 終了したプロジェクトのアーカイブ。アーカイブ中のプロジェクトは一覧・統計から外れ、新しい学習記録を受け付けない
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMPTZ;
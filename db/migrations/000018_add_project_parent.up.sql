@@ -0,0 +1,4 @@
+-- プロジェクトの階層化（サブトピック）。親が完全に削除された子はトップレベルに戻す
+ALTER TABLE projects ADD COLUMN parent_id UUID REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_projects_parent ON projects(parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS target_date;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
+ALTER TABLE projects DROP COLUMN IF EXISTS icon;
+ALTER TABLE projects DROP COLUMN IF EXISTS color;
+ALTER TABLE projects DROP COLUMN IF EXISTS description;
@@ -0,0 +1,6 @@
+-- プロジェクトの説明・色・アイコン・状態・完了目標日
+ALTER TABLE projects ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN color TEXT NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN icon TEXT NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('planned', 'active', 'paused', 'done'));
+ALTER TABLE projects ADD COLUMN target_date DATE;
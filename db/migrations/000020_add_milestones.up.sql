@@ -0,0 +1,24 @@
+-- プロジェクトのマイルストーンと、マイルストーンごとのチェックリスト
+CREATE TABLE milestones (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    due_date DATE,
+    position INTEGER NOT NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_milestones_project ON milestones(project_id, position);
+
+CREATE TABLE checklist_items (
+    id UUID PRIMARY KEY,
+    milestone_id UUID NOT NULL REFERENCES milestones(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL
+);
+
+CREATE INDEX idx_checklist_items_milestone ON checklist_items(milestone_id, position);
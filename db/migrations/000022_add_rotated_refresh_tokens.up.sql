@@ -0,0 +1,8 @@
+-- ローテーション済みのリフレッシュトークンのハッシュ。再利用されたトークンと、単に不正なトークンを区別するために保持する
+CREATE TABLE rotated_refresh_tokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rotated_refresh_tokens_session ON rotated_refresh_tokens(session_id);
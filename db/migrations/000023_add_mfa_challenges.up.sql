@@ -0,0 +1,7 @@
+-- 二要素認証の途中のログイン。認証コードの試行回数を数え、上限を超えたチャレンジは使えなくする。ユーザーごとに最新の1件のみ保持する
+CREATE TABLE mfa_challenges (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,20 @@
+-- name: CreateActivityType :exec
+INSERT INTO activity_types (id, user_id, name, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetActivityTypeByID :one
+SELECT id, user_id, name, created_at, updated_at
+FROM activity_types
+WHERE id = $1;
+
+-- name: ListActivityTypesByUserID :many
+SELECT id, user_id, name, created_at, updated_at
+FROM activity_types
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: UpdateActivityType :execresult
+UPDATE activity_types SET name = $1, updated_at = $2 WHERE id = $3;
+
+-- name: DeleteActivityType :execresult
+DELETE FROM activity_types WHERE id = $1;
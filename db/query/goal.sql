@@ -7,8 +7,21 @@ DO UPDATE SET target_minutes_per_week = EXCLUDED.target_minutes_per_week,
               end_date = EXCLUDED.end_date,
               updated_at = EXCLUDED.updated_at;
 
--- name: ListGoalsByUserID :many
-SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at
+-- name: ListGoalsByCreatedAt :many
+SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at, deleted_at
 FROM goals
-WHERE user_id = $1
-ORDER BY created_at;
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListGoalsByCreatedAtDesc :many
+SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at, deleted_at
+FROM goals
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.narg(row_limit);
+
+-- name: DeleteGoalsByUserID :execrows
+DELETE FROM goals WHERE user_id = $1;
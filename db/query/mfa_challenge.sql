@@ -0,0 +1,13 @@
+-- name: CreateMFAChallenge :exec
+-- A user has at most one challenge; logging in again replaces it.
+INSERT INTO mfa_challenges (id, user_id, attempts, expires_at)
+VALUES ($1, $2, 0, $3)
+ON CONFLICT (user_id) DO UPDATE SET id = EXCLUDED.id, attempts = 0, expires_at = EXCLUDED.expires_at;
+
+-- name: IncrementMFAChallengeAttempts :one
+UPDATE mfa_challenges SET attempts = attempts + 1
+WHERE id = $1 AND expires_at > $2
+RETURNING attempts;
+
+-- name: DeleteMFAChallenge :execresult
+DELETE FROM mfa_challenges WHERE id = $1;
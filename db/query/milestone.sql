@@ -0,0 +1,36 @@
+-- name: CreateMilestone :exec
+INSERT INTO milestones (id, project_id, user_id, title, due_date, position, completed_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetMilestoneByID :one
+SELECT id, project_id, user_id, title, due_date, position, completed_at, created_at, updated_at
+FROM milestones
+WHERE id = $1;
+
+-- name: ListMilestonesByProjectIDs :many
+SELECT id, project_id, user_id, title, due_date, position, completed_at, created_at, updated_at
+FROM milestones
+WHERE project_id = ANY(@project_ids::uuid[])
+ORDER BY project_id, position, created_at, id;
+
+-- name: UpdateMilestone :execresult
+UPDATE milestones SET title = $1, due_date = $2, completed_at = $3, updated_at = $4 WHERE id = $5;
+
+-- name: UpdateMilestonePosition :execresult
+UPDATE milestones SET position = $1 WHERE id = $2;
+
+-- name: DeleteMilestone :execresult
+DELETE FROM milestones WHERE id = $1;
+
+-- name: CreateChecklistItem :exec
+INSERT INTO checklist_items (id, milestone_id, title, done, position)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListChecklistItemsByMilestoneIDs :many
+SELECT id, milestone_id, title, done, position
+FROM checklist_items
+WHERE milestone_id = ANY(@milestone_ids::uuid[])
+ORDER BY milestone_id, position;
+
+-- name: DeleteChecklistItemsByMilestoneID :exec
+DELETE FROM checklist_items WHERE milestone_id = $1;
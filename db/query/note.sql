@@ -3,18 +3,70 @@ INSERT INTO notes (id, project_id, user_id, title, content, tags, created_at, up
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetNoteByID :one
-SELECT id, project_id, user_id, title, content, tags, created_at, updated_at
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
 FROM notes
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NULL;
 
--- name: ListNotesByProjectID :many
-SELECT id, project_id, user_id, title, content, tags, created_at, updated_at
+-- name: ListNotesByUpdatedAt :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
 FROM notes
-WHERE project_id = $1
-ORDER BY updated_at DESC;
+WHERE project_id = @project_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_updated_at)::timestamptz IS NULL OR (updated_at, id) > (sqlc.narg(after_updated_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY updated_at, id
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListNotesByUpdatedAtDesc :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = @project_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_updated_at)::timestamptz IS NULL OR (updated_at, id) < (sqlc.narg(after_updated_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY updated_at DESC, id DESC
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListNotesByCreatedAt :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = @project_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListNotesByCreatedAtDesc :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = @project_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.narg(row_limit);
 
 -- name: UpdateNote :execresult
-UPDATE notes SET title = $1, content = $2, tags = $3, updated_at = $4 WHERE id = $5;
+UPDATE notes SET title = $1, content = $2, tags = $3, updated_at = $4 WHERE id = $5 AND deleted_at IS NULL;
+
+-- name: TrashNote :execresult
+UPDATE notes SET deleted_at = @deleted_at WHERE id = @id AND deleted_at IS NULL;
+
+-- name: GetTrashedNoteByID :one
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedNotesByUserID :many
+-- Notes trashed with their project are left out; they come back when the project is restored.
+SELECT n.id, n.project_id, n.user_id, n.title, n.content, n.tags, n.created_at, n.updated_at, n.deleted_at
+FROM notes n
+JOIN projects p ON p.id = n.project_id
+WHERE n.user_id = $1 AND n.deleted_at IS NOT NULL AND p.deleted_at IS NULL
+ORDER BY n.deleted_at DESC, n.id;
+
+-- name: RestoreNote :execresult
+UPDATE notes SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedNotes :execrows
+DELETE FROM notes WHERE deleted_at < $1;
+
+-- name: DeleteNotesByUserID :execrows
+DELETE FROM notes WHERE user_id = $1;
 
--- name: DeleteNote :execresult
-DELETE FROM notes WHERE id = $1;
+-- name: DeleteNotesInProjectsOfUser :execrows
+-- Deletes the notes other members wrote in the user's projects, once the user's own are deleted.
+DELETE FROM notes WHERE project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1);
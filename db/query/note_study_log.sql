@@ -0,0 +1,21 @@
+-- name: LinkNoteStudyLog :exec
+INSERT INTO note_study_logs (note_id, study_log_id, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (note_id, study_log_id) DO NOTHING;
+
+-- name: UnlinkNoteStudyLog :execresult
+DELETE FROM note_study_logs WHERE note_id = $1 AND study_log_id = $2;
+
+-- name: ListLinkedStudyLogsByNoteIDs :many
+SELECT l.note_id, s.id, s.project_id, s.studied_at, s.minutes
+FROM note_study_logs l
+JOIN study_logs s ON s.id = l.study_log_id
+WHERE l.note_id = ANY(@note_ids::uuid[]) AND s.deleted_at IS NULL
+ORDER BY s.studied_at, s.id;
+
+-- name: ListLinkedNotesByStudyLogIDs :many
+SELECT l.study_log_id, n.id, n.project_id, n.title
+FROM note_study_logs l
+JOIN notes n ON n.id = l.note_id
+WHERE l.study_log_id = ANY(@study_log_ids::uuid[]) AND n.deleted_at IS NULL
+ORDER BY n.title, n.id;
@@ -0,0 +1,25 @@
+-- name: CreatePersonalAccessToken :exec
+INSERT INTO personal_access_tokens (id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetPersonalAccessTokenByID :one
+SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at
+FROM personal_access_tokens
+WHERE id = $1;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at
+FROM personal_access_tokens
+WHERE token_hash = $1;
+
+-- name: ListPersonalAccessTokensByUserID :many
+SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at
+FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdatePersonalAccessTokenLastUsed :exec
+UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2;
+
+-- name: DeletePersonalAccessToken :execresult
+DELETE FROM personal_access_tokens WHERE id = $1;
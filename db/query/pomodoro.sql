@@ -0,0 +1,22 @@
+-- name: CreatePomodoroSession :exec
+INSERT INTO pomodoro_sessions (user_id, project_id, note, work_minutes, break_minutes, cycles, phase, phase_started_at, completed_pomodoros, started_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: GetPomodoroSessionByUserID :one
+SELECT user_id, project_id, note, work_minutes, break_minutes, cycles, phase, phase_started_at, completed_pomodoros, started_at, updated_at
+FROM pomodoro_sessions
+WHERE user_id = $1;
+
+-- name: ListDuePomodoroSessions :many
+SELECT user_id, project_id, note, work_minutes, break_minutes, cycles, phase, phase_started_at, completed_pomodoros, started_at, updated_at
+FROM pomodoro_sessions
+WHERE phase_started_at + CASE WHEN phase = 'work' THEN work_minutes ELSE break_minutes END * INTERVAL '1 minute' <= sqlc.arg(now)::timestamptz
+ORDER BY phase_started_at;
+
+-- name: UpdatePomodoroSession :execresult
+UPDATE pomodoro_sessions
+SET phase = @phase, phase_started_at = @phase_started_at, completed_pomodoros = @completed_pomodoros, updated_at = @updated_at
+WHERE user_id = @user_id AND updated_at = @previous_updated_at;
+
+-- name: DeletePomodoroSession :execresult
+DELETE FROM pomodoro_sessions WHERE user_id = @user_id AND updated_at = @previous_updated_at;
@@ -1,20 +1,148 @@
 -- name: CreateProject :exec
-INSERT INTO projects (id, user_id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO projects (id, user_id, parent_id, name, description, color, icon, status, target_date, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: GetProjectByID :one
-SELECT id, user_id, name, created_at, updated_at
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
 FROM projects
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NULL;
 
--- name: ListProjectsByUserID :many
-SELECT id, user_id, name, created_at, updated_at
+-- name: ListProjectsByName :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
 FROM projects
-WHERE user_id = $1
-ORDER BY name;
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_name)::text IS NULL OR name > sqlc.narg(after_name)::text)
+  AND (sqlc.narg(archived)::boolean IS NULL OR (archived_at IS NOT NULL) = sqlc.narg(archived)::boolean)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY name
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListProjectsByNameDesc :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_name)::text IS NULL OR name < sqlc.narg(after_name)::text)
+  AND (sqlc.narg(archived)::boolean IS NULL OR (archived_at IS NOT NULL) = sqlc.narg(archived)::boolean)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY name DESC
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListProjectsByCreatedAt :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+  AND (sqlc.narg(archived)::boolean IS NULL OR (archived_at IS NOT NULL) = sqlc.narg(archived)::boolean)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at, id
+LIMIT sqlc.narg(row_limit);
+
+-- name: ListProjectsByCreatedAtDesc :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = @user_id AND deleted_at IS NULL
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL OR (created_at, id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+  AND (sqlc.narg(archived)::boolean IS NULL OR (archived_at IS NOT NULL) = sqlc.narg(archived)::boolean)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.narg(row_limit);
 
 -- name: UpdateProject :execresult
-UPDATE projects SET name = $1, updated_at = $2 WHERE id = $3;
+UPDATE projects
+SET name = $1, parent_id = $2, description = $3, color = $4, icon = $5, status = $6, target_date = $7, archived_at = $8, updated_at = $9
+WHERE id = $10 AND deleted_at IS NULL;
+
+-- name: TrashProject :execresult
+UPDATE projects SET deleted_at = @deleted_at WHERE id = @id AND deleted_at IS NULL;
+
+-- name: TrashNotesByProjectID :exec
+UPDATE notes SET deleted_at = @deleted_at WHERE project_id = @project_id AND deleted_at IS NULL;
+
+-- name: TrashStudyLogsByProjectID :exec
+UPDATE study_logs SET deleted_at = @deleted_at WHERE project_id = @project_id AND deleted_at IS NULL;
+
+-- name: TrashGoalsByProjectID :exec
+UPDATE goals SET deleted_at = @deleted_at WHERE project_id = @project_id AND deleted_at IS NULL;
+
+-- name: DeleteTimersByProjectID :exec
+DELETE FROM timers WHERE project_id = $1;
+
+-- name: DeletePomodoroSessionsByProjectID :exec
+DELETE FROM pomodoro_sessions WHERE project_id = $1;
+
+-- name: GetProjectDeletionImpact :one
+-- Counts what trashing the project would move to the trash with it.
+SELECT
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NULL) AS study_logs,
+    (SELECT COALESCE(SUM(s.minutes), 0) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NULL)::bigint AS total_minutes,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.deleted_at IS NULL) AS notes,
+    (SELECT COUNT(*) FROM goals g WHERE g.project_id = $1 AND g.deleted_at IS NULL) AS goals;
+
+-- name: GetTrashedProjectByID :one
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedProjectsByUserID :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id;
+
+-- name: RestoreNotesByProjectID :exec
+-- Children are restored before the project, while its deleted_at still tells which of them were trashed with it.
+UPDATE notes SET deleted_at = NULL
+WHERE project_id = @project_id AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = @project_id);
+
+-- name: RestoreStudyLogsByProjectID :exec
+UPDATE study_logs SET deleted_at = NULL
+WHERE project_id = @project_id AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = @project_id);
+
+-- name: RestoreGoalsByProjectID :exec
+UPDATE goals SET deleted_at = NULL
+WHERE project_id = @project_id AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = @project_id);
+
+-- name: RestoreProject :execresult
+UPDATE projects SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: GetProjectMergeCounts :one
+-- Counts the study logs and notes that merging the project moves although they belong to members
+-- other than the owner, or are in the trash.
+SELECT
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.user_id <> p.user_id) AS other_members_study_logs,
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NOT NULL) AS trashed_study_logs,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.user_id <> p.user_id) AS other_members_notes,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.deleted_at IS NOT NULL) AS trashed_notes
+FROM projects p
+WHERE p.id = $1;
+
+-- name: MoveStudyLogsToProject :execrows
+UPDATE study_logs SET project_id = @target_id WHERE project_id = @source_id;
+
+-- name: MoveNotesToProject :execrows
+UPDATE notes SET project_id = @target_id WHERE project_id = @source_id;
+
+-- name: MoveMilestonesToProject :execrows
+-- Moved milestones are placed after the target's own, keeping their order.
+UPDATE milestones
+SET project_id = @target_id,
+    position = position + (SELECT COALESCE(MAX(m.position) + 1, 0) FROM milestones m WHERE m.project_id = @target_id)
+WHERE milestones.project_id = @source_id;
+
+-- name: MoveTimersToProject :exec
+UPDATE timers SET project_id = @target_id WHERE project_id = @source_id;
+
+-- name: MovePomodoroSessionsToProject :exec
+UPDATE pomodoro_sessions SET project_id = @target_id WHERE project_id = @source_id;
+
+-- name: DeleteGoalsByProjectID :execrows
+DELETE FROM goals WHERE project_id = $1;
+
+-- name: DeleteProject :execrows
+DELETE FROM projects WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: PurgeTrashedProjects :execrows
+DELETE FROM projects WHERE deleted_at < $1;
 
--- name: DeleteProject :execresult
-DELETE FROM projects WHERE id = $1;
+-- name: DeleteProjectsByUserID :execrows
+DELETE FROM projects WHERE user_id = $1;
@@ -0,0 +1,27 @@
+-- name: CreateProjectMember :exec
+INSERT INTO project_members (project_id, user_id, role, invited_by, invited_at, accepted_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetProjectMember :one
+SELECT project_id, user_id, role, invited_by, invited_at, accepted_at
+FROM project_members
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: ListProjectMembersByProjectID :many
+SELECT project_id, user_id, role, invited_by, invited_at, accepted_at
+FROM project_members
+WHERE project_id = $1
+ORDER BY invited_at, user_id;
+
+-- name: ListProjectMembersByUserID :many
+SELECT m.project_id, m.user_id, m.role, m.invited_by, m.invited_at, m.accepted_at
+FROM project_members m
+JOIN projects p ON p.id = m.project_id
+WHERE m.user_id = $1 AND p.deleted_at IS NULL
+ORDER BY m.invited_at, m.project_id;
+
+-- name: UpdateProjectMember :execresult
+UPDATE project_members SET role = $1, accepted_at = $2 WHERE project_id = $3 AND user_id = $4;
+
+-- name: DeleteProjectMember :execresult
+DELETE FROM project_members WHERE project_id = $1 AND user_id = $2;
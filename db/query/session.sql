@@ -0,0 +1,36 @@
+-- name: CreateSession :exec
+INSERT INTO sessions (id, user_id, refresh_token_hash, user_agent, ip_address, created_at, last_used_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetSessionByID :one
+SELECT id, user_id, refresh_token_hash, user_agent, ip_address, created_at, last_used_at, expires_at
+FROM sessions
+WHERE id = $1;
+
+-- name: ListSessionsByUserID :many
+SELECT id, user_id, refresh_token_hash, user_agent, ip_address, created_at, last_used_at, expires_at
+FROM sessions
+WHERE user_id = $1
+ORDER BY last_used_at DESC;
+
+-- name: RotateSession :execresult
+-- Replaces the refresh token and records the previous one as rotated out, in a single statement.
+WITH rotated AS (
+    UPDATE sessions
+    SET refresh_token_hash = @refresh_token_hash, ip_address = @ip_address, last_used_at = @last_used_at, expires_at = @expires_at
+    WHERE id = @id AND refresh_token_hash = @previous_refresh_token_hash
+    RETURNING id
+)
+INSERT INTO rotated_refresh_tokens (token_hash, session_id, rotated_at)
+SELECT @previous_refresh_token_hash, id, @last_used_at FROM rotated;
+
+-- name: IsRotatedRefreshToken :one
+SELECT EXISTS (
+    SELECT 1 FROM rotated_refresh_tokens WHERE session_id = $1 AND token_hash = $2
+);
+
+-- name: DeleteSession :execresult
+DELETE FROM sessions WHERE id = $1;
+
+-- name: DeleteSessionsByUserID :exec
+DELETE FROM sessions WHERE user_id = $1;
@@ -1,11 +1,64 @@
 -- name: CreateStudyLog :exec
-INSERT INTO study_logs (id, user_id, project_id, studied_at, minutes, note, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO study_logs (id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, import_key, created_at, updated_at)
+VALUES (@id, @user_id, @project_id, sqlc.narg(activity_type_id), @studied_at, @minutes, @note, @pomodoros, sqlc.narg(import_key), @created_at, @updated_at);
+
+-- name: CreateStudyLogs :batchexec
+INSERT INTO study_logs (id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at)
+VALUES (@id, @user_id, @project_id, sqlc.narg(activity_type_id), @studied_at, @minutes, @note, @pomodoros, @created_at, @updated_at);
 
 -- name: GetStudyLogByID :one
-SELECT id, user_id, project_id, studied_at, minutes, note, created_at
+SELECT id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at
+FROM study_logs
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateStudyLog :execresult
+UPDATE study_logs SET project_id = $1, activity_type_id = $2, studied_at = $3, minutes = $4, note = $5, updated_at = $6 WHERE id = $7 AND deleted_at IS NULL;
+
+-- name: TrashStudyLog :execresult
+UPDATE study_logs SET deleted_at = @deleted_at WHERE id = @id AND deleted_at IS NULL;
+
+-- name: GetTrashedStudyLogByID :one
+SELECT id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at, deleted_at
+FROM study_logs
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedStudyLogsByUserID :many
+-- Logs trashed with their project are left out; they come back when the project is restored.
+SELECT l.id, l.user_id, l.project_id, l.activity_type_id, l.studied_at, l.minutes, l.note, l.pomodoros, l.created_at, l.updated_at, l.deleted_at
+FROM study_logs l
+JOIN projects p ON p.id = l.project_id
+WHERE l.user_id = $1 AND l.deleted_at IS NOT NULL AND p.deleted_at IS NULL
+ORDER BY l.deleted_at DESC, l.id;
+
+-- name: RestoreStudyLog :execresult
+UPDATE study_logs SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedStudyLogs :execrows
+DELETE FROM study_logs WHERE deleted_at < $1;
+
+-- name: DeleteStudyLogsByUserID :execrows
+DELETE FROM study_logs WHERE user_id = $1;
+
+-- name: DeleteStudyLogsInProjectsOfUser :execrows
+-- Deletes the study logs other members wrote in the user's projects, once the user's own are deleted.
+DELETE FROM study_logs WHERE project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1);
+
+-- name: ListStudyLogImportKeys :many
+SELECT import_key::text
 FROM study_logs
+WHERE user_id = @user_id AND import_key = ANY(@import_keys::text[]) AND deleted_at IS NULL;
+
+-- name: CreateStudyLogRevision :exec
+INSERT INTO study_log_revisions (id, study_log_id, edited_by, project_id, activity_type_id, studied_at, minutes, note, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetStudyLogRevisionByID :one
+SELECT id, study_log_id, edited_by, project_id, studied_at, minutes, note, created_at, activity_type_id
+FROM study_log_revisions
 WHERE id = $1;
 
--- name: DeleteStudyLog :execresult
-DELETE FROM study_logs WHERE id = $1;
+-- name: ListStudyLogRevisions :many
+SELECT id, study_log_id, edited_by, project_id, studied_at, minutes, note, created_at, activity_type_id
+FROM study_log_revisions
+WHERE study_log_id = $1
+ORDER BY created_at DESC, id;
@@ -0,0 +1,22 @@
+-- name: CreateTimer :exec
+INSERT INTO timers (user_id, project_id, note, started_at, resumed_at, elapsed_seconds, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetTimerByUserID :one
+SELECT user_id, project_id, note, started_at, resumed_at, elapsed_seconds, updated_at
+FROM timers
+WHERE user_id = $1;
+
+-- name: UpdateTimer :execresult
+UPDATE timers SET resumed_at = $1, elapsed_seconds = $2, updated_at = $3
+WHERE user_id = $4 AND started_at = $5;
+
+-- name: DeleteTimer :execresult
+DELETE FROM timers WHERE user_id = $1 AND started_at = $2;
+
+-- name: ListExpiredTimers :many
+SELECT user_id, project_id, note, started_at, resumed_at, elapsed_seconds, updated_at
+FROM timers
+WHERE resumed_at IS NOT NULL
+  AND elapsed_seconds + EXTRACT(EPOCH FROM sqlc.arg(now)::timestamptz - resumed_at) >= sqlc.arg(max_seconds)::integer
+ORDER BY resumed_at;
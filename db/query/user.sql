@@ -1,8 +1,41 @@
 -- name: CreateUser :exec
-INSERT INTO users (id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4);
+INSERT INTO users (id, name, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes, created_at, updated_at)
+VALUES (@id, @name, @email, @password_hash, @totp_secret, @totp_enabled, @totp_last_step, @recovery_code_hashes, @timezone, @week_start, @locale, @default_session_minutes, @created_at, @updated_at);
 
 -- name: GetUserByID :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes
 FROM users
 WHERE id = $1;
+
+-- name: GetUserByEmail :one
+SELECT id, name, created_at, updated_at, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes
+FROM users
+WHERE email = $1;
+
+-- name: UpdateUser :execresult
+UPDATE users
+SET name = @name, email = @email, password_hash = @password_hash,
+    totp_secret = @totp_secret, totp_enabled = @totp_enabled, totp_last_step = @totp_last_step, recovery_code_hashes = @recovery_code_hashes,
+    timezone = @timezone, week_start = @week_start, locale = @locale, default_session_minutes = @default_session_minutes,
+    updated_at = @updated_at
+WHERE id = @id;
+
+-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1;
+
+-- name: GetUserDeletionImpact :one
+-- Counts what deleting the user would remove, as DeleteUser's transaction does: the user's own
+-- study logs and notes, and those other members wrote in the user's projects.
+SELECT
+    (SELECT COUNT(*) FROM projects p WHERE p.user_id = $1) AS projects,
+    (SELECT COUNT(*) FROM study_logs s
+     WHERE s.user_id = $1 OR s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS study_logs,
+    (SELECT COALESCE(SUM(s.minutes), 0) FROM study_logs s
+     WHERE s.user_id = $1 OR s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1))::bigint AS total_minutes,
+    (SELECT COUNT(*) FROM study_logs s
+     WHERE s.user_id <> $1 AND s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS other_members_study_logs,
+    (SELECT COUNT(*) FROM notes n
+     WHERE n.user_id = $1 OR n.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS notes,
+    (SELECT COUNT(*) FROM notes n
+     WHERE n.user_id <> $1 AND n.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS other_members_notes,
+    (SELECT COUNT(*) FROM goals g WHERE g.user_id = $1) AS goals;
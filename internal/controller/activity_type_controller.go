@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type createActivityTypeInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.CreateActivityTypeRequest
+}
+
+type activityTypeOutput struct {
+	Body dto.ActivityTypeResponse
+}
+
+type listActivityTypesInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type listActivityTypesOutput struct {
+	Body []dto.ActivityTypeResponse
+}
+
+type updateActivityTypeInput struct {
+	ID   string `path:"id" doc:"Activity type ID"`
+	Body dto.UpdateActivityTypeRequest
+}
+
+type deleteActivityTypeInput struct {
+	ID string `path:"id" doc:"Activity type ID"`
+}
+
+// RegisterActivityTypeRoutes registers activity type-related routes to the Huma API.
+func RegisterActivityTypeRoutes(api huma.API, uc *usecase.ActivityTypeUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-activity-type",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/activity-types",
+		Summary:       "Create an activity type",
+		Description:   "Adds a kind of study, such as reading or exercises, to the user's catalogue. Study logs can be tagged with it.",
+		Tags:          []string{"ActivityTypes"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *createActivityTypeInput) (*activityTypeOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		activityType, err := uc.CreateActivityType(ctx, input.UserID, input.Body.Name)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &activityTypeOutput{Body: dto.ToActivityTypeResponse(activityType)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-activity-types",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/activity-types",
+		Summary:     "List activity types for a user",
+		Tags:        []string{"ActivityTypes"},
+		Security:    bearerAuth(domain.ScopeStudyLogsRead),
+	}, func(ctx context.Context, input *listActivityTypesInput) (*listActivityTypesOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		activityTypes, err := uc.ListActivityTypes(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &listActivityTypesOutput{Body: dto.ToActivityTypeResponseList(activityTypes)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-activity-type",
+		Method:      http.MethodPut,
+		Path:        "/activity-types/{id}",
+		Summary:     "Rename an activity type",
+		Tags:        []string{"ActivityTypes"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *updateActivityTypeInput) (*activityTypeOutput, error) {
+		activityType, err := uc.RenameActivityType(ctx, authUserID(ctx), input.ID, input.Body.Name)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &activityTypeOutput{Body: dto.ToActivityTypeResponse(activityType)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-activity-type",
+		Method:        http.MethodDelete,
+		Path:          "/activity-types/{id}",
+		Summary:       "Delete an activity type",
+		Description:   "The study logs tagged with the activity type are kept without one.",
+		Tags:          []string{"ActivityTypes"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *deleteActivityTypeInput) (*struct{}, error) {
+		if err := uc.DeleteActivityType(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
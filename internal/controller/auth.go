@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+// bearerAuth returns the security requirement for operations that need an authenticated user.
+// Personal access tokens must have been granted every listed scope.
+func bearerAuth(scopes ...string) []map[string][]string {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return []map[string][]string{{"bearer": scopes}}
+}
+
+type principalKey struct{}
+
+// authMiddleware authenticates requests to operations that declare a security requirement,
+// checks the scopes they require and stores the authenticated principal in the request context.
+func authMiddleware(api huma.API, uc *usecase.AuthUsecase) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if len(ctx.Operation().Security) == 0 {
+			next(ctx)
+			return
+		}
+
+		scheme, token, _ := strings.Cut(ctx.Header("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			ctx.SetHeader("WWW-Authenticate", "Bearer")
+			_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		principal, err := uc.Authenticate(ctx.Context(), token)
+		if err != nil {
+			if domain.IsUnauthorized(err) {
+				ctx.SetHeader("WWW-Authenticate", `Bearer error="invalid_token"`)
+				_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, err.Error())
+				return
+			}
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		for _, requirement := range ctx.Operation().Security {
+			for _, scope := range requirement["bearer"] {
+				if !principal.HasScope(scope) {
+					ctx.SetHeader("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+					_ = huma.WriteErr(api, ctx, http.StatusForbidden, "token is missing required scope: "+scope)
+					return
+				}
+			}
+		}
+
+		next(huma.WithValue(ctx, principalKey{}, principal))
+	}
+}
+
+// authPrincipal returns the principal authenticated by authMiddleware.
+func authPrincipal(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	if principal == nil {
+		return &domain.Principal{}
+	}
+	return principal
+}
+
+// authUserID returns the ID of the user authenticated by authMiddleware.
+func authUserID(ctx context.Context) string {
+	return authPrincipal(ctx).UserID
+}
+
+// requireUser ensures the authenticated user is the user addressed by the request path.
+func requireUser(ctx context.Context, userID string) error {
+	if authUserID(ctx) != userID {
+		return huma.Error403Forbidden("cannot access another user's resources")
+	}
+	return nil
+}
+
+// requirePasswordLogin rejects callers using a personal access token, so that a leaked
+// token cannot be used to mint further tokens.
+func requirePasswordLogin(ctx context.Context) error {
+	if authPrincipal(ctx).IsPersonalAccessToken() {
+		return huma.Error403Forbidden("personal access tokens cannot be used for this operation")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type loginInput struct {
+	UserAgent string `header:"User-Agent" doc:"Client user agent, recorded with the session"`
+	Body      dto.LoginRequest
+	ipAddress string
+}
+
+// Resolve records the client IP address, which is not available as an input parameter.
+func (i *loginInput) Resolve(ctx huma.Context) []error {
+	i.ipAddress = remoteIP(ctx)
+	return nil
+}
+
+type refreshInput struct {
+	Body      dto.RefreshRequest
+	ipAddress string
+}
+
+// Resolve records the client IP address, which is not available as an input parameter.
+func (i *refreshInput) Resolve(ctx huma.Context) []error {
+	i.ipAddress = remoteIP(ctx)
+	return nil
+}
+
+type mfaLoginInput struct {
+	UserAgent string `header:"User-Agent" doc:"Client user agent, recorded with the session"`
+	Body      dto.MFALoginRequest
+	ipAddress string
+}
+
+// Resolve records the client IP address, which is not available as an input parameter.
+func (i *mfaLoginInput) Resolve(ctx huma.Context) []error {
+	i.ipAddress = remoteIP(ctx)
+	return nil
+}
+
+type loginOutput struct {
+	Body dto.LoginResponse
+}
+
+type authTokenOutput struct {
+	Body dto.AuthTokenResponse
+}
+
+// RegisterAuthRoutes registers authentication-related routes to the Huma API.
+func RegisterAuthRoutes(api huma.API, uc *usecase.AuthUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "login",
+		Method:      http.MethodPost,
+		Path:        "/auth/login",
+		Summary:     "Log in with email and password",
+		Description: "If two-factor authentication is enabled, no tokens are issued; instead mfaRequired is true and the returned mfaToken must be sent to POST /auth/login/mfa with a code.",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusUnauthorized},
+	}, func(ctx context.Context, input *loginInput) (*loginOutput, error) {
+		result, err := uc.Login(ctx, input.Body.Email, input.Body.Password, input.UserAgent, input.ipAddress)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &loginOutput{Body: dto.ToLoginResponse(result)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "login-mfa",
+		Method:      http.MethodPost,
+		Path:        "/auth/login/mfa",
+		Summary:     "Complete a login with a second factor",
+		Description: "Accepts a TOTP code from the authenticator app or one of the recovery codes. Each recovery code can be used once.",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusUnauthorized},
+	}, func(ctx context.Context, input *mfaLoginInput) (*authTokenOutput, error) {
+		token, err := uc.CompleteMFALogin(ctx, input.Body.MFAToken, input.Body.Code, input.UserAgent, input.ipAddress)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &authTokenOutput{Body: dto.ToAuthTokenResponse(token)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "refresh-token",
+		Method:      http.MethodPost,
+		Path:        "/auth/refresh",
+		Summary:     "Exchange a refresh token for new tokens",
+		Description: "Each refresh token can be used once. Reusing a rotated refresh token revokes the whole session.",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusUnauthorized},
+	}, func(ctx context.Context, input *refreshInput) (*authTokenOutput, error) {
+		token, err := uc.Refresh(ctx, input.Body.RefreshToken, input.ipAddress)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &authTokenOutput{Body: dto.ToAuthTokenResponse(token)}, nil
+	})
+}
+
+// remoteIP returns the client IP address without the port.
+func remoteIP(ctx huma.Context) string {
+	addr := ctx.RemoteAddr()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
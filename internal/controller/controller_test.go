@@ -1,18 +1,30 @@
 package controller_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/shnaki/studytrack-api/internal/controller"
 	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 	"github.com/shnaki/studytrack-api/internal/usecase/port"
 )
@@ -21,6 +33,14 @@ import (
 
 type mockUserRepository struct {
 	users map[string]*domain.User
+	// Repositories whose records are removed together with a user.
+	projectRepo  *mockProjectRepository
+	studyLogRepo *mockStudyLogRepository
+	goalRepo     *mockGoalRepository
+	noteRepo     *mockNoteRepository
+	sessionRepo  *mockSessionRepository
+	timerRepo    *mockTimerRepository
+	pomodoroRepo *mockPomodoroRepository
 }
 
 func newMockUserRepo() *mockUserRepository {
@@ -28,6 +48,11 @@ func newMockUserRepo() *mockUserRepository {
 }
 
 func (m *mockUserRepository) Create(_ context.Context, user *domain.User) error {
+	for _, u := range m.users {
+		if u.Email == user.Email {
+			return domain.ErrConflict("user with this email already exists")
+		}
+	}
 	m.users[user.ID] = user
 	return nil
 }
@@ -40,12 +65,149 @@ func (m *mockUserRepository) FindByID(_ context.Context, id string) (*domain.Use
 	return u, nil
 }
 
+func (m *mockUserRepository) FindByEmail(_ context.Context, email string) (*domain.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, domain.ErrNotFound("user")
+}
+
+func (m *mockUserRepository) Update(_ context.Context, user *domain.User) error {
+	if _, ok := m.users[user.ID]; !ok {
+		return domain.ErrNotFound("user")
+	}
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepository) Delete(ctx context.Context, id string) (*domain.UserDeletionSummary, error) {
+	if _, ok := m.users[id]; !ok {
+		return nil, domain.ErrNotFound("user")
+	}
+	summary := &domain.UserDeletionSummary{}
+	for key, n := range m.noteRepo.notes {
+		if n.UserID == id || m.ownsProject(id, n.ProjectID) {
+			delete(m.noteRepo.notes, key)
+			summary.Notes++
+			if n.UserID != id {
+				summary.OtherMembersNotes++
+			}
+		}
+	}
+	for key, l := range m.studyLogRepo.logs {
+		if l.UserID == id || m.ownsProject(id, l.ProjectID) {
+			delete(m.studyLogRepo.logs, key)
+			summary.StudyLogs++
+			if l.UserID != id {
+				summary.OtherMembersStudyLogs++
+			}
+		}
+	}
+	for key, g := range m.goalRepo.goals {
+		if g.UserID == id {
+			delete(m.goalRepo.goals, key)
+			summary.Goals++
+		}
+	}
+	for key, p := range m.projectRepo.projects {
+		if p.UserID == id {
+			delete(m.projectRepo.projects, key)
+			summary.Projects++
+		}
+	}
+	_ = m.sessionRepo.DeleteByUserID(ctx, id)
+	delete(m.timerRepo.timers, id)
+	delete(m.pomodoroRepo.sessions, id)
+	delete(m.users, id)
+	return summary, nil
+}
+
+func (m *mockUserRepository) DeletionImpact(_ context.Context, id string) (*domain.DeletionImpact, error) {
+	impact := &domain.DeletionImpact{}
+	for _, p := range m.projectRepo.projects {
+		if p.UserID == id {
+			impact.Projects++
+		}
+	}
+	for _, l := range m.studyLogRepo.logs {
+		if l.UserID == id || m.ownsProject(id, l.ProjectID) {
+			impact.StudyLogs++
+			impact.TotalMinutes += l.Minutes
+			if l.UserID != id {
+				impact.OtherMembersStudyLogs++
+			}
+		}
+	}
+	for _, n := range m.noteRepo.notes {
+		if n.UserID == id || m.ownsProject(id, n.ProjectID) {
+			impact.Notes++
+			if n.UserID != id {
+				impact.OtherMembersNotes++
+			}
+		}
+	}
+	for _, g := range m.goalRepo.goals {
+		if g.UserID == id {
+			impact.Goals++
+		}
+	}
+	return impact, nil
+}
+
+// ownsProject reports whether the project belongs to the user, whose projects are deleted with it.
+func (m *mockUserRepository) ownsProject(userID, projectID string) bool {
+	p, ok := m.projectRepo.projects[projectID]
+	return ok && p.UserID == userID
+}
+
+// mockPage sorts items in the order of the page and returns those after its cursor, up to its limit.
+func mockPage[T any](items []T, page port.PageRequest, cursorOf func(T) domain.Cursor) []T {
+	compare := func(a, b domain.Cursor) int {
+		c := a.Time.Compare(b.Time)
+		if c == 0 {
+			c = strings.Compare(a.Text, b.Text)
+		}
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if page.Sort.Descending {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(items, func(a, b T) int { return compare(cursorOf(a), cursorOf(b)) })
+	if page.After != nil {
+		items = slices.DeleteFunc(items, func(item T) bool { return compare(cursorOf(item), *page.After) <= 0 })
+	}
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
 type mockProjectRepository struct {
 	projects map[string]*domain.Project
+	trashed  map[string]*domain.Project
+	// Repositories whose records are trashed and restored together with a project.
+	noteRepo     *mockNoteRepository
+	studyLogRepo *mockStudyLogRepository
+	// Repositories a copied project's goal and milestones are created in.
+	goalRepo      *mockGoalRepository
+	milestoneRepo *mockMilestoneRepository
+	// Notes and study logs trashed together with a project, keyed by project ID.
+	trashedNotes map[string][]*domain.Note
+	trashedLogs  map[string][]*domain.StudyLog
 }
 
 func newMockProjectRepo() *mockProjectRepository {
-	return &mockProjectRepository{projects: make(map[string]*domain.Project)}
+	return &mockProjectRepository{
+		projects:     make(map[string]*domain.Project),
+		trashed:      make(map[string]*domain.Project),
+		trashedNotes: make(map[string][]*domain.Note),
+		trashedLogs:  make(map[string][]*domain.StudyLog),
+	}
 }
 
 func (m *mockProjectRepository) Create(_ context.Context, p *domain.Project) error {
@@ -61,14 +223,19 @@ func (m *mockProjectRepository) FindByID(_ context.Context, id string) (*domain.
 	return p, nil
 }
 
-func (m *mockProjectRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Project, error) {
+func (m *mockProjectRepository) FindByUserID(_ context.Context, userID string, filter port.ProjectFilter, page port.PageRequest) ([]*domain.Project, error) {
 	var result []*domain.Project
 	for _, p := range m.projects {
-		if p.UserID == userID {
+		if p.UserID == userID && (filter.Archived == nil || p.IsArchived() == *filter.Archived) && (filter.Status == "" || p.Status == filter.Status) {
 			result = append(result, p)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(p *domain.Project) domain.Cursor {
+		if page.Sort.Key == domain.SortByCreatedAt {
+			return domain.Cursor{Time: p.CreatedAt, ID: p.ID}
+		}
+		return domain.Cursor{Text: p.Name, ID: p.ID}
+	}), nil
 }
 
 func (m *mockProjectRepository) Update(_ context.Context, p *domain.Project) error {
@@ -76,17 +243,376 @@ func (m *mockProjectRepository) Update(_ context.Context, p *domain.Project) err
 	return nil
 }
 
-func (m *mockProjectRepository) Delete(_ context.Context, id string) error {
+func (m *mockProjectRepository) CreateCopy(_ context.Context, c *domain.ProjectCopy) error {
+	for _, existing := range m.projects {
+		if existing.UserID == c.Project.UserID && existing.Name == c.Project.Name {
+			return domain.ErrConflict("project with this name already exists for this user")
+		}
+	}
+	m.projects[c.Project.ID] = c.Project
+	for _, n := range c.Notes {
+		m.noteRepo.notes[n.ID] = n
+	}
+	if c.Goal != nil {
+		m.goalRepo.goals[c.Goal.UserID+":"+c.Goal.ProjectID] = c.Goal
+	}
+	for _, milestone := range c.Milestones {
+		m.milestoneRepo.milestones[milestone.ID] = milestone
+	}
+	return nil
+}
+
+func (m *mockProjectRepository) Merge(_ context.Context, sourceID, targetID string, goal *domain.Goal) (*domain.ProjectMergeSummary, error) {
+	if _, ok := m.projects[sourceID]; !ok {
+		return nil, domain.ErrNotFound("project")
+	}
+	ownerID := m.projects[sourceID].UserID
+	summary := &domain.ProjectMergeSummary{}
+	for _, l := range append(slices.Collect(maps.Values(m.studyLogRepo.logs)), slices.Collect(maps.Values(m.studyLogRepo.trashed))...) {
+		if l.ProjectID == sourceID {
+			l.ProjectID = targetID
+			summary.StudyLogs++
+			if l.UserID != ownerID {
+				summary.OtherMembersStudyLogs++
+			}
+			if l.DeletedAt != nil {
+				summary.TrashedStudyLogs++
+			}
+		}
+	}
+	for _, n := range append(slices.Collect(maps.Values(m.noteRepo.notes)), slices.Collect(maps.Values(m.noteRepo.trashed))...) {
+		if n.ProjectID == sourceID {
+			n.ProjectID = targetID
+			summary.Notes++
+			if n.UserID != ownerID {
+				summary.OtherMembersNotes++
+			}
+			if n.DeletedAt != nil {
+				summary.TrashedNotes++
+			}
+		}
+	}
+	for _, milestone := range m.milestoneRepo.milestones {
+		if milestone.ProjectID == sourceID {
+			milestone.ProjectID = targetID
+			summary.Milestones++
+		}
+	}
+	for key, g := range m.goalRepo.goals {
+		if g.ProjectID == sourceID {
+			delete(m.goalRepo.goals, key)
+			summary.Goals++
+		}
+	}
+	if goal != nil {
+		m.goalRepo.goals[goal.UserID+":"+goal.ProjectID] = goal
+	}
+	delete(m.projects, sourceID)
+	return summary, nil
+}
+
+func (m *mockProjectRepository) Trash(_ context.Context, id string, at time.Time) error {
+	p, ok := m.projects[id]
+	if !ok {
+		return domain.ErrNotFound("project")
+	}
+	p.DeletedAt = &at
 	delete(m.projects, id)
+	m.trashed[id] = p
+	for key, n := range m.noteRepo.notes {
+		if n.ProjectID == id {
+			n.DeletedAt = &at
+			delete(m.noteRepo.notes, key)
+			m.trashedNotes[id] = append(m.trashedNotes[id], n)
+		}
+	}
+	for key, l := range m.studyLogRepo.logs {
+		if l.ProjectID == id {
+			l.DeletedAt = &at
+			delete(m.studyLogRepo.logs, key)
+			m.trashedLogs[id] = append(m.trashedLogs[id], l)
+		}
+	}
+	return nil
+}
+
+func (m *mockProjectRepository) DeletionImpact(_ context.Context, id string) (*domain.DeletionImpact, error) {
+	impact := &domain.DeletionImpact{}
+	for _, l := range m.studyLogRepo.logs {
+		if l.ProjectID == id {
+			impact.StudyLogs++
+			impact.TotalMinutes += l.Minutes
+		}
+	}
+	for _, n := range m.noteRepo.notes {
+		if n.ProjectID == id {
+			impact.Notes++
+		}
+	}
+	for _, g := range m.goalRepo.goals {
+		if g.ProjectID == id {
+			impact.Goals++
+		}
+	}
+	return impact, nil
+}
+
+func (m *mockProjectRepository) FindTrashedByID(_ context.Context, id string) (*domain.Project, error) {
+	p, ok := m.trashed[id]
+	if !ok {
+		return nil, domain.ErrNotFound("project")
+	}
+	return p, nil
+}
+
+func (m *mockProjectRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.Project, error) {
+	var result []*domain.Project
+	for _, p := range m.trashed {
+		if p.UserID == userID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockProjectRepository) Restore(_ context.Context, id string) error {
+	p, ok := m.trashed[id]
+	if !ok {
+		return domain.ErrNotFound("project")
+	}
+	for _, existing := range m.projects {
+		if existing.UserID == p.UserID && existing.Name == p.Name {
+			return domain.ErrConflict("project with this name already exists for this user")
+		}
+	}
+	p.DeletedAt = nil
+	delete(m.trashed, id)
+	m.projects[id] = p
+	for _, n := range m.trashedNotes[id] {
+		n.DeletedAt = nil
+		m.noteRepo.notes[n.ID] = n
+	}
+	for _, l := range m.trashedLogs[id] {
+		l.DeletedAt = nil
+		m.studyLogRepo.logs[l.ID] = l
+	}
+	delete(m.trashedNotes, id)
+	delete(m.trashedLogs, id)
+	return nil
+}
+
+func (m *mockProjectRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, p := range m.trashed {
+		if p.DeletedAt.Before(before) {
+			delete(m.trashed, id)
+			delete(m.trashedNotes, id)
+			delete(m.trashedLogs, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+type mockMilestoneRepository struct {
+	milestones map[string]*domain.Milestone
+}
+
+func newMockMilestoneRepo() *mockMilestoneRepository {
+	return &mockMilestoneRepository{milestones: make(map[string]*domain.Milestone)}
+}
+
+func (m *mockMilestoneRepository) Create(_ context.Context, milestone *domain.Milestone) error {
+	m.milestones[milestone.ID] = milestone
+	return nil
+}
+
+func (m *mockMilestoneRepository) FindByID(_ context.Context, id string) (*domain.Milestone, error) {
+	milestone, ok := m.milestones[id]
+	if !ok {
+		return nil, domain.ErrNotFound("milestone")
+	}
+	return milestone, nil
+}
+
+func (m *mockMilestoneRepository) FindByProjectIDs(_ context.Context, projectIDs []string) ([]*domain.Milestone, error) {
+	var result []*domain.Milestone
+	for _, milestone := range m.milestones {
+		if slices.Contains(projectIDs, milestone.ProjectID) {
+			result = append(result, milestone)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.Milestone) int {
+		if c := strings.Compare(a.ProjectID, b.ProjectID); c != 0 {
+			return c
+		}
+		return a.Position - b.Position
+	})
+	return result, nil
+}
+
+func (m *mockMilestoneRepository) Update(_ context.Context, milestone *domain.Milestone) error {
+	if _, ok := m.milestones[milestone.ID]; !ok {
+		return domain.ErrNotFound("milestone")
+	}
+	m.milestones[milestone.ID] = milestone
+	return nil
+}
+
+func (m *mockMilestoneRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.milestones[id]; !ok {
+		return domain.ErrNotFound("milestone")
+	}
+	delete(m.milestones, id)
+	return nil
+}
+
+func (m *mockMilestoneRepository) Reorder(_ context.Context, ids []string) error {
+	for i, id := range ids {
+		milestone, ok := m.milestones[id]
+		if !ok {
+			return domain.ErrNotFound("milestone")
+		}
+		milestone.Position = i
+	}
+	return nil
+}
+
+type mockProjectMemberRepository struct {
+	members map[string]*domain.ProjectMember
+}
+
+func newMockProjectMemberRepo() *mockProjectMemberRepository {
+	return &mockProjectMemberRepository{members: make(map[string]*domain.ProjectMember)}
+}
+
+func projectMemberKey(projectID, userID string) string {
+	return projectID + "/" + userID
+}
+
+func (m *mockProjectMemberRepository) Create(_ context.Context, member *domain.ProjectMember) error {
+	key := projectMemberKey(member.ProjectID, member.UserID)
+	if _, ok := m.members[key]; ok {
+		return domain.ErrConflict("the user is already a member of the project or invited to it")
+	}
+	m.members[key] = member
+	return nil
+}
+
+func (m *mockProjectMemberRepository) Find(_ context.Context, projectID, userID string) (*domain.ProjectMember, error) {
+	member, ok := m.members[projectMemberKey(projectID, userID)]
+	if !ok {
+		return nil, domain.ErrNotFound("project member")
+	}
+	return member, nil
+}
+
+func (m *mockProjectMemberRepository) FindByProjectID(_ context.Context, projectID string) ([]*domain.ProjectMember, error) {
+	return m.filter(func(member *domain.ProjectMember) bool { return member.ProjectID == projectID }), nil
+}
+
+func (m *mockProjectMemberRepository) FindByUserID(_ context.Context, userID string) ([]*domain.ProjectMember, error) {
+	return m.filter(func(member *domain.ProjectMember) bool { return member.UserID == userID }), nil
+}
+
+func (m *mockProjectMemberRepository) filter(match func(*domain.ProjectMember) bool) []*domain.ProjectMember {
+	result := []*domain.ProjectMember{}
+	for _, member := range m.members {
+		if match(member) {
+			result = append(result, member)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.ProjectMember) int {
+		if c := a.InvitedAt.Compare(b.InvitedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return result
+}
+
+func (m *mockProjectMemberRepository) Update(_ context.Context, member *domain.ProjectMember) error {
+	key := projectMemberKey(member.ProjectID, member.UserID)
+	if _, ok := m.members[key]; !ok {
+		return domain.ErrNotFound("project member")
+	}
+	m.members[key] = member
+	return nil
+}
+
+func (m *mockProjectMemberRepository) Delete(_ context.Context, projectID, userID string) error {
+	key := projectMemberKey(projectID, userID)
+	if _, ok := m.members[key]; !ok {
+		return domain.ErrNotFound("project member")
+	}
+	delete(m.members, key)
+	return nil
+}
+
+type mockActivityTypeRepository struct {
+	activityTypes map[string]*domain.ActivityType
+}
+
+func newMockActivityTypeRepo() *mockActivityTypeRepository {
+	return &mockActivityTypeRepository{activityTypes: make(map[string]*domain.ActivityType)}
+}
+
+func (m *mockActivityTypeRepository) Create(_ context.Context, a *domain.ActivityType) error {
+	for _, existing := range m.activityTypes {
+		if existing.UserID == a.UserID && existing.Name == a.Name {
+			return domain.ErrConflict("activity type with this name already exists for this user")
+		}
+	}
+	m.activityTypes[a.ID] = a
+	return nil
+}
+
+func (m *mockActivityTypeRepository) FindByID(_ context.Context, id string) (*domain.ActivityType, error) {
+	a, ok := m.activityTypes[id]
+	if !ok {
+		return nil, domain.ErrNotFound("activity type")
+	}
+	return a, nil
+}
+
+func (m *mockActivityTypeRepository) FindByUserID(_ context.Context, userID string) ([]*domain.ActivityType, error) {
+	var result []*domain.ActivityType
+	for _, a := range m.activityTypes {
+		if a.UserID == userID {
+			result = append(result, a)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.ActivityType) int { return strings.Compare(a.Name, b.Name) })
+	return result, nil
+}
+
+func (m *mockActivityTypeRepository) Update(_ context.Context, a *domain.ActivityType) error {
+	if _, ok := m.activityTypes[a.ID]; !ok {
+		return domain.ErrNotFound("activity type")
+	}
+	m.activityTypes[a.ID] = a
+	return nil
+}
+
+func (m *mockActivityTypeRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.activityTypes[id]; !ok {
+		return domain.ErrNotFound("activity type")
+	}
+	delete(m.activityTypes, id)
 	return nil
 }
 
 type mockStudyLogRepository struct {
-	logs map[string]*domain.StudyLog
+	logs      map[string]*domain.StudyLog
+	trashed   map[string]*domain.StudyLog
+	revisions []*domain.StudyLogRevision
 }
 
 func newMockStudyLogRepo() *mockStudyLogRepository {
-	return &mockStudyLogRepository{logs: make(map[string]*domain.StudyLog)}
+	return &mockStudyLogRepository{
+		logs:    make(map[string]*domain.StudyLog),
+		trashed: make(map[string]*domain.StudyLog),
+	}
 }
 
 func (m *mockStudyLogRepository) Create(_ context.Context, l *domain.StudyLog) error {
@@ -94,6 +620,13 @@ func (m *mockStudyLogRepository) Create(_ context.Context, l *domain.StudyLog) e
 	return nil
 }
 
+func (m *mockStudyLogRepository) CreateBatch(_ context.Context, logs []*domain.StudyLog) error {
+	for _, l := range logs {
+		m.logs[l.ID] = l
+	}
+	return nil
+}
+
 func (m *mockStudyLogRepository) FindByID(_ context.Context, id string) (*domain.StudyLog, error) {
 	l, ok := m.logs[id]
 	if !ok {
@@ -102,7 +635,7 @@ func (m *mockStudyLogRepository) FindByID(_ context.Context, id string) (*domain
 	return l, nil
 }
 
-func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string, filter port.StudyLogFilter) ([]*domain.StudyLog, error) {
+func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string, filter port.StudyLogFilter, page port.PageRequest) ([]*domain.StudyLog, error) {
 	var result []*domain.StudyLog
 	for _, l := range m.logs {
 		if l.UserID != userID {
@@ -117,16 +650,105 @@ func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string,
 		if filter.ProjectID != nil && l.ProjectID != *filter.ProjectID {
 			continue
 		}
+		if filter.ActivityTypeID != nil && l.ActivityTypeID != *filter.ActivityTypeID {
+			continue
+		}
 		result = append(result, l)
 	}
+	return mockPage(result, page, func(l *domain.StudyLog) domain.Cursor {
+		return domain.Cursor{Time: l.StudiedAt, ID: l.ID}
+	}), nil
+}
+
+func (m *mockStudyLogRepository) FindImportKeys(_ context.Context, userID string, keys []string) ([]string, error) {
+	var found []string
+	for _, l := range m.logs {
+		if l.UserID == userID && l.ImportKey != "" && slices.Contains(keys, l.ImportKey) {
+			found = append(found, l.ImportKey)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockStudyLogRepository) Update(_ context.Context, l *domain.StudyLog, revision *domain.StudyLogRevision) error {
+	if _, ok := m.logs[l.ID]; !ok {
+		return domain.ErrNotFound("study log")
+	}
+	m.logs[l.ID] = l
+	m.revisions = append(m.revisions, revision)
+	return nil
+}
+
+func (m *mockStudyLogRepository) FindRevisionByID(_ context.Context, id string) (*domain.StudyLogRevision, error) {
+	for _, r := range m.revisions {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, domain.ErrNotFound("study log revision")
+}
+
+func (m *mockStudyLogRepository) FindRevisions(_ context.Context, studyLogID string) ([]*domain.StudyLogRevision, error) {
+	var result []*domain.StudyLogRevision
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if m.revisions[i].StudyLogID == studyLogID {
+			result = append(result, m.revisions[i])
+		}
+	}
 	return result, nil
 }
 
-func (m *mockStudyLogRepository) Delete(_ context.Context, id string) error {
+func (m *mockStudyLogRepository) Trash(_ context.Context, id string, at time.Time) error {
+	l, ok := m.logs[id]
+	if !ok {
+		return domain.ErrNotFound("study log")
+	}
+	l.DeletedAt = &at
 	delete(m.logs, id)
+	m.trashed[id] = l
+	return nil
+}
+
+func (m *mockStudyLogRepository) FindTrashedByID(_ context.Context, id string) (*domain.StudyLog, error) {
+	l, ok := m.trashed[id]
+	if !ok {
+		return nil, domain.ErrNotFound("study log")
+	}
+	return l, nil
+}
+
+func (m *mockStudyLogRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.StudyLog, error) {
+	var result []*domain.StudyLog
+	for _, l := range m.trashed {
+		if l.UserID == userID {
+			result = append(result, l)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStudyLogRepository) Restore(_ context.Context, id string) error {
+	l, ok := m.trashed[id]
+	if !ok {
+		return domain.ErrNotFound("study log")
+	}
+	l.DeletedAt = nil
+	delete(m.trashed, id)
+	m.logs[id] = l
 	return nil
 }
 
+func (m *mockStudyLogRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, l := range m.trashed {
+		if l.DeletedAt.Before(before) {
+			delete(m.trashed, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
 type mockGoalRepository struct {
 	goals map[string]*domain.Goal
 }
@@ -140,22 +762,28 @@ func (m *mockGoalRepository) Upsert(_ context.Context, g *domain.Goal) error {
 	return nil
 }
 
-func (m *mockGoalRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Goal, error) {
+func (m *mockGoalRepository) FindByUserID(_ context.Context, userID string, page port.PageRequest) ([]*domain.Goal, error) {
 	var result []*domain.Goal
 	for _, g := range m.goals {
 		if g.UserID == userID {
 			result = append(result, g)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(g *domain.Goal) domain.Cursor {
+		return domain.Cursor{Time: g.CreatedAt, ID: g.ID}
+	}), nil
 }
 
 type mockNoteRepository struct {
-	notes map[string]*domain.Note
+	notes   map[string]*domain.Note
+	trashed map[string]*domain.Note
 }
 
 func newMockNoteRepo() *mockNoteRepository {
-	return &mockNoteRepository{notes: make(map[string]*domain.Note)}
+	return &mockNoteRepository{
+		notes:   make(map[string]*domain.Note),
+		trashed: make(map[string]*domain.Note),
+	}
 }
 
 func (m *mockNoteRepository) Create(_ context.Context, n *domain.Note) error {
@@ -171,14 +799,19 @@ func (m *mockNoteRepository) FindByID(_ context.Context, id string) (*domain.Not
 	return n, nil
 }
 
-func (m *mockNoteRepository) FindByProjectID(_ context.Context, projectID string) ([]*domain.Note, error) {
+func (m *mockNoteRepository) FindByProjectID(_ context.Context, projectID string, page port.PageRequest) ([]*domain.Note, error) {
 	var result []*domain.Note
 	for _, n := range m.notes {
 		if n.ProjectID == projectID {
 			result = append(result, n)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(n *domain.Note) domain.Cursor {
+		if page.Sort.Key == domain.SortByCreatedAt {
+			return domain.Cursor{Time: n.CreatedAt, ID: n.ID}
+		}
+		return domain.Cursor{Time: n.UpdatedAt, ID: n.ID}
+	}), nil
 }
 
 func (m *mockNoteRepository) Update(_ context.Context, n *domain.Note) error {
@@ -189,11 +822,373 @@ func (m *mockNoteRepository) Update(_ context.Context, n *domain.Note) error {
 	return nil
 }
 
-func (m *mockNoteRepository) Delete(_ context.Context, id string) error {
-	if _, ok := m.notes[id]; !ok {
-		return domain.ErrNotFound("note")
+func (m *mockNoteRepository) Trash(_ context.Context, id string, at time.Time) error {
+	n, ok := m.notes[id]
+	if !ok {
+		return domain.ErrNotFound("note")
 	}
+	n.DeletedAt = &at
 	delete(m.notes, id)
+	m.trashed[id] = n
+	return nil
+}
+
+func (m *mockNoteRepository) FindTrashedByID(_ context.Context, id string) (*domain.Note, error) {
+	n, ok := m.trashed[id]
+	if !ok {
+		return nil, domain.ErrNotFound("note")
+	}
+	return n, nil
+}
+
+func (m *mockNoteRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.Note, error) {
+	var result []*domain.Note
+	for _, n := range m.trashed {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockNoteRepository) Restore(_ context.Context, id string) error {
+	n, ok := m.trashed[id]
+	if !ok {
+		return domain.ErrNotFound("note")
+	}
+	n.DeletedAt = nil
+	delete(m.trashed, id)
+	m.notes[id] = n
+	return nil
+}
+
+func (m *mockNoteRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, note := range m.trashed {
+		if note.DeletedAt.Before(before) {
+			delete(m.trashed, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+type mockNoteLinkRepository struct {
+	noteRepo     *mockNoteRepository
+	studyLogRepo *mockStudyLogRepository
+	links        map[[2]string]bool // keyed by note ID and study log ID
+}
+
+func newMockNoteLinkRepo(noteRepo *mockNoteRepository, studyLogRepo *mockStudyLogRepository) *mockNoteLinkRepository {
+	return &mockNoteLinkRepository{noteRepo: noteRepo, studyLogRepo: studyLogRepo, links: make(map[[2]string]bool)}
+}
+
+func (m *mockNoteLinkRepository) Link(_ context.Context, noteID, studyLogID string) error {
+	m.links[[2]string{noteID, studyLogID}] = true
+	return nil
+}
+
+func (m *mockNoteLinkRepository) Unlink(_ context.Context, noteID, studyLogID string) error {
+	if !m.links[[2]string{noteID, studyLogID}] {
+		return domain.ErrNotFound("note link")
+	}
+	delete(m.links, [2]string{noteID, studyLogID})
+	return nil
+}
+
+func (m *mockNoteLinkRepository) FindStudyLogsByNoteIDs(ctx context.Context, noteIDs []string) (map[string][]domain.LinkedStudyLog, error) {
+	result := make(map[string][]domain.LinkedStudyLog)
+	for link := range m.links {
+		if !slices.Contains(noteIDs, link[0]) {
+			continue
+		}
+		l, err := m.studyLogRepo.FindByID(ctx, link[1])
+		if err != nil {
+			continue
+		}
+		result[link[0]] = append(result[link[0]], domain.LinkedStudyLog{ID: l.ID, ProjectID: l.ProjectID, StudiedAt: l.StudiedAt, Minutes: l.Minutes})
+	}
+	for _, logs := range result {
+		slices.SortFunc(logs, func(a, b domain.LinkedStudyLog) int { return a.StudiedAt.Compare(b.StudiedAt) })
+	}
+	return result, nil
+}
+
+func (m *mockNoteLinkRepository) FindNotesByStudyLogIDs(_ context.Context, studyLogIDs []string) (map[string][]domain.LinkedNote, error) {
+	result := make(map[string][]domain.LinkedNote)
+	for link := range m.links {
+		if !slices.Contains(studyLogIDs, link[1]) {
+			continue
+		}
+		n, ok := m.noteRepo.notes[link[0]]
+		if !ok {
+			continue
+		}
+		result[link[1]] = append(result[link[1]], domain.LinkedNote{ID: n.ID, ProjectID: n.ProjectID, Title: n.Title})
+	}
+	for _, notes := range result {
+		slices.SortFunc(notes, func(a, b domain.LinkedNote) int { return strings.Compare(a.Title, b.Title) })
+	}
+	return result, nil
+}
+
+type mockPersonalAccessTokenRepository struct {
+	tokens map[string]*domain.PersonalAccessToken
+}
+
+func newMockPersonalAccessTokenRepo() *mockPersonalAccessTokenRepository {
+	return &mockPersonalAccessTokenRepository{tokens: make(map[string]*domain.PersonalAccessToken)}
+}
+
+func (m *mockPersonalAccessTokenRepository) Create(_ context.Context, t *domain.PersonalAccessToken) error {
+	m.tokens[t.ID] = t
+	return nil
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByID(_ context.Context, id string) (*domain.PersonalAccessToken, error) {
+	t, ok := m.tokens[id]
+	if !ok {
+		return nil, domain.ErrNotFound("personal access token")
+	}
+	return t, nil
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByHash(_ context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrNotFound("personal access token")
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByUserID(_ context.Context, userID string) ([]*domain.PersonalAccessToken, error) {
+	var result []*domain.PersonalAccessToken
+	for _, t := range m.tokens {
+		if t.UserID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPersonalAccessTokenRepository) UpdateLastUsed(_ context.Context, id string, lastUsedAt time.Time) error {
+	t, ok := m.tokens[id]
+	if !ok {
+		return domain.ErrNotFound("personal access token")
+	}
+	t.LastUsedAt = &lastUsedAt
+	return nil
+}
+
+func (m *mockPersonalAccessTokenRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.tokens[id]; !ok {
+		return domain.ErrNotFound("personal access token")
+	}
+	delete(m.tokens, id)
+	return nil
+}
+
+type mockMFAChallengeRepository struct {
+	attempts map[string]int
+}
+
+func newMockMFAChallengeRepo() *mockMFAChallengeRepository {
+	return &mockMFAChallengeRepository{attempts: make(map[string]int)}
+}
+
+func (m *mockMFAChallengeRepository) Create(_ context.Context, id, _ string, _ time.Time) error {
+	m.attempts[id] = 0
+	return nil
+}
+
+func (m *mockMFAChallengeRepository) RecordAttempt(_ context.Context, id string, _ time.Time) (int, error) {
+	n, ok := m.attempts[id]
+	if !ok {
+		return 0, domain.ErrNotFound("MFA challenge")
+	}
+	m.attempts[id] = n + 1
+	return n + 1, nil
+}
+
+func (m *mockMFAChallengeRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.attempts[id]; !ok {
+		return domain.ErrNotFound("MFA challenge")
+	}
+	delete(m.attempts, id)
+	return nil
+}
+
+type mockSessionRepository struct {
+	sessions map[string]*domain.Session
+	// rotated maps the hashes of rotated-out refresh tokens to their session IDs.
+	rotated map[string]string
+}
+
+func newMockSessionRepo() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*domain.Session), rotated: make(map[string]string)}
+}
+
+func (m *mockSessionRepository) Create(_ context.Context, s *domain.Session) error {
+	saved := *s
+	m.sessions[s.ID] = &saved
+	return nil
+}
+
+func (m *mockSessionRepository) FindByID(_ context.Context, id string) (*domain.Session, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, domain.ErrNotFound("session")
+	}
+	found := *s
+	return &found, nil
+}
+
+func (m *mockSessionRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Session, error) {
+	var result []*domain.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) Rotate(_ context.Context, s *domain.Session, previousHash string) error {
+	existing, ok := m.sessions[s.ID]
+	if !ok || existing.RefreshTokenHash != previousHash {
+		return domain.ErrConflict("refresh token was already used")
+	}
+	saved := *s
+	m.rotated[previousHash] = s.ID
+	m.sessions[s.ID] = &saved
+	return nil
+}
+
+func (m *mockSessionRepository) IsRotatedToken(_ context.Context, sessionID, tokenHash string) (bool, error) {
+	return m.rotated[tokenHash] == sessionID, nil
+}
+
+func (m *mockSessionRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.sessions[id]; !ok {
+		return domain.ErrNotFound("session")
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteByUserID(_ context.Context, userID string) error {
+	for id, s := range m.sessions {
+		if s.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
+
+type mockTimerRepository struct {
+	timers map[string]*domain.Timer
+}
+
+func newMockTimerRepo() *mockTimerRepository {
+	return &mockTimerRepository{timers: make(map[string]*domain.Timer)}
+}
+
+func (m *mockTimerRepository) Create(_ context.Context, timer *domain.Timer) error {
+	if _, ok := m.timers[timer.UserID]; ok {
+		return domain.ErrConflict("a timer is already active for this user")
+	}
+	m.timers[timer.UserID] = timer
+	return nil
+}
+
+func (m *mockTimerRepository) FindByUserID(_ context.Context, userID string) (*domain.Timer, error) {
+	timer, ok := m.timers[userID]
+	if !ok {
+		return nil, domain.ErrNotFound("timer")
+	}
+	copied := *timer
+	return &copied, nil
+}
+
+func (m *mockTimerRepository) FindExpired(_ context.Context, now time.Time) ([]*domain.Timer, error) {
+	var result []*domain.Timer
+	for _, timer := range m.timers {
+		if timer.IsExpired(now) {
+			result = append(result, timer)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockTimerRepository) Update(_ context.Context, timer *domain.Timer) error {
+	existing, ok := m.timers[timer.UserID]
+	if !ok || !existing.StartedAt.Equal(timer.StartedAt) {
+		return domain.ErrNotFound("timer")
+	}
+	m.timers[timer.UserID] = timer
+	return nil
+}
+
+func (m *mockTimerRepository) Delete(_ context.Context, timer *domain.Timer) error {
+	existing, ok := m.timers[timer.UserID]
+	if !ok || !existing.StartedAt.Equal(timer.StartedAt) {
+		return domain.ErrNotFound("timer")
+	}
+	delete(m.timers, timer.UserID)
+	return nil
+}
+
+type mockPomodoroRepository struct {
+	sessions     map[string]*domain.PomodoroSession
+	studyLogRepo *mockStudyLogRepository
+}
+
+func newMockPomodoroRepo(studyLogRepo *mockStudyLogRepository) *mockPomodoroRepository {
+	return &mockPomodoroRepository{sessions: make(map[string]*domain.PomodoroSession), studyLogRepo: studyLogRepo}
+}
+
+func (m *mockPomodoroRepository) Create(_ context.Context, session *domain.PomodoroSession) error {
+	if _, ok := m.sessions[session.UserID]; ok {
+		return domain.ErrConflict("a pomodoro session is already active for this user")
+	}
+	m.sessions[session.UserID] = session
+	return nil
+}
+
+func (m *mockPomodoroRepository) FindByUserID(_ context.Context, userID string) (*domain.PomodoroSession, error) {
+	session, ok := m.sessions[userID]
+	if !ok {
+		return nil, domain.ErrNotFound("pomodoro session")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (m *mockPomodoroRepository) FindDue(_ context.Context, now time.Time) ([]*domain.PomodoroSession, error) {
+	var result []*domain.PomodoroSession
+	for _, session := range m.sessions {
+		if !now.Before(session.PhaseEndsAt()) {
+			copied := *session
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPomodoroRepository) Save(_ context.Context, session *domain.PomodoroSession, previousUpdatedAt time.Time, logs []*domain.StudyLog) error {
+	existing, ok := m.sessions[session.UserID]
+	if !ok || !existing.UpdatedAt.Equal(previousUpdatedAt) {
+		return domain.ErrConflict("pomodoro session was changed concurrently")
+	}
+	if session.IsFinished() {
+		delete(m.sessions, session.UserID)
+	} else {
+		m.sessions[session.UserID] = session
+	}
+	for _, log := range logs {
+		m.studyLogRepo.logs[log.ID] = log
+	}
 	return nil
 }
 
@@ -206,14 +1201,54 @@ func setupRouter(t *testing.T) (http.Handler, *mockUserRepository, *mockProjectR
 	studyLogRepo := newMockStudyLogRepo()
 	goalRepo := newMockGoalRepo()
 	noteRepo := newMockNoteRepo()
-
+	patRepo := newMockPersonalAccessTokenRepo()
+	sessionRepo := newMockSessionRepo()
+	mfaRepo := newMockMFAChallengeRepo()
+	timerRepo := newMockTimerRepo()
+	pomodoroRepo := newMockPomodoroRepo(studyLogRepo)
+	activityTypeRepo := newMockActivityTypeRepo()
+	noteLinkRepo := newMockNoteLinkRepo(noteRepo, studyLogRepo)
+	milestoneRepo := newMockMilestoneRepo()
+	memberRepo := newMockProjectMemberRepo()
+	userRepo.projectRepo = projectRepo
+	userRepo.studyLogRepo = studyLogRepo
+	userRepo.goalRepo = goalRepo
+	userRepo.noteRepo = noteRepo
+	userRepo.sessionRepo = sessionRepo
+	userRepo.timerRepo = timerRepo
+	userRepo.pomodoroRepo = pomodoroRepo
+	projectRepo.noteRepo = noteRepo
+	projectRepo.studyLogRepo = studyLogRepo
+	projectRepo.goalRepo = goalRepo
+	projectRepo.milestoneRepo = milestoneRepo
+
+	hasher := auth.NewBcryptHasher(bcrypt.MinCost)
+	tokens := auth.NewTokenManager("test-secret", time.Hour)
+	opaqueTokens := auth.NewOpaqueTokenGenerator()
+	totp := auth.NewTOTPProvider("StudyTrack")
+
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, userRepo, milestoneRepo, goalRepo, tokens)
+	studyLogUsecase := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, activityTypeRepo, noteLinkRepo, memberRepo)
 	usecases := &controller.Usecases{
-		User:     usecase.NewUserUsecase(userRepo),
-		Project:  usecase.NewProjectUsecase(projectRepo, userRepo),
-		StudyLog: usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo),
-		Goal:     usecase.NewGoalUsecase(goalRepo, userRepo, projectRepo),
-		Stats:    usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo),
-		Note:     usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo),
+		Auth:                usecase.NewAuthUsecase(userRepo, patRepo, sessionRepo, mfaRepo, hasher, tokens, opaqueTokens, totp, 24*time.Hour),
+		User:                usecase.NewUserUsecase(userRepo, hasher, tokens),
+		Project:             projectUsecase,
+		StudyLog:            studyLogUsecase,
+		StudyLogImport:      usecase.NewStudyLogImportUsecase(studyLogRepo, userRepo, projectUsecase),
+		ActivityType:        usecase.NewActivityTypeUsecase(activityTypeRepo, userRepo),
+		Milestone:           usecase.NewMilestoneUsecase(milestoneRepo, projectRepo, userRepo),
+		ProjectMember:       usecase.NewProjectMemberUsecase(memberRepo, projectRepo, userRepo, studyLogRepo),
+		ProjectClone:        usecase.NewProjectCloneUsecase(projectRepo, memberRepo, userRepo, noteRepo, goalRepo, milestoneRepo),
+		Timer:               usecase.NewTimerUsecase(timerRepo, userRepo, projectRepo, studyLogUsecase, memberRepo),
+		Pomodoro:            usecase.NewPomodoroUsecase(pomodoroRepo, userRepo, projectRepo, studyLogRepo, memberRepo),
+		Goal:                usecase.NewGoalUsecase(goalRepo, userRepo, projectRepo),
+		Stats:               usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo, userRepo, activityTypeRepo, milestoneRepo, memberRepo),
+		Note:                usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo, studyLogRepo, noteLinkRepo, memberRepo),
+		PersonalAccessToken: usecase.NewPersonalAccessTokenUsecase(patRepo, userRepo, opaqueTokens),
+		Session:             usecase.NewSessionUsecase(sessionRepo, userRepo),
+		TwoFactor:           usecase.NewTwoFactorUsecase(userRepo, totp, opaqueTokens),
+		Takeout:             usecase.NewTakeoutUsecase(userRepo, projectRepo, studyLogRepo, goalRepo, noteRepo, activityTypeRepo, noteLinkRepo, milestoneRepo),
+		Trash:               usecase.NewTrashUsecase(projectRepo, noteRepo, studyLogRepo, userRepo, 30*24*time.Hour),
 	}
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -233,6 +1268,38 @@ func jsonRequest(method, path string, body any) *http.Request {
 	return req
 }
 
+func authRequest(method, path, token string, body any) *http.Request {
+	req := jsonRequest(method, path, body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// signUp creates a user and logs in as that user, returning the user ID and access token.
+func signUp(t *testing.T, handler http.Handler, name string) (string, string) {
+	t.Helper()
+	email := strings.ToLower(name) + "@example.com"
+
+	createRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{
+		"name": name, "email": email, "password": "password123",
+	}))
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("setup: create user failed with status %d; body: %s", createRR.Code, createRR.Body.String())
+	}
+	var user map[string]any
+	parseJSON(t, createRR, &user)
+
+	loginRR := doRequest(handler, jsonRequest("POST", "/v1/auth/login", map[string]string{
+		"email": email, "password": "password123",
+	}))
+	if loginRR.Code != http.StatusOK {
+		t.Fatalf("setup: login failed with status %d; body: %s", loginRR.Code, loginRR.Body.String())
+	}
+	var login map[string]any
+	parseJSON(t, loginRR, &login)
+
+	return user["id"].(string), login["accessToken"].(string)
+}
+
 func doRequest(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
@@ -251,7 +1318,8 @@ func parseJSON(t *testing.T, rr *httptest.ResponseRecorder, v any) {
 func TestCreateUser_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	req := jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"})
+	body := map[string]string{"name": "Alice", "email": "Alice@Example.com", "password": "password123"}
+	req := jsonRequest("POST", "/v1/users", body)
 	rr := doRequest(handler, req)
 
 	if rr.Code != http.StatusCreated {
@@ -264,6 +1332,12 @@ func TestCreateUser_Success(t *testing.T) {
 	if resp["name"] != "Alice" {
 		t.Errorf("expected name 'Alice', got '%v'", resp["name"])
 	}
+	if resp["email"] != "alice@example.com" {
+		t.Errorf("expected email 'alice@example.com', got '%v'", resp["email"])
+	}
+	if _, ok := resp["password"]; ok {
+		t.Error("expected password not to be returned")
+	}
 	if resp["id"] == nil || resp["id"] == "" {
 		t.Error("expected id to be set")
 	}
@@ -279,7 +1353,8 @@ func TestCreateUser_ValidationError_EmptyName(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
 	// Huma validates minLength:"1" on the struct tag, so empty name yields 422
-	req := jsonRequest("POST", "/v1/users", map[string]string{"name": ""})
+	body := map[string]string{"name": "", "email": "alice@example.com", "password": "password123"}
+	req := jsonRequest("POST", "/v1/users", body)
 	rr := doRequest(handler, req)
 
 	if rr.Code != http.StatusUnprocessableEntity {
@@ -287,22 +1362,37 @@ func TestCreateUser_ValidationError_EmptyName(t *testing.T) {
 	}
 }
 
-func TestGetUser_Found(t *testing.T) {
+func TestCreateUser_ValidationError_ShortPassword(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// First create a user
-	createReq := jsonRequest("POST", "/v1/users", map[string]string{"name": "Bob"})
-	createRR := doRequest(handler, createReq)
-	if createRR.Code != http.StatusCreated {
-		t.Fatalf("setup: create user failed with status %d", createRR.Code)
+	body := map[string]string{"name": "Alice", "email": "alice@example.com", "password": "short"}
+	req := jsonRequest("POST", "/v1/users", body)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
 	}
+}
 
-	var created map[string]any
-	parseJSON(t, createRR, &created)
-	userID := created["id"].(string)
+func TestCreateUser_DuplicateEmail(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	signUp(t, handler, "Alice")
+
+	body := map[string]string{"name": "Another", "email": "alice@example.com", "password": "password123"}
+	req := jsonRequest("POST", "/v1/users", body)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusConflict, rr.Code, rr.Body.String())
+	}
+}
+
+func TestGetUser_Found(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	userID, token := signUp(t, handler, "Bob")
 
-	// Now get the user
-	getReq := jsonRequest("GET", "/v1/users/"+userID, nil)
+	getReq := authRequest("GET", "/v1/users/"+userID, token, nil)
 	getRR := doRequest(handler, getReq)
 
 	if getRR.Code != http.StatusOK {
@@ -319,813 +1409,1176 @@ func TestGetUser_Found(t *testing.T) {
 	}
 }
 
-func TestGetUser_NotFound(t *testing.T) {
+func TestGetUser_OtherUser(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+	otherID, _ := signUp(t, handler, "Bob")
 
-	req := jsonRequest("GET", "/v1/users/nonexistent-id", nil)
+	req := authRequest("GET", "/v1/users/"+otherID, token, nil)
 	rr := doRequest(handler, req)
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
 	}
 }
 
-// --- Project Tests ---
+// --- Auth Tests ---
 
-func TestCreateProject_Success(t *testing.T) {
+func TestUpdateUser_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	// Create user first
-	createUserReq := jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"})
-	createUserRR := doRequest(handler, createUserReq)
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
-
-	// Create project
-	createProjReq := jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Mathematics"})
-	createProjRR := doRequest(handler, createProjReq)
-
-	if createProjRR.Code != http.StatusCreated {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createProjRR.Code, createProjRR.Body.String())
+	body := map[string]any{
+		"name": "Alice Smith",
+		"preferences": map[string]any{
+			"timezone":              "Asia/Tokyo",
+			"weekStart":             "sunday",
+			"locale":                "ja-JP",
+			"defaultSessionMinutes": 25,
+		},
+	}
+	rr := doRequest(handler, authRequest("PUT", "/v1/users/"+userID, token, body))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
 	}
 
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	if proj["name"] != "Mathematics" {
-		t.Errorf("expected name 'Mathematics', got '%v'", proj["name"])
+	var user struct {
+		Name        string `json:"name"`
+		Preferences struct {
+			Timezone              string `json:"timezone"`
+			WeekStart             string `json:"weekStart"`
+			Locale                string `json:"locale"`
+			DefaultSessionMinutes int    `json:"defaultSessionMinutes"`
+		} `json:"preferences"`
 	}
-	if proj["userId"] != userID {
-		t.Errorf("expected userId '%s', got '%v'", userID, proj["userId"])
+	parseJSON(t, rr, &user)
+	if user.Name != "Alice Smith" {
+		t.Errorf("expected name 'Alice Smith', got '%s'", user.Name)
 	}
-	if proj["id"] == nil || proj["id"] == "" {
-		t.Error("expected project id to be set")
+	if user.Preferences.Timezone != "Asia/Tokyo" || user.Preferences.WeekStart != "sunday" || user.Preferences.Locale != "ja-JP" || user.Preferences.DefaultSessionMinutes != 25 {
+		t.Errorf("unexpected preferences: %+v", user.Preferences)
 	}
 }
 
-func TestListProjects_Success(t *testing.T) {
+func TestUpdateUser_InvalidTimezone(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	// Create user
-	createUserReq := jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"})
-	createUserRR := doRequest(handler, createUserReq)
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	body := map[string]any{
+		"name": "Alice",
+		"preferences": map[string]any{
+			"timezone":              "Nowhere/City",
+			"weekStart":             "monday",
+			"locale":                "en",
+			"defaultSessionMinutes": 60,
+		},
+	}
+	rr := doRequest(handler, authRequest("PUT", "/v1/users/"+userID, token, body))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
 
-	// Create two projects
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "English"}))
+func TestDeleteUser_Success(t *testing.T) {
+	handler, userRepo, projectRepo, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	otherID, otherToken := signUp(t, handler, "Bob")
+
+	for _, u := range []struct{ id, token string }{{userID, token}, {otherID, otherToken}} {
+		projRR := doRequest(handler, authRequest("POST", "/v1/users/"+u.id+"/projects", u.token, map[string]string{"name": "Math"}))
+		var proj map[string]any
+		parseJSON(t, projRR, &proj)
+		logBody := map[string]any{"projectId": proj["id"], "studiedAt": "2024-01-15T10:00:00Z", "minutes": 30}
+		doRequest(handler, authRequest("POST", "/v1/users/"+u.id+"/study-logs", u.token, logBody))
+	}
 
-	// List projects
-	listReq := jsonRequest("GET", "/v1/users/"+userID+"/projects", nil)
-	listRR := doRequest(handler, listReq)
+	rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+userID+"?force=true", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var summary map[string]any
+	parseJSON(t, rr, &summary)
+	if summary["deletedProjects"] != float64(1) || summary["deletedStudyLogs"] != float64(1) {
+		t.Errorf("unexpected deletion summary: %v", summary)
+	}
 
-	if listRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	if _, ok := userRepo.users[userID]; ok {
+		t.Error("expected user to be deleted")
+	}
+	if len(projectRepo.projects) != 1 || len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected only the other user's data to remain, got %d projects and %d logs", len(projectRepo.projects), len(studyLogRepo.logs))
 	}
+	if rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID, token, nil)); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected token of deleted user to be rejected, got %d", rr.Code)
+	}
+}
 
-	var projects []map[string]any
-	parseJSON(t, listRR, &projects)
-	if len(projects) != 2 {
-		t.Fatalf("expected 2 projects, got %d", len(projects))
+func TestDeleteUser_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+	otherID, _ := signUp(t, handler, "Bob")
+
+	rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+otherID, token, nil))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
 	}
 }
 
-func TestUpdateProject_Success(t *testing.T) {
+func TestExportUserData(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/export", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("expected Content-Type application/zip, got %s", ct)
+	}
+	if cd := rr.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment;") {
+		t.Errorf("expected attachment Content-Disposition, got %s", cd)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+	if err != nil {
+		t.Fatalf("expected a valid ZIP archive: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, name := range []string{"manifest.json", "profile.json", "projects.json", "activity_types.json", "study_logs.json", "study_logs.csv", "goals.json", "notes.json"} {
+		if !names[name] {
+			t.Errorf("expected %s in archive", name)
+		}
+	}
+}
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+func TestExportUserData_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+	otherID, _ := signUp(t, handler, "Bob")
 
-	// Update project
-	updateReq := jsonRequest("PUT", "/v1/projects/"+projectID, map[string]string{"name": "Advanced Math"})
-	updateRR := doRequest(handler, updateReq)
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+otherID+"/export", token, nil))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
 
-	if updateRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, updateRR.Code, updateRR.Body.String())
+func TestLogin_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	if token == "" {
+		t.Fatal("expected access token to be set")
 	}
 
-	var updated map[string]any
-	parseJSON(t, updateRR, &updated)
-	if updated["name"] != "Advanced Math" {
-		t.Errorf("expected name 'Advanced Math', got '%v'", updated["name"])
+	req := authRequest("GET", "/v1/users/"+userID, token, nil)
+	rr := doRequest(handler, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
 	}
 }
 
-func TestDeleteProject_Success(t *testing.T) {
+func TestLogin_WrongPassword(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	signUp(t, handler, "Alice")
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	body := map[string]string{"email": "alice@example.com", "password": "wrong-password"}
+	rr := doRequest(handler, jsonRequest("POST", "/v1/auth/login", body))
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+	}
+}
 
-	// Delete project
-	deleteReq := jsonRequest("DELETE", "/v1/projects/"+projectID, nil)
-	deleteRR := doRequest(handler, deleteReq)
+func TestAuth_MissingToken(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, _ := signUp(t, handler, "Alice")
 
-	if deleteRR.Code != http.StatusNoContent {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
+	rr := doRequest(handler, jsonRequest("GET", "/v1/users/"+userID+"/projects", nil))
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("WWW-Authenticate") == "" {
+		t.Error("expected WWW-Authenticate header to be set")
 	}
 }
 
-func TestListProjects_UserNotFound(t *testing.T) {
+func TestAuth_InvalidToken(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, _ := signUp(t, handler, "Alice")
 
-	req := jsonRequest("GET", "/v1/users/nonexistent-user/projects", nil)
-	rr := doRequest(handler, req)
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects", "invalid-token", nil))
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, rr.Code, rr.Body.String())
 	}
 }
 
-// --- StudyLog Tests ---
-
-func TestCreateStudyLog_Success(t *testing.T) {
+func TestAuth_OtherUsersNote(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	aliceID, aliceToken := signUp(t, handler, "Alice")
+	_, bobToken := signUp(t, handler, "Bob")
 
-	// Create user
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
-
-	// Create project
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+aliceID+"/projects", aliceToken, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
 
-	// Create study log
-	studiedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
-	body := map[string]any{
-		"projectId": projectID,
-		"studiedAt": studiedAt.Format(time.RFC3339),
-		"minutes":   60,
-		"note":      "Chapter 1",
-	}
-	createLogReq := jsonRequest("POST", "/v1/users/"+userID+"/study-logs", body)
-	createLogRR := doRequest(handler, createLogReq)
+	body := map[string]any{"title": "Secret", "content": "private"}
+	createNoteRR := doRequest(handler, authRequest("POST", "/v1/users/"+aliceID+"/projects/"+projectID+"/notes", aliceToken, body))
+	var note map[string]any
+	parseJSON(t, createNoteRR, &note)
+	noteID := note["id"].(string)
 
-	if createLogRR.Code != http.StatusCreated {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createLogRR.Code, createLogRR.Body.String())
+	rr := doRequest(handler, authRequest("GET", "/v1/notes/"+noteID, bobToken, nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
 	}
 
-	var logResp map[string]any
-	parseJSON(t, createLogRR, &logResp)
-	if logResp["userId"] != userID {
-		t.Errorf("expected userId '%s', got '%v'", userID, logResp["userId"])
-	}
-	if logResp["projectId"] != projectID {
-		t.Errorf("expected projectId '%s', got '%v'", projectID, logResp["projectId"])
-	}
-	if int(logResp["minutes"].(float64)) != 60 {
-		t.Errorf("expected minutes 60, got %v", logResp["minutes"])
-	}
-	if logResp["note"] != "Chapter 1" {
-		t.Errorf("expected note 'Chapter 1', got '%v'", logResp["note"])
+	rr = doRequest(handler, authRequest("DELETE", "/v1/notes/"+noteID, bobToken, nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
 	}
 }
 
-func TestListStudyLogs_Success(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
-
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+// --- Session Tests ---
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+// loginAs logs in as a user created by signUp and returns the parsed token response.
+func loginAs(t *testing.T, handler http.Handler, name string) map[string]any {
+	t.Helper()
+	body := map[string]string{"email": strings.ToLower(name) + "@example.com", "password": "password123"}
+	req := jsonRequest("POST", "/v1/auth/login", body)
+	req.Header.Set("User-Agent", "test-device")
+	rr := doRequest(handler, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("setup: login failed with status %d; body: %s", rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	return resp
+}
 
-	// Create two study logs
-	studiedAt1 := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
-	studiedAt2 := time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": studiedAt1.Format(time.RFC3339), "minutes": 60, "note": "session 1",
-	}))
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": studiedAt2.Format(time.RFC3339), "minutes": 45, "note": "session 2",
-	}))
+func refresh(handler http.Handler, refreshToken string) *httptest.ResponseRecorder {
+	return doRequest(handler, jsonRequest("POST", "/v1/auth/refresh", map[string]string{"refreshToken": refreshToken}))
+}
 
-	// List study logs
-	listReq := jsonRequest("GET", "/v1/users/"+userID+"/study-logs", nil)
-	listRR := doRequest(handler, listReq)
+func TestRefresh_RotatesToken(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, _ := signUp(t, handler, "Alice")
+	first := loginAs(t, handler, "Alice")
 
-	if listRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	rr := refresh(handler, first["refreshToken"].(string))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var second map[string]any
+	parseJSON(t, rr, &second)
+	if second["refreshToken"] == first["refreshToken"] {
+		t.Error("expected refresh token to be rotated")
 	}
 
-	var logs []map[string]any
-	parseJSON(t, listRR, &logs)
-	if len(logs) != 2 {
-		t.Fatalf("expected 2 logs, got %d", len(logs))
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID, second["accessToken"].(string), nil))
+	if getRR.Code != http.StatusOK {
+		t.Fatalf("expected new access token to work, got %d; body: %s", getRR.Code, getRR.Body.String())
 	}
 }
 
-func TestListStudyLogs_WithDateFilter(t *testing.T) {
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, _ := signUp(t, handler, "Alice")
+	first := loginAs(t, handler, "Alice")
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	rr := refresh(handler, first["refreshToken"].(string))
+	var second map[string]any
+	parseJSON(t, rr, &second)
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+	reuseRR := refresh(handler, first["refreshToken"].(string))
+	if reuseRR.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, reuseRR.Code, reuseRR.Body.String())
+	}
 
-	// Create logs on different dates
-	jan15 := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
-	feb10 := time.Date(2024, 2, 10, 10, 0, 0, 0, time.UTC)
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": jan15.Format(time.RFC3339), "minutes": 60, "note": "january",
-	}))
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": feb10.Format(time.RFC3339), "minutes": 45, "note": "february",
-	}))
+	if rr := refresh(handler, second["refreshToken"].(string)); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected latest refresh token to be revoked, got %d", rr.Code)
+	}
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID, second["accessToken"].(string), nil))
+	if getRR.Code != http.StatusUnauthorized {
+		t.Errorf("expected access token to be revoked, got %d", getRR.Code)
+	}
+}
 
-	// Filter to January only
-	listReq := jsonRequest("GET", "/v1/users/"+userID+"/study-logs?from=2024-01-01&to=2024-01-31", nil)
-	listRR := doRequest(handler, listReq)
+func TestListSessions(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, _ := signUp(t, handler, "Alice")
+	current := loginAs(t, handler, "Alice")
 
-	if listRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/sessions", current["accessToken"].(string), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
 	}
-
-	var logs []map[string]any
-	parseJSON(t, listRR, &logs)
-	if len(logs) != 1 {
-		t.Fatalf("expected 1 log in January, got %d", len(logs))
+	var sessions []map[string]any
+	parseJSON(t, rr, &sessions)
+	// One session from signUp and one from loginAs
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
 	}
-	if logs[0]["note"] != "january" {
-		t.Errorf("expected note 'january', got '%v'", logs[0]["note"])
+	for _, s := range sessions {
+		isCurrent := s["id"] == current["sessionId"]
+		if s["current"] != isCurrent {
+			t.Errorf("expected current=%v for session %v", isCurrent, s["id"])
+		}
+		if isCurrent && s["userAgent"] != "test-device" {
+			t.Errorf("expected userAgent 'test-device', got '%v'", s["userAgent"])
+		}
 	}
 }
 
-func TestDeleteStudyLog_Success(t *testing.T) {
+func TestRevokeSession(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	other := loginAs(t, handler, "Alice")
 
-	// Create user, project, and study log
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	rr := doRequest(handler, authRequest("DELETE", "/v1/sessions/"+other["sessionId"].(string), token, nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+	if rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID, other["accessToken"].(string), nil)); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected revoked session to be rejected, got %d", rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID, token, nil)); rr.Code != http.StatusOK {
+		t.Errorf("expected other session to keep working, got %d", rr.Code)
+	}
+}
 
-	studiedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
-	createLogRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": studiedAt.Format(time.RFC3339), "minutes": 60, "note": "to delete",
-	}))
-	var logResp map[string]any
-	parseJSON(t, createLogRR, &logResp)
-	logID := logResp["id"].(string)
+func TestRevokeAllSessions(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	other := loginAs(t, handler, "Alice")
 
-	// Delete study log
-	deleteReq := jsonRequest("DELETE", "/v1/study-logs/"+logID, nil)
-	deleteRR := doRequest(handler, deleteReq)
+	rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+userID+"/sessions", token, nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
 
-	if deleteRR.Code != http.StatusNoContent {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
+	for _, accessToken := range []string{token, other["accessToken"].(string)} {
+		if rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID, accessToken, nil)); rr.Code != http.StatusUnauthorized {
+			t.Errorf("expected all sessions to be revoked, got %d", rr.Code)
+		}
+	}
+	if rr := refresh(handler, other["refreshToken"].(string)); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected refresh token to be revoked, got %d", rr.Code)
 	}
 }
 
-// --- Goal Tests ---
-
-func TestUpsertGoal_Success(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
+// --- Two-Factor Tests ---
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+// totpCode computes the current RFC 6238 code for a base32 secret.
+func totpCode(t *testing.T, secret string) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("failed to decode TOTP secret: %v", err)
+	}
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+func TestTwoFactor_EnrollAndLogin(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	// Upsert goal
-	body := map[string]any{
-		"targetMinutesPerWeek": 300,
-		"startDate":            "2024-01-01",
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/two-factor/totp", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var enrollment map[string]any
+	parseJSON(t, rr, &enrollment)
+	secret := enrollment["secret"].(string)
+	if !strings.HasPrefix(enrollment["provisioningUri"].(string), "otpauth://totp/") {
+		t.Errorf("unexpected provisioning URI: %v", enrollment["provisioningUri"])
 	}
-	upsertReq := jsonRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, body)
-	upsertRR := doRequest(handler, upsertReq)
 
-	if upsertRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, upsertRR.Code, upsertRR.Body.String())
+	wrongRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/two-factor/totp/confirm", token, map[string]string{"code": "abcdef"}))
+	if wrongRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, wrongRR.Code, wrongRR.Body.String())
 	}
 
-	var goalResp map[string]any
-	parseJSON(t, upsertRR, &goalResp)
-	if goalResp["userId"] != userID {
-		t.Errorf("expected userId '%s', got '%v'", userID, goalResp["userId"])
+	confirmRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/two-factor/totp/confirm", token, map[string]string{"code": totpCode(t, secret)}))
+	if confirmRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, confirmRR.Code, confirmRR.Body.String())
 	}
-	if goalResp["projectId"] != projectID {
-		t.Errorf("expected projectId '%s', got '%v'", projectID, goalResp["projectId"])
+	var confirmed struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
 	}
-	if int(goalResp["targetMinutesPerWeek"].(float64)) != 300 {
-		t.Errorf("expected targetMinutesPerWeek 300, got %v", goalResp["targetMinutesPerWeek"])
+	parseJSON(t, confirmRR, &confirmed)
+	if len(confirmed.RecoveryCodes) != domain.RecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", domain.RecoveryCodeCount, len(confirmed.RecoveryCodes))
 	}
-	if goalResp["startDate"] != "2024-01-01" {
-		t.Errorf("expected startDate '2024-01-01', got '%v'", goalResp["startDate"])
+
+	login := loginAs(t, handler, "Alice")
+	if login["mfaRequired"] != true || login["accessToken"] != nil {
+		t.Fatalf("expected MFA challenge without tokens, got %v", login)
 	}
-	if goalResp["endDate"] != nil {
-		t.Errorf("expected endDate nil, got '%v'", goalResp["endDate"])
+	mfaToken := login["mfaToken"].(string)
+
+	mfaBody := map[string]string{"mfaToken": mfaToken, "code": confirmed.RecoveryCodes[0]}
+	mfaRR := doRequest(handler, jsonRequest("POST", "/v1/auth/login/mfa", mfaBody))
+	if mfaRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, mfaRR.Code, mfaRR.Body.String())
+	}
+	var tokens map[string]any
+	parseJSON(t, mfaRR, &tokens)
+
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID, tokens["accessToken"].(string), nil))
+	var user map[string]any
+	parseJSON(t, getRR, &user)
+	if user["twoFactorEnabled"] != true {
+		t.Error("expected twoFactorEnabled to be true")
+	}
+
+	if reuseRR := doRequest(handler, jsonRequest("POST", "/v1/auth/login/mfa", mfaBody)); reuseRR.Code != http.StatusUnauthorized {
+		t.Errorf("expected recovery code reuse to be rejected, got %d", reuseRR.Code)
 	}
 }
 
-func TestUpsertGoal_WithEndDate(t *testing.T) {
+func TestTwoFactor_MFATokenIsNotAnAccessToken(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/two-factor/totp", token, nil))
+	var enrollment map[string]any
+	parseJSON(t, rr, &enrollment)
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/two-factor/totp/confirm", token, map[string]string{"code": totpCode(t, enrollment["secret"].(string))}))
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
+	login := loginAs(t, handler, "Alice")
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID, login["mfaToken"].(string), nil))
+	if getRR.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, getRR.Code, getRR.Body.String())
+	}
+}
 
-	endDate := "2024-06-30"
-	body := map[string]any{
-		"targetMinutesPerWeek": 200,
-		"startDate":            "2024-01-01",
-		"endDate":              endDate,
+// --- Personal Access Token Tests ---
+
+// createPAT creates a personal access token with the given scopes and returns its plaintext value.
+func createPAT(t *testing.T, handler http.Handler, userID, token string, scopes ...string) string {
+	t.Helper()
+	body := map[string]any{"name": "script", "scopes": scopes}
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/personal-access-tokens", token, body))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("setup: create token failed with status %d; body: %s", rr.Code, rr.Body.String())
 	}
-	upsertReq := jsonRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, body)
-	upsertRR := doRequest(handler, upsertReq)
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	return resp["token"].(string)
+}
 
-	if upsertRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, upsertRR.Code, upsertRR.Body.String())
+func TestPersonalAccessToken_ScopeAllowed(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	pat := createPAT(t, handler, userID, token, "projects:read")
+
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects", pat, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
 	}
+}
 
-	var goalResp map[string]any
-	parseJSON(t, upsertRR, &goalResp)
-	if goalResp["endDate"] != "2024-06-30" {
-		t.Errorf("expected endDate '2024-06-30', got '%v'", goalResp["endDate"])
+func TestPersonalAccessToken_ScopeMissing(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	pat := createPAT(t, handler, userID, token, "projects:read")
+
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", pat, map[string]string{"name": "Math"}))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Header().Get("WWW-Authenticate"), "insufficient_scope") {
+		t.Errorf("expected insufficient_scope challenge, got %q", rr.Header().Get("WWW-Authenticate"))
 	}
 }
 
-func TestListGoals_Success(t *testing.T) {
+func TestPersonalAccessToken_CannotManageTokens(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	pat := createPAT(t, handler, userID, token, "projects:read")
 
-	// Create user and two projects
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	body := map[string]any{"name": "escalated", "scopes": []string{"projects:write"}}
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/personal-access-tokens", pat, body))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
 
-	createProj1RR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj1 map[string]any
-	parseJSON(t, createProj1RR, &proj1)
-	projectID1 := proj1["id"].(string)
+func TestPersonalAccessToken_UnknownScope(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	createProj2RR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "English"}))
-	var proj2 map[string]any
-	parseJSON(t, createProj2RR, &proj2)
-	projectID2 := proj2["id"].(string)
-
-	// Upsert goals for both projects
-	doRequest(handler, jsonRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID1, map[string]any{
-		"targetMinutesPerWeek": 300, "startDate": "2024-01-01",
-	}))
-	doRequest(handler, jsonRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID2, map[string]any{
-		"targetMinutesPerWeek": 150, "startDate": "2024-01-01",
-	}))
+	body := map[string]any{"name": "script", "scopes": []string{"admin"}}
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/personal-access-tokens", token, body))
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	}
+}
 
-	// List goals
-	listReq := jsonRequest("GET", "/v1/users/"+userID+"/goals", nil)
-	listRR := doRequest(handler, listReq)
+func TestPersonalAccessToken_ListAndRevoke(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	pat := createPAT(t, handler, userID, token, "projects:read")
 
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/personal-access-tokens", token, nil))
 	if listRR.Code != http.StatusOK {
 		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
 	}
+	var tokens []map[string]any
+	parseJSON(t, listRR, &tokens)
+	if len(tokens) != 1 {
+		t.Fatalf("expected 1 token, got %d", len(tokens))
+	}
+	if _, ok := tokens[0]["token"]; ok {
+		t.Error("expected token value not to be listed")
+	}
 
-	var goals []map[string]any
-	parseJSON(t, listRR, &goals)
-	if len(goals) != 2 {
-		t.Fatalf("expected 2 goals, got %d", len(goals))
+	revokeRR := doRequest(handler, authRequest("DELETE", "/v1/personal-access-tokens/"+tokens[0]["id"].(string), token, nil))
+	if revokeRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, revokeRR.Code, revokeRR.Body.String())
+	}
+
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects", pat, nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnauthorized, rr.Code, rr.Body.String())
 	}
 }
 
-// --- Stats Tests ---
+// --- Project Tests ---
 
-func TestGetWeeklyStats_Success(t *testing.T) {
+func TestCreateProject_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
-
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
-	var proj map[string]any
-	parseJSON(t, createProjRR, &proj)
-	projectID := proj["id"].(string)
-
-	// Create a study log within the week
-	studiedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC) // Tuesday in the week of 2024-01-01
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/study-logs", map[string]any{
-		"projectId": projectID, "studiedAt": studiedAt.Format(time.RFC3339), "minutes": 90, "note": "study session",
-	}))
-
-	// Upsert a goal
-	doRequest(handler, jsonRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, map[string]any{
-		"targetMinutesPerWeek": 200, "startDate": "2024-01-01",
-	}))
-
-	// Get weekly stats
-	statsReq := jsonRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-01", nil)
-	statsRR := doRequest(handler, statsReq)
-
-	if statsRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, statsRR.Code, statsRR.Body.String())
-	}
-
-	var statsResp map[string]any
-	parseJSON(t, statsRR, &statsResp)
+	// Create user first
+	userID, token := signUp(t, handler, "Alice")
 
-	if statsResp["weekStart"] != "2024-01-01" {
-		t.Errorf("expected weekStart '2024-01-01', got '%v'", statsResp["weekStart"])
-	}
-	if int(statsResp["totalMinutes"].(float64)) != 90 {
-		t.Errorf("expected totalMinutes 90, got %v", statsResp["totalMinutes"])
-	}
+	// Create project
+	createProjReq := authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Mathematics"})
+	createProjRR := doRequest(handler, createProjReq)
 
-	projects, ok := statsResp["projects"].([]any)
-	if !ok {
-		t.Fatalf("expected projects to be a list")
-	}
-	if len(projects) != 1 {
-		t.Fatalf("expected 1 project, got %d", len(projects))
+	if createProjRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createProjRR.Code, createProjRR.Body.String())
 	}
 
-	mathStats := projects[0].(map[string]any)
-	if mathStats["projectName"] != "Math" {
-		t.Errorf("expected projectName 'Math', got '%v'", mathStats["projectName"])
-	}
-	if int(mathStats["totalMinutes"].(float64)) != 90 {
-		t.Errorf("expected totalMinutes 90 for Math, got %v", mathStats["totalMinutes"])
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	if proj["name"] != "Mathematics" {
+		t.Errorf("expected name 'Mathematics', got '%v'", proj["name"])
 	}
-	if int(mathStats["targetMinutesPerWeek"].(float64)) != 200 {
-		t.Errorf("expected targetMinutesPerWeek 200, got %v", mathStats["targetMinutesPerWeek"])
+	if proj["userId"] != userID {
+		t.Errorf("expected userId '%s', got '%v'", userID, proj["userId"])
 	}
-	// Achievement rate: 90/200 * 100 = 45.0
-	if mathStats["achievementRate"].(float64) != 45.0 {
-		t.Errorf("expected achievementRate 45.0, got %v", mathStats["achievementRate"])
+	if proj["id"] == nil || proj["id"] == "" {
+		t.Error("expected project id to be set")
 	}
 }
 
-func TestGetWeeklyStats_MissingWeekStart(t *testing.T) {
+func TestListProjects_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create a user first so we don't get 404 for user
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	// Create user
+	userID, token := signUp(t, handler, "Alice")
 
-	// weekStart is required:"true" in the handler, Huma will return 422 for missing required query param
-	statsReq := jsonRequest("GET", "/v1/users/"+userID+"/stats/weekly", nil)
-	statsRR := doRequest(handler, statsReq)
+	// Create two projects
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "English"}))
 
-	// Huma returns 422 for missing required query parameters
-	if statsRR.Code != http.StatusUnprocessableEntity {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, statsRR.Code, statsRR.Body.String())
+	// List projects
+	listReq := authRequest("GET", "/v1/users/"+userID+"/projects", token, nil)
+	listRR := doRequest(handler, listReq)
+
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+
+	var projects []map[string]any
+	parseJSON(t, listRR, &projects)
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
 	}
 }
 
-func TestGetWeeklyStats_InvalidWeekStartFormat(t *testing.T) {
+func TestListProjects_Paginated(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create user
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	userID, token := signUp(t, handler, "Alice")
+	for _, name := range []string{"Math", "English", "History"} {
+		doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": name}))
+	}
 
-	// Invalid date format
-	statsReq := jsonRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=not-a-date", nil)
-	statsRR := doRequest(handler, statsReq)
+	firstRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects?limit=2", token, nil))
+	if firstRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, firstRR.Code, firstRR.Body.String())
+	}
+	var first []map[string]any
+	parseJSON(t, firstRR, &first)
+	if len(first) != 2 || first[0]["name"] != "English" || first[1]["name"] != "History" {
+		t.Fatalf("unexpected first page: %v", first)
+	}
+	cursor := firstRR.Header().Get("X-Next-Cursor")
+	if cursor == "" {
+		t.Fatal("expected X-Next-Cursor header")
+	}
+	link := firstRR.Header().Get("Link")
+	if !strings.HasPrefix(link, "</v1/users/"+userID+"/projects?") || !strings.HasSuffix(link, `>; rel="next"`) || !strings.Contains(link, "cursor="+cursor) {
+		t.Errorf("unexpected Link header: %s", link)
+	}
 
-	if statsRR.Code != http.StatusBadRequest {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, statsRR.Code, statsRR.Body.String())
+	nextPath := strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+	secondRR := doRequest(handler, authRequest("GET", nextPath, token, nil))
+	if secondRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, secondRR.Code, secondRR.Body.String())
+	}
+	var second []map[string]any
+	parseJSON(t, secondRR, &second)
+	if len(second) != 1 || second[0]["name"] != "Math" {
+		t.Fatalf("unexpected second page: %v", second)
+	}
+	if secondRR.Header().Get("Link") != "" || secondRR.Header().Get("X-Next-Cursor") != "" {
+		t.Error("expected no next page headers on the last page")
 	}
 }
 
-// --- Additional edge case tests ---
-
-func TestCreateProject_UserNotFound(t *testing.T) {
+func TestListProjects_InvalidCursor(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	req := jsonRequest("POST", "/v1/users/nonexistent-user/projects", map[string]string{"name": "Math"})
-	rr := doRequest(handler, req)
+	userID, token := signUp(t, handler, "Alice")
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects?cursor=bogus", token, nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
 	}
 }
 
-func TestUpdateProject_NotFound(t *testing.T) {
+func TestUpdateProject_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	req := jsonRequest("PUT", "/v1/projects/nonexistent-id", map[string]string{"name": "Updated"})
-	rr := doRequest(handler, req)
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
-	}
-}
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
 
-func TestDeleteProject_NotFound(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
+	// Update project
+	updateReq := authRequest("PUT", "/v1/projects/"+projectID, token, map[string]string{"name": "Advanced Math"})
+	updateRR := doRequest(handler, updateReq)
 
-	req := jsonRequest("DELETE", "/v1/projects/nonexistent-id", nil)
-	rr := doRequest(handler, req)
+	if updateRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, updateRR.Code, updateRR.Body.String())
+	}
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	var updated map[string]any
+	parseJSON(t, updateRR, &updated)
+	if updated["name"] != "Advanced Math" {
+		t.Errorf("expected name 'Advanced Math', got '%v'", updated["name"])
 	}
 }
 
-func TestDeleteStudyLog_NotFound(t *testing.T) {
+func TestDeleteProject_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	req := jsonRequest("DELETE", "/v1/study-logs/nonexistent-id", nil)
-	rr := doRequest(handler, req)
-
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
-	}
-}
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
 
-func TestUpsertGoal_UserNotFound(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
 
-	body := map[string]any{
-		"targetMinutesPerWeek": 300,
-		"startDate":            "2024-01-01",
-	}
-	req := jsonRequest("PUT", "/v1/users/nonexistent/goals/some-project", body)
-	rr := doRequest(handler, req)
+	// Delete project
+	deleteReq := authRequest("DELETE", "/v1/projects/"+projectID+"?force=true", token, nil)
+	deleteRR := doRequest(handler, deleteReq)
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	if deleteRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
 	}
 }
 
-func TestListGoals_UserNotFound(t *testing.T) {
+func TestListProjects_OtherUser(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
 
-	req := jsonRequest("GET", "/v1/users/nonexistent/goals", nil)
+	req := authRequest("GET", "/v1/users/nonexistent-user/projects", token, nil)
 	rr := doRequest(handler, req)
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
 	}
 }
 
-// --- Note Tests ---
+// --- StudyLog Tests ---
 
-func TestCreateNote_Success(t *testing.T) {
+func TestCreateStudyLog_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	// Create user
+	userID, token := signUp(t, handler, "Alice")
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	// Create project
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
 
-	// Create note
+	// Create study log
+	studiedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 	body := map[string]any{
-		"title":   "My Note",
-		"content": "some content",
-		"tags":    []string{"go", "api"},
+		"projectId": projectID,
+		"studiedAt": studiedAt.Format(time.RFC3339),
+		"minutes":   60,
+		"note":      "Chapter 1",
 	}
-	createNoteReq := jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", body)
-	createNoteRR := doRequest(handler, createNoteReq)
+	createLogReq := authRequest("POST", "/v1/users/"+userID+"/study-logs", token, body)
+	createLogRR := doRequest(handler, createLogReq)
 
-	if createNoteRR.Code != http.StatusCreated {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createNoteRR.Code, createNoteRR.Body.String())
+	if createLogRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createLogRR.Code, createLogRR.Body.String())
 	}
 
-	var noteResp map[string]any
-	parseJSON(t, createNoteRR, &noteResp)
-	if noteResp["title"] != "My Note" {
-		t.Errorf("expected title 'My Note', got '%v'", noteResp["title"])
-	}
-	if noteResp["content"] != "some content" {
-		t.Errorf("expected content 'some content', got '%v'", noteResp["content"])
+	var logResp map[string]any
+	parseJSON(t, createLogRR, &logResp)
+	if logResp["userId"] != userID {
+		t.Errorf("expected userId '%s', got '%v'", userID, logResp["userId"])
 	}
-	if noteResp["userId"] != userID {
-		t.Errorf("expected userId '%s', got '%v'", userID, noteResp["userId"])
+	if logResp["projectId"] != projectID {
+		t.Errorf("expected projectId '%s', got '%v'", projectID, logResp["projectId"])
 	}
-	if noteResp["projectId"] != projectID {
-		t.Errorf("expected projectId '%s', got '%v'", projectID, noteResp["projectId"])
+	if int(logResp["minutes"].(float64)) != 60 {
+		t.Errorf("expected minutes 60, got %v", logResp["minutes"])
 	}
-	tags, ok := noteResp["tags"].([]any)
-	if !ok || len(tags) != 2 {
-		t.Errorf("expected 2 tags, got %v", noteResp["tags"])
+	if logResp["note"] != "Chapter 1" {
+		t.Errorf("expected note 'Chapter 1', got '%v'", logResp["note"])
 	}
 }
 
-func TestListNotes_Success(t *testing.T) {
+func TestCreateStudyLog_EndedAtAndOverlap(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create user and project
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
-
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	userID, token := signUp(t, handler, "Alice")
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
 
-	// Create two notes
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", map[string]any{
-		"title": "Note 1", "content": "content 1",
-	}))
-	doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", map[string]any{
-		"title": "Note 2", "content": "content 2",
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": start.Format(time.RFC3339), "endedAt": start.Add(8 * time.Hour).Format(time.RFC3339),
 	}))
-
-	// List notes
-	listReq := jsonRequest("GET", "/v1/users/"+userID+"/projects/"+projectID+"/notes", nil)
-	listRR := doRequest(handler, listReq)
-
-	if listRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var log map[string]any
+	parseJSON(t, rr, &log)
+	if log["minutes"] != float64(480) || log["endedAt"] != "2024-01-15T17:00:00Z" {
+		t.Errorf("expected 480 minutes ending at 17:00, got %v minutes ending at %v", log["minutes"], log["endedAt"])
 	}
 
-	var notes []map[string]any
-	parseJSON(t, listRR, &notes)
-	if len(notes) != 2 {
-		t.Fatalf("expected 2 notes, got %d", len(notes))
+	rr = doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": start.Add(time.Hour).Format(time.RFC3339), "minutes": 480,
+	}))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusConflict, rr.Code, rr.Body.String())
 	}
 }
 
-func TestGetNote_Success(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
-
-	// Create user, project, and note
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+func TestCreateStudyLogBatch(t *testing.T) {
+	handler, _, _, studyLogRepo, _ := setupRouter(t)
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	userID, token := signUp(t, handler, "Alice")
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
+	hour := func(h int) string { return time.Date(2024, 1, 15, h, 0, 0, 0, time.UTC).Format(time.RFC3339) }
 
-	createNoteRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", map[string]any{
-		"title": "My Note", "content": "some content",
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs:batch", token, map[string]any{
+		"logs": []map[string]any{
+			{"projectId": projectID, "studiedAt": hour(9), "minutes": 30},
+			{"projectId": projectID, "studiedAt": hour(10), "minutes": 45},
+		},
 	}))
-	var created map[string]any
-	parseJSON(t, createNoteRR, &created)
-	noteID := created["id"].(string)
-
-	// Get note
-	getReq := jsonRequest("GET", "/v1/notes/"+noteID, nil)
-	getRR := doRequest(handler, getReq)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected 2 logs, got %d", len(studyLogRepo.logs))
+	}
 
-	if getRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, getRR.Code, getRR.Body.String())
+	rr = doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs:batch", token, map[string]any{
+		"logs": []map[string]any{
+			{"projectId": projectID, "studiedAt": hour(11), "minutes": 30},
+			{"projectId": "nonexistent", "studiedAt": hour(12), "minutes": 30},
+		},
+	}))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected no logs from the failed atomic batch, got %d", len(studyLogRepo.logs)-2)
 	}
 
-	var noteResp map[string]any
-	parseJSON(t, getRR, &noteResp)
-	if noteResp["title"] != "My Note" {
-		t.Errorf("expected title 'My Note', got '%v'", noteResp["title"])
+	rr = doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs:batch", token, map[string]any{
+		"mode": "bestEffort",
+		"logs": []map[string]any{
+			{"projectId": projectID, "studiedAt": hour(13), "minutes": 30},
+			{"projectId": "nonexistent", "studiedAt": hour(14), "minutes": 30},
+		},
+	}))
+	if rr.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusMultiStatus, rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Created int
+		Failed  int
+		Results []struct {
+			Index  int
+			Status int
+			Error  string
+		}
+	}
+	parseJSON(t, rr, &resp)
+	if resp.Created != 1 || resp.Failed != 1 {
+		t.Errorf("expected 1 created and 1 failed, got %d and %d", resp.Created, resp.Failed)
+	}
+	if resp.Results[0].Status != http.StatusCreated || resp.Results[1].Status != http.StatusNotFound || resp.Results[1].Error == "" {
+		t.Errorf("unexpected results: %+v", resp.Results)
 	}
 }
 
-func TestGetNote_NotFound(t *testing.T) {
+func TestListStudyLogs_Success(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	req := jsonRequest("GET", "/v1/notes/nonexistent-id", nil)
-	rr := doRequest(handler, req)
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	// Create two study logs
+	studiedAt1 := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	studiedAt2 := time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt1.Format(time.RFC3339), "minutes": 60, "note": "session 1",
+	}))
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt2.Format(time.RFC3339), "minutes": 45, "note": "session 2",
+	}))
+
+	// List study logs
+	listReq := authRequest("GET", "/v1/users/"+userID+"/study-logs", token, nil)
+	listRR := doRequest(handler, listReq)
+
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+
+	var logs []map[string]any
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(logs))
 	}
 }
 
-func TestUpdateNote_Success(t *testing.T) {
+func TestListStudyLogs_WithDateFilter(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
 
-	// Create user, project, and note
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
 
-	createNoteRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", map[string]any{
-		"title": "Old Title", "content": "old content",
+	// Create logs on different dates
+	jan15 := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	feb10 := time.Date(2024, 2, 10, 10, 0, 0, 0, time.UTC)
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": jan15.Format(time.RFC3339), "minutes": 60, "note": "january",
+	}))
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": feb10.Format(time.RFC3339), "minutes": 45, "note": "february",
 	}))
-	var created map[string]any
-	parseJSON(t, createNoteRR, &created)
-	noteID := created["id"].(string)
 
-	// Update note
-	updateReq := jsonRequest("PUT", "/v1/notes/"+noteID, map[string]any{
-		"title": "New Title", "content": "new content", "tags": []string{"updated"},
-	})
-	updateRR := doRequest(handler, updateReq)
+	// Filter to January only
+	listReq := authRequest("GET", "/v1/users/"+userID+"/study-logs?from=2024-01-01&to=2024-01-31", token, nil)
+	listRR := doRequest(handler, listReq)
 
-	if updateRR.Code != http.StatusOK {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, updateRR.Code, updateRR.Body.String())
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
 	}
 
-	var updated map[string]any
-	parseJSON(t, updateRR, &updated)
-	if updated["title"] != "New Title" {
-		t.Errorf("expected title 'New Title', got '%v'", updated["title"])
+	var logs []map[string]any
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log in January, got %d", len(logs))
 	}
-	if updated["content"] != "new content" {
-		t.Errorf("expected content 'new content', got '%v'", updated["content"])
+	if logs[0]["note"] != "january" {
+		t.Errorf("expected note 'january', got '%v'", logs[0]["note"])
 	}
 }
 
-func TestUpdateNote_NotFound(t *testing.T) {
+func TestListStudyLogs_WithTimezone(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	req := jsonRequest("PUT", "/v1/notes/nonexistent-id", map[string]any{
-		"title": "Title", "content": "content",
-	})
-	rr := doRequest(handler, req)
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+
+	// 23:30 on Jan 15 in Tokyo is still Jan 15 14:30 in UTC; 00:30 on Jan 16 in Tokyo is Jan 15 in UTC.
+	for _, studiedAt := range []string{"2024-01-15T23:30:00+09:00", "2024-01-16T00:30:00+09:00"} {
+		doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+			"projectId": proj["id"], "studiedAt": studiedAt, "minutes": 30,
+		}))
+	}
+
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs?from=2024-01-15&to=2024-01-15&tz=Asia/Tokyo", token, nil))
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+	var logs []map[string]any
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 1 {
+		t.Errorf("expected 1 log on Jan 15 in Tokyo, got %d", len(logs))
+	}
+
+	utcRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs?from=2024-01-15&to=2024-01-15", token, nil))
+	parseJSON(t, utcRR, &logs)
+	if len(logs) != 2 {
+		t.Errorf("expected 2 logs on Jan 15 in the default UTC timezone, got %d", len(logs))
+	}
+}
+
+func TestListStudyLogs_InvalidTimezone(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs?from=2024-01-15&tz=Nowhere/City", token, nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
+
+// createStudyLog creates a project and a study log in it, returning the study log ID.
+func createStudyLog(t *testing.T, handler http.Handler, userID, token string) string {
+	t.Helper()
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	logRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": proj["id"], "studiedAt": "2024-01-15T10:00:00Z", "minutes": 60, "note": "chapter 1",
+	}))
+	if logRR.Code != http.StatusCreated {
+		t.Fatalf("setup: create study log failed with status %d; body: %s", logRR.Code, logRR.Body.String())
+	}
+	var log map[string]any
+	parseJSON(t, logRR, &log)
+	return log["id"].(string)
+}
+
+func TestUpdateStudyLog_Put(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	logID := createStudyLog(t, handler, userID, token)
+	var logs []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs", token, nil)), &logs)
+	before := logs[0]
+
+	rr := doRequest(handler, authRequest("PUT", "/v1/study-logs/"+logID, token, map[string]any{
+		"projectId": before["projectId"], "studiedAt": "2024-01-15T11:00:00Z", "minutes": 90, "note": "chapter 2",
+	}))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	if resp["id"] != logID || resp["createdAt"] != before["createdAt"] {
+		t.Error("expected ID and createdAt to be kept")
+	}
+	if resp["minutes"] != float64(90) || resp["note"] != "chapter 2" || resp["studiedAt"] != "2024-01-15T11:00:00Z" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
+
+func TestUpdateStudyLog_PatchAndRevert(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	logID := createStudyLog(t, handler, userID, token)
+
+	rr := doRequest(handler, authRequest("PATCH", "/v1/study-logs/"+logID, token, map[string]any{"minutes": 45}))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	if resp["minutes"] != float64(45) || resp["note"] != "chapter 1" {
+		t.Errorf("expected only minutes to change, got %v", resp)
+	}
+
+	revRR := doRequest(handler, authRequest("GET", "/v1/study-logs/"+logID+"/revisions", token, nil))
+	if revRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, revRR.Code, revRR.Body.String())
+	}
+	var revisions []map[string]any
+	parseJSON(t, revRR, &revisions)
+	if len(revisions) != 1 || revisions[0]["minutes"] != float64(60) || revisions[0]["editedBy"] != userID {
+		t.Fatalf("expected 1 revision with the previous minutes, got %v", revisions)
+	}
+
+	revertRR := doRequest(handler, authRequest("POST", "/v1/study-logs/"+logID+"/revisions/"+revisions[0]["id"].(string)+"/revert", token, nil))
+	if revertRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, revertRR.Code, revertRR.Body.String())
+	}
+	parseJSON(t, revertRR, &resp)
+	if resp["minutes"] != float64(60) {
+		t.Errorf("expected minutes to be reverted to 60, got %v", resp["minutes"])
+	}
+}
+
+func TestUpdateStudyLog_Invalid(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	logID := createStudyLog(t, handler, userID, token)
+
+	rr := doRequest(handler, authRequest("PATCH", "/v1/study-logs/"+logID, token, map[string]any{"minutes": 0}))
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	}
+}
 
+func TestUpdateStudyLog_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	logID := createStudyLog(t, handler, userID, token)
+	_, otherToken := signUp(t, handler, "Bob")
+
+	rr := doRequest(handler, authRequest("PATCH", "/v1/study-logs/"+logID, otherToken, map[string]any{"note": "mine"}))
 	if rr.Code != http.StatusNotFound {
 		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
 	}
+	revRR := doRequest(handler, authRequest("GET", "/v1/study-logs/"+logID+"/revisions", otherToken, nil))
+	if revRR.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, revRR.Code, revRR.Body.String())
+	}
 }
 
-func TestDeleteNote_Success(t *testing.T) {
+func csvRequest(path, token, body string) *http.Request {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestImportStudyLogs_Success(t *testing.T) {
+	handler, _, projectRepo, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	csv := "Start,Duration,Project,Description\n" +
+		"2024-01-15 09:00,1:30,Math,limits\n" +
+		"2024-01-16 09:00,oops,Math,\n"
+	path := "/v1/users/" + userID + "/study-logs/import?dateColumn=Start&durationColumn=Duration&projectColumn=Project&noteColumn=Description"
+
+	rr := doRequest(handler, csvRequest(path, token, csv))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	if resp["imported"] != float64(1) || resp["failed"] != float64(1) {
+		t.Errorf("expected 1 imported and 1 failed, got %v", resp)
+	}
+	if errs := resp["errors"].([]any); len(errs) != 1 || errs[0].(map[string]any)["line"] != float64(3) {
+		t.Errorf("expected an error on line 3, got %v", resp["errors"])
+	}
+	if len(projectRepo.projects) != 1 || len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 project and 1 study log, got %d and %d", len(projectRepo.projects), len(studyLogRepo.logs))
+	}
+
+	again := doRequest(handler, csvRequest(path, token, csv))
+	parseJSON(t, again, &resp)
+	if resp["imported"] != float64(0) || resp["duplicates"] != float64(1) {
+		t.Errorf("expected the re-import to skip the duplicate, got %v", resp)
+	}
+}
+
+func TestImportStudyLogs_DryRun(t *testing.T) {
+	handler, _, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	rr := doRequest(handler, csvRequest("/v1/users/"+userID+"/study-logs/import?dryRun=true", token, "date,minutes,project\n2024-01-15 09:00,60,Math\n"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	parseJSON(t, rr, &resp)
+	if resp["dryRun"] != true || resp["imported"] != float64(1) {
+		t.Errorf("unexpected response: %v", resp)
+	}
+	if len(studyLogRepo.logs) != 0 {
+		t.Errorf("expected nothing to be saved, got %d study logs", len(studyLogRepo.logs))
+	}
+}
+
+func TestImportStudyLogs_MissingColumn(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	// Create user, project, and note
-	createUserRR := doRequest(handler, jsonRequest("POST", "/v1/users", map[string]string{"name": "Alice"}))
-	var user map[string]any
-	parseJSON(t, createUserRR, &user)
-	userID := user["id"].(string)
+	rr := doRequest(handler, csvRequest("/v1/users/"+userID+"/study-logs/import", token, "when,minutes,project\n2024-01-15,60,Math\n"))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
+
+func TestImportStudyLogs_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+	otherID, _ := signUp(t, handler, "Bob")
+
+	rr := doRequest(handler, csvRequest("/v1/users/"+otherID+"/study-logs/import", token, "date,minutes,project\n"))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+func TestDeleteStudyLog_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user, project, and study log
+	userID, token := signUp(t, handler, "Alice")
 
-	createProjRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects", map[string]string{"name": "Math"}))
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
 	var proj map[string]any
 	parseJSON(t, createProjRR, &proj)
 	projectID := proj["id"].(string)
 
-	createNoteRR := doRequest(handler, jsonRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", map[string]any{
-		"title": "To Delete", "content": "content",
+	studiedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	createLogRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt.Format(time.RFC3339), "minutes": 60, "note": "to delete",
 	}))
-	var created map[string]any
-	parseJSON(t, createNoteRR, &created)
-	noteID := created["id"].(string)
+	var logResp map[string]any
+	parseJSON(t, createLogRR, &logResp)
+	logID := logResp["id"].(string)
 
-	// Delete note
-	deleteReq := jsonRequest("DELETE", "/v1/notes/"+noteID, nil)
+	// Delete study log
+	deleteReq := authRequest("DELETE", "/v1/study-logs/"+logID, token, nil)
 	deleteRR := doRequest(handler, deleteReq)
 
 	if deleteRR.Code != http.StatusNoContent {
@@ -1133,25 +2586,1670 @@ func TestDeleteNote_Success(t *testing.T) {
 	}
 }
 
-func TestDeleteNote_NotFound(t *testing.T) {
+// --- Timer Tests ---
+
+func TestActivityTypes_TagFilterAndStats(t *testing.T) {
 	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
 
-	req := jsonRequest("DELETE", "/v1/notes/nonexistent-id", nil)
-	rr := doRequest(handler, req)
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	// Create an activity type; the same name again is a conflict
+	createTypeRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/activity-types", token, map[string]string{"name": "Reading"}))
+	if createTypeRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createTypeRR.Code, createTypeRR.Body.String())
+	}
+	var activityType map[string]any
+	parseJSON(t, createTypeRR, &activityType)
+	activityTypeID := activityType["id"].(string)
+
+	dupRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/activity-types", token, map[string]string{"name": "Reading"}))
+	if dupRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, dupRR.Code)
+	}
+
+	// Log one tagged and one untagged session
+	studiedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	taggedRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "activityTypeId": activityTypeID, "studiedAt": studiedAt.Format(time.RFC3339), "minutes": 60,
+	}))
+	if taggedRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, taggedRR.Code, taggedRR.Body.String())
+	}
+	var tagged map[string]any
+	parseJSON(t, taggedRR, &tagged)
+	if tagged["activityTypeId"] != activityTypeID {
+		t.Errorf("expected activityTypeId '%s', got '%v'", activityTypeID, tagged["activityTypeId"])
+	}
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt.Add(2 * time.Hour).Format(time.RFC3339), "minutes": 30,
+	}))
+
+	// Filter the list by activity type
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs?activityTypeId="+activityTypeID, token, nil))
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+	var logs []map[string]any
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 1 || logs[0]["id"] != tagged["id"] {
+		t.Errorf("expected only the tagged log, got %v", logs)
+	}
+
+	// Weekly stats break the project's minutes down by activity type
+	statsRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-01", token, nil))
+	var statsResp struct {
+		Projects []struct {
+			Activities []struct {
+				ActivityTypeID   string `json:"activityTypeId"`
+				ActivityTypeName string `json:"activityTypeName"`
+				TotalMinutes     int    `json:"totalMinutes"`
+			} `json:"activities"`
+		} `json:"projects"`
+	}
+	parseJSON(t, statsRR, &statsResp)
+	if len(statsResp.Projects) != 1 || len(statsResp.Projects[0].Activities) != 2 {
+		t.Fatalf("expected 1 project with 2 activities, got %+v", statsResp)
+	}
+	reading, untyped := statsResp.Projects[0].Activities[0], statsResp.Projects[0].Activities[1]
+	if reading.ActivityTypeID != activityTypeID || reading.ActivityTypeName != "Reading" || reading.TotalMinutes != 60 {
+		t.Errorf("unexpected Reading activity: %+v", reading)
+	}
+	if untyped.ActivityTypeID != "" || untyped.TotalMinutes != 30 {
+		t.Errorf("unexpected untyped activity: %+v", untyped)
+	}
+
+	// Deleting the activity type keeps its study logs
+	delRR := doRequest(handler, authRequest("DELETE", "/v1/activity-types/"+activityTypeID, token, nil))
+	if delRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, delRR.Code, delRR.Body.String())
+	}
+	listRR = doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs", token, nil))
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 2 {
+		t.Errorf("expected 2 logs after deleting the activity type, got %d", len(logs))
 	}
 }
 
-func TestCreateNote_UserNotFound(t *testing.T) {
-	handler, _, _, _, _ := setupRouter(t)
+func TestTimer_StartPauseResumeStop(t *testing.T) {
+	handler, userRepo, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
 
-	body := map[string]any{"title": "Note", "content": "content"}
-	req := jsonRequest("POST", "/v1/users/nonexistent/projects/some-project/notes", body)
-	rr := doRequest(handler, req)
+	startRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/start", token, map[string]any{"projectId": proj["id"], "note": "chapter 1"}))
+	if startRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, startRR.Code, startRR.Body.String())
+	}
+	var timer map[string]any
+	parseJSON(t, startRR, &timer)
+	if timer["state"] != "running" || timer["autoStopAt"] == nil {
+		t.Errorf("expected a running timer, got %v", timer)
+	}
 
-	if rr.Code != http.StatusNotFound {
-		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	againRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/start", token, map[string]any{"projectId": proj["id"]}))
+	if againRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a second timer, got %d", http.StatusConflict, againRR.Code)
+	}
+
+	pauseRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/pause", token, nil))
+	parseJSON(t, pauseRR, &timer)
+	if pauseRR.Code != http.StatusOK || timer["state"] != "paused" {
+		t.Fatalf("expected a paused timer, got %d; body: %s", pauseRR.Code, pauseRR.Body.String())
+	}
+	resumeRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/resume", token, nil))
+	parseJSON(t, resumeRR, &timer)
+	if resumeRR.Code != http.StatusOK || timer["state"] != "running" {
+		t.Fatalf("expected a running timer, got %d; body: %s", resumeRR.Code, resumeRR.Body.String())
+	}
+
+	// Pretend the timer has been running for 40 minutes.
+	stored := userRepo.timerRepo.timers[userID]
+	resumedAt := stored.ResumedAt.Add(-40 * time.Minute)
+	stored.ResumedAt = &resumedAt
+
+	stopRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/stop", token, nil))
+	if stopRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, stopRR.Code, stopRR.Body.String())
+	}
+	var log map[string]any
+	parseJSON(t, stopRR, &log)
+	if log["minutes"] != float64(40) || log["note"] != "chapter 1" {
+		t.Errorf("unexpected study log: %v", log)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 study log, got %d", len(studyLogRepo.logs))
+	}
+
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/timer", token, nil))
+	if getRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d after stopping, got %d", http.StatusNotFound, getRR.Code)
+	}
+}
+
+func TestTimer_StopWithNote(t *testing.T) {
+	handler, userRepo, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	timer, _ := domain.NewTimer(userID, proj["id"].(string), "", time.Now().Add(-15*time.Minute))
+	userRepo.timerRepo.timers[userID] = timer
+
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/stop", token, map[string]string{"note": "done"}))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var log map[string]any
+	parseJSON(t, rr, &log)
+	if log["minutes"] != float64(15) || log["note"] != "done" {
+		t.Errorf("unexpected study log: %v", log)
+	}
+}
+
+func TestTimer_Discard(t *testing.T) {
+	handler, _, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/timer/start", token, map[string]any{"projectId": proj["id"]}))
+
+	rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+userID+"/timer", token, nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+	if len(studyLogRepo.logs) != 0 {
+		t.Errorf("expected no study log, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestTimer_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+	otherID, _ := signUp(t, handler, "Bob")
+
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+otherID+"/timer", token, nil))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+// --- Pomodoro Tests ---
+
+func TestPomodoro_StartAndStop(t *testing.T) {
+	handler, userRepo, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+
+	startRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/pomodoro/start", token, map[string]any{"projectId": proj["id"], "note": "drills"}))
+	if startRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, startRR.Code, startRR.Body.String())
+	}
+	var session map[string]any
+	parseJSON(t, startRR, &session)
+	if session["phase"] != "work" || session["workMinutes"] != float64(25) || session["cycles"] != float64(4) || session["phaseEndsAt"] == nil {
+		t.Errorf("expected a default session in its first work phase, got %v", session)
+	}
+
+	againRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/pomodoro/start", token, map[string]any{"projectId": proj["id"]}))
+	if againRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a second session, got %d", http.StatusConflict, againRR.Code)
+	}
+	skipRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/pomodoro/skip-break", token, nil))
+	if skipRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d when skipping during work, got %d", http.StatusConflict, skipRR.Code)
+	}
+
+	// Pretend the session started 35 minutes ago: one pomodoro done and the break just ended.
+	stored := userRepo.pomodoroRepo.sessions[userID]
+	stored.PhaseStartedAt = stored.PhaseStartedAt.Add(-35 * time.Minute)
+	stored.StartedAt = stored.PhaseStartedAt
+
+	getRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/pomodoro", token, nil))
+	var current map[string]any
+	parseJSON(t, getRR, &current)
+	if getRR.Code != http.StatusOK || current["phase"] != "work" || current["completedPomodoros"] != float64(1) {
+		t.Fatalf("expected the second work phase, got %d: %v", getRR.Code, current)
+	}
+
+	// Stopping five minutes into the second work phase records it without a pomodoro.
+	stopRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/pomodoro/stop", token, nil))
+	var stopped map[string]any
+	parseJSON(t, stopRR, &stopped)
+	if stopRR.Code != http.StatusOK || stopped["phase"] != "finished" || stopped["phaseEndsAt"] != nil {
+		t.Fatalf("expected a finished session, got %d: %v", stopRR.Code, stopped)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Fatalf("expected 2 study logs, got %d", len(studyLogRepo.logs))
+	}
+	minutes, pomodoros := 0, 0
+	for _, log := range studyLogRepo.logs {
+		minutes += log.Minutes
+		pomodoros += log.Pomodoros
+		if log.Note != "drills" {
+			t.Errorf("expected note 'drills', got %q", log.Note)
+		}
+	}
+	if minutes != 30 || pomodoros != 1 {
+		t.Errorf("expected 30 minutes and 1 pomodoro, got %d and %d", minutes, pomodoros)
+	}
+
+	notFoundRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/pomodoro", token, nil))
+	if notFoundRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d after stopping, got %d", http.StatusNotFound, notFoundRR.Code)
+	}
+}
+
+func TestPomodoro_InvalidSettings(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+
+	rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/pomodoro/start", token, map[string]any{"projectId": proj["id"], "workMinutes": 500}))
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	}
+}
+
+// --- Goal Tests ---
+
+func TestUpsertGoal_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	// Upsert goal
+	body := map[string]any{
+		"targetMinutesPerWeek": 300,
+		"startDate":            "2024-01-01",
+	}
+	upsertReq := authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, token, body)
+	upsertRR := doRequest(handler, upsertReq)
+
+	if upsertRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, upsertRR.Code, upsertRR.Body.String())
+	}
+
+	var goalResp map[string]any
+	parseJSON(t, upsertRR, &goalResp)
+	if goalResp["userId"] != userID {
+		t.Errorf("expected userId '%s', got '%v'", userID, goalResp["userId"])
+	}
+	if goalResp["projectId"] != projectID {
+		t.Errorf("expected projectId '%s', got '%v'", projectID, goalResp["projectId"])
+	}
+	if int(goalResp["targetMinutesPerWeek"].(float64)) != 300 {
+		t.Errorf("expected targetMinutesPerWeek 300, got %v", goalResp["targetMinutesPerWeek"])
+	}
+	if goalResp["startDate"] != "2024-01-01" {
+		t.Errorf("expected startDate '2024-01-01', got '%v'", goalResp["startDate"])
+	}
+	if goalResp["endDate"] != nil {
+		t.Errorf("expected endDate nil, got '%v'", goalResp["endDate"])
+	}
+}
+
+func TestUpsertGoal_WithEndDate(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	endDate := "2024-06-30"
+	body := map[string]any{
+		"targetMinutesPerWeek": 200,
+		"startDate":            "2024-01-01",
+		"endDate":              endDate,
+	}
+	upsertReq := authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, token, body)
+	upsertRR := doRequest(handler, upsertReq)
+
+	if upsertRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, upsertRR.Code, upsertRR.Body.String())
+	}
+
+	var goalResp map[string]any
+	parseJSON(t, upsertRR, &goalResp)
+	if goalResp["endDate"] != "2024-06-30" {
+		t.Errorf("expected endDate '2024-06-30', got '%v'", goalResp["endDate"])
+	}
+}
+
+func TestListGoals_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and two projects
+	userID, token := signUp(t, handler, "Alice")
+
+	createProj1RR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj1 map[string]any
+	parseJSON(t, createProj1RR, &proj1)
+	projectID1 := proj1["id"].(string)
+
+	createProj2RR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "English"}))
+	var proj2 map[string]any
+	parseJSON(t, createProj2RR, &proj2)
+	projectID2 := proj2["id"].(string)
+
+	// Upsert goals for both projects
+	doRequest(handler, authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID1, token, map[string]any{
+		"targetMinutesPerWeek": 300, "startDate": "2024-01-01",
+	}))
+	doRequest(handler, authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID2, token, map[string]any{
+		"targetMinutesPerWeek": 150, "startDate": "2024-01-01",
+	}))
+
+	// List goals
+	listReq := authRequest("GET", "/v1/users/"+userID+"/goals", token, nil)
+	listRR := doRequest(handler, listReq)
+
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+
+	var goals []map[string]any
+	parseJSON(t, listRR, &goals)
+	if len(goals) != 2 {
+		t.Fatalf("expected 2 goals, got %d", len(goals))
+	}
+}
+
+// --- Stats Tests ---
+
+func TestGetWeeklyStats_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	// Create a study log within the week
+	studiedAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC) // Tuesday in the week of 2024-01-01
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt.Format(time.RFC3339), "minutes": 90, "note": "study session",
+	}))
+
+	// Upsert a goal
+	doRequest(handler, authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, token, map[string]any{
+		"targetMinutesPerWeek": 200, "startDate": "2024-01-01",
+	}))
+
+	// Get weekly stats
+	statsReq := authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-01", token, nil)
+	statsRR := doRequest(handler, statsReq)
+
+	if statsRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, statsRR.Code, statsRR.Body.String())
+	}
+
+	var statsResp map[string]any
+	parseJSON(t, statsRR, &statsResp)
+
+	if statsResp["weekStart"] != "2024-01-01" {
+		t.Errorf("expected weekStart '2024-01-01', got '%v'", statsResp["weekStart"])
+	}
+	if int(statsResp["totalMinutes"].(float64)) != 90 {
+		t.Errorf("expected totalMinutes 90, got %v", statsResp["totalMinutes"])
+	}
+
+	projects, ok := statsResp["projects"].([]any)
+	if !ok {
+		t.Fatalf("expected projects to be a list")
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+
+	mathStats := projects[0].(map[string]any)
+	if mathStats["projectName"] != "Math" {
+		t.Errorf("expected projectName 'Math', got '%v'", mathStats["projectName"])
+	}
+	if int(mathStats["totalMinutes"].(float64)) != 90 {
+		t.Errorf("expected totalMinutes 90 for Math, got %v", mathStats["totalMinutes"])
+	}
+	if int(mathStats["targetMinutesPerWeek"].(float64)) != 200 {
+		t.Errorf("expected targetMinutesPerWeek 200, got %v", mathStats["targetMinutesPerWeek"])
+	}
+	// Achievement rate: 90/200 * 100 = 45.0
+	if mathStats["achievementRate"].(float64) != 45.0 {
+		t.Errorf("expected achievementRate 45.0, got %v", mathStats["achievementRate"])
+	}
+}
+
+func TestGetWeeklyStats_Pomodoros(t *testing.T) {
+	handler, _, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	projectID := proj["id"].(string)
+
+	for i, pomodoros := range []int{1, 1, 0} {
+		log, _ := domain.NewStudyLog(fmt.Sprintf("log-%d", i), userID, projectID, time.Date(2024, 1, 2, 10+i, 0, 0, 0, time.UTC), 25, "")
+		log.Pomodoros = pomodoros
+		studyLogRepo.logs[log.ID] = log
+	}
+
+	rr := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-01", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var stats map[string]any
+	parseJSON(t, rr, &stats)
+	if stats["totalPomodoros"] != float64(2) {
+		t.Errorf("expected totalPomodoros 2, got %v", stats["totalPomodoros"])
+	}
+	projects := stats["projects"].([]any)
+	if len(projects) != 1 || projects[0].(map[string]any)["pomodoros"] != float64(2) {
+		t.Errorf("expected 2 pomodoros for Math, got %v", projects)
+	}
+}
+
+func TestGetWeeklyStats_MissingWeekStart(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create a user first so we don't get 404 for user
+	userID, token := signUp(t, handler, "Alice")
+
+	// weekStart is required:"true" in the handler, Huma will return 422 for missing required query param
+	statsReq := authRequest("GET", "/v1/users/"+userID+"/stats/weekly", token, nil)
+	statsRR := doRequest(handler, statsReq)
+
+	// Huma returns 422 for missing required query parameters
+	if statsRR.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusUnprocessableEntity, statsRR.Code, statsRR.Body.String())
+	}
+}
+
+func TestGetWeeklyStats_InvalidWeekStartFormat(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user
+	userID, token := signUp(t, handler, "Alice")
+
+	// Invalid date format
+	statsReq := authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=not-a-date", token, nil)
+	statsRR := doRequest(handler, statsReq)
+
+	if statsRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusBadRequest, statsRR.Code, statsRR.Body.String())
+	}
+}
+
+// --- Additional edge case tests ---
+
+func TestCreateProject_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("POST", "/v1/users/nonexistent-user/projects", token, map[string]string{"name": "Math"})
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdateProject_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("PUT", "/v1/projects/nonexistent-id", token, map[string]string{"name": "Updated"})
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestDeleteProject_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("DELETE", "/v1/projects/nonexistent-id", token, nil)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestDeleteStudyLog_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("DELETE", "/v1/study-logs/nonexistent-id", token, nil)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpsertGoal_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	body := map[string]any{
+		"targetMinutesPerWeek": 300,
+		"startDate":            "2024-01-01",
+	}
+	req := authRequest("PUT", "/v1/users/nonexistent/goals/some-project", token, body)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+func TestListGoals_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("GET", "/v1/users/nonexistent/goals", token, nil)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+// --- Note Tests ---
+
+func TestCreateNote_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	// Create note
+	body := map[string]any{
+		"title":   "My Note",
+		"content": "some content",
+		"tags":    []string{"go", "api"},
+	}
+	createNoteReq := authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, body)
+	createNoteRR := doRequest(handler, createNoteReq)
+
+	if createNoteRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createNoteRR.Code, createNoteRR.Body.String())
+	}
+
+	var noteResp map[string]any
+	parseJSON(t, createNoteRR, &noteResp)
+	if noteResp["title"] != "My Note" {
+		t.Errorf("expected title 'My Note', got '%v'", noteResp["title"])
+	}
+	if noteResp["content"] != "some content" {
+		t.Errorf("expected content 'some content', got '%v'", noteResp["content"])
+	}
+	if noteResp["userId"] != userID {
+		t.Errorf("expected userId '%s', got '%v'", userID, noteResp["userId"])
+	}
+	if noteResp["projectId"] != projectID {
+		t.Errorf("expected projectId '%s', got '%v'", projectID, noteResp["projectId"])
+	}
+	tags, ok := noteResp["tags"].([]any)
+	if !ok || len(tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", noteResp["tags"])
+	}
+}
+
+func TestListNotes_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user and project
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	// Create two notes
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{
+		"title": "Note 1", "content": "content 1",
+	}))
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{
+		"title": "Note 2", "content": "content 2",
+	}))
+
+	// List notes
+	listReq := authRequest("GET", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, nil)
+	listRR := doRequest(handler, listReq)
+
+	if listRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, listRR.Code, listRR.Body.String())
+	}
+
+	var notes []map[string]any
+	parseJSON(t, listRR, &notes)
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+}
+
+func TestGetNote_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user, project, and note
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	createNoteRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{
+		"title": "My Note", "content": "some content",
+	}))
+	var created map[string]any
+	parseJSON(t, createNoteRR, &created)
+	noteID := created["id"].(string)
+
+	// Get note
+	getReq := authRequest("GET", "/v1/notes/"+noteID, token, nil)
+	getRR := doRequest(handler, getReq)
+
+	if getRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, getRR.Code, getRR.Body.String())
+	}
+
+	var noteResp map[string]any
+	parseJSON(t, getRR, &noteResp)
+	if noteResp["title"] != "My Note" {
+		t.Errorf("expected title 'My Note', got '%v'", noteResp["title"])
+	}
+}
+
+func TestGetNote_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("GET", "/v1/notes/nonexistent-id", token, nil)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdateNote_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user, project, and note
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	createNoteRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{
+		"title": "Old Title", "content": "old content",
+	}))
+	var created map[string]any
+	parseJSON(t, createNoteRR, &created)
+	noteID := created["id"].(string)
+
+	// Update note
+	updateReq := authRequest("PUT", "/v1/notes/"+noteID, token, map[string]any{
+		"title": "New Title", "content": "new content", "tags": []string{"updated"},
+	})
+	updateRR := doRequest(handler, updateReq)
+
+	if updateRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, updateRR.Code, updateRR.Body.String())
+	}
+
+	var updated map[string]any
+	parseJSON(t, updateRR, &updated)
+	if updated["title"] != "New Title" {
+		t.Errorf("expected title 'New Title', got '%v'", updated["title"])
+	}
+	if updated["content"] != "new content" {
+		t.Errorf("expected content 'new content', got '%v'", updated["content"])
+	}
+}
+
+func TestUpdateNote_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("PUT", "/v1/notes/nonexistent-id", token, map[string]any{
+		"title": "Title", "content": "content",
+	})
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestDeleteNote_Success(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+
+	// Create user, project, and note
+	userID, token := signUp(t, handler, "Alice")
+
+	createProjRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, createProjRR, &proj)
+	projectID := proj["id"].(string)
+
+	createNoteRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{
+		"title": "To Delete", "content": "content",
+	}))
+	var created map[string]any
+	parseJSON(t, createNoteRR, &created)
+	noteID := created["id"].(string)
+
+	// Delete note
+	deleteReq := authRequest("DELETE", "/v1/notes/"+noteID, token, nil)
+	deleteRR := doRequest(handler, deleteReq)
+
+	if deleteRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
+	}
+}
+
+func TestDeleteNote_NotFound(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	req := authRequest("DELETE", "/v1/notes/nonexistent-id", token, nil)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestCreateNote_OtherUser(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	_, token := signUp(t, handler, "Alice")
+
+	body := map[string]any{"title": "Note", "content": "content"}
+	req := authRequest("POST", "/v1/users/nonexistent/projects/some-project/notes", token, body)
+	rr := doRequest(handler, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+}
+
+func TestNoteStudyLogLinks(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	projectID := proj["id"].(string)
+
+	logRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": "2024-01-15T10:00:00Z", "minutes": 60,
+	}))
+	var log map[string]any
+	parseJSON(t, logRR, &log)
+	logID := log["id"].(string)
+	if notes, ok := log["notes"].([]any); !ok || len(notes) != 0 {
+		t.Errorf("expected an empty notes list on a new study log, got %v", log["notes"])
+	}
+
+	noteRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{"title": "Limits"}))
+	var note map[string]any
+	parseJSON(t, noteRR, &note)
+	noteID := note["id"].(string)
+
+	// Link the note to the study log
+	linkRR := doRequest(handler, authRequest("PUT", "/v1/notes/"+noteID+"/study-logs/"+logID, token, nil))
+	if linkRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, linkRR.Code, linkRR.Body.String())
+	}
+	var linked struct {
+		StudyLogs []struct {
+			ID      string `json:"id"`
+			Minutes int    `json:"minutes"`
+		} `json:"studyLogs"`
+	}
+	parseJSON(t, linkRR, &linked)
+	if len(linked.StudyLogs) != 1 || linked.StudyLogs[0].ID != logID || linked.StudyLogs[0].Minutes != 60 {
+		t.Errorf("expected the note to embed the study log, got %+v", linked.StudyLogs)
+	}
+
+	// The study log lists the note
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs", token, nil))
+	var logs []struct {
+		Notes []struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"notes"`
+	}
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 1 || len(logs[0].Notes) != 1 || logs[0].Notes[0].ID != noteID || logs[0].Notes[0].Title != "Limits" {
+		t.Errorf("expected the study log to embed the note, got %+v", logs)
+	}
+
+	// Another user can't link to the note
+	_, otherToken := signUp(t, handler, "Bob")
+	otherRR := doRequest(handler, authRequest("PUT", "/v1/notes/"+noteID+"/study-logs/"+logID, otherToken, nil))
+	if otherRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, otherRR.Code)
+	}
+
+	// Unlink; unlinking again is not found
+	unlinkRR := doRequest(handler, authRequest("DELETE", "/v1/notes/"+noteID+"/study-logs/"+logID, token, nil))
+	if unlinkRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, unlinkRR.Code, unlinkRR.Body.String())
+	}
+	getRR := doRequest(handler, authRequest("GET", "/v1/notes/"+noteID, token, nil))
+	parseJSON(t, getRR, &linked)
+	if len(linked.StudyLogs) != 0 {
+		t.Errorf("expected no linked study logs after unlinking, got %+v", linked.StudyLogs)
+	}
+	unlinkRR = doRequest(handler, authRequest("DELETE", "/v1/notes/"+noteID+"/study-logs/"+logID, token, nil))
+	if unlinkRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, unlinkRR.Code)
+	}
+}
+
+func TestTrash_DeleteAndRestore(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	projectID := proj["id"].(string)
+
+	logRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": "2024-01-15T10:00:00Z", "minutes": 60,
+	}))
+	var log map[string]any
+	parseJSON(t, logRR, &log)
+	logID := log["id"].(string)
+
+	noteRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+projectID+"/notes", token, map[string]any{"title": "Limits"}))
+	var note map[string]any
+	parseJSON(t, noteRR, &note)
+	noteID := note["id"].(string)
+
+	// A deleted study log is listed in the trash and can be restored
+	deleteRR := doRequest(handler, authRequest("DELETE", "/v1/study-logs/"+logID, token, nil))
+	if deleteRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
+	}
+	var trash struct {
+		Projects []struct {
+			ID        string     `json:"id"`
+			DeletedAt *time.Time `json:"deletedAt"`
+		} `json:"projects"`
+		Notes []struct {
+			ID string `json:"id"`
+		} `json:"notes"`
+		StudyLogs []struct {
+			ID        string     `json:"id"`
+			DeletedAt *time.Time `json:"deletedAt"`
+		} `json:"studyLogs"`
+	}
+	trashRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/trash", token, nil))
+	parseJSON(t, trashRR, &trash)
+	if len(trash.StudyLogs) != 1 || trash.StudyLogs[0].ID != logID || trash.StudyLogs[0].DeletedAt == nil {
+		t.Errorf("expected the trashed study log, got %+v", trash.StudyLogs)
+	}
+	restoreRR := doRequest(handler, authRequest("POST", "/v1/study-logs/"+logID+"/restore", token, nil))
+	if restoreRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, restoreRR.Code, restoreRR.Body.String())
+	}
+
+	// Deleting the project hides it with its contents; they are not listed separately
+	deleteRR = doRequest(handler, authRequest("DELETE", "/v1/projects/"+projectID+"?force=true", token, nil))
+	if deleteRR.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusNoContent, deleteRR.Code, deleteRR.Body.String())
+	}
+	getRR := doRequest(handler, authRequest("GET", "/v1/notes/"+noteID, token, nil))
+	if getRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a note of a trashed project, got %d", http.StatusNotFound, getRR.Code)
+	}
+	trashRR = doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/trash", token, nil))
+	parseJSON(t, trashRR, &trash)
+	if len(trash.Projects) != 1 || trash.Projects[0].ID != projectID || len(trash.Notes) != 0 || len(trash.StudyLogs) != 0 {
+		t.Errorf("expected only the project in the trash, got %+v", trash)
+	}
+
+	// Another user can't restore it
+	_, otherToken := signUp(t, handler, "Bob")
+	otherRR := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/restore", otherToken, nil))
+	if otherRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, otherRR.Code)
+	}
+
+	// Restoring the project brings its contents back
+	restoreRR = doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/restore", token, nil))
+	if restoreRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, restoreRR.Code, restoreRR.Body.String())
+	}
+	var restored map[string]any
+	parseJSON(t, restoreRR, &restored)
+	if _, ok := restored["deletedAt"]; ok {
+		t.Errorf("expected no deletedAt on a restored project, got %v", restored["deletedAt"])
+	}
+	getRR = doRequest(handler, authRequest("GET", "/v1/notes/"+noteID, token, nil))
+	if getRR.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, getRR.Code)
+	}
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs", token, nil))
+	var logs []map[string]any
+	parseJSON(t, listRR, &logs)
+	if len(logs) != 1 {
+		t.Errorf("expected the study log to be restored, got %d logs", len(logs))
+	}
+}
+
+func TestProject_ArchiveAndUnarchive(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	projRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"}))
+	var proj map[string]any
+	parseJSON(t, projRR, &proj)
+	projectID := proj["id"].(string)
+
+	archiveRR := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/archive", token, nil))
+	if archiveRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, archiveRR.Code, archiveRR.Body.String())
+	}
+	var archived map[string]any
+	parseJSON(t, archiveRR, &archived)
+	if archived["archivedAt"] == nil {
+		t.Error("expected archivedAt on an archived project")
+	}
+	againRR := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/archive", token, nil))
+	if againRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d archiving twice, got %d", http.StatusConflict, againRR.Code)
+	}
+
+	// Archived projects are only listed on request
+	var projects []map[string]any
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects", token, nil))
+	parseJSON(t, listRR, &projects)
+	if len(projects) != 0 {
+		t.Errorf("expected no active projects, got %d", len(projects))
+	}
+	listRR = doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects?archived=true", token, nil))
+	parseJSON(t, listRR, &projects)
+	if len(projects) != 1 || projects[0]["id"] != projectID {
+		t.Errorf("expected the archived project, got %v", projects)
+	}
+
+	// No study time can be recorded in it
+	logRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": "2024-01-15T10:00:00Z", "minutes": 60,
+	}))
+	if logRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d logging to an archived project, got %d", http.StatusConflict, logRR.Code)
+	}
+
+	unarchiveRR := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/unarchive", token, nil))
+	if unarchiveRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, unarchiveRR.Code, unarchiveRR.Body.String())
+	}
+	logRR = doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": projectID, "studiedAt": "2024-01-15T10:00:00Z", "minutes": 60,
+	}))
+	if logRR.Code != http.StatusCreated {
+		t.Errorf("expected status %d after unarchiving, got %d; body: %s", http.StatusCreated, logRR.Code, logRR.Body.String())
+	}
+}
+
+func TestProject_SubProjects(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	parentRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "AWS certification"}))
+	var parent map[string]any
+	parseJSON(t, parentRR, &parent)
+	parentID := parent["id"].(string)
+
+	childRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "IAM", "parentId": parentID}))
+	if childRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, childRR.Code, childRR.Body.String())
+	}
+	var child map[string]any
+	parseJSON(t, childRR, &child)
+	if child["parentId"] != parentID {
+		t.Errorf("expected parentId %s, got %v", parentID, child["parentId"])
+	}
+	childID := child["id"].(string)
+
+	// A project can't be moved under its own sub-project
+	moveRR := doRequest(handler, authRequest("POST", "/v1/projects/"+parentID+"/move", token, map[string]string{"parentId": childID}))
+	if moveRR.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d; body: %s", http.StatusBadRequest, moveRR.Code, moveRR.Body.String())
+	}
+	deleteRR := doRequest(handler, authRequest("DELETE", "/v1/projects/"+parentID, token, nil))
+	if deleteRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d deleting a project with sub-projects, got %d", http.StatusConflict, deleteRR.Code)
+	}
+
+	// Stats roll the sub-project's minutes up into the parent
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+		"projectId": childID, "studiedAt": "2024-01-15T10:00:00Z", "minutes": 45,
+	}))
+	statsRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-15", token, nil))
+	var stats struct {
+		Projects []struct {
+			ProjectID      string `json:"projectId"`
+			TotalMinutes   int    `json:"totalMinutes"`
+			SubtreeMinutes int    `json:"subtreeMinutes"`
+		} `json:"projects"`
+	}
+	parseJSON(t, statsRR, &stats)
+	for _, ps := range stats.Projects {
+		if ps.ProjectID == parentID && (ps.TotalMinutes != 0 || ps.SubtreeMinutes != 45) {
+			t.Errorf("expected 0 own and 45 subtree minutes for the parent, got %+v", ps)
+		}
+	}
+
+	// Moving the sub-project to the top level frees the parent
+	moveRR = doRequest(handler, authRequest("POST", "/v1/projects/"+childID+"/move", token, map[string]string{}))
+	if moveRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, moveRR.Code, moveRR.Body.String())
+	}
+	var moved map[string]any
+	parseJSON(t, moveRR, &moved)
+	if _, ok := moved["parentId"]; ok {
+		t.Errorf("expected no parentId on a top-level project, got %v", moved["parentId"])
+	}
+}
+
+func TestProject_Details(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	createRR := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]any{
+		"name": "AWS certification", "description": "SAA-C03", "color": "#ff9900", "icon": "☁️",
+		"status": "planned", "targetDate": "2025-03-31",
+	}))
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createRR.Code, createRR.Body.String())
+	}
+	var created map[string]any
+	parseJSON(t, createRR, &created)
+	if created["color"] != "#ff9900" || created["icon"] != "☁️" || created["status"] != "planned" || created["targetDate"] != "2025-03-31" {
+		t.Errorf("unexpected project details: %v", created)
+	}
+	projectID := created["id"].(string)
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Go"}))
+
+	var projects []map[string]any
+	listRR := doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects?status=planned", token, nil))
+	parseJSON(t, listRR, &projects)
+	if len(projects) != 1 || projects[0]["id"] != projectID {
+		t.Errorf("expected only the planned project, got %v", projects)
+	}
+
+	badRR := doRequest(handler, authRequest("PUT", "/v1/projects/"+projectID, token, map[string]any{"name": "AWS", "color": "orange"}))
+	if badRR.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d for an invalid color, got %d", http.StatusUnprocessableEntity, badRR.Code)
+	}
+	badRR = doRequest(handler, authRequest("PUT", "/v1/projects/"+projectID, token, map[string]any{"name": "AWS", "targetDate": "March 31"}))
+	if badRR.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid target date, got %d", http.StatusBadRequest, badRR.Code)
+	}
+
+	updateRR := doRequest(handler, authRequest("PUT", "/v1/projects/"+projectID, token, map[string]any{"name": "AWS", "status": "done"}))
+	if updateRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, updateRR.Code, updateRR.Body.String())
+	}
+	var updated map[string]any
+	parseJSON(t, updateRR, &updated)
+	if updated["status"] != "done" || updated["color"] != nil || updated["targetDate"] != nil {
+		t.Errorf("expected the omitted details to be cleared, got %v", updated)
+	}
+}
+
+func TestMilestones(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	_, otherToken := signUp(t, handler, "Bob")
+
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"})), &project)
+	projectID := project["id"].(string)
+	milestonesPath := "/v1/users/" + userID + "/projects/" + projectID + "/milestones"
+
+	createRR := doRequest(handler, authRequest("POST", milestonesPath, token, map[string]any{
+		"title": "Chapter 1", "dueDate": "2024-01-03", "items": []string{"Read", "Exercises"},
+	}))
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, createRR.Code, createRR.Body.String())
+	}
+	var first map[string]any
+	parseJSON(t, createRR, &first)
+	firstID := first["id"].(string)
+	if first["dueDate"] != "2024-01-03" || first["done"] != false || len(first["items"].([]any)) != 2 {
+		t.Errorf("unexpected milestone: %v", first)
+	}
+	var second map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", milestonesPath, token, map[string]string{"title": "Chapter 2"})), &second)
+	secondID := second["id"].(string)
+
+	badRR := doRequest(handler, authRequest("POST", milestonesPath, token, map[string]string{"title": "Chapter 3", "dueDate": "soon"}))
+	if badRR.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid due date, got %d", http.StatusBadRequest, badRR.Code)
+	}
+
+	item := first["items"].([]any)[0].(map[string]any)
+	itemRR := doRequest(handler, authRequest("PUT", "/v1/milestones/"+firstID+"/items/"+item["id"].(string), token, map[string]any{"title": "Read", "done": true}))
+	if itemRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, itemRR.Code, itemRR.Body.String())
+	}
+	var withItemDone map[string]any
+	parseJSON(t, itemRR, &withItemDone)
+	if withItemDone["progress"] != 50.0 {
+		t.Errorf("expected milestone progress 50, got %v", withItemDone["progress"])
+	}
+
+	completeRR := doRequest(handler, authRequest("POST", "/v1/milestones/"+secondID+"/complete", token, nil))
+	if completeRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, completeRR.Code, completeRR.Body.String())
+	}
+	var completed map[string]any
+	parseJSON(t, completeRR, &completed)
+	if completed["done"] != true || completed["completedAt"] == nil {
+		t.Errorf("expected the milestone to be completed, got %v", completed)
+	}
+	if rr := doRequest(handler, authRequest("POST", "/v1/milestones/"+secondID+"/complete", token, nil)); rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d completing twice, got %d", http.StatusConflict, rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("POST", "/v1/milestones/"+secondID+"/complete", otherToken, nil)); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for another user's milestone, got %d", http.StatusNotFound, rr.Code)
+	}
+
+	orderRR := doRequest(handler, authRequest("PUT", milestonesPath+"/order", token, map[string]any{"milestoneIds": []string{secondID, firstID}}))
+	if orderRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, orderRR.Code, orderRR.Body.String())
+	}
+	var list struct {
+		Progress   float64          `json:"progress"`
+		Milestones []map[string]any `json:"milestones"`
+	}
+	parseJSON(t, doRequest(handler, authRequest("GET", milestonesPath, token, nil)), &list)
+	if len(list.Milestones) != 2 || list.Milestones[0]["id"] != secondID || list.Milestones[1]["id"] != firstID {
+		t.Errorf("expected the reordered milestones, got %v", list.Milestones)
+	}
+	if list.Progress != 75 {
+		t.Errorf("expected project progress 75, got %v", list.Progress)
+	}
+
+	var projects []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/projects", token, nil)), &projects)
+	if len(projects) != 1 || projects[0]["progress"] != 75.0 {
+		t.Errorf("expected project progress 75 in the project list, got %v", projects)
+	}
+
+	var stats map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/stats/weekly?weekStart=2024-01-01&tz=UTC", token, nil)), &stats)
+	overdue, _ := stats["overdueMilestones"].([]any)
+	if len(overdue) != 1 || overdue[0].(map[string]any)["milestoneId"] != firstID {
+		t.Errorf("expected the open milestone to be overdue, got %v", stats["overdueMilestones"])
+	}
+
+	if rr := doRequest(handler, authRequest("DELETE", "/v1/milestones/"+firstID, token, nil)); rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+}
+
+func TestProjectMembers(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	ownerID, ownerToken := signUp(t, handler, "Alice")
+	editorID, editorToken := signUp(t, handler, "Bob")
+	viewerID, viewerToken := signUp(t, handler, "Carol")
+	_, strangerToken := signUp(t, handler, "Dave")
+
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+ownerID+"/projects", ownerToken, map[string]string{"name": "Study group"})), &project)
+	projectID := project["id"].(string)
+	membersPath := "/v1/projects/" + projectID + "/members"
+
+	inviteRR := doRequest(handler, authRequest("POST", membersPath, ownerToken, map[string]string{"email": "Bob@Example.com", "role": "editor"}))
+	if inviteRR.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusAccepted, inviteRR.Code, inviteRR.Body.String())
+	}
+	doRequest(handler, authRequest("POST", membersPath, ownerToken, map[string]string{"email": "carol@example.com", "role": "viewer"}))
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		body  map[string]string
+		want  int
+	}{
+		{"again", ownerToken, map[string]string{"email": "bob@example.com", "role": "viewer"}, http.StatusAccepted},
+		{"unknown email", ownerToken, map[string]string{"email": "nobody@example.com", "role": "viewer"}, http.StatusAccepted},
+		{"owner role", ownerToken, map[string]string{"email": "dave@example.com", "role": "owner"}, http.StatusUnprocessableEntity},
+		{"by a stranger", strangerToken, map[string]string{"email": "dave@example.com", "role": "viewer"}, http.StatusNotFound},
+	} {
+		if rr := doRequest(handler, authRequest("POST", membersPath, tc.token, tc.body)); rr.Code != tc.want {
+			t.Errorf("invite %s: expected status %d, got %d; body: %s", tc.name, tc.want, rr.Code, rr.Body.String())
+		}
+	}
+
+	var memberships []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+editorID+"/project-memberships", editorToken, nil)), &memberships)
+	if len(memberships) != 1 || memberships[0]["projectName"] != "Study group" || memberships[0]["status"] != "pending" || memberships[0]["role"] != "editor" {
+		t.Errorf("unexpected memberships: %v", memberships)
+	}
+	if rr := doRequest(handler, authRequest("GET", membersPath, editorToken, nil)); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d before accepting, got %d", http.StatusNotFound, rr.Code)
+	}
+	for _, token := range []string{editorToken, viewerToken} {
+		if rr := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/accept", token, nil)); rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+	}
+
+	var members []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", membersPath, viewerToken, nil)), &members)
+	if len(members) != 3 || members[0]["role"] != "owner" || members[1]["userName"] != "Bob" || members[2]["status"] != "accepted" {
+		t.Errorf("unexpected members: %v", members)
+	}
+	if rr := doRequest(handler, authRequest("PUT", membersPath+"/"+viewerID, editorToken, map[string]string{"role": "editor"})); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d changing a role as an editor, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	studiedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	logRR := doRequest(handler, authRequest("POST", "/v1/users/"+editorID+"/study-logs", editorToken, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt, "minutes": 45,
+	}))
+	if logRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, logRR.Code, logRR.Body.String())
+	}
+	viewerLogRR := doRequest(handler, authRequest("POST", "/v1/users/"+viewerID+"/study-logs", viewerToken, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt, "minutes": 45,
+	}))
+	if viewerLogRR.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for a viewer's study log, got %d", http.StatusForbidden, viewerLogRR.Code)
+	}
+	doRequest(handler, authRequest("POST", "/v1/users/"+ownerID+"/study-logs", ownerToken, map[string]any{
+		"projectId": projectID, "studiedAt": studiedAt, "minutes": 30,
+	}))
+
+	noteRR := doRequest(handler, authRequest("POST", "/v1/users/"+editorID+"/projects/"+projectID+"/notes", editorToken, map[string]any{"title": "Chapter 1", "content": "summary"}))
+	if noteRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, noteRR.Code, noteRR.Body.String())
+	}
+	var note map[string]any
+	parseJSON(t, noteRR, &note)
+	if rr := doRequest(handler, authRequest("GET", "/v1/notes/"+note["id"].(string), viewerToken, nil)); rr.Code != http.StatusOK {
+		t.Errorf("expected status %d reading a member's note, got %d", http.StatusOK, rr.Code)
+	}
+
+	statsRR := doRequest(handler, authRequest("GET", "/v1/projects/"+projectID+"/member-stats?from=2024-01-15&to=2024-01-15&tz=UTC", viewerToken, nil))
+	if statsRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, statsRR.Code, statsRR.Body.String())
+	}
+	var stats map[string]any
+	parseJSON(t, statsRR, &stats)
+	if stats["totalMinutes"] != 75.0 || len(stats["members"].([]any)) != 3 {
+		t.Errorf("unexpected member stats: %v", stats)
+	}
+	if rr := doRequest(handler, authRequest("GET", "/v1/projects/"+projectID+"/member-stats?from=soon", viewerToken, nil)); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid date, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	if rr := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/leave", ownerToken, nil)); rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d when the owner leaves, got %d", http.StatusConflict, rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/leave", editorToken, nil)); rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+	if rr := doRequest(handler, authRequest("DELETE", membersPath+"/"+viewerID, ownerToken, nil)); rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+	parseJSON(t, doRequest(handler, authRequest("GET", membersPath, ownerToken, nil)), &members)
+	if len(members) != 1 {
+		t.Errorf("expected only the owner to be left, got %v", members)
+	}
+}
+
+func TestProjectClone(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	ownerID, ownerToken := signUp(t, handler, "Alice")
+	memberID, memberToken := signUp(t, handler, "Bob")
+	_, strangerToken := signUp(t, handler, "Carol")
+
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+ownerID+"/projects", ownerToken, map[string]string{"name": "Math"})), &project)
+	projectID := project["id"].(string)
+	clonePath := "/v1/projects/" + projectID + "/clone"
+	doRequest(handler, authRequest("POST", "/v1/users/"+ownerID+"/projects/"+projectID+"/notes", ownerToken, map[string]any{
+		"title": "Formulas", "content": "a^2 + b^2", "tags": []string{"algebra"},
+	}))
+	start := time.Now().UTC().AddDate(0, 0, -10).Format(domain.DateLayout)
+	doRequest(handler, authRequest("PUT", "/v1/users/"+ownerID+"/goals/"+projectID, ownerToken, map[string]any{
+		"targetMinutesPerWeek": 300, "startDate": start,
+	}))
+
+	rr := doRequest(handler, authRequest("POST", clonePath, ownerToken, map[string]any{"notes": true, "goal": true}))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var clone struct {
+		Project          map[string]any `json:"project"`
+		NotesCopied      int            `json:"notesCopied"`
+		Goal             map[string]any `json:"goal"`
+		MilestonesCopied int            `json:"milestonesCopied"`
+	}
+	parseJSON(t, rr, &clone)
+	if clone.Project["name"] != "Math (2)" || clone.NotesCopied != 1 || clone.MilestonesCopied != 0 {
+		t.Errorf("unexpected clone: %+v", clone)
+	}
+	if clone.Goal == nil || clone.Goal["startDate"] != time.Now().UTC().Format(domain.DateLayout) {
+		t.Errorf("expected the goal to start today, got: %v", clone.Goal)
+	}
+	var notes []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+ownerID+"/projects/"+clone.Project["id"].(string)+"/notes", ownerToken, nil)), &notes)
+	if len(notes) != 1 || notes[0]["title"] != "Formulas" {
+		t.Errorf("unexpected notes in the clone: %v", notes)
+	}
+
+	if rr := doRequest(handler, authRequest("POST", clonePath, strangerToken, map[string]any{})); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a stranger, got %d", http.StatusNotFound, rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("POST", clonePath, ownerToken, map[string]any{"targetUserId": memberID})); rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for a user who is not a member, got %d", http.StatusForbidden, rr.Code)
+	}
+	doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/members", ownerToken, map[string]string{"email": "bob@example.com", "role": "viewer"}))
+	doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/accept", memberToken, nil))
+	rr = doRequest(handler, authRequest("POST", clonePath, ownerToken, map[string]any{"targetUserId": memberID, "name": "Bob's math"}))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	parseJSON(t, rr, &clone)
+	if clone.Project["userId"] != memberID || clone.Project["name"] != "Bob's math" {
+		t.Errorf("unexpected clone for the member: %v", clone.Project)
+	}
+}
+
+func TestMergeProject(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	createProject := func(name string) string {
+		var project map[string]any
+		parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": name})), &project)
+		return project["id"].(string)
+	}
+	goID, golangID := createProject("Go"), createProject("Golang")
+	var logIDs []string
+	for _, day := range []int{15, 16} {
+		studiedAt := time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		var log map[string]any
+		parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+			"projectId": golangID, "studiedAt": studiedAt, "minutes": 30,
+		})), &log)
+		logIDs = append(logIDs, log["id"].(string))
+	}
+	doRequest(handler, authRequest("DELETE", "/v1/study-logs/"+logIDs[1], token, nil))
+	// A member of the source project has a study log in it too.
+	memberID, memberToken := signUp(t, handler, "Bob")
+	doRequest(handler, authRequest("POST", "/v1/projects/"+golangID+"/members", token, map[string]string{"email": "bob@example.com", "role": "editor"}))
+	doRequest(handler, authRequest("POST", "/v1/projects/"+golangID+"/accept", memberToken, nil))
+	doRequest(handler, authRequest("POST", "/v1/users/"+memberID+"/study-logs", memberToken, map[string]any{
+		"projectId": golangID, "studiedAt": time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC).Format(time.RFC3339), "minutes": 30,
+	}))
+	doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects/"+golangID+"/notes", token, map[string]any{"title": "Generics"}))
+	for _, id := range []string{goID, golangID} {
+		doRequest(handler, authRequest("PUT", "/v1/users/"+userID+"/goals/"+id, token, map[string]any{
+			"targetMinutesPerWeek": 150, "startDate": "2024-01-01",
+		}))
+	}
+	mergePath := "/v1/projects/" + golangID + "/merge"
+
+	if rr := doRequest(handler, authRequest("POST", mergePath, token, map[string]any{"targetProjectId": goID})); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a goal strategy, got %d; body: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+	rr := doRequest(handler, authRequest("POST", mergePath, token, map[string]any{"targetProjectId": goID, "goalStrategy": "sum"}))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var merged struct {
+		Project                    map[string]any `json:"project"`
+		Goal                       map[string]any `json:"goal"`
+		MovedStudyLogs             int            `json:"movedStudyLogs"`
+		MovedOtherMembersStudyLogs int            `json:"movedOtherMembersStudyLogs"`
+		MovedTrashedStudyLogs      int            `json:"movedTrashedStudyLogs"`
+		MovedNotes                 int            `json:"movedNotes"`
+		MergedGoals                int            `json:"mergedGoals"`
+	}
+	parseJSON(t, rr, &merged)
+	if merged.Project["id"] != goID || merged.MovedStudyLogs != 3 || merged.MovedNotes != 1 || merged.MergedGoals != 1 {
+		t.Errorf("unexpected merge: %+v", merged)
+	}
+	if merged.MovedOtherMembersStudyLogs != 1 || merged.MovedTrashedStudyLogs != 1 {
+		t.Errorf("expected the member's and the trashed study log to be reported, got %+v", merged)
+	}
+	if merged.Goal["targetMinutesPerWeek"] != float64(300) {
+		t.Errorf("expected the summed goal, got: %v", merged.Goal)
+	}
+
+	var logs []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/study-logs?projectId="+goID, token, nil)), &logs)
+	if len(logs) != 1 {
+		t.Errorf("expected the study log in the target project, got %d", len(logs))
+	}
+	if rr := doRequest(handler, authRequest("POST", mergePath, token, map[string]any{"targetProjectId": goID})); rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d merging a deleted project, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestDeletionPreview(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"})), &project)
+	projectID := project["id"].(string)
+	addLog := func(day int) {
+		studiedAt := time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+			"projectId": projectID, "studiedAt": studiedAt, "minutes": 45,
+		}))
+	}
+	addLog(15)
+	doRequest(handler, authRequest("PUT", "/v1/users/"+userID+"/goals/"+projectID, token, map[string]any{
+		"targetMinutesPerWeek": 120, "startDate": "2024-01-01",
+	}))
+
+	rr := doRequest(handler, authRequest("GET", "/v1/projects/"+projectID+"/deletion-preview", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var preview struct {
+		StudyLogs             int            `json:"studyLogs"`
+		TotalMinutes          int            `json:"totalMinutes"`
+		Goal                  map[string]any `json:"goal"`
+		ConfirmationToken     string         `json:"confirmationToken"`
+		ConfirmationExpiresAt time.Time      `json:"confirmationExpiresAt"`
+	}
+	parseJSON(t, rr, &preview)
+	if preview.StudyLogs != 1 || preview.TotalMinutes != 45 || preview.Goal["targetMinutesPerWeek"] != float64(120) || preview.ConfirmationToken == "" || !preview.ConfirmationExpiresAt.After(time.Now()) {
+		t.Errorf("unexpected project deletion preview: %+v", preview)
+	}
+
+	deletePath := "/v1/projects/" + projectID
+	if rr := doRequest(handler, authRequest("DELETE", deletePath, token, nil)); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a confirmation token, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("DELETE", deletePath+"?confirmationToken=0123456789abcdef", token, nil)); rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d with a forged confirmation token, got %d", http.StatusConflict, rr.Code)
+	}
+	addLog(16)
+	if rr := doRequest(handler, authRequest("DELETE", deletePath+"?confirmationToken="+preview.ConfirmationToken, token, nil)); rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d with a stale confirmation token, got %d", http.StatusConflict, rr.Code)
+	}
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/projects/"+projectID+"/deletion-preview", token, nil)), &preview)
+	if rr := doRequest(handler, authRequest("DELETE", deletePath+"?confirmationToken="+preview.ConfirmationToken, token, nil)); rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d with the confirmation token, got %d; body: %s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+
+	rr = doRequest(handler, authRequest("GET", "/v1/users/"+userID+"/deletion-preview", token, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var userPreview struct {
+		ConfirmationToken string `json:"confirmationToken"`
+	}
+	parseJSON(t, rr, &userPreview)
+	if rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+userID, token, nil)); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a confirmation token, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+userID+"?confirmationToken="+userPreview.ConfirmationToken, token, nil)); rr.Code != http.StatusOK {
+		t.Errorf("expected status %d with the confirmation token, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+}
+
+func TestExportUserData_OutlastsServerWriteTimeout(t *testing.T) {
+	handler, _, _, studyLogRepo, _ := setupRouter(t)
+	userID, token := signUp(t, handler, "Alice")
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/projects", token, map[string]string{"name": "Math"})), &project)
+	// More logs than fit in one page of the export.
+	const count = 1201
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range count {
+		id := fmt.Sprintf("log-%04d", i)
+		studyLogRepo.logs[id] = &domain.StudyLog{
+			ID: id, UserID: userID, ProjectID: project["id"].(string), StudiedAt: start.Add(time.Duration(i) * time.Hour), Minutes: 30,
+		}
+	}
+
+	// The server's write timeout has passed before the export writes anything.
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Config.WriteTimeout = time.Nanosecond
+	srv.Start()
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/users/"+userID+"/export", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected the whole archive, got: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("expected a valid ZIP archive: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != "study_logs.json" {
+			continue
+		}
+		rc, _ := f.Open()
+		var logs []map[string]any
+		if err := json.NewDecoder(rc).Decode(&logs); err != nil {
+			t.Fatalf("failed to parse study_logs.json: %v", err)
+		}
+		_ = rc.Close()
+		if len(logs) != count {
+			t.Errorf("expected %d study logs, got %d", count, len(logs))
+		}
+		return
+	}
+	t.Error("expected study_logs.json in archive")
+}
+
+func TestUserDeletionPreview_SharedProject(t *testing.T) {
+	handler, _, _, _, _ := setupRouter(t)
+	ownerID, ownerToken := signUp(t, handler, "Alice")
+	memberID, memberToken := signUp(t, handler, "Bob")
+
+	var project map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+ownerID+"/projects", ownerToken, map[string]string{"name": "Study group"})), &project)
+	projectID := project["id"].(string)
+	doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/members", ownerToken, map[string]string{"email": "bob@example.com", "role": "editor"}))
+	doRequest(handler, authRequest("POST", "/v1/projects/"+projectID+"/accept", memberToken, nil))
+	var memberProject map[string]any
+	parseJSON(t, doRequest(handler, authRequest("POST", "/v1/users/"+memberID+"/projects", memberToken, map[string]string{"name": "Own"})), &memberProject)
+	addLog := func(userID, token, projectID string, day, minutes int) {
+		studiedAt := time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		rr := doRequest(handler, authRequest("POST", "/v1/users/"+userID+"/study-logs", token, map[string]any{
+			"projectId": projectID, "studiedAt": studiedAt, "minutes": minutes,
+		}))
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d; body: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+	}
+	addLog(ownerID, ownerToken, projectID, 15, 45)
+	addLog(memberID, memberToken, projectID, 16, 30)
+	addLog(memberID, memberToken, memberProject["id"].(string), 17, 60)
+	doRequest(handler, authRequest("POST", "/v1/users/"+memberID+"/projects/"+projectID+"/notes", memberToken, map[string]any{"title": "Chapter 1"}))
+
+	var preview struct {
+		StudyLogs             int    `json:"studyLogs"`
+		TotalMinutes          int    `json:"totalMinutes"`
+		OtherMembersStudyLogs int    `json:"otherMembersStudyLogs"`
+		Notes                 int    `json:"notes"`
+		OtherMembersNotes     int    `json:"otherMembersNotes"`
+		ConfirmationToken     string `json:"confirmationToken"`
+	}
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+ownerID+"/deletion-preview", ownerToken, nil)), &preview)
+	if preview.StudyLogs != 2 || preview.TotalMinutes != 75 || preview.OtherMembersStudyLogs != 1 || preview.Notes != 1 || preview.OtherMembersNotes != 1 {
+		t.Errorf("expected the member's study log and note in the shared project to be counted, got %+v", preview)
+	}
+
+	rr := doRequest(handler, authRequest("DELETE", "/v1/users/"+ownerID+"?confirmationToken="+preview.ConfirmationToken, ownerToken, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var deleted struct {
+		DeletedStudyLogs             int `json:"deletedStudyLogs"`
+		DeletedOtherMembersStudyLogs int `json:"deletedOtherMembersStudyLogs"`
+		DeletedNotes                 int `json:"deletedNotes"`
+		DeletedOtherMembersNotes     int `json:"deletedOtherMembersNotes"`
+	}
+	parseJSON(t, rr, &deleted)
+	if deleted.DeletedStudyLogs != 2 || deleted.DeletedOtherMembersStudyLogs != 1 || deleted.DeletedNotes != 1 || deleted.DeletedOtherMembersNotes != 1 {
+		t.Errorf("unexpected deletion: %+v", deleted)
+	}
+	var logs []map[string]any
+	parseJSON(t, doRequest(handler, authRequest("GET", "/v1/users/"+memberID+"/study-logs", memberToken, nil)), &logs)
+	if len(logs) != 1 || logs[0]["minutes"] != float64(60) {
+		t.Errorf("expected only the member's study log in their own project to be kept, got %v", logs)
 	}
 }
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// CreateActivityTypeRequest represents the request body for creating an activity type.
+type CreateActivityTypeRequest struct {
+	Name string `json:"name" minLength:"1" maxLength:"50" doc:"Activity type name" example:"reading"`
+}
+
+// UpdateActivityTypeRequest represents the request body for renaming an activity type.
+type UpdateActivityTypeRequest struct {
+	Name string `json:"name" minLength:"1" maxLength:"50" doc:"Activity type name" example:"reading"`
+}
+
+// ActivityTypeResponse represents the response body for an activity type.
+type ActivityTypeResponse struct {
+	ID        string    `json:"id" doc:"Activity type ID"`
+	UserID    string    `json:"userId" doc:"Owner user ID"`
+	Name      string    `json:"name" doc:"Activity type name"`
+	CreatedAt time.Time `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt time.Time `json:"updatedAt" doc:"Last update timestamp"`
+}
+
+// ToActivityTypeResponse converts a domain.ActivityType to an ActivityTypeResponse.
+func ToActivityTypeResponse(a *domain.ActivityType) ActivityTypeResponse {
+	return ActivityTypeResponse{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// ToActivityTypeResponseList converts a list of domain.ActivityType to a list of ActivityTypeResponse.
+func ToActivityTypeResponseList(activityTypes []*domain.ActivityType) []ActivityTypeResponse {
+	result := make([]ActivityTypeResponse, len(activityTypes))
+	for i, a := range activityTypes {
+		result[i] = ToActivityTypeResponse(a)
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// LoginRequest represents the request body for logging in.
+type LoginRequest struct {
+	Email    string `json:"email" minLength:"1" maxLength:"254" doc:"Email address"`
+	Password string `json:"password" minLength:"1" maxLength:"72" doc:"Password"`
+}
+
+// RefreshRequest represents the request body for refreshing an access token.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" minLength:"1" doc:"Refresh token from the last login or refresh"`
+}
+
+// MFALoginRequest represents the request body for completing a login with a second factor.
+type MFALoginRequest struct {
+	MFAToken string `json:"mfaToken" minLength:"1" doc:"MFA token from POST /auth/login"`
+	Code     string `json:"code" minLength:"1" maxLength:"32" doc:"Code from the authenticator app, or an unused recovery code"`
+}
+
+// LoginResponse represents the response body for a password login.
+// When two-factor authentication is enabled, only the MFA fields are set and the
+// login must be completed with POST /auth/login/mfa.
+type LoginResponse struct {
+	*AuthTokenResponse
+	MFARequired  bool       `json:"mfaRequired" doc:"Whether a second factor is required to finish logging in"`
+	MFAToken     string     `json:"mfaToken,omitempty" doc:"Short-lived token for POST /auth/login/mfa"`
+	MFAExpiresAt *time.Time `json:"mfaExpiresAt,omitempty" doc:"MFA token expiry"`
+}
+
+// AuthTokenResponse represents the response body for an issued access token.
+type AuthTokenResponse struct {
+	UserID           string    `json:"userId" doc:"Authenticated user ID"`
+	SessionID        string    `json:"sessionId" doc:"Session ID"`
+	AccessToken      string    `json:"accessToken" doc:"Bearer access token"`
+	TokenType        string    `json:"tokenType" doc:"Token type (always Bearer)"`
+	ExpiresAt        time.Time `json:"expiresAt" doc:"Access token expiry"`
+	RefreshToken     string    `json:"refreshToken" doc:"Single-use refresh token; replaced on every refresh"`
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt" doc:"Refresh token expiry"`
+}
+
+// ToAuthTokenResponse converts a domain.AuthToken to an AuthTokenResponse.
+func ToAuthTokenResponse(t *domain.AuthToken) AuthTokenResponse {
+	return AuthTokenResponse{
+		UserID:           t.UserID,
+		SessionID:        t.SessionID,
+		AccessToken:      t.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        t.ExpiresAt,
+		RefreshToken:     t.RefreshToken,
+		RefreshExpiresAt: t.RefreshExpiresAt,
+	}
+}
+
+// ToLoginResponse converts a domain.LoginResult to a LoginResponse.
+func ToLoginResponse(r *domain.LoginResult) LoginResponse {
+	if r.MFAChallenge != nil {
+		return LoginResponse{
+			MFARequired:  true,
+			MFAToken:     r.MFAChallenge.Token,
+			MFAExpiresAt: &r.MFAChallenge.ExpiresAt,
+		}
+	}
+	token := ToAuthTokenResponse(r.Token)
+	return LoginResponse{AuthTokenResponse: &token}
+}
@@ -11,10 +11,12 @@ import (
 func TestToUserResponse(t *testing.T) {
 	now := time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC)
 	user := &domain.User{
-		ID:        "user-123",
-		Name:      "Alice",
-		CreatedAt: now,
-		UpdatedAt: now.Add(1 * time.Hour),
+		ID:           "user-123",
+		Name:         "Alice",
+		Email:        "alice@example.com",
+		PasswordHash: "hashed",
+		CreatedAt:    now,
+		UpdatedAt:    now.Add(1 * time.Hour),
 	}
 
 	resp := dto.ToUserResponse(user)
@@ -25,6 +27,9 @@ func TestToUserResponse(t *testing.T) {
 	if resp.Name != "Alice" {
 		t.Errorf("expected Name 'Alice', got '%s'", resp.Name)
 	}
+	if resp.Email != "alice@example.com" {
+		t.Errorf("expected Email 'alice@example.com', got '%s'", resp.Email)
+	}
 	if !resp.CreatedAt.Equal(now) {
 		t.Errorf("expected CreatedAt %v, got %v", now, resp.CreatedAt)
 	}
@@ -272,9 +277,9 @@ func TestToGoalResponseList_Empty(t *testing.T) {
 }
 
 func TestToWeeklyStatsResponse(t *testing.T) {
-	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	stats := &domain.WeeklyStats{
-		WeekStart: weekStart,
+		WeekStart: domain.Date{Year: 2024, Month: time.January, Day: 1},
+		Timezone:  "Asia/Tokyo",
 		Projects: []domain.ProjectWeeklyStats{
 			{
 				ProjectID:            "p1",
@@ -299,6 +304,9 @@ func TestToWeeklyStatsResponse(t *testing.T) {
 	if resp.WeekStart != "2024-01-01" {
 		t.Errorf("expected WeekStart '2024-01-01', got '%s'", resp.WeekStart)
 	}
+	if resp.Timezone != "Asia/Tokyo" {
+		t.Errorf("expected Timezone 'Asia/Tokyo', got '%s'", resp.Timezone)
+	}
 	if resp.TotalMinutes != 180 {
 		t.Errorf("expected TotalMinutes 180, got %d", resp.TotalMinutes)
 	}
@@ -342,9 +350,8 @@ func TestToWeeklyStatsResponse(t *testing.T) {
 }
 
 func TestToWeeklyStatsResponse_NoProjects(t *testing.T) {
-	weekStart := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)
 	stats := &domain.WeeklyStats{
-		WeekStart:    weekStart,
+		WeekStart:    domain.Date{Year: 2024, Month: time.February, Day: 5},
 		Projects:     []domain.ProjectWeeklyStats{},
 		TotalMinutes: 0,
 	}
@@ -451,3 +458,54 @@ func TestToNoteResponseList_Empty(t *testing.T) {
 		t.Errorf("expected empty list, got %d items", len(result))
 	}
 }
+
+func TestToPersonalAccessTokenResponse(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := &domain.PersonalAccessToken{
+		ID:         "pat-1",
+		UserID:     "u1",
+		Name:       "cli",
+		TokenHash:  "secret-hash",
+		Scopes:     []string{domain.ScopeStudyLogsWrite},
+		LastUsedAt: &now,
+		CreatedAt:  now,
+	}
+
+	resp := dto.ToPersonalAccessTokenResponse(token)
+
+	if resp.ID != "pat-1" {
+		t.Errorf("expected ID 'pat-1', got '%s'", resp.ID)
+	}
+	if len(resp.Scopes) != 1 || resp.Scopes[0] != domain.ScopeStudyLogsWrite {
+		t.Errorf("expected scopes [%s], got %v", domain.ScopeStudyLogsWrite, resp.Scopes)
+	}
+	if resp.LastUsedAt == nil || !resp.LastUsedAt.Equal(now) {
+		t.Errorf("expected LastUsedAt %v, got %v", now, resp.LastUsedAt)
+	}
+	if resp.ExpiresAt != nil {
+		t.Errorf("expected ExpiresAt nil, got %v", resp.ExpiresAt)
+	}
+}
+
+func TestToSessionResponseList_MarksCurrent(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := []*domain.Session{
+		{ID: "s1", UserID: "u1", RefreshTokenHash: "h1", UserAgent: "phone", CreatedAt: now, LastUsedAt: now, ExpiresAt: now},
+		{ID: "s2", UserID: "u1", RefreshTokenHash: "h2", UserAgent: "laptop", CreatedAt: now, LastUsedAt: now, ExpiresAt: now},
+	}
+
+	result := dto.ToSessionResponseList(sessions, "s2")
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(result))
+	}
+	if result[0].Current {
+		t.Error("expected first session not to be current")
+	}
+	if !result[1].Current {
+		t.Error("expected second session to be current")
+	}
+	if result[1].UserAgent != "laptop" {
+		t.Errorf("expected UserAgent 'laptop', got '%s'", result[1].UserAgent)
+	}
+}
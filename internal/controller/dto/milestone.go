@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// CreateMilestoneRequest represents the request body for creating a milestone.
+type CreateMilestoneRequest struct {
+	Title   string   `json:"title" minLength:"1" maxLength:"200" doc:"Milestone title" example:"Finish chapter 3"`
+	DueDate *string  `json:"dueDate,omitempty" doc:"Due date (YYYY-MM-DD)"`
+	Items   []string `json:"items,omitempty" maxItems:"100" doc:"Titles of the checklist items to start with, in order"`
+}
+
+// UpdateMilestoneRequest represents the request body for updating a milestone.
+// A due date left out of the request is cleared.
+type UpdateMilestoneRequest struct {
+	Title   string  `json:"title" minLength:"1" maxLength:"200" doc:"Milestone title" example:"Finish chapter 3"`
+	DueDate *string `json:"dueDate,omitempty" doc:"Due date (YYYY-MM-DD)"`
+}
+
+// ReorderMilestonesRequest represents the request body for reordering the milestones of a project.
+type ReorderMilestonesRequest struct {
+	MilestoneIDs []string `json:"milestoneIds" doc:"IDs of all of the project's milestones in the new order"`
+}
+
+// CreateChecklistItemRequest represents the request body for adding a checklist item.
+type CreateChecklistItemRequest struct {
+	Title string `json:"title" minLength:"1" maxLength:"200" doc:"Checklist item title"`
+}
+
+// UpdateChecklistItemRequest represents the request body for updating a checklist item.
+type UpdateChecklistItemRequest struct {
+	Title string `json:"title" minLength:"1" maxLength:"200" doc:"Checklist item title"`
+	Done  bool   `json:"done" doc:"Whether the item is done"`
+}
+
+// ChecklistItemResponse represents a checklist item of a milestone.
+type ChecklistItemResponse struct {
+	ID    string `json:"id" doc:"Checklist item ID"`
+	Title string `json:"title" doc:"Checklist item title"`
+	Done  bool   `json:"done" doc:"Whether the item is done"`
+}
+
+// MilestoneResponse represents the response body for a milestone.
+type MilestoneResponse struct {
+	ID          string                  `json:"id" doc:"Milestone ID"`
+	ProjectID   string                  `json:"projectId" doc:"Project ID"`
+	UserID      string                  `json:"userId" doc:"Owner user ID"`
+	Title       string                  `json:"title" doc:"Milestone title"`
+	DueDate     *string                 `json:"dueDate,omitempty" doc:"Due date (omitted if not set)"`
+	Position    int                     `json:"position" doc:"Position among the project's milestones, starting at 0"`
+	Done        bool                    `json:"done" doc:"Whether the milestone is completed"`
+	CompletedAt *time.Time              `json:"completedAt,omitempty" doc:"When the milestone was completed (omitted until then)"`
+	Progress    float64                 `json:"progress" doc:"Completion percentage: 100 once completed, otherwise the share of checklist items done"`
+	Items       []ChecklistItemResponse `json:"items" doc:"Checklist items in order"`
+	CreatedAt   time.Time               `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt   time.Time               `json:"updatedAt" doc:"Last update timestamp"`
+}
+
+// MilestoneListResponse represents the milestones of a project with the project's completion percentage.
+type MilestoneListResponse struct {
+	Progress   float64             `json:"progress" doc:"Project completion percentage: the average progress of its milestones"`
+	Milestones []MilestoneResponse `json:"milestones" doc:"Milestones in order"`
+}
+
+// ToMilestoneResponse converts a domain.Milestone to a MilestoneResponse.
+func ToMilestoneResponse(m *domain.Milestone) MilestoneResponse {
+	items := make([]ChecklistItemResponse, len(m.Items))
+	for i, item := range m.Items {
+		items[i] = ChecklistItemResponse{
+			ID:    item.ID,
+			Title: item.Title,
+			Done:  item.Done,
+		}
+	}
+	resp := MilestoneResponse{
+		ID:          m.ID,
+		ProjectID:   m.ProjectID,
+		UserID:      m.UserID,
+		Title:       m.Title,
+		Position:    m.Position,
+		Done:        m.IsCompleted(),
+		CompletedAt: m.CompletedAt,
+		Progress:    m.Progress(),
+		Items:       items,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.DueDate != nil {
+		dueDate := m.DueDate.String()
+		resp.DueDate = &dueDate
+	}
+	return resp
+}
+
+// ToMilestoneListResponse converts the milestones of a project to a MilestoneListResponse.
+func ToMilestoneListResponse(milestones []*domain.Milestone) MilestoneListResponse {
+	result := make([]MilestoneResponse, len(milestones))
+	for i, m := range milestones {
+		result[i] = ToMilestoneResponse(m)
+	}
+	return MilestoneListResponse{
+		Progress:   domain.ProjectProgress(milestones),
+		Milestones: result,
+	}
+}
@@ -22,14 +22,23 @@ type UpdateNoteRequest struct {
 
 // NoteResponse represents the response body for a note.
 type NoteResponse struct {
-	ID        string    `json:"id" doc:"Note ID"`
-	ProjectID string    `json:"projectId" doc:"Project ID"`
-	UserID    string    `json:"userId" doc:"Owner user ID"`
-	Title     string    `json:"title" doc:"Note title"`
-	Content   string    `json:"content" doc:"Note content"`
-	Tags      []string  `json:"tags" doc:"Note tags"`
-	CreatedAt time.Time `json:"createdAt" doc:"Creation timestamp"`
-	UpdatedAt time.Time `json:"updatedAt" doc:"Last update timestamp"`
+	ID        string                   `json:"id" doc:"Note ID"`
+	ProjectID string                   `json:"projectId" doc:"Project ID"`
+	UserID    string                   `json:"userId" doc:"Owner user ID"`
+	Title     string                   `json:"title" doc:"Note title"`
+	Content   string                   `json:"content" doc:"Note content"`
+	Tags      []string                 `json:"tags" doc:"Note tags"`
+	StudyLogs []LinkedStudyLogResponse `json:"studyLogs" doc:"Study logs of the sessions the note was written in, ordered by start time"`
+	CreatedAt time.Time                `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt time.Time                `json:"updatedAt" doc:"Last update timestamp"`
+	DeletedAt *time.Time               `json:"deletedAt,omitempty" doc:"When the note was moved to the trash (omitted unless trashed)"`
+}
+
+// LinkedNoteResponse represents a note linked to a study log.
+type LinkedNoteResponse struct {
+	ID        string `json:"id" doc:"Note ID"`
+	ProjectID string `json:"projectId" doc:"Project ID"`
+	Title     string `json:"title" doc:"Note title"`
 }
 
 // ToNoteResponse converts a domain.Note to a NoteResponse.
@@ -45,8 +54,10 @@ func ToNoteResponse(n *domain.Note) NoteResponse {
 		Title:     n.Title,
 		Content:   n.Content,
 		Tags:      tags,
+		StudyLogs: toLinkedStudyLogResponseList(n.StudyLogs),
 		CreatedAt: n.CreatedAt,
 		UpdatedAt: n.UpdatedAt,
+		DeletedAt: n.DeletedAt,
 	}
 }
 
@@ -58,3 +69,11 @@ func ToNoteResponseList(notes []*domain.Note) []NoteResponse {
 	}
 	return result
 }
+
+func toLinkedNoteResponseList(notes []domain.LinkedNote) []LinkedNoteResponse {
+	result := make([]LinkedNoteResponse, len(notes))
+	for i, n := range notes {
+		result[i] = LinkedNoteResponse{ID: n.ID, ProjectID: n.ProjectID, Title: n.Title}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// CreatePersonalAccessTokenRequest represents the request body for creating a personal access token.
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name" minLength:"1" maxLength:"100" doc:"Token name" example:"editor-plugin"`
+	Scopes    []string   `json:"scopes" minItems:"1" enum:"users:read,projects:read,projects:write,studylogs:read,studylogs:write,goals:read,goals:write,stats:read,notes:read,notes:write" doc:"Scopes granted to the token"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" doc:"Expiry timestamp, optional (never expires if omitted)"`
+}
+
+// PersonalAccessTokenResponse represents the response body for a personal access token.
+type PersonalAccessTokenResponse struct {
+	ID         string     `json:"id" doc:"Token ID"`
+	Name       string     `json:"name" doc:"Token name"`
+	Scopes     []string   `json:"scopes" doc:"Scopes granted to the token"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" doc:"Last time the token was used"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" doc:"Expiry timestamp"`
+	CreatedAt  time.Time  `json:"createdAt" doc:"Creation timestamp"`
+}
+
+// CreatedPersonalAccessTokenResponse represents the response body for a newly created personal access token.
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token" doc:"Token value; shown only once"`
+}
+
+// ToPersonalAccessTokenResponse converts a domain.PersonalAccessToken to a PersonalAccessTokenResponse.
+func ToPersonalAccessTokenResponse(t *domain.PersonalAccessToken) PersonalAccessTokenResponse {
+	return PersonalAccessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// ToPersonalAccessTokenResponseList converts a list of domain.PersonalAccessToken to a list of PersonalAccessTokenResponse.
+func ToPersonalAccessTokenResponseList(tokens []*domain.PersonalAccessToken) []PersonalAccessTokenResponse {
+	result := make([]PersonalAccessTokenResponse, len(tokens))
+	for i, t := range tokens {
+		result[i] = ToPersonalAccessTokenResponse(t)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// StartPomodoroRequest represents the request body for starting a pomodoro session.
+type StartPomodoroRequest struct {
+	ProjectID    string `json:"projectId" doc:"Project ID"`
+	Note         string `json:"note,omitempty" maxLength:"1000" doc:"Optional note for the study logs"`
+	WorkMinutes  int    `json:"workMinutes,omitempty" default:"25" minimum:"1" maximum:"180" doc:"Length of each work phase"`
+	BreakMinutes int    `json:"breakMinutes,omitempty" default:"5" minimum:"1" maximum:"60" doc:"Length of each break"`
+	Cycles       int    `json:"cycles,omitempty" default:"4" minimum:"1" maximum:"24" doc:"Number of work phases"`
+}
+
+// PomodoroResponse represents the response body for a pomodoro session.
+type PomodoroResponse struct {
+	ProjectID          string     `json:"projectId" doc:"Project ID"`
+	Note               string     `json:"note" doc:"Note"`
+	WorkMinutes        int        `json:"workMinutes" doc:"Length of each work phase"`
+	BreakMinutes       int        `json:"breakMinutes" doc:"Length of each break"`
+	Cycles             int        `json:"cycles" doc:"Number of work phases"`
+	CompletedPomodoros int        `json:"completedPomodoros" doc:"Work phases completed so far"`
+	Phase              string     `json:"phase" enum:"work,break,finished" doc:"Current phase"`
+	PhaseStartedAt     time.Time  `json:"phaseStartedAt" doc:"When the current phase started"`
+	PhaseEndsAt        *time.Time `json:"phaseEndsAt,omitempty" doc:"When the current phase ends (omitted once finished)"`
+	StartedAt          time.Time  `json:"startedAt" doc:"When the session was started"`
+}
+
+// ToPomodoroResponse converts a domain.PomodoroSession to a PomodoroResponse.
+func ToPomodoroResponse(s *domain.PomodoroSession) PomodoroResponse {
+	resp := PomodoroResponse{
+		ProjectID:          s.ProjectID,
+		Note:               s.Note,
+		WorkMinutes:        s.Settings.WorkMinutes,
+		BreakMinutes:       s.Settings.BreakMinutes,
+		Cycles:             s.Settings.Cycles,
+		CompletedPomodoros: s.CompletedPomodoros,
+		Phase:              string(s.Phase),
+		PhaseStartedAt:     s.PhaseStartedAt,
+		StartedAt:          s.StartedAt,
+	}
+	if !s.IsFinished() {
+		endsAt := s.PhaseEndsAt()
+		resp.PhaseEndsAt = &endsAt
+	}
+	return resp
+}
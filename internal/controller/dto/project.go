@@ -6,34 +6,109 @@ import (
 	"github.com/shnaki/studytrack-api/internal/domain"
 )
 
+// ProjectDetailsRequest represents the descriptive fields of a project in a request body.
+type ProjectDetailsRequest struct {
+	Description string  `json:"description,omitempty" maxLength:"2000" doc:"Project description"`
+	Color       string  `json:"color,omitempty" pattern:"^#[0-9A-Fa-f]{6}$" doc:"Hex color such as #1e90ff"`
+	Icon        string  `json:"icon,omitempty" maxLength:"32" doc:"Emoji or short icon name"`
+	Status      string  `json:"status,omitempty" enum:"planned,active,paused,done" doc:"Lifecycle status (defaults to active)"`
+	TargetDate  *string `json:"targetDate,omitempty" doc:"Target completion date (YYYY-MM-DD)"`
+}
+
+// ToDomain converts ProjectDetailsRequest to domain.ProjectDetails.
+func (r ProjectDetailsRequest) ToDomain() (domain.ProjectDetails, error) {
+	details := domain.ProjectDetails{
+		Description: r.Description,
+		Color:       r.Color,
+		Icon:        r.Icon,
+		Status:      domain.ProjectStatus(r.Status),
+	}
+	if r.TargetDate != nil {
+		d, err := domain.ParseDate(*r.TargetDate)
+		if err != nil {
+			return domain.ProjectDetails{}, err
+		}
+		details.TargetDate = &d
+	}
+	return details, nil
+}
+
 // CreateProjectRequest represents the request body for creating a project.
 type CreateProjectRequest struct {
-	Name string `json:"name" minLength:"1" maxLength:"200" doc:"Project name"`
+	Name     string `json:"name" minLength:"1" maxLength:"200" doc:"Project name"`
+	ParentID string `json:"parentId,omitempty" doc:"Optional ID of one of the user's projects to create this project as a sub-topic of"`
+	ProjectDetailsRequest
 }
 
 // UpdateProjectRequest represents the request body for updating a project.
+// Details left out of the request are cleared.
 type UpdateProjectRequest struct {
 	Name string `json:"name" minLength:"1" maxLength:"200" doc:"Project name"`
+	ProjectDetailsRequest
+}
+
+// MoveProjectRequest represents the request body for moving a project in the project hierarchy.
+type MoveProjectRequest struct {
+	ParentID string `json:"parentId,omitempty" doc:"ID of the new parent project; omit to make the project top-level"`
+}
+
+// MergeProjectRequest represents the request body for merging a project into another project.
+type MergeProjectRequest struct {
+	TargetProjectID string `json:"targetProjectId" minLength:"1" doc:"ID of the project to merge this project into"`
+	GoalStrategy    string `json:"goalStrategy,omitempty" enum:"keep-target,keep-source,sum" doc:"Which goal to keep when both projects have one: the target's, this project's, or the sum of both; required in that case"`
+}
+
+// ProjectDeletionPreviewResponse represents what deleting a project would move to the trash with it.
+type ProjectDeletionPreviewResponse struct {
+	ProjectID             string        `json:"projectId" doc:"Project ID"`
+	StudyLogs             int           `json:"studyLogs" doc:"Number of study logs that would be moved to the trash"`
+	TotalMinutes          int           `json:"totalMinutes" doc:"Total minutes of those study logs"`
+	Notes                 int           `json:"notes" doc:"Number of notes that would be moved to the trash"`
+	Goal                  *GoalResponse `json:"goal,omitempty" doc:"The goal that would be moved to the trash (omitted if the project has none)"`
+	ConfirmationToken     string        `json:"confirmationToken" doc:"Pass as confirmationToken to delete the project; only you can use it, and it stops working once it expires or any of the above changes"`
+	ConfirmationExpiresAt time.Time     `json:"confirmationExpiresAt" doc:"When the confirmation token expires"`
 }
 
 // ProjectResponse represents the response body for a project.
 type ProjectResponse struct {
-	ID        string    `json:"id" doc:"Project ID"`
-	UserID    string    `json:"userId" doc:"Owner user ID"`
-	Name      string    `json:"name" doc:"Project name"`
-	CreatedAt time.Time `json:"createdAt" doc:"Creation timestamp"`
-	UpdatedAt time.Time `json:"updatedAt" doc:"Last update timestamp"`
+	ID          string     `json:"id" doc:"Project ID"`
+	UserID      string     `json:"userId" doc:"Owner user ID"`
+	Name        string     `json:"name" doc:"Project name"`
+	ParentID    string     `json:"parentId,omitempty" doc:"Parent project ID (omitted for a top-level project)"`
+	Description string     `json:"description" doc:"Project description"`
+	Color       string     `json:"color,omitempty" doc:"Hex color (omitted if not set)"`
+	Icon        string     `json:"icon,omitempty" doc:"Emoji or short icon name (omitted if not set)"`
+	Status      string     `json:"status" doc:"Lifecycle status: planned, active, paused or done"`
+	TargetDate  *string    `json:"targetDate,omitempty" doc:"Target completion date (omitted if not set)"`
+	CreatedAt   time.Time  `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt   time.Time  `json:"updatedAt" doc:"Last update timestamp"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" doc:"When the project was moved to the trash (omitted unless trashed)"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty" doc:"When the project was archived (omitted unless archived)"`
+	Progress    float64    `json:"progress" doc:"Completion percentage: the average progress of the project's milestones (0 without milestones)"`
 }
 
 // ToProjectResponse converts a domain.Project to a ProjectResponse.
 func ToProjectResponse(p *domain.Project) ProjectResponse {
-	return ProjectResponse{
-		ID:        p.ID,
-		UserID:    p.UserID,
-		Name:      p.Name,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+	resp := ProjectResponse{
+		ID:          p.ID,
+		UserID:      p.UserID,
+		Name:        p.Name,
+		ParentID:    p.ParentID,
+		Description: p.Description,
+		Color:       p.Color,
+		Icon:        p.Icon,
+		Status:      string(p.Status),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		DeletedAt:   p.DeletedAt,
+		ArchivedAt:  p.ArchivedAt,
+		Progress:    p.Progress,
+	}
+	if p.TargetDate != nil {
+		s := p.TargetDate.String()
+		resp.TargetDate = &s
 	}
+	return resp
 }
 
 // ToProjectResponseList converts a list of domain.Project to a list of ProjectResponse.
@@ -44,3 +119,56 @@ func ToProjectResponseList(projects []*domain.Project) []ProjectResponse {
 	}
 	return result
 }
+
+// ProjectMergeResponse represents the response body for a project merged into another project.
+type ProjectMergeResponse struct {
+	SourceProjectID            string          `json:"sourceProjectId" doc:"ID of the merged project, which has been deleted"`
+	Project                    ProjectResponse `json:"project" doc:"The project the source was merged into"`
+	Goal                       *GoalResponse   `json:"goal,omitempty" doc:"The goal of the project after the merge (omitted if neither project had one)"`
+	MovedStudyLogs             int             `json:"movedStudyLogs" doc:"Number of study logs moved"`
+	MovedOtherMembersStudyLogs int             `json:"movedOtherMembersStudyLogs" doc:"Number of the moved study logs that belong to other members of the source (included in movedStudyLogs)"`
+	MovedTrashedStudyLogs      int             `json:"movedTrashedStudyLogs" doc:"Number of the moved study logs that are in the trash (included in movedStudyLogs)"`
+	MovedNotes                 int             `json:"movedNotes" doc:"Number of notes moved"`
+	MovedOtherMembersNotes     int             `json:"movedOtherMembersNotes" doc:"Number of the moved notes that belong to other members of the source (included in movedNotes)"`
+	MovedTrashedNotes          int             `json:"movedTrashedNotes" doc:"Number of the moved notes that are in the trash (included in movedNotes)"`
+	MovedMilestones            int             `json:"movedMilestones" doc:"Number of milestones moved"`
+	MergedGoals                int             `json:"mergedGoals" doc:"Number of goals of the source merged into the project's goal"`
+}
+
+// ToProjectMergeResponse converts a domain.ProjectMergeSummary to a ProjectMergeResponse.
+func ToProjectMergeResponse(sourceID string, s *domain.ProjectMergeSummary) ProjectMergeResponse {
+	resp := ProjectMergeResponse{
+		SourceProjectID:            sourceID,
+		Project:                    ToProjectResponse(s.Target),
+		MovedStudyLogs:             s.StudyLogs,
+		MovedOtherMembersStudyLogs: s.OtherMembersStudyLogs,
+		MovedTrashedStudyLogs:      s.TrashedStudyLogs,
+		MovedNotes:                 s.Notes,
+		MovedOtherMembersNotes:     s.OtherMembersNotes,
+		MovedTrashedNotes:          s.TrashedNotes,
+		MovedMilestones:            s.Milestones,
+		MergedGoals:                s.Goals,
+	}
+	if s.Goal != nil {
+		goal := ToGoalResponse(s.Goal)
+		resp.Goal = &goal
+	}
+	return resp
+}
+
+// ToProjectDeletionPreviewResponse converts a domain.DeletionImpact to a ProjectDeletionPreviewResponse.
+func ToProjectDeletionPreviewResponse(projectID string, i *domain.DeletionImpact) ProjectDeletionPreviewResponse {
+	resp := ProjectDeletionPreviewResponse{
+		ProjectID:             projectID,
+		StudyLogs:             i.StudyLogs,
+		TotalMinutes:          i.TotalMinutes,
+		Notes:                 i.Notes,
+		ConfirmationToken:     i.ConfirmationToken,
+		ConfirmationExpiresAt: i.ConfirmationExpiresAt,
+	}
+	if i.Goal != nil {
+		goal := ToGoalResponse(i.Goal)
+		resp.Goal = &goal
+	}
+	return resp
+}
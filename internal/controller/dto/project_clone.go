@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// CloneProjectRequest represents the request body for cloning a project.
+type CloneProjectRequest struct {
+	TargetUserID string `json:"targetUserId,omitempty" doc:"ID of a member of the project to create the clone for; omit to create it for yourself"`
+	Name         string `json:"name,omitempty" maxLength:"200" doc:"Name of the clone; omit to use the name of the project. A number such as (2) is added if the name is taken"`
+	Notes        bool   `json:"notes,omitempty" doc:"Copy the notes of the project, with their tags"`
+	Goal         bool   `json:"goal,omitempty" doc:"Copy the goal of the project, moved to start today"`
+	Milestones   bool   `json:"milestones,omitempty" doc:"Copy the milestones of the project, with their checklists unchecked"`
+}
+
+// ProjectCloneResponse represents a cloned project and what was copied into it.
+type ProjectCloneResponse struct {
+	Project          ProjectResponse `json:"project" doc:"The new project"`
+	NotesCopied      int             `json:"notesCopied" doc:"Number of notes copied"`
+	Goal             *GoalResponse   `json:"goal,omitempty" doc:"The copied goal (omitted if no goal was copied)"`
+	MilestonesCopied int             `json:"milestonesCopied" doc:"Number of milestones copied"`
+}
+
+// ToProjectCloneResponse converts a domain.ProjectCopy to a ProjectCloneResponse.
+func ToProjectCloneResponse(c *domain.ProjectCopy) ProjectCloneResponse {
+	resp := ProjectCloneResponse{
+		Project:          ToProjectResponse(c.Project),
+		NotesCopied:      len(c.Notes),
+		MilestonesCopied: len(c.Milestones),
+	}
+	if c.Goal != nil {
+		goal := ToGoalResponse(c.Goal)
+		resp.Goal = &goal
+	}
+	return resp
+}
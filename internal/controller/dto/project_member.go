@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// InviteProjectMemberRequest represents the request body for inviting a user to a project.
+type InviteProjectMemberRequest struct {
+	Email string `json:"email" minLength:"1" maxLength:"254" doc:"Email address of the user to invite"`
+	Role  string `json:"role" enum:"editor,viewer" doc:"Role of the member: editors record study time and write notes in the project, viewers only read"`
+}
+
+// UpdateProjectMemberRequest represents the request body for changing the role of a project member.
+type UpdateProjectMemberRequest struct {
+	Role string `json:"role" enum:"editor,viewer" doc:"Role of the member"`
+}
+
+// ProjectMemberResponse represents a member of a project, or a pending invitation to it.
+type ProjectMemberResponse struct {
+	ProjectID   string     `json:"projectId" doc:"Project ID"`
+	ProjectName string     `json:"projectName" doc:"Project name"`
+	UserID      string     `json:"userId" doc:"Member user ID"`
+	UserName    string     `json:"userName" doc:"Member name"`
+	Role        string     `json:"role" enum:"owner,editor,viewer" doc:"Role of the member in the project"`
+	Status      string     `json:"status" enum:"pending,accepted" doc:"Whether the invitation has been accepted"`
+	InvitedAt   time.Time  `json:"invitedAt" doc:"When the user was invited (when the project was created, for the owner)"`
+	AcceptedAt  *time.Time `json:"acceptedAt,omitempty" doc:"When the invitation was accepted (omitted while pending)"`
+}
+
+// MemberStudyStatsResponse represents the time one member studied in a shared project.
+type MemberStudyStatsResponse struct {
+	UserID       string `json:"userId" doc:"Member user ID"`
+	UserName     string `json:"userName" doc:"Member name"`
+	Role         string `json:"role" enum:"owner,editor,viewer" doc:"Role of the member in the project"`
+	TotalMinutes int    `json:"totalMinutes" doc:"Total minutes the member studied in the project"`
+	Pomodoros    int    `json:"pomodoros" doc:"Pomodoros the member completed in the project"`
+}
+
+// ProjectMemberStatsResponse represents the time studied in a shared project, broken down by member.
+type ProjectMemberStatsResponse struct {
+	ProjectID      string                     `json:"projectId" doc:"Project ID"`
+	Timezone       string                     `json:"timezone" doc:"Timezone used for day boundaries"`
+	Members        []MemberStudyStatsResponse `json:"members" doc:"The owner first, then the members who have accepted their invitations"`
+	TotalMinutes   int                        `json:"totalMinutes" doc:"Total minutes across all members"`
+	TotalPomodoros int                        `json:"totalPomodoros" doc:"Total pomodoros across all members"`
+}
+
+// ToProjectMemberResponse converts a domain.ProjectMember to a ProjectMemberResponse.
+func ToProjectMemberResponse(m *domain.ProjectMember) ProjectMemberResponse {
+	status := "pending"
+	if m.IsAccepted() {
+		status = "accepted"
+	}
+	return ProjectMemberResponse{
+		ProjectID:   m.ProjectID,
+		ProjectName: m.ProjectName,
+		UserID:      m.UserID,
+		UserName:    m.UserName,
+		Role:        string(m.Role),
+		Status:      status,
+		InvitedAt:   m.InvitedAt,
+		AcceptedAt:  m.AcceptedAt,
+	}
+}
+
+// ToProjectMemberResponseList converts a list of domain.ProjectMember to a list of ProjectMemberResponse.
+func ToProjectMemberResponseList(members []*domain.ProjectMember) []ProjectMemberResponse {
+	result := make([]ProjectMemberResponse, len(members))
+	for i, m := range members {
+		result[i] = ToProjectMemberResponse(m)
+	}
+	return result
+}
+
+// ToProjectMemberStatsResponse converts domain.ProjectMemberStats to a ProjectMemberStatsResponse.
+func ToProjectMemberStatsResponse(s *domain.ProjectMemberStats) ProjectMemberStatsResponse {
+	members := make([]MemberStudyStatsResponse, len(s.Members))
+	for i, m := range s.Members {
+		members[i] = MemberStudyStatsResponse{
+			UserID:       m.UserID,
+			UserName:     m.UserName,
+			Role:         string(m.Role),
+			TotalMinutes: m.TotalMinutes,
+			Pomodoros:    m.Pomodoros,
+		}
+	}
+	return ProjectMemberStatsResponse{
+		ProjectID:      s.ProjectID,
+		Timezone:       s.Timezone,
+		Members:        members,
+		TotalMinutes:   s.TotalMinutes,
+		TotalPomodoros: s.TotalPomodoros,
+	}
+}
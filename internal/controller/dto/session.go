@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// SessionResponse represents the response body for a login session.
+type SessionResponse struct {
+	ID         string    `json:"id" doc:"Session ID"`
+	UserAgent  string    `json:"userAgent" doc:"User agent of the device that logged in"`
+	IPAddress  string    `json:"ipAddress" doc:"IP address of the last login or refresh"`
+	Current    bool      `json:"current" doc:"Whether this is the session making the request"`
+	CreatedAt  time.Time `json:"createdAt" doc:"Login timestamp"`
+	LastUsedAt time.Time `json:"lastUsedAt" doc:"Last login or refresh timestamp"`
+	ExpiresAt  time.Time `json:"expiresAt" doc:"Expiry unless refreshed"`
+}
+
+// ToSessionResponse converts a domain.Session to a SessionResponse.
+func ToSessionResponse(s *domain.Session, currentSessionID string) SessionResponse {
+	return SessionResponse{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		Current:    s.ID == currentSessionID,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+}
+
+// ToSessionResponseList converts a list of domain.Session to a list of SessionResponse.
+func ToSessionResponseList(sessions []*domain.Session, currentSessionID string) []SessionResponse {
+	result := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		result[i] = ToSessionResponse(s, currentSessionID)
+	}
+	return result
+}
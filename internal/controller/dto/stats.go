@@ -4,35 +4,85 @@ import "github.com/shnaki/studytrack-api/internal/domain"
 
 // ProjectWeeklyStatsResponse represents weekly statistics for a specific project.
 type ProjectWeeklyStatsResponse struct {
-	ProjectID            string  `json:"projectId" doc:"Project ID"`
-	ProjectName          string  `json:"projectName" doc:"Project name"`
-	TotalMinutes         int     `json:"totalMinutes" doc:"Total minutes studied this week"`
-	TargetMinutesPerWeek int     `json:"targetMinutesPerWeek" doc:"Weekly goal target (0 if no goal)"`
-	AchievementRate      float64 `json:"achievementRate" doc:"Achievement rate percentage (0 if no goal)"`
+	ProjectID            string                        `json:"projectId" doc:"Project ID"`
+	ProjectName          string                        `json:"projectName" doc:"Project name"`
+	ParentID             string                        `json:"parentId,omitempty" doc:"Parent project ID (omitted for a top-level project)"`
+	TotalMinutes         int                           `json:"totalMinutes" doc:"Total minutes studied this week in the project itself"`
+	Pomodoros            int                           `json:"pomodoros" doc:"Pomodoros completed this week in the project itself"`
+	SubtreeMinutes       int                           `json:"subtreeMinutes" doc:"Total minutes studied this week in the project and all of its sub-projects"`
+	SubtreePomodoros     int                           `json:"subtreePomodoros" doc:"Pomodoros completed this week in the project and all of its sub-projects"`
+	TargetMinutesPerWeek int                           `json:"targetMinutesPerWeek" doc:"Weekly goal target (0 if no goal)"`
+	AchievementRate      float64                       `json:"achievementRate" doc:"Achievement rate percentage of the subtree minutes (0 if no goal)"`
+	Activities           []ActivityWeeklyStatsResponse `json:"activities" doc:"Minutes this week by activity type"`
+}
+
+// ActivityWeeklyStatsResponse represents the minutes spent on one activity type of a project in a week.
+type ActivityWeeklyStatsResponse struct {
+	ActivityTypeID   string `json:"activityTypeId,omitempty" doc:"Activity type ID (omitted for logs without an activity type)"`
+	ActivityTypeName string `json:"activityTypeName,omitempty" doc:"Activity type name (omitted for logs without an activity type)"`
+	TotalMinutes     int    `json:"totalMinutes" doc:"Total minutes studied this week"`
 }
 
 // WeeklyStatsResponse represents weekly statistics for all projects.
 type WeeklyStatsResponse struct {
-	WeekStart    string                       `json:"weekStart" doc:"Week start date"`
-	Projects     []ProjectWeeklyStatsResponse `json:"projects" doc:"Per-project stats"`
-	TotalMinutes int                          `json:"totalMinutes" doc:"Total minutes across all projects"`
+	WeekStart         string                       `json:"weekStart" doc:"Week start date"`
+	Timezone          string                       `json:"timezone" doc:"Timezone used for day boundaries"`
+	Projects          []ProjectWeeklyStatsResponse `json:"projects" doc:"Per-project stats"`
+	TotalMinutes      int                          `json:"totalMinutes" doc:"Total minutes across all projects"`
+	TotalPomodoros    int                          `json:"totalPomodoros" doc:"Total pomodoros completed across all projects"`
+	OverdueMilestones []OverdueMilestoneResponse   `json:"overdueMilestones" doc:"Milestones of the listed projects that are past their due date without being completed, by due date"`
+}
+
+// OverdueMilestoneResponse represents a milestone that is past its due date without being completed.
+type OverdueMilestoneResponse struct {
+	MilestoneID string `json:"milestoneId" doc:"Milestone ID"`
+	ProjectID   string `json:"projectId" doc:"Project ID"`
+	ProjectName string `json:"projectName" doc:"Project name"`
+	Title       string `json:"title" doc:"Milestone title"`
+	DueDate     string `json:"dueDate" doc:"Due date"`
 }
 
 // ToWeeklyStatsResponse converts domain.WeeklyStats to WeeklyStatsResponse.
 func ToWeeklyStatsResponse(s *domain.WeeklyStats) WeeklyStatsResponse {
 	projects := make([]ProjectWeeklyStatsResponse, len(s.Projects))
 	for i, proj := range s.Projects {
+		activities := make([]ActivityWeeklyStatsResponse, len(proj.Activities))
+		for j, a := range proj.Activities {
+			activities[j] = ActivityWeeklyStatsResponse{
+				ActivityTypeID:   a.ActivityTypeID,
+				ActivityTypeName: a.ActivityTypeName,
+				TotalMinutes:     a.TotalMinutes,
+			}
+		}
 		projects[i] = ProjectWeeklyStatsResponse{
 			ProjectID:            proj.ProjectID,
 			ProjectName:          proj.ProjectName,
+			ParentID:             proj.ParentID,
 			TotalMinutes:         proj.TotalMinutes,
+			Pomodoros:            proj.Pomodoros,
+			SubtreeMinutes:       proj.SubtreeMinutes,
+			SubtreePomodoros:     proj.SubtreePomodoros,
 			TargetMinutesPerWeek: proj.TargetMinutesPerWeek,
 			AchievementRate:      proj.AchievementRate,
+			Activities:           activities,
+		}
+	}
+	overdue := make([]OverdueMilestoneResponse, len(s.OverdueMilestones))
+	for i, m := range s.OverdueMilestones {
+		overdue[i] = OverdueMilestoneResponse{
+			MilestoneID: m.MilestoneID,
+			ProjectID:   m.ProjectID,
+			ProjectName: m.ProjectName,
+			Title:       m.Title,
+			DueDate:     m.DueDate.String(),
 		}
 	}
 	return WeeklyStatsResponse{
-		WeekStart:    s.WeekStart.Format("2006-01-02"),
-		Projects:     projects,
-		TotalMinutes: s.TotalMinutes,
+		WeekStart:         s.WeekStart.String(),
+		Timezone:          s.Timezone,
+		Projects:          projects,
+		TotalMinutes:      s.TotalMinutes,
+		TotalPomodoros:    s.TotalPomodoros,
+		OverdueMilestones: overdue,
 	}
 }
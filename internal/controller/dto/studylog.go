@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
@@ -8,33 +9,82 @@ import (
 
 // CreateStudyLogRequest represents the request body for creating a study log.
 type CreateStudyLogRequest struct {
-	ProjectID string    `json:"projectId" doc:"Project ID"`
-	StudiedAt time.Time `json:"studiedAt" doc:"When the study session occurred"`
-	Minutes   int       `json:"minutes" minimum:"1" maximum:"1440" doc:"Duration in minutes"`
-	Note      string    `json:"note,omitempty" maxLength:"1000" doc:"Optional note"`
+	ProjectID      string     `json:"projectId" doc:"Project ID"`
+	ActivityTypeID string     `json:"activityTypeId,omitempty" doc:"Optional ID of one of the user's activity types"`
+	StudiedAt      time.Time  `json:"studiedAt" doc:"When the study session started"`
+	Minutes        int        `json:"minutes,omitempty" minimum:"1" maximum:"1440" doc:"Duration in minutes; required unless endedAt is given"`
+	EndedAt        *time.Time `json:"endedAt,omitempty" doc:"When the study session ended; minutes are derived from it"`
+	Note           string     `json:"note,omitempty" maxLength:"1000" doc:"Optional note"`
+}
+
+// CreateStudyLogBatchRequest represents the request body for creating several study logs at once.
+type CreateStudyLogBatchRequest struct {
+	Mode string                  `json:"mode,omitempty" enum:"atomic,bestEffort" default:"atomic" doc:"atomic creates every log or none of them; bestEffort creates the valid logs and reports the others"`
+	Logs []CreateStudyLogRequest `json:"logs" minItems:"1" maxItems:"100" doc:"Study logs to create"`
+}
+
+// UpdateStudyLogRequest represents the request body for replacing a study log.
+type UpdateStudyLogRequest struct {
+	ProjectID      string     `json:"projectId" doc:"Project ID"`
+	ActivityTypeID string     `json:"activityTypeId,omitempty" doc:"Optional ID of one of the user's activity types"`
+	StudiedAt      time.Time  `json:"studiedAt" doc:"When the study session started"`
+	Minutes        int        `json:"minutes,omitempty" minimum:"1" maximum:"1440" doc:"Duration in minutes; required unless endedAt is given"`
+	EndedAt        *time.Time `json:"endedAt,omitempty" doc:"When the study session ended; minutes are derived from it"`
+	Note           string     `json:"note,omitempty" maxLength:"1000" doc:"Optional note"`
+}
+
+// PatchStudyLogRequest represents the request body for partially updating a study log.
+// Omitted fields are left unchanged.
+type PatchStudyLogRequest struct {
+	ProjectID      *string    `json:"projectId,omitempty" doc:"Project ID"`
+	ActivityTypeID *string    `json:"activityTypeId,omitempty" doc:"Activity type ID; an empty string removes the activity type"`
+	StudiedAt      *time.Time `json:"studiedAt,omitempty" doc:"When the study session started"`
+	Minutes        *int       `json:"minutes,omitempty" minimum:"1" maximum:"1440" doc:"Duration in minutes"`
+	EndedAt        *time.Time `json:"endedAt,omitempty" doc:"When the study session ended; minutes are derived from it"`
+	Note           *string    `json:"note,omitempty" maxLength:"1000" doc:"Note"`
 }
 
 // StudyLogResponse represents the response body for a study log.
 type StudyLogResponse struct {
+	ID             string               `json:"id" doc:"Study log ID"`
+	UserID         string               `json:"userId" doc:"User ID"`
+	ProjectID      string               `json:"projectId" doc:"Project ID"`
+	ActivityTypeID string               `json:"activityTypeId,omitempty" doc:"Activity type ID (omitted if the log has none)"`
+	StudiedAt      time.Time            `json:"studiedAt" doc:"When the study session started"`
+	EndedAt        time.Time            `json:"endedAt" doc:"When the study session ended"`
+	Minutes        int                  `json:"minutes" doc:"Duration in minutes"`
+	Note           string               `json:"note" doc:"Note"`
+	Pomodoros      int                  `json:"pomodoros" doc:"Completed pomodoros recorded by this log (0 unless recorded by a pomodoro session)"`
+	Notes          []LinkedNoteResponse `json:"notes" doc:"Notes written during the session, ordered by title"`
+	CreatedAt      time.Time            `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt      time.Time            `json:"updatedAt" doc:"Last update timestamp"`
+	DeletedAt      *time.Time           `json:"deletedAt,omitempty" doc:"When the log was moved to the trash (omitted unless trashed)"`
+}
+
+// LinkedStudyLogResponse represents a study log linked to a note.
+type LinkedStudyLogResponse struct {
 	ID        string    `json:"id" doc:"Study log ID"`
-	UserID    string    `json:"userId" doc:"User ID"`
 	ProjectID string    `json:"projectId" doc:"Project ID"`
-	StudiedAt time.Time `json:"studiedAt" doc:"When the study session occurred"`
+	StudiedAt time.Time `json:"studiedAt" doc:"When the study session started"`
 	Minutes   int       `json:"minutes" doc:"Duration in minutes"`
-	Note      string    `json:"note" doc:"Note"`
-	CreatedAt time.Time `json:"createdAt" doc:"Creation timestamp"`
 }
 
 // ToStudyLogResponse converts a domain.StudyLog to a StudyLogResponse.
 func ToStudyLogResponse(l *domain.StudyLog) StudyLogResponse {
 	return StudyLogResponse{
-		ID:        l.ID,
-		UserID:    l.UserID,
-		ProjectID: l.ProjectID,
-		StudiedAt: l.StudiedAt,
-		Minutes:   l.Minutes,
-		Note:      l.Note,
-		CreatedAt: l.CreatedAt,
+		ID:             l.ID,
+		UserID:         l.UserID,
+		ProjectID:      l.ProjectID,
+		ActivityTypeID: l.ActivityTypeID,
+		StudiedAt:      l.StudiedAt,
+		EndedAt:        l.EndedAt(),
+		Minutes:        l.Minutes,
+		Note:           l.Note,
+		Pomodoros:      l.Pomodoros,
+		Notes:          toLinkedNoteResponseList(l.Notes),
+		CreatedAt:      l.CreatedAt,
+		UpdatedAt:      l.UpdatedAt,
+		DeletedAt:      l.DeletedAt,
 	}
 }
 
@@ -46,3 +96,79 @@ func ToStudyLogResponseList(logs []*domain.StudyLog) []StudyLogResponse {
 	}
 	return result
 }
+
+func toLinkedStudyLogResponseList(logs []domain.LinkedStudyLog) []LinkedStudyLogResponse {
+	result := make([]LinkedStudyLogResponse, len(logs))
+	for i, l := range logs {
+		result[i] = LinkedStudyLogResponse{ID: l.ID, ProjectID: l.ProjectID, StudiedAt: l.StudiedAt, Minutes: l.Minutes}
+	}
+	return result
+}
+
+// StudyLogBatchItemResponse represents the outcome of creating one study log of a batch.
+type StudyLogBatchItemResponse struct {
+	Index  int               `json:"index" doc:"Position of the log in the request"`
+	Status int               `json:"status" doc:"201 when the log was created, otherwise the status a single create request would have failed with"`
+	Log    *StudyLogResponse `json:"log,omitempty" doc:"The created study log"`
+	Error  string            `json:"error,omitempty" doc:"Why the log was not created"`
+}
+
+// StudyLogBatchResponse represents the response body for a study log batch.
+type StudyLogBatchResponse struct {
+	Created int                         `json:"created" doc:"Number of study logs created"`
+	Failed  int                         `json:"failed" doc:"Number of study logs that were not created"`
+	Results []StudyLogBatchItemResponse `json:"results" doc:"Per-log results, in request order"`
+}
+
+// ToStudyLogBatchResponse converts the results of a study log batch to a StudyLogBatchResponse.
+// statusOf returns the HTTP status of an item error.
+func ToStudyLogBatchResponse(results []domain.StudyLogBatchResult, statusOf func(*domain.Error) int) StudyLogBatchResponse {
+	resp := StudyLogBatchResponse{Results: make([]StudyLogBatchItemResponse, len(results))}
+	for i, r := range results {
+		item := StudyLogBatchItemResponse{Index: i}
+		if r.Err != nil {
+			item.Status = statusOf(r.Err)
+			item.Error = r.Err.Message
+			resp.Failed++
+		} else {
+			log := ToStudyLogResponse(r.Log)
+			item.Status = http.StatusCreated
+			item.Log = &log
+			resp.Created++
+		}
+		resp.Results[i] = item
+	}
+	return resp
+}
+
+// StudyLogRevisionResponse represents the values a study log had before an edit.
+type StudyLogRevisionResponse struct {
+	ID             string    `json:"id" doc:"Revision ID"`
+	StudyLogID     string    `json:"studyLogId" doc:"Study log ID"`
+	EditedBy       string    `json:"editedBy,omitempty" doc:"ID of the user who made the edit (omitted if the user was deleted)"`
+	EditedAt       time.Time `json:"editedAt" doc:"When the edit was made"`
+	ProjectID      string    `json:"projectId" doc:"Project ID before the edit"`
+	ActivityTypeID string    `json:"activityTypeId,omitempty" doc:"Activity type ID before the edit (omitted if there was none)"`
+	StudiedAt      time.Time `json:"studiedAt" doc:"Session time before the edit"`
+	Minutes        int       `json:"minutes" doc:"Duration in minutes before the edit"`
+	Note           string    `json:"note" doc:"Note before the edit"`
+}
+
+// ToStudyLogRevisionResponseList converts a list of domain.StudyLogRevision to a list of StudyLogRevisionResponse.
+func ToStudyLogRevisionResponseList(revisions []*domain.StudyLogRevision) []StudyLogRevisionResponse {
+	result := make([]StudyLogRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		result[i] = StudyLogRevisionResponse{
+			ID:             r.ID,
+			StudyLogID:     r.StudyLogID,
+			EditedBy:       r.EditedBy,
+			EditedAt:       r.CreatedAt,
+			ProjectID:      r.ProjectID,
+			ActivityTypeID: r.ActivityTypeID,
+			StudiedAt:      r.StudiedAt,
+			Minutes:        r.Minutes,
+			Note:           r.Note,
+		}
+	}
+	return result
+}
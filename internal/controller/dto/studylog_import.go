@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/shnaki/studytrack-api/internal/domain"
+
+// StudyLogImportErrorResponse represents a row that could not be imported.
+type StudyLogImportErrorResponse struct {
+	Line    int    `json:"line" doc:"Line number in the CSV file (the header is line 1)"`
+	Message string `json:"message" doc:"Why the row was not imported"`
+}
+
+// StudyLogImportResponse represents the response body for a study log import.
+type StudyLogImportResponse struct {
+	DryRun          bool                          `json:"dryRun" doc:"Whether nothing was saved"`
+	Rows            int                           `json:"rows" doc:"Number of data rows read"`
+	Imported        int                           `json:"imported" doc:"Number of study logs imported (or that would be imported)"`
+	Duplicates      int                           `json:"duplicates" doc:"Number of rows skipped because they were already imported"`
+	Failed          int                           `json:"failed" doc:"Number of rows with errors"`
+	CreatedProjects []string                      `json:"createdProjects" doc:"Projects created for unknown project names"`
+	Errors          []StudyLogImportErrorResponse `json:"errors" doc:"Per-row errors"`
+}
+
+// ToStudyLogImportResponse converts a domain.StudyLogImportResult to a StudyLogImportResponse.
+func ToStudyLogImportResponse(r *domain.StudyLogImportResult) StudyLogImportResponse {
+	errs := make([]StudyLogImportErrorResponse, len(r.Errors))
+	for i, e := range r.Errors {
+		errs[i] = StudyLogImportErrorResponse{Line: e.Line, Message: e.Message}
+	}
+	return StudyLogImportResponse{
+		DryRun:          r.DryRun,
+		Rows:            r.Rows,
+		Imported:        r.Imported,
+		Duplicates:      r.Duplicates,
+		Failed:          len(r.Errors),
+		CreatedProjects: r.CreatedProjects,
+		Errors:          errs,
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// StartTimerRequest represents the request body for starting a timer.
+type StartTimerRequest struct {
+	ProjectID string `json:"projectId" doc:"Project ID"`
+	Note      string `json:"note,omitempty" maxLength:"1000" doc:"Optional note for the study log"`
+}
+
+// StopTimerRequest represents the optional request body for stopping a timer.
+type StopTimerRequest struct {
+	Note *string `json:"note,omitempty" maxLength:"1000" doc:"Replaces the note given when the timer was started"`
+}
+
+// TimerResponse represents the response body for a timer.
+type TimerResponse struct {
+	ProjectID      string     `json:"projectId" doc:"Project ID"`
+	Note           string     `json:"note" doc:"Note"`
+	State          string     `json:"state" enum:"running,paused" doc:"Whether the timer is running or paused"`
+	StartedAt      time.Time  `json:"startedAt" doc:"When the timer was started"`
+	ResumedAt      *time.Time `json:"resumedAt,omitempty" doc:"When the timer last started running (omitted while paused)"`
+	ElapsedSeconds int        `json:"elapsedSeconds" doc:"Running time so far, excluding pauses"`
+	AutoStopAt     *time.Time `json:"autoStopAt,omitempty" doc:"When the running timer will be stopped automatically"`
+}
+
+// ToTimerResponse converts a domain.Timer to a TimerResponse as of the given time.
+func ToTimerResponse(t *domain.Timer, now time.Time) TimerResponse {
+	elapsed := t.ElapsedAt(now)
+	resp := TimerResponse{
+		ProjectID:      t.ProjectID,
+		Note:           t.Note,
+		State:          "paused",
+		StartedAt:      t.StartedAt,
+		ResumedAt:      t.ResumedAt,
+		ElapsedSeconds: int(elapsed / time.Second),
+	}
+	if t.IsRunning() {
+		resp.State = "running"
+		autoStopAt := now.Add(domain.MaxTimerDuration - elapsed)
+		resp.AutoStopAt = &autoStopAt
+	}
+	return resp
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/shnaki/studytrack-api/internal/domain"
+
+// TrashResponse represents the response body for a user's trash.
+type TrashResponse struct {
+	Projects  []ProjectResponse  `json:"projects" doc:"Trashed projects, most recently deleted first"`
+	Notes     []NoteResponse     `json:"notes" doc:"Trashed notes whose project is not in the trash, most recently deleted first"`
+	StudyLogs []StudyLogResponse `json:"studyLogs" doc:"Trashed study logs whose project is not in the trash, most recently deleted first"`
+}
+
+// ToTrashResponse converts a domain.Trash to a TrashResponse.
+func ToTrashResponse(t *domain.Trash) TrashResponse {
+	return TrashResponse{
+		Projects:  ToProjectResponseList(t.Projects),
+		Notes:     ToNoteResponseList(t.Notes),
+		StudyLogs: ToStudyLogResponseList(t.StudyLogs),
+	}
+}
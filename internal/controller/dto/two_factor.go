@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/shnaki/studytrack-api/internal/domain"
+
+// TOTPCodeRequest represents a request body carrying a code from the authenticator app.
+type TOTPCodeRequest struct {
+	Code string `json:"code" minLength:"1" maxLength:"32" doc:"Current code from the authenticator app (disabling also accepts a recovery code)"`
+}
+
+// TOTPEnrollmentResponse represents the response body for starting TOTP enrollment.
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret" doc:"Base32 TOTP secret for manual entry"`
+	ProvisioningURI string `json:"provisioningUri" doc:"otpauth:// URI, usually shown as a QR code"`
+}
+
+// RecoveryCodesResponse represents the response body containing newly issued recovery codes.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" doc:"One-time recovery codes; shown only once"`
+}
+
+// ToTOTPEnrollmentResponse converts a domain.TOTPEnrollment to a TOTPEnrollmentResponse.
+func ToTOTPEnrollmentResponse(e *domain.TOTPEnrollment) TOTPEnrollmentResponse {
+	return TOTPEnrollmentResponse{
+		Secret:          e.Secret,
+		ProvisioningURI: e.ProvisioningURI,
+	}
+}
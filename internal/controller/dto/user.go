@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
@@ -8,23 +9,126 @@ import (
 
 // CreateUserRequest represents the request body for creating a user.
 type CreateUserRequest struct {
-	Name string `json:"name" minLength:"1" maxLength:"100" doc:"User name"`
+	Name     string `json:"name" minLength:"1" maxLength:"100" doc:"User name"`
+	Email    string `json:"email" minLength:"1" maxLength:"254" doc:"Email address used to log in"`
+	Password string `json:"password" minLength:"8" maxLength:"72" doc:"Password (8-72 bytes)"`
+}
+
+// UpdateUserRequest represents the request body for updating a user.
+type UpdateUserRequest struct {
+	Name        string          `json:"name" minLength:"1" maxLength:"100" doc:"User name"`
+	Preferences UserPreferences `json:"preferences" doc:"User preferences"`
+}
+
+// UserPreferences represents the user preferences in request and response bodies.
+type UserPreferences struct {
+	Timezone              string `json:"timezone" minLength:"1" maxLength:"64" doc:"IANA time zone used for dates and weekly stats" example:"Asia/Tokyo"`
+	WeekStart             string `json:"weekStart" enum:"sunday,monday,tuesday,wednesday,thursday,friday,saturday" doc:"First day of the week"`
+	Locale                string `json:"locale" minLength:"2" maxLength:"35" doc:"BCP 47 language tag" example:"ja-JP"`
+	DefaultSessionMinutes int    `json:"defaultSessionMinutes" minimum:"1" maximum:"1440" doc:"Default length of a study session in minutes"`
+}
+
+// UserDeletionResponse represents the response body for a deleted user.
+type UserDeletionResponse struct {
+	UserID                       string `json:"userId" doc:"Deleted user ID"`
+	DeletedProjects              int    `json:"deletedProjects" doc:"Number of projects deleted"`
+	DeletedStudyLogs             int    `json:"deletedStudyLogs" doc:"Number of study logs deleted"`
+	DeletedOtherMembersStudyLogs int    `json:"deletedOtherMembersStudyLogs" doc:"Number of the deleted study logs that other members wrote in the user's projects (included in deletedStudyLogs)"`
+	DeletedGoals                 int    `json:"deletedGoals" doc:"Number of goals deleted"`
+	DeletedNotes                 int    `json:"deletedNotes" doc:"Number of notes deleted"`
+	DeletedOtherMembersNotes     int    `json:"deletedOtherMembersNotes" doc:"Number of the deleted notes that other members wrote in the user's projects (included in deletedNotes)"`
+}
+
+// UserDeletionPreviewResponse represents what deleting a user would remove.
+type UserDeletionPreviewResponse struct {
+	UserID                string    `json:"userId" doc:"User ID"`
+	Projects              int       `json:"projects" doc:"Number of projects that would be deleted"`
+	StudyLogs             int       `json:"studyLogs" doc:"Number of study logs that would be deleted"`
+	TotalMinutes          int       `json:"totalMinutes" doc:"Total minutes of the study logs that would be deleted"`
+	OtherMembersStudyLogs int       `json:"otherMembersStudyLogs" doc:"Number of the study logs that other members wrote in the user's projects (included in studyLogs)"`
+	Notes                 int       `json:"notes" doc:"Number of notes that would be deleted"`
+	OtherMembersNotes     int       `json:"otherMembersNotes" doc:"Number of the notes that other members wrote in the user's projects (included in notes)"`
+	Goals                 int       `json:"goals" doc:"Number of goals that would be deleted"`
+	ConfirmationToken     string    `json:"confirmationToken" doc:"Pass as confirmationToken to delete the user; only you can use it, and it stops working once it expires or any of the above changes"`
+	ConfirmationExpiresAt time.Time `json:"confirmationExpiresAt" doc:"When the confirmation token expires"`
 }
 
 // UserResponse represents the response body for a user.
 type UserResponse struct {
-	ID        string    `json:"id" doc:"User ID"`
-	Name      string    `json:"name" doc:"User name"`
-	CreatedAt time.Time `json:"createdAt" doc:"Creation timestamp"`
-	UpdatedAt time.Time `json:"updatedAt" doc:"Last update timestamp"`
+	ID               string          `json:"id" doc:"User ID"`
+	Name             string          `json:"name" doc:"User name"`
+	Email            string          `json:"email" doc:"Email address"`
+	TwoFactorEnabled bool            `json:"twoFactorEnabled" doc:"Whether TOTP two-factor authentication is enabled"`
+	Preferences      UserPreferences `json:"preferences" doc:"User preferences"`
+	CreatedAt        time.Time       `json:"createdAt" doc:"Creation timestamp"`
+	UpdatedAt        time.Time       `json:"updatedAt" doc:"Last update timestamp"`
 }
 
 // ToUserResponse converts a domain.User to a UserResponse.
 func ToUserResponse(u *domain.User) UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Name:      u.Name,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:               u.ID,
+		Name:             u.Name,
+		Email:            u.Email,
+		TwoFactorEnabled: u.TwoFactor.TOTPEnabled,
+		Preferences:      ToUserPreferences(u.Preferences),
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+	}
+}
+
+// ToUserPreferences converts domain.UserPreferences to UserPreferences.
+func ToUserPreferences(p domain.UserPreferences) UserPreferences {
+	return UserPreferences{
+		Timezone:              p.Timezone,
+		WeekStart:             strings.ToLower(p.WeekStart.String()),
+		Locale:                p.Locale,
+		DefaultSessionMinutes: p.DefaultSessionMinutes,
+	}
+}
+
+// ToDomain converts UserPreferences to domain.UserPreferences.
+// An unrecognized week start is mapped to an out-of-range weekday so domain validation rejects it.
+func (p UserPreferences) ToDomain() domain.UserPreferences {
+	weekStart := time.Weekday(-1)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), p.WeekStart) {
+			weekStart = d
+		}
+	}
+	return domain.UserPreferences{
+		Timezone:              p.Timezone,
+		WeekStart:             weekStart,
+		Locale:                p.Locale,
+		DefaultSessionMinutes: p.DefaultSessionMinutes,
+	}
+}
+
+// ToUserDeletionPreviewResponse converts a domain.DeletionImpact to a UserDeletionPreviewResponse.
+func ToUserDeletionPreviewResponse(userID string, i *domain.DeletionImpact) UserDeletionPreviewResponse {
+	return UserDeletionPreviewResponse{
+		UserID:                userID,
+		Projects:              i.Projects,
+		StudyLogs:             i.StudyLogs,
+		TotalMinutes:          i.TotalMinutes,
+		OtherMembersStudyLogs: i.OtherMembersStudyLogs,
+		Notes:                 i.Notes,
+		OtherMembersNotes:     i.OtherMembersNotes,
+		Goals:                 i.Goals,
+		ConfirmationToken:     i.ConfirmationToken,
+		ConfirmationExpiresAt: i.ConfirmationExpiresAt,
+	}
+}
+
+// ToUserDeletionResponse converts a domain.UserDeletionSummary to a UserDeletionResponse.
+func ToUserDeletionResponse(userID string, s *domain.UserDeletionSummary) UserDeletionResponse {
+	return UserDeletionResponse{
+		UserID:                       userID,
+		DeletedProjects:              s.Projects,
+		DeletedStudyLogs:             s.StudyLogs,
+		DeletedOtherMembersStudyLogs: s.OtherMembersStudyLogs,
+		DeletedGoals:                 s.Goals,
+		DeletedNotes:                 s.Notes,
+		DeletedOtherMembersNotes:     s.OtherMembersNotes,
 	}
 }
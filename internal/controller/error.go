@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
 
@@ -18,7 +19,20 @@ func toHTTPError(err error) error {
 			return huma.Error400BadRequest(domErr.Message)
 		case domain.ErrorTypeConflict:
 			return huma.Error409Conflict(domErr.Message)
+		case domain.ErrorTypeUnauthorized:
+			return huma.Error401Unauthorized(domErr.Message)
+		case domain.ErrorTypeForbidden:
+			return huma.Error403Forbidden(domErr.Message)
 		}
 	}
 	return huma.Error500InternalServerError("internal server error")
 }
+
+// httpStatus returns the HTTP status toHTTPError reports a domain error with.
+func httpStatus(err *domain.Error) int {
+	var statusErr huma.StatusError
+	if errors.As(toHTTPError(err), &statusErr) {
+		return statusErr.GetStatus()
+	}
+	return http.StatusInternalServerError
+}
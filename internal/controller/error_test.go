@@ -50,6 +50,32 @@ func TestToHTTPError_Conflict(t *testing.T) {
 	}
 }
 
+func TestToHTTPError_Unauthorized(t *testing.T) {
+	domErr := domain.ErrUnauthorized("invalid token")
+	httpErr := toHTTPError(domErr)
+
+	var se huma.StatusError
+	if !errors.As(httpErr, &se) {
+		t.Fatalf("expected huma.StatusError, got %T: %v", httpErr, httpErr)
+	}
+	if se.GetStatus() != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, se.GetStatus())
+	}
+}
+
+func TestToHTTPError_Forbidden(t *testing.T) {
+	domErr := domain.ErrForbidden("access denied")
+	httpErr := toHTTPError(domErr)
+
+	var se huma.StatusError
+	if !errors.As(httpErr, &se) {
+		t.Fatalf("expected huma.StatusError, got %T: %v", httpErr, httpErr)
+	}
+	if se.GetStatus() != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, se.GetStatus())
+	}
+}
+
 func TestToHTTPError_GenericError(t *testing.T) {
 	genericErr := fmt.Errorf("something went wrong")
 	httpErr := toHTTPError(genericErr)
@@ -8,6 +8,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
@@ -23,10 +24,15 @@ type upsertGoalOutput struct {
 
 type listGoalsInput struct {
 	UserID string `path:"userId" doc:"User ID"`
+	Sort   string `query:"sort" enum:"createdAt,-createdAt" doc:"Sort order, prefixed with - for descending order (defaults to createdAt)"`
+	Limit  int    `query:"limit" minimum:"1" maximum:"200" default:"50" doc:"Maximum number of items to return"`
+	Cursor string `query:"cursor" doc:"Cursor of the next page, from the X-Next-Cursor header of the previous response"`
 }
 
 type listGoalsOutput struct {
-	Body []dto.GoalResponse
+	Link       string `header:"Link" doc:"Link to the next page with rel=\"next\"; absent on the last page"`
+	NextCursor string `header:"X-Next-Cursor" doc:"Cursor of the next page; absent on the last page"`
+	Body       []dto.GoalResponse
 }
 
 // RegisterGoalRoutes registers goal-related routes to the Huma API.
@@ -37,7 +43,11 @@ func RegisterGoalRoutes(api huma.API, uc *usecase.GoalUsecase) {
 		Path:        "/users/{userId}/goals/{projectId}",
 		Summary:     "Create or update a goal for a project",
 		Tags:        []string{"Goals"},
+		Security:    bearerAuth(domain.ScopeGoalsWrite),
 	}, func(ctx context.Context, input *upsertGoalInput) (*upsertGoalOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
 		startDate, err := time.Parse("2006-01-02", input.Body.StartDate)
 		if err != nil {
 			return nil, huma.Error400BadRequest("invalid startDate format, expected YYYY-MM-DD")
@@ -65,11 +75,19 @@ func RegisterGoalRoutes(api huma.API, uc *usecase.GoalUsecase) {
 		Path:        "/users/{userId}/goals",
 		Summary:     "List goals for a user",
 		Tags:        []string{"Goals"},
+		Security:    bearerAuth(domain.ScopeGoalsRead),
 	}, func(ctx context.Context, input *listGoalsInput) (*listGoalsOutput, error) {
-		goals, err := uc.ListGoals(ctx, input.UserID)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		goals, next, err := uc.ListGoals(ctx, input.UserID, usecase.PageOptions{Limit: input.Limit, Cursor: input.Cursor, Sort: input.Sort})
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
-		return &listGoalsOutput{Body: dto.ToGoalResponseList(goals)}, nil
+		return &listGoalsOutput{
+			Link:       nextPageLink("/users/"+input.UserID+"/goals", nil, input.Limit, input.Sort, next),
+			NextCursor: next,
+			Body:       dto.ToGoalResponseList(goals),
+		}, nil
 	})
 }
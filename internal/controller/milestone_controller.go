@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type createMilestoneInput struct {
+	UserID    string `path:"userId" doc:"User ID"`
+	ProjectID string `path:"projectId" doc:"Project ID"`
+	Body      dto.CreateMilestoneRequest
+}
+
+type milestoneOutput struct {
+	Body dto.MilestoneResponse
+}
+
+type listMilestonesInput struct {
+	UserID    string `path:"userId" doc:"User ID"`
+	ProjectID string `path:"projectId" doc:"Project ID"`
+}
+
+type milestoneListOutput struct {
+	Body dto.MilestoneListResponse
+}
+
+type reorderMilestonesInput struct {
+	UserID    string `path:"userId" doc:"User ID"`
+	ProjectID string `path:"projectId" doc:"Project ID"`
+	Body      dto.ReorderMilestonesRequest
+}
+
+type updateMilestoneInput struct {
+	ID   string `path:"id" doc:"Milestone ID"`
+	Body dto.UpdateMilestoneRequest
+}
+
+type milestoneIDInput struct {
+	ID string `path:"id" doc:"Milestone ID"`
+}
+
+type createChecklistItemInput struct {
+	ID   string `path:"id" doc:"Milestone ID"`
+	Body dto.CreateChecklistItemRequest
+}
+
+type updateChecklistItemInput struct {
+	ID     string `path:"id" doc:"Milestone ID"`
+	ItemID string `path:"itemId" doc:"Checklist item ID"`
+	Body   dto.UpdateChecklistItemRequest
+}
+
+type deleteChecklistItemInput struct {
+	ID     string `path:"id" doc:"Milestone ID"`
+	ItemID string `path:"itemId" doc:"Checklist item ID"`
+}
+
+// RegisterMilestoneRoutes registers milestone-related routes to the Huma API.
+func RegisterMilestoneRoutes(api huma.API, uc *usecase.MilestoneUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-milestone",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/projects/{projectId}/milestones",
+		Summary:       "Create a milestone",
+		Description:   "Adds a milestone at the end of the project's milestones, optionally with a checklist.",
+		Tags:          []string{"Milestones"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *createMilestoneInput) (*milestoneOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		dueDate, err := parseDueDate(input.Body.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		milestone, err := uc.CreateMilestone(ctx, input.UserID, input.ProjectID, input.Body.Title, dueDate, input.Body.Items)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-milestones",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/projects/{projectId}/milestones",
+		Summary:     "List the milestones of a project",
+		Description: "Returns the project's milestones in order, with their checklists and the project's completion percentage.",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsRead),
+	}, func(ctx context.Context, input *listMilestonesInput) (*milestoneListOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		milestones, err := uc.ListMilestones(ctx, input.UserID, input.ProjectID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneListOutput{Body: dto.ToMilestoneListResponse(milestones)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reorder-milestones",
+		Method:      http.MethodPut,
+		Path:        "/users/{userId}/projects/{projectId}/milestones/order",
+		Summary:     "Reorder the milestones of a project",
+		Description: "The request must list each of the project's milestones exactly once.",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *reorderMilestonesInput) (*milestoneListOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		milestones, err := uc.ReorderMilestones(ctx, input.UserID, input.ProjectID, input.Body.MilestoneIDs)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneListOutput{Body: dto.ToMilestoneListResponse(milestones)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-milestone",
+		Method:      http.MethodPut,
+		Path:        "/milestones/{id}",
+		Summary:     "Update a milestone",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *updateMilestoneInput) (*milestoneOutput, error) {
+		dueDate, err := parseDueDate(input.Body.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		milestone, err := uc.UpdateMilestone(ctx, authUserID(ctx), input.ID, input.Body.Title, dueDate)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "complete-milestone",
+		Method:      http.MethodPost,
+		Path:        "/milestones/{id}/complete",
+		Summary:     "Complete a milestone",
+		Description: "Records the current time as when the milestone was completed. Completing a completed milestone is a conflict.",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *milestoneIDInput) (*milestoneOutput, error) {
+		milestone, err := uc.CompleteMilestone(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reopen-milestone",
+		Method:      http.MethodPost,
+		Path:        "/milestones/{id}/reopen",
+		Summary:     "Reopen a completed milestone",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *milestoneIDInput) (*milestoneOutput, error) {
+		milestone, err := uc.ReopenMilestone(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-milestone",
+		Method:        http.MethodDelete,
+		Path:          "/milestones/{id}",
+		Summary:       "Delete a milestone",
+		Description:   "Deletes the milestone together with its checklist.",
+		Tags:          []string{"Milestones"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *milestoneIDInput) (*struct{}, error) {
+		if err := uc.DeleteMilestone(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-checklist-item",
+		Method:        http.MethodPost,
+		Path:          "/milestones/{id}/items",
+		Summary:       "Add a checklist item",
+		Description:   "Appends an item to the milestone's checklist and returns the milestone.",
+		Tags:          []string{"Milestones"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *createChecklistItemInput) (*milestoneOutput, error) {
+		milestone, err := uc.AddChecklistItem(ctx, authUserID(ctx), input.ID, input.Body.Title)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-checklist-item",
+		Method:      http.MethodPut,
+		Path:        "/milestones/{id}/items/{itemId}",
+		Summary:     "Update a checklist item",
+		Description: "Renames the item or checks it off, and returns the milestone.",
+		Tags:        []string{"Milestones"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *updateChecklistItemInput) (*milestoneOutput, error) {
+		milestone, err := uc.UpdateChecklistItem(ctx, authUserID(ctx), input.ID, input.ItemID, input.Body.Title, input.Body.Done)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &milestoneOutput{Body: dto.ToMilestoneResponse(milestone)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-checklist-item",
+		Method:        http.MethodDelete,
+		Path:          "/milestones/{id}/items/{itemId}",
+		Summary:       "Delete a checklist item",
+		Tags:          []string{"Milestones"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *deleteChecklistItemInput) (*struct{}, error) {
+		if err := uc.RemoveChecklistItem(ctx, authUserID(ctx), input.ID, input.ItemID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
+
+// parseDueDate parses an optional YYYY-MM-DD due date.
+func parseDueDate(s *string) (*domain.Date, error) {
+	if s == nil {
+		return nil, nil
+	}
+	d, err := domain.ParseDate(*s)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid 'dueDate' format, expected YYYY-MM-DD")
+	}
+	return &d, nil
+}
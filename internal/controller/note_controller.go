@@ -7,6 +7,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
@@ -23,10 +24,15 @@ type createNoteOutput struct {
 type listNotesInput struct {
 	UserID    string `path:"userId" doc:"User ID"`
 	ProjectID string `path:"projectId" doc:"Project ID"`
+	Sort      string `query:"sort" enum:"updatedAt,-updatedAt,createdAt,-createdAt" doc:"Sort order, prefixed with - for descending order (defaults to -updatedAt)"`
+	Limit     int    `query:"limit" minimum:"1" maximum:"200" default:"50" doc:"Maximum number of items to return"`
+	Cursor    string `query:"cursor" doc:"Cursor of the next page, from the X-Next-Cursor header of the previous response"`
 }
 
 type listNotesOutput struct {
-	Body []dto.NoteResponse
+	Link       string `header:"Link" doc:"Link to the next page with rel=\"next\"; absent on the last page"`
+	NextCursor string `header:"X-Next-Cursor" doc:"Cursor of the next page; absent on the last page"`
+	Body       []dto.NoteResponse
 }
 
 type getNoteInput struct {
@@ -50,6 +56,23 @@ type deleteNoteInput struct {
 	ID string `path:"id" doc:"Note ID"`
 }
 
+type restoreNoteInput struct {
+	ID string `path:"id" doc:"Note ID"`
+}
+
+type restoreNoteOutput struct {
+	Body dto.NoteResponse
+}
+
+type noteStudyLogInput struct {
+	ID         string `path:"id" doc:"Note ID"`
+	StudyLogID string `path:"studyLogId" doc:"Study log ID"`
+}
+
+type linkNoteStudyLogOutput struct {
+	Body dto.NoteResponse
+}
+
 // RegisterNoteRoutes registers note-related routes to the Huma API.
 func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 	huma.Register(api, huma.Operation{
@@ -58,8 +81,12 @@ func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 		Path:          "/users/{userId}/projects/{projectId}/notes",
 		Summary:       "Create a new note",
 		Tags:          []string{"Notes"},
+		Security:      bearerAuth(domain.ScopeNotesWrite),
 		DefaultStatus: http.StatusCreated,
 	}, func(ctx context.Context, input *createNoteInput) (*createNoteOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
 		note, err := uc.CreateNote(ctx, input.UserID, input.ProjectID, input.Body.Title, input.Body.Content, input.Body.Tags)
 		if err != nil {
 			return nil, toHTTPError(err)
@@ -73,12 +100,20 @@ func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 		Path:        "/users/{userId}/projects/{projectId}/notes",
 		Summary:     "List notes for a project",
 		Tags:        []string{"Notes"},
+		Security:    bearerAuth(domain.ScopeNotesRead),
 	}, func(ctx context.Context, input *listNotesInput) (*listNotesOutput, error) {
-		notes, err := uc.ListNotes(ctx, input.UserID, input.ProjectID)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		notes, next, err := uc.ListNotes(ctx, input.UserID, input.ProjectID, usecase.PageOptions{Limit: input.Limit, Cursor: input.Cursor, Sort: input.Sort})
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
-		return &listNotesOutput{Body: dto.ToNoteResponseList(notes)}, nil
+		return &listNotesOutput{
+			Link:       nextPageLink("/users/"+input.UserID+"/projects/"+input.ProjectID+"/notes", nil, input.Limit, input.Sort, next),
+			NextCursor: next,
+			Body:       dto.ToNoteResponseList(notes),
+		}, nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -87,8 +122,9 @@ func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 		Path:        "/notes/{id}",
 		Summary:     "Get a note by ID",
 		Tags:        []string{"Notes"},
+		Security:    bearerAuth(domain.ScopeNotesRead),
 	}, func(ctx context.Context, input *getNoteInput) (*getNoteOutput, error) {
-		note, err := uc.GetNote(ctx, input.ID)
+		note, err := uc.GetNote(ctx, authUserID(ctx), input.ID)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -101,8 +137,9 @@ func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 		Path:        "/notes/{id}",
 		Summary:     "Update a note",
 		Tags:        []string{"Notes"},
+		Security:    bearerAuth(domain.ScopeNotesWrite),
 	}, func(ctx context.Context, input *updateNoteInput) (*updateNoteOutput, error) {
-		note, err := uc.UpdateNote(ctx, input.ID, input.Body.Title, input.Body.Content, input.Body.Tags)
+		note, err := uc.UpdateNote(ctx, authUserID(ctx), input.ID, input.Body.Title, input.Body.Content, input.Body.Tags)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -113,11 +150,59 @@ func RegisterNoteRoutes(api huma.API, uc *usecase.NoteUsecase) {
 		OperationID:   "delete-note",
 		Method:        http.MethodDelete,
 		Path:          "/notes/{id}",
-		Summary:       "Delete a note",
+		Summary:       "Move a note to the trash",
 		Tags:          []string{"Notes"},
+		Security:      bearerAuth(domain.ScopeNotesWrite),
 		DefaultStatus: http.StatusNoContent,
 	}, func(ctx context.Context, input *deleteNoteInput) (*struct{}, error) {
-		if err := uc.DeleteNote(ctx, input.ID); err != nil {
+		if err := uc.DeleteNote(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-note",
+		Method:      http.MethodPost,
+		Path:        "/notes/{id}/restore",
+		Summary:     "Restore a note from the trash",
+		Description: "A note that was trashed together with its project is restored with the project instead.",
+		Tags:        []string{"Notes"},
+		Security:    bearerAuth(domain.ScopeNotesWrite),
+	}, func(ctx context.Context, input *restoreNoteInput) (*restoreNoteOutput, error) {
+		note, err := uc.RestoreNote(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &restoreNoteOutput{Body: dto.ToNoteResponse(note)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "link-note-study-log",
+		Method:      http.MethodPut,
+		Path:        "/notes/{id}/study-logs/{studyLogId}",
+		Summary:     "Link a note to a study log",
+		Description: "Records that the note was written during the study session. Linking an already linked study log does nothing.",
+		Tags:        []string{"Notes"},
+		Security:    bearerAuth(domain.ScopeNotesWrite),
+	}, func(ctx context.Context, input *noteStudyLogInput) (*linkNoteStudyLogOutput, error) {
+		note, err := uc.LinkStudyLog(ctx, authUserID(ctx), input.ID, input.StudyLogID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &linkNoteStudyLogOutput{Body: dto.ToNoteResponse(note)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "unlink-note-study-log",
+		Method:        http.MethodDelete,
+		Path:          "/notes/{id}/study-logs/{studyLogId}",
+		Summary:       "Unlink a note from a study log",
+		Tags:          []string{"Notes"},
+		Security:      bearerAuth(domain.ScopeNotesWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *noteStudyLogInput) (*struct{}, error) {
+		if err := uc.UnlinkStudyLog(ctx, authUserID(ctx), input.ID, input.StudyLogID); err != nil {
 			return nil, toHTTPError(err)
 		}
 		return nil, nil
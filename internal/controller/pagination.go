@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// nextPageLink returns the Link header value pointing at the page after the current one, or an
+// empty string on the last page. path is relative to the API base path; query holds the list's
+// filters, which are kept with the limit and sort order so the next page continues the same list.
+func nextPageLink(path string, query url.Values, limit int, sort, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	next := url.Values{}
+	for key, values := range query {
+		if len(values) > 0 && values[0] != "" {
+			next.Set(key, values[0])
+		}
+	}
+	next.Set("limit", strconv.Itoa(limit))
+	if sort != "" {
+		next.Set("sort", sort)
+	}
+	next.Set("cursor", cursor)
+	return "<" + apiBasePath + path + "?" + next.Encode() + `>; rel="next"`
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type createPersonalAccessTokenInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.CreatePersonalAccessTokenRequest
+}
+
+type createPersonalAccessTokenOutput struct {
+	Body dto.CreatedPersonalAccessTokenResponse
+}
+
+type listPersonalAccessTokensInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type listPersonalAccessTokensOutput struct {
+	Body []dto.PersonalAccessTokenResponse
+}
+
+type revokePersonalAccessTokenInput struct {
+	ID string `path:"id" doc:"Token ID"`
+}
+
+// RegisterPersonalAccessTokenRoutes registers personal access token-related routes to the Huma API.
+// Tokens can only be managed after logging in with a password.
+func RegisterPersonalAccessTokenRoutes(api huma.API, uc *usecase.PersonalAccessTokenUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-personal-access-token",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/personal-access-tokens",
+		Summary:       "Create a personal access token",
+		Tags:          []string{"PersonalAccessTokens"},
+		Security:      bearerAuth(),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *createPersonalAccessTokenInput) (*createPersonalAccessTokenOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		token, plaintext, err := uc.CreateToken(ctx, input.UserID, input.Body.Name, input.Body.Scopes, input.Body.ExpiresAt)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &createPersonalAccessTokenOutput{Body: dto.CreatedPersonalAccessTokenResponse{
+			PersonalAccessTokenResponse: dto.ToPersonalAccessTokenResponse(token),
+			Token:                       plaintext,
+		}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-personal-access-tokens",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/personal-access-tokens",
+		Summary:     "List personal access tokens for a user",
+		Tags:        []string{"PersonalAccessTokens"},
+		Security:    bearerAuth(),
+	}, func(ctx context.Context, input *listPersonalAccessTokensInput) (*listPersonalAccessTokensOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		tokens, err := uc.ListTokens(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &listPersonalAccessTokensOutput{Body: dto.ToPersonalAccessTokenResponseList(tokens)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-personal-access-token",
+		Method:        http.MethodDelete,
+		Path:          "/personal-access-tokens/{id}",
+		Summary:       "Revoke a personal access token",
+		Tags:          []string{"PersonalAccessTokens"},
+		Security:      bearerAuth(),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *revokePersonalAccessTokenInput) (*struct{}, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := uc.RevokeToken(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type startPomodoroInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.StartPomodoroRequest
+}
+
+type pomodoroInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type pomodoroOutput struct {
+	Body dto.PomodoroResponse
+}
+
+// RegisterPomodoroRoutes registers pomodoro session routes to the Huma API.
+func RegisterPomodoroRoutes(api huma.API, uc *usecase.PomodoroUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "start-pomodoro",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/pomodoro/start",
+		Summary:       "Start a pomodoro session",
+		Description:   "Alternates work phases and breaks. Each completed work phase is recorded as a study log counting one pomodoro.",
+		Tags:          []string{"Pomodoro"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *startPomodoroInput) (*pomodoroOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		settings := domain.PomodoroSettings{
+			WorkMinutes:  input.Body.WorkMinutes,
+			BreakMinutes: input.Body.BreakMinutes,
+			Cycles:       input.Body.Cycles,
+		}
+		session, err := uc.StartPomodoro(ctx, input.UserID, input.Body.ProjectID, input.Body.Note, settings)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &pomodoroOutput{Body: dto.ToPomodoroResponse(session)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-pomodoro",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/pomodoro",
+		Summary:     "Get the pomodoro session",
+		Description: "Returns the session in its current phase. A session whose last work phase has ended is returned once as finished.",
+		Tags:        []string{"Pomodoro"},
+		Security:    bearerAuth(domain.ScopeStudyLogsRead),
+	}, func(ctx context.Context, input *pomodoroInput) (*pomodoroOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		session, err := uc.GetPomodoro(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &pomodoroOutput{Body: dto.ToPomodoroResponse(session)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "skip-pomodoro-break",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/pomodoro/skip-break",
+		Summary:     "Skip the current break",
+		Tags:        []string{"Pomodoro"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *pomodoroInput) (*pomodoroOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		session, err := uc.SkipBreak(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &pomodoroOutput{Body: dto.ToPomodoroResponse(session)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "stop-pomodoro",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/pomodoro/stop",
+		Summary:     "Stop the pomodoro session",
+		Description: "Finishes the session. A work phase in progress is recorded as a study log without counting as a pomodoro.",
+		Tags:        []string{"Pomodoro"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *pomodoroInput) (*pomodoroOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		session, err := uc.StopPomodoro(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &pomodoroOutput{Body: dto.ToPomodoroResponse(session)}, nil
+	})
+}
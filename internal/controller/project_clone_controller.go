@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type cloneProjectInput struct {
+	ID   string `path:"id" doc:"Project ID"`
+	Body dto.CloneProjectRequest
+}
+
+type projectCloneOutput struct {
+	Body dto.ProjectCloneResponse
+}
+
+// RegisterProjectCloneRoutes registers routes for cloning projects to the Huma API.
+func RegisterProjectCloneRoutes(api huma.API, uc *usecase.ProjectCloneUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "clone-project",
+		Method:        http.MethodPost,
+		Path:          "/projects/{id}/clone",
+		Summary:       "Clone a project",
+		Description:   "Creates a copy of a project to use as a template, optionally with its notes, goal and milestones. Study logs are never copied. Dates are moved so that the goal, or the project, starts today. Only the owner can clone a project for one of its members.",
+		Tags:          []string{"Projects"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *cloneProjectInput) (*projectCloneOutput, error) {
+		clone, err := uc.CloneProject(ctx, authUserID(ctx), input.ID, usecase.ProjectCloneOptions{
+			TargetUserID: input.Body.TargetUserID,
+			Name:         input.Body.Name,
+			Notes:        input.Body.Notes,
+			Goal:         input.Body.Goal,
+			Milestones:   input.Body.Milestones,
+		})
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectCloneOutput{Body: dto.ToProjectCloneResponse(clone)}, nil
+	})
+}
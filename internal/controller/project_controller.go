@@ -3,10 +3,12 @@ package controller
 import (
 	"context"
 	"net/http"
+	"net/url"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
@@ -20,11 +22,18 @@ type createProjectOutput struct {
 }
 
 type listProjectsInput struct {
-	UserID string `path:"userId" doc:"User ID"`
+	UserID   string `path:"userId" doc:"User ID"`
+	Archived string `query:"archived" enum:"false,true,all" default:"false" doc:"Whether to list unarchived projects, archived projects or all projects"`
+	Status   string `query:"status" enum:"planned,active,paused,done" doc:"Only list projects with this lifecycle status"`
+	Sort     string `query:"sort" enum:"name,-name,createdAt,-createdAt" doc:"Sort order, prefixed with - for descending order (defaults to name)"`
+	Limit    int    `query:"limit" minimum:"1" maximum:"200" default:"50" doc:"Maximum number of items to return"`
+	Cursor   string `query:"cursor" doc:"Cursor of the next page, from the X-Next-Cursor header of the previous response"`
 }
 
 type listProjectsOutput struct {
-	Body []dto.ProjectResponse
+	Link       string `header:"Link" doc:"Link to the next page with rel=\"next\"; absent on the last page"`
+	NextCursor string `header:"X-Next-Cursor" doc:"Cursor of the next page; absent on the last page"`
+	Body       []dto.ProjectResponse
 }
 
 type updateProjectInput struct {
@@ -36,10 +45,54 @@ type updateProjectOutput struct {
 	Body dto.ProjectResponse
 }
 
+type previewProjectDeletionInput struct {
+	ID string `path:"id" doc:"Project ID"`
+}
+
+type previewProjectDeletionOutput struct {
+	Body dto.ProjectDeletionPreviewResponse
+}
+
 type deleteProjectInput struct {
+	ID                string `path:"id" doc:"Project ID"`
+	ConfirmationToken string `query:"confirmationToken" doc:"Confirmation token from the deletion preview"`
+	Force             bool   `query:"force" doc:"Delete without a confirmation token"`
+}
+
+type restoreProjectInput struct {
 	ID string `path:"id" doc:"Project ID"`
 }
 
+type restoreProjectOutput struct {
+	Body dto.ProjectResponse
+}
+
+type moveProjectInput struct {
+	ID   string `path:"id" doc:"Project ID"`
+	Body dto.MoveProjectRequest
+}
+
+type moveProjectOutput struct {
+	Body dto.ProjectResponse
+}
+
+type mergeProjectInput struct {
+	ID   string `path:"id" doc:"Project ID"`
+	Body dto.MergeProjectRequest
+}
+
+type mergeProjectOutput struct {
+	Body dto.ProjectMergeResponse
+}
+
+type archiveProjectInput struct {
+	ID string `path:"id" doc:"Project ID"`
+}
+
+type archiveProjectOutput struct {
+	Body dto.ProjectResponse
+}
+
 // RegisterProjectRoutes registers project-related routes to the Huma API.
 func RegisterProjectRoutes(api huma.API, uc *usecase.ProjectUsecase) {
 	huma.Register(api, huma.Operation{
@@ -48,9 +101,17 @@ func RegisterProjectRoutes(api huma.API, uc *usecase.ProjectUsecase) {
 		Path:          "/users/{userId}/projects",
 		Summary:       "Create a new project",
 		Tags:          []string{"Projects"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
 		DefaultStatus: http.StatusCreated,
 	}, func(ctx context.Context, input *createProjectInput) (*createProjectOutput, error) {
-		project, err := uc.CreateProject(ctx, input.UserID, input.Body.Name)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		details, err := input.Body.ToDomain()
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		project, err := uc.CreateProject(ctx, input.UserID, input.Body.Name, input.Body.ParentID, details)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -63,12 +124,26 @@ func RegisterProjectRoutes(api huma.API, uc *usecase.ProjectUsecase) {
 		Path:        "/users/{userId}/projects",
 		Summary:     "List projects for a user",
 		Tags:        []string{"Projects"},
+		Security:    bearerAuth(domain.ScopeProjectsRead),
 	}, func(ctx context.Context, input *listProjectsInput) (*listProjectsOutput, error) {
-		projects, err := uc.ListProjects(ctx, input.UserID)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		filter := usecase.ProjectListFilter{Status: input.Status}
+		if input.Archived != "all" {
+			archived := input.Archived == "true"
+			filter.Archived = &archived
+		}
+		projects, next, err := uc.ListProjects(ctx, input.UserID, filter, usecase.PageOptions{Limit: input.Limit, Cursor: input.Cursor, Sort: input.Sort})
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
-		return &listProjectsOutput{Body: dto.ToProjectResponseList(projects)}, nil
+		filters := url.Values{"archived": {input.Archived}, "status": {input.Status}}
+		return &listProjectsOutput{
+			Link:       nextPageLink("/users/"+input.UserID+"/projects", filters, input.Limit, input.Sort, next),
+			NextCursor: next,
+			Body:       dto.ToProjectResponseList(projects),
+		}, nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -77,8 +152,13 @@ func RegisterProjectRoutes(api huma.API, uc *usecase.ProjectUsecase) {
 		Path:        "/projects/{id}",
 		Summary:     "Update a project",
 		Tags:        []string{"Projects"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
 	}, func(ctx context.Context, input *updateProjectInput) (*updateProjectOutput, error) {
-		project, err := uc.UpdateProject(ctx, input.ID, input.Body.Name)
+		details, err := input.Body.ToDomain()
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		project, err := uc.UpdateProject(ctx, authUserID(ctx), input.ID, input.Body.Name, details)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -86,16 +166,120 @@ func RegisterProjectRoutes(api huma.API, uc *usecase.ProjectUsecase) {
 	})
 
 	huma.Register(api, huma.Operation{
-		OperationID:   "delete-project",
-		Method:        http.MethodDelete,
-		Path:          "/projects/{id}",
-		Summary:       "Delete a project",
+		OperationID: "move-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/move",
+		Summary:     "Move a project in the project hierarchy",
+		Description: "The project's sub-projects move with it. Fails with 400 if the project would be placed under " +
+			"one of its own sub-projects or the hierarchy would get deeper than 4 levels.",
+		Tags:     []string{"Projects"},
+		Security: bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *moveProjectInput) (*moveProjectOutput, error) {
+		project, err := uc.MoveProject(ctx, authUserID(ctx), input.ID, input.Body.ParentID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &moveProjectOutput{Body: dto.ToProjectResponse(project)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "archive-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/archive",
+		Summary:     "Archive a project",
+		Description: "An archived project is hidden from the project list and from weekly stats except in weeks it was studied in. " +
+			"Its study logs are kept, but no new study time can be recorded in it. Fails with 409 if the project is already archived.",
+		Tags:     []string{"Projects"},
+		Security: bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *archiveProjectInput) (*archiveProjectOutput, error) {
+		project, err := uc.ArchiveProject(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &archiveProjectOutput{Body: dto.ToProjectResponse(project)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "unarchive-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/unarchive",
+		Summary:     "Unarchive a project",
+		Description: "Fails with 409 if the project is not archived.",
+		Tags:        []string{"Projects"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *archiveProjectInput) (*archiveProjectOutput, error) {
+		project, err := uc.UnarchiveProject(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &archiveProjectOutput{Body: dto.ToProjectResponse(project)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-project-deletion",
+		Method:      http.MethodGet,
+		Path:        "/projects/{id}/deletion-preview",
+		Summary:     "Preview what deleting a project would move to the trash",
+		Description: "Reports the study logs, notes and goal that deleting the project would move to the trash with it, and the token that confirms the deletion.",
+		Tags:        []string{"Projects"},
+		Security:    bearerAuth(domain.ScopeProjectsRead),
+	}, func(ctx context.Context, input *previewProjectDeletionInput) (*previewProjectDeletionOutput, error) {
+		impact, err := uc.PreviewProjectDeletion(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &previewProjectDeletionOutput{Body: dto.ToProjectDeletionPreviewResponse(input.ID, impact)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-project",
+		Method:      http.MethodDelete,
+		Path:        "/projects/{id}",
+		Summary:     "Move a project to the trash",
+		Description: "The project's notes, study logs and goal are moved to the trash with it. Fails with 409 if the project has sub-projects. " +
+			"The deletion must be confirmed with the confirmationToken from the deletion preview (400 without it, 409 if the project's contents have changed since the preview), or forced with force=true.",
 		Tags:          []string{"Projects"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
 		DefaultStatus: http.StatusNoContent,
 	}, func(ctx context.Context, input *deleteProjectInput) (*struct{}, error) {
-		if err := uc.DeleteProject(ctx, input.ID); err != nil {
+		if err := uc.DeleteProject(ctx, authUserID(ctx), input.ID, input.ConfirmationToken, input.Force); err != nil {
 			return nil, toHTTPError(err)
 		}
 		return nil, nil
 	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/restore",
+		Summary:     "Restore a project from the trash",
+		Description: "The notes, study logs and goal that were trashed with the project are restored with it. " +
+			"Fails with 409 if the user has since created another project with the same name.",
+		Tags:     []string{"Projects"},
+		Security: bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *restoreProjectInput) (*restoreProjectOutput, error) {
+		project, err := uc.RestoreProject(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &restoreProjectOutput{Body: dto.ToProjectResponse(project)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "merge-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/merge",
+		Summary:     "Merge a project into another project",
+		Description: "Moves the project's study logs, notes, milestones and running timers into the target project and deletes the project, " +
+			"in a single transaction. If both projects have a goal, goalStrategy chooses the goal to keep (400 without it). " +
+			"Fails with 409 if the project has sub-projects or the target project is archived.",
+		Tags:     []string{"Projects"},
+		Security: bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *mergeProjectInput) (*mergeProjectOutput, error) {
+		summary, err := uc.MergeProject(ctx, authUserID(ctx), input.ID, input.Body.TargetProjectID, input.Body.GoalStrategy)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &mergeProjectOutput{Body: dto.ToProjectMergeResponse(input.ID, summary)}, nil
+	})
 }
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type inviteProjectMemberInput struct {
+	ID   string `path:"id" doc:"Project ID"`
+	Body dto.InviteProjectMemberRequest
+}
+
+type projectMemberOutput struct {
+	Body dto.ProjectMemberResponse
+}
+
+type projectMemberListOutput struct {
+	Body []dto.ProjectMemberResponse
+}
+
+type projectMembersInput struct {
+	ID string `path:"id" doc:"Project ID"`
+}
+
+type updateProjectMemberInput struct {
+	ID     string `path:"id" doc:"Project ID"`
+	UserID string `path:"userId" doc:"Member user ID"`
+	Body   dto.UpdateProjectMemberRequest
+}
+
+type removeProjectMemberInput struct {
+	ID     string `path:"id" doc:"Project ID"`
+	UserID string `path:"userId" doc:"Member user ID"`
+}
+
+type listProjectMembershipsInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type getProjectMemberStatsInput struct {
+	ID   string `path:"id" doc:"Project ID"`
+	From string `query:"from" doc:"Start date (YYYY-MM-DD)" example:"2024-01-01"`
+	To   string `query:"to" doc:"End date (YYYY-MM-DD)" example:"2024-01-31"`
+	TZ   string `query:"tz" doc:"IANA timezone for day boundaries (defaults to the user's timezone)" example:"Asia/Tokyo"`
+}
+
+type projectMemberStatsOutput struct {
+	Body dto.ProjectMemberStatsResponse
+}
+
+// RegisterProjectMemberRoutes registers routes for sharing projects with study groups to the Huma API.
+func RegisterProjectMemberRoutes(api huma.API, uc *usecase.ProjectMemberUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "invite-project-member",
+		Method:        http.MethodPost,
+		Path:          "/projects/{id}/members",
+		Summary:       "Invite a user to a project",
+		Description:   "Only the owner can invite members. The invitation is pending until the invited user accepts it. The response is the same whether or not the email address has an account, or has already been invited.",
+		Tags:          []string{"ProjectMembers"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *inviteProjectMemberInput) (*struct{}, error) {
+		if err := uc.InviteMember(ctx, authUserID(ctx), input.ID, input.Body.Email, input.Body.Role); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-project-members",
+		Method:      http.MethodGet,
+		Path:        "/projects/{id}/members",
+		Summary:     "List the members of a project",
+		Description: "Returns the owner followed by the members and pending invitations, in the order they were invited.",
+		Tags:        []string{"ProjectMembers"},
+		Security:    bearerAuth(domain.ScopeProjectsRead),
+	}, func(ctx context.Context, input *projectMembersInput) (*projectMemberListOutput, error) {
+		members, err := uc.ListMembers(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectMemberListOutput{Body: dto.ToProjectMemberResponseList(members)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-project-member",
+		Method:      http.MethodPut,
+		Path:        "/projects/{id}/members/{userId}",
+		Summary:     "Change the role of a project member",
+		Tags:        []string{"ProjectMembers"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *updateProjectMemberInput) (*projectMemberOutput, error) {
+		member, err := uc.UpdateMemberRole(ctx, authUserID(ctx), input.ID, input.UserID, input.Body.Role)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectMemberOutput{Body: dto.ToProjectMemberResponse(member)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-project-member",
+		Method:        http.MethodDelete,
+		Path:          "/projects/{id}/members/{userId}",
+		Summary:       "Remove a member from a project",
+		Description:   "Removes a member or withdraws an invitation. The study logs and notes the member wrote in the project are kept.",
+		Tags:          []string{"ProjectMembers"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *removeProjectMemberInput) (*struct{}, error) {
+		if err := uc.RemoveMember(ctx, authUserID(ctx), input.ID, input.UserID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-project-invitation",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/accept",
+		Summary:     "Accept an invitation to a project",
+		Tags:        []string{"ProjectMembers"},
+		Security:    bearerAuth(domain.ScopeProjectsWrite),
+	}, func(ctx context.Context, input *projectMembersInput) (*projectMemberOutput, error) {
+		member, err := uc.AcceptInvitation(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectMemberOutput{Body: dto.ToProjectMemberResponse(member)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "leave-project",
+		Method:        http.MethodPost,
+		Path:          "/projects/{id}/leave",
+		Summary:       "Leave a project",
+		Description:   "Declines an invitation to the project, or leaves a project the user has joined. The study logs and notes the user wrote in the project are kept. The owner cannot leave.",
+		Tags:          []string{"ProjectMembers"},
+		Security:      bearerAuth(domain.ScopeProjectsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *projectMembersInput) (*struct{}, error) {
+		if err := uc.LeaveProject(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-project-memberships",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/project-memberships",
+		Summary:     "List the projects shared with a user",
+		Description: "Returns the projects of other users the user has joined or been invited to, with the user's role and whether the invitation has been accepted.",
+		Tags:        []string{"ProjectMembers"},
+		Security:    bearerAuth(domain.ScopeProjectsRead),
+	}, func(ctx context.Context, input *listProjectMembershipsInput) (*projectMemberListOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		memberships, err := uc.ListMemberships(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectMemberListOutput{Body: dto.ToProjectMemberResponseList(memberships)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-project-member-stats",
+		Method:      http.MethodGet,
+		Path:        "/projects/{id}/member-stats",
+		Summary:     "Get study time by member",
+		Description: "Breaks the time studied in the project down by member. The dates are inclusive and optional; without them all study logs count.",
+		Tags:        []string{"ProjectMembers"},
+		Security:    bearerAuth(domain.ScopeStatsRead),
+	}, func(ctx context.Context, input *getProjectMemberStatsInput) (*projectMemberStatsOutput, error) {
+		var from, to *domain.Date
+		if input.From != "" {
+			d, err := domain.ParseDate(input.From)
+			if err != nil {
+				return nil, huma.Error400BadRequest("invalid 'from' date format, expected YYYY-MM-DD")
+			}
+			from = &d
+		}
+		if input.To != "" {
+			d, err := domain.ParseDate(input.To)
+			if err != nil {
+				return nil, huma.Error400BadRequest("invalid 'to' date format, expected YYYY-MM-DD")
+			}
+			to = &d
+		}
+		stats, err := uc.GetMemberStats(ctx, authUserID(ctx), input.ID, from, to, input.TZ)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &projectMemberStatsOutput{Body: dto.ToProjectMemberStatsResponse(stats)}, nil
+	})
+}
@@ -15,14 +15,30 @@ import (
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
+// apiBasePath is the path the versioned API is mounted on.
+const apiBasePath = "/v1"
+
 // Usecases holds all application usecases.
 type Usecases struct {
-	User     *usecase.UserUsecase
-	Project  *usecase.ProjectUsecase
-	StudyLog *usecase.StudyLogUsecase
-	Goal     *usecase.GoalUsecase
-	Stats    *usecase.StatsUsecase
-	Note     *usecase.NoteUsecase
+	Auth                *usecase.AuthUsecase
+	User                *usecase.UserUsecase
+	Project             *usecase.ProjectUsecase
+	StudyLog            *usecase.StudyLogUsecase
+	StudyLogImport      *usecase.StudyLogImportUsecase
+	ActivityType        *usecase.ActivityTypeUsecase
+	Milestone           *usecase.MilestoneUsecase
+	ProjectMember       *usecase.ProjectMemberUsecase
+	ProjectClone        *usecase.ProjectCloneUsecase
+	Timer               *usecase.TimerUsecase
+	Pomodoro            *usecase.PomodoroUsecase
+	Goal                *usecase.GoalUsecase
+	Stats               *usecase.StatsUsecase
+	Note                *usecase.NoteUsecase
+	PersonalAccessToken *usecase.PersonalAccessTokenUsecase
+	Session             *usecase.SessionUsecase
+	TwoFactor           *usecase.TwoFactorUsecase
+	Takeout             *usecase.TakeoutUsecase
+	Trash               *usecase.TrashUsecase
 }
 
 // NewRouter creates and configures the main HTTP router.
@@ -37,30 +53,53 @@ func NewRouter(usecases *Usecases, corsOrigins []string, logger *slog.Logger) ht
 
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   corsOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "X-Next-Cursor"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	}))
 
 	// Versioned API sub-router
 	v1 := chi.NewRouter()
-	router.Mount("/v1", v1)
+	router.Mount(apiBasePath, v1)
 
 	// Huma API on /v1
 	config := huma.DefaultConfig("StudyTrack API", "1.0.0")
 	config.Info.Description = "Learning progress tracking REST API"
-	config.Servers = []*huma.Server{{URL: "/v1"}}
+	config.Servers = []*huma.Server{{URL: apiBasePath}}
+	config.Components.SecuritySchemes = map[string]*huma.SecurityScheme{
+		"bearer": {
+			Type:        "http",
+			Scheme:      "bearer",
+			Description: "Access token from POST /auth/login, or a personal access token (stpat_...) limited to its scopes",
+		},
+	}
 	api := humachi.New(v1, config)
 
+	// Authentication must be registered before the operations it applies to
+	api.UseMiddleware(authMiddleware(api, usecases.Auth))
+
 	// Register routes (paths are relative to /v1)
+	RegisterAuthRoutes(api, usecases.Auth)
 	RegisterUserRoutes(api, usecases.User)
 	RegisterProjectRoutes(api, usecases.Project)
+	RegisterMilestoneRoutes(api, usecases.Milestone)
+	RegisterProjectMemberRoutes(api, usecases.ProjectMember)
+	RegisterProjectCloneRoutes(api, usecases.ProjectClone)
 	RegisterStudyLogRoutes(api, usecases.StudyLog)
+	RegisterStudyLogImportRoutes(api, usecases.StudyLogImport)
+	RegisterActivityTypeRoutes(api, usecases.ActivityType)
+	RegisterTimerRoutes(api, usecases.Timer)
+	RegisterPomodoroRoutes(api, usecases.Pomodoro)
 	RegisterGoalRoutes(api, usecases.Goal)
 	RegisterStatsRoutes(api, usecases.Stats)
 	RegisterNoteRoutes(api, usecases.Note)
+	RegisterPersonalAccessTokenRoutes(api, usecases.PersonalAccessToken)
+	RegisterSessionRoutes(api, usecases.Session)
+	RegisterTwoFactorRoutes(api, usecases.TwoFactor)
+	RegisterTakeoutRoutes(api, usecases.Takeout)
+	RegisterTrashRoutes(api, usecases.Trash)
 
 	return router
 }
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type listSessionsInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type listSessionsOutput struct {
+	Body []dto.SessionResponse
+}
+
+type revokeSessionInput struct {
+	ID string `path:"id" doc:"Session ID"`
+}
+
+type revokeAllSessionsInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+// RegisterSessionRoutes registers session-related routes to the Huma API.
+// Sessions can only be managed after logging in with a password.
+func RegisterSessionRoutes(api huma.API, uc *usecase.SessionUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-sessions",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/sessions",
+		Summary:     "List active sessions for a user",
+		Tags:        []string{"Sessions"},
+		Security:    bearerAuth(),
+	}, func(ctx context.Context, input *listSessionsInput) (*listSessionsOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		sessions, err := uc.ListSessions(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &listSessionsOutput{Body: dto.ToSessionResponseList(sessions, authPrincipal(ctx).SessionID)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-session",
+		Method:        http.MethodDelete,
+		Path:          "/sessions/{id}",
+		Summary:       "Revoke a session",
+		Tags:          []string{"Sessions"},
+		Security:      bearerAuth(),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *revokeSessionInput) (*struct{}, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := uc.RevokeSession(ctx, authUserID(ctx), input.ID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-all-sessions",
+		Method:        http.MethodDelete,
+		Path:          "/users/{userId}/sessions",
+		Summary:       "Revoke all sessions for a user",
+		Description:   "Signs the user out on every device, including the one making the request.",
+		Tags:          []string{"Sessions"},
+		Security:      bearerAuth(),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *revokeAllSessionsInput) (*struct{}, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		if err := uc.RevokeAllSessions(ctx, input.UserID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
@@ -3,17 +3,18 @@ package controller
 import (
 	"context"
 	"net/http"
-	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
 type getWeeklyStatsInput struct {
 	UserID    string `path:"userId" doc:"User ID"`
 	WeekStart string `query:"weekStart" required:"true" doc:"Week start date (YYYY-MM-DD)" example:"2024-01-01"`
+	TZ        string `query:"tz" doc:"IANA timezone for day boundaries (defaults to the user's timezone)" example:"Asia/Tokyo"`
 }
 
 type getWeeklyStatsOutput struct {
@@ -28,13 +29,17 @@ func RegisterStatsRoutes(api huma.API, uc *usecase.StatsUsecase) {
 		Path:        "/users/{userId}/stats/weekly",
 		Summary:     "Get weekly study statistics",
 		Tags:        []string{"Stats"},
+		Security:    bearerAuth(domain.ScopeStatsRead),
 	}, func(ctx context.Context, input *getWeeklyStatsInput) (*getWeeklyStatsOutput, error) {
-		weekStart, err := time.Parse("2006-01-02", input.WeekStart)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		weekStart, err := domain.ParseDate(input.WeekStart)
 		if err != nil {
 			return nil, huma.Error400BadRequest("invalid weekStart format, expected YYYY-MM-DD")
 		}
 
-		stats, err := uc.GetWeeklyStats(ctx, input.UserID, weekStart)
+		stats, err := uc.GetWeeklyStats(ctx, input.UserID, weekStart, input.TZ)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -3,13 +3,13 @@ package controller
 import (
 	"context"
 	"net/http"
-	"time"
+	"net/url"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
-	"github.com/shnaki/studytrack-api/internal/usecase/port"
 )
 
 type createStudyLogInput struct {
@@ -21,91 +21,294 @@ type createStudyLogOutput struct {
 	Body dto.StudyLogResponse
 }
 
+type createStudyLogBatchInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.CreateStudyLogBatchRequest
+}
+
+type createStudyLogBatchOutput struct {
+	Status int
+	Body   dto.StudyLogBatchResponse
+}
+
 type listStudyLogsInput struct {
-	UserID    string `path:"userId" doc:"User ID"`
-	From      string `query:"from" doc:"Start date (YYYY-MM-DD)" example:"2024-01-01"`
-	To        string `query:"to" doc:"End date (YYYY-MM-DD)" example:"2024-01-31"`
-	ProjectID string `query:"projectId" doc:"Filter by project ID"`
+	UserID         string `path:"userId" doc:"User ID"`
+	From           string `query:"from" doc:"Start date (YYYY-MM-DD)" example:"2024-01-01"`
+	To             string `query:"to" doc:"End date (YYYY-MM-DD)" example:"2024-01-31"`
+	ProjectID      string `query:"projectId" doc:"Filter by project ID"`
+	ActivityTypeID string `query:"activityTypeId" doc:"Filter by activity type ID"`
+	TZ             string `query:"tz" doc:"IANA timezone for from/to (defaults to the user's timezone)" example:"Asia/Tokyo"`
+	Sort           string `query:"sort" enum:"studiedAt,-studiedAt" doc:"Sort order, prefixed with - for descending order (defaults to -studiedAt)"`
+	Limit          int    `query:"limit" minimum:"1" maximum:"200" default:"50" doc:"Maximum number of items to return"`
+	Cursor         string `query:"cursor" doc:"Cursor of the next page, from the X-Next-Cursor header of the previous response"`
 }
 
 type listStudyLogsOutput struct {
-	Body []dto.StudyLogResponse
+	Link       string `header:"Link" doc:"Link to the next page with rel=\"next\"; absent on the last page"`
+	NextCursor string `header:"X-Next-Cursor" doc:"Cursor of the next page; absent on the last page"`
+	Body       []dto.StudyLogResponse
+}
+
+type updateStudyLogInput struct {
+	ID   string `path:"id" doc:"Study log ID"`
+	Body dto.UpdateStudyLogRequest
+}
+
+type patchStudyLogInput struct {
+	ID   string `path:"id" doc:"Study log ID"`
+	Body dto.PatchStudyLogRequest
+}
+
+type studyLogOutput struct {
+	Body dto.StudyLogResponse
+}
+
+type listStudyLogRevisionsInput struct {
+	ID string `path:"id" doc:"Study log ID"`
+}
+
+type listStudyLogRevisionsOutput struct {
+	Body []dto.StudyLogRevisionResponse
+}
+
+type revertStudyLogInput struct {
+	ID         string `path:"id" doc:"Study log ID"`
+	RevisionID string `path:"revisionId" doc:"Revision ID"`
 }
 
 type deleteStudyLogInput struct {
 	ID string `path:"id" doc:"Study log ID"`
 }
 
+type restoreStudyLogInput struct {
+	ID string `path:"id" doc:"Study log ID"`
+}
+
 // RegisterStudyLogRoutes registers study log-related routes to the Huma API.
 func RegisterStudyLogRoutes(api huma.API, uc *usecase.StudyLogUsecase) {
 	huma.Register(api, huma.Operation{
-		OperationID:   "create-study-log",
-		Method:        http.MethodPost,
-		Path:          "/users/{userId}/study-logs",
-		Summary:       "Create a study log",
+		OperationID: "create-study-log",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/study-logs",
+		Summary:     "Create a study log",
+		Description: "The session is given by its start and either its minutes or its end time. " +
+			"It must not overlap the user's other study logs, and the logs starting on one day in the user's timezone may total at most 1440 minutes.",
 		Tags:          []string{"StudyLogs"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
 		DefaultStatus: http.StatusCreated,
 	}, func(ctx context.Context, input *createStudyLogInput) (*createStudyLogOutput, error) {
-		log, err := uc.CreateStudyLog(ctx, input.UserID, input.Body.ProjectID, input.Body.StudiedAt, input.Body.Minutes, input.Body.Note)
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		minutes, err := domain.StudyLogMinutes(input.Body.StudiedAt, input.Body.Minutes, input.Body.EndedAt)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		log, err := uc.CreateStudyLog(ctx, input.UserID, input.Body.ProjectID, input.Body.ActivityTypeID, input.Body.StudiedAt, minutes, input.Body.Note)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
 		return &createStudyLogOutput{Body: dto.ToStudyLogResponse(log)}, nil
 	})
 
+	huma.Register(api, huma.Operation{
+		OperationID: "create-study-log-batch",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/study-logs:batch",
+		Summary:     "Create several study logs",
+		Description: "In atomic mode the logs are created in one transaction, and any invalid log fails the whole request. " +
+			"In bestEffort mode the valid logs are created and the response reports each log's status; " +
+			"the response status is 207 when some logs were not created.",
+		Tags:          []string{"StudyLogs"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *createStudyLogBatchInput) (*createStudyLogBatchOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		inputs := make([]usecase.StudyLogInput, len(input.Body.Logs))
+		for i, l := range input.Body.Logs {
+			inputs[i] = usecase.StudyLogInput{
+				ProjectID:      l.ProjectID,
+				ActivityTypeID: l.ActivityTypeID,
+				StudiedAt:      l.StudiedAt,
+				Minutes:        l.Minutes,
+				EndedAt:        l.EndedAt,
+				Note:           l.Note,
+			}
+		}
+		results, err := uc.CreateStudyLogs(ctx, input.UserID, inputs, input.Body.Mode != "bestEffort")
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		resp := dto.ToStudyLogBatchResponse(results, httpStatus)
+		status := http.StatusCreated
+		if resp.Failed > 0 {
+			status = http.StatusMultiStatus
+		}
+		return &createStudyLogBatchOutput{Status: status, Body: resp}, nil
+	})
+
 	huma.Register(api, huma.Operation{
 		OperationID: "list-study-logs",
 		Method:      http.MethodGet,
 		Path:        "/users/{userId}/study-logs",
 		Summary:     "List study logs for a user",
 		Tags:        []string{"StudyLogs"},
+		Security:    bearerAuth(domain.ScopeStudyLogsRead),
 	}, func(ctx context.Context, input *listStudyLogsInput) (*listStudyLogsOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
 		filter, err := parseStudyLogFilter(input)
 		if err != nil {
 			return nil, err
 		}
-		logs, err := uc.ListStudyLogs(ctx, input.UserID, filter)
+		logs, next, err := uc.ListStudyLogs(ctx, input.UserID, filter, usecase.PageOptions{Limit: input.Limit, Cursor: input.Cursor, Sort: input.Sort})
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		filters := url.Values{"from": {input.From}, "to": {input.To}, "projectId": {input.ProjectID}, "activityTypeId": {input.ActivityTypeID}, "tz": {input.TZ}}
+		return &listStudyLogsOutput{
+			Link:       nextPageLink("/users/"+input.UserID+"/study-logs", filters, input.Limit, input.Sort, next),
+			NextCursor: next,
+			Body:       dto.ToStudyLogResponseList(logs),
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-study-log",
+		Method:      http.MethodPut,
+		Path:        "/study-logs/{id}",
+		Summary:     "Update a study log",
+		Description: "Replaces the study log's fields. The previous values are kept as a revision.",
+		Tags:        []string{"StudyLogs"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *updateStudyLogInput) (*studyLogOutput, error) {
+		log, err := uc.UpdateStudyLog(ctx, authUserID(ctx), input.ID, usecase.StudyLogChanges{
+			ProjectID:      &input.Body.ProjectID,
+			ActivityTypeID: &input.Body.ActivityTypeID,
+			StudiedAt:      &input.Body.StudiedAt,
+			Minutes:        &input.Body.Minutes,
+			EndedAt:        input.Body.EndedAt,
+			Note:           &input.Body.Note,
+		})
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &studyLogOutput{Body: dto.ToStudyLogResponse(log)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "patch-study-log",
+		Method:      http.MethodPatch,
+		Path:        "/study-logs/{id}",
+		Summary:     "Partially update a study log",
+		Description: "Changes only the fields present in the request. The previous values are kept as a revision.",
+		Tags:        []string{"StudyLogs"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *patchStudyLogInput) (*studyLogOutput, error) {
+		log, err := uc.UpdateStudyLog(ctx, authUserID(ctx), input.ID, usecase.StudyLogChanges{
+			ProjectID:      input.Body.ProjectID,
+			ActivityTypeID: input.Body.ActivityTypeID,
+			StudiedAt:      input.Body.StudiedAt,
+			Minutes:        input.Body.Minutes,
+			EndedAt:        input.Body.EndedAt,
+			Note:           input.Body.Note,
+		})
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
-		return &listStudyLogsOutput{Body: dto.ToStudyLogResponseList(logs)}, nil
+		return &studyLogOutput{Body: dto.ToStudyLogResponse(log)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-study-log-revisions",
+		Method:      http.MethodGet,
+		Path:        "/study-logs/{id}/revisions",
+		Summary:     "List the revisions of a study log",
+		Description: "Each revision holds the values the study log had before an edit, newest first.",
+		Tags:        []string{"StudyLogs"},
+		Security:    bearerAuth(domain.ScopeStudyLogsRead),
+	}, func(ctx context.Context, input *listStudyLogRevisionsInput) (*listStudyLogRevisionsOutput, error) {
+		revisions, err := uc.ListStudyLogRevisions(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &listStudyLogRevisionsOutput{Body: dto.ToStudyLogRevisionResponseList(revisions)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revert-study-log",
+		Method:      http.MethodPost,
+		Path:        "/study-logs/{id}/revisions/{revisionId}/revert",
+		Summary:     "Revert a study log to a revision",
+		Description: "Restores the values the study log had before the revision's edit. The revert is recorded as a new revision.",
+		Tags:        []string{"StudyLogs"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *revertStudyLogInput) (*studyLogOutput, error) {
+		log, err := uc.RevertStudyLog(ctx, authUserID(ctx), input.ID, input.RevisionID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &studyLogOutput{Body: dto.ToStudyLogResponse(log)}, nil
 	})
 
 	huma.Register(api, huma.Operation{
 		OperationID:   "delete-study-log",
 		Method:        http.MethodDelete,
 		Path:          "/study-logs/{id}",
-		Summary:       "Delete a study log",
+		Summary:       "Move a study log to the trash",
 		Tags:          []string{"StudyLogs"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
 		DefaultStatus: http.StatusNoContent,
 	}, func(ctx context.Context, input *deleteStudyLogInput) (*struct{}, error) {
-		if err := uc.DeleteStudyLog(ctx, input.ID); err != nil {
+		if err := uc.DeleteStudyLog(ctx, authUserID(ctx), input.ID); err != nil {
 			return nil, toHTTPError(err)
 		}
 		return nil, nil
 	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-study-log",
+		Method:      http.MethodPost,
+		Path:        "/study-logs/{id}/restore",
+		Summary:     "Restore a study log from the trash",
+		Description: "Like a new log, the restored log must not overlap the user's other study logs. " +
+			"A log that was trashed together with its project is restored with the project instead.",
+		Tags:     []string{"StudyLogs"},
+		Security: bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *restoreStudyLogInput) (*studyLogOutput, error) {
+		log, err := uc.RestoreStudyLog(ctx, authUserID(ctx), input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &studyLogOutput{Body: dto.ToStudyLogResponse(log)}, nil
+	})
 }
 
-func parseStudyLogFilter(input *listStudyLogsInput) (port.StudyLogFilter, error) {
-	var filter port.StudyLogFilter
+func parseStudyLogFilter(input *listStudyLogsInput) (usecase.StudyLogListFilter, error) {
+	filter := usecase.StudyLogListFilter{Timezone: input.TZ}
 
 	if input.From != "" {
-		t, err := time.Parse("2006-01-02", input.From)
+		d, err := domain.ParseDate(input.From)
 		if err != nil {
 			return filter, huma.Error400BadRequest("invalid 'from' date format, expected YYYY-MM-DD")
 		}
-		filter.From = &t
+		filter.From = &d
 	}
 	if input.To != "" {
-		t, err := time.Parse("2006-01-02", input.To)
+		d, err := domain.ParseDate(input.To)
 		if err != nil {
 			return filter, huma.Error400BadRequest("invalid 'to' date format, expected YYYY-MM-DD")
 		}
-		endOfDay := t.AddDate(0, 0, 1)
-		filter.To = &endOfDay
+		filter.To = &d
 	}
 	if input.ProjectID != "" {
 		filter.ProjectID = &input.ProjectID
 	}
+	if input.ActivityTypeID != "" {
+		filter.ActivityTypeID = &input.ActivityTypeID
+	}
 	return filter, nil
 }
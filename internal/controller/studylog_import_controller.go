@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+// maxStudyLogImportBytes is the largest CSV file accepted by the import endpoint.
+const maxStudyLogImportBytes = 10 << 20
+
+type importStudyLogsInput struct {
+	UserID           string `path:"userId" doc:"User ID"`
+	DateColumn       string `query:"dateColumn" default:"date" doc:"Column with the date, or date and time, of each session"`
+	TimeColumn       string `query:"timeColumn" doc:"Column with the time of day, when the date column holds only a date"`
+	DurationColumn   string `query:"durationColumn" default:"minutes" doc:"Column with the duration in minutes or as H:MM / HH:MM:SS"`
+	ProjectColumn    string `query:"projectColumn" default:"project" doc:"Column with the project name; unknown projects are created"`
+	NoteColumn       string `query:"noteColumn" doc:"Column with the note"`
+	ExternalIDColumn string `query:"externalIdColumn" doc:"Column with a stable ID from the source system; without it duplicates are detected by content"`
+	TZ               string `query:"tz" doc:"IANA timezone for times without a UTC offset (defaults to the user's timezone)" example:"Asia/Tokyo"`
+	DryRun           bool   `query:"dryRun" doc:"Validate and report without saving anything"`
+	RawBody          []byte `contentType:"text/csv"`
+}
+
+type importStudyLogsOutput struct {
+	Body dto.StudyLogImportResponse
+}
+
+// RegisterStudyLogImportRoutes registers study log import routes to the Huma API.
+func RegisterStudyLogImportRoutes(api huma.API, uc *usecase.StudyLogImportUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "import-study-logs",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/study-logs/import",
+		Summary:     "Import study logs from a CSV file",
+		Description: "The request body is a CSV file with a header row; the query parameters name the columns to read. " +
+			"Rows with errors are reported individually and do not stop the import. Rows that were already imported are skipped.",
+		Tags:         []string{"StudyLogs"},
+		Security:     bearerAuth(domain.ScopeStudyLogsWrite, domain.ScopeProjectsWrite),
+		MaxBodyBytes: maxStudyLogImportBytes,
+	}, func(ctx context.Context, input *importStudyLogsInput) (*importStudyLogsOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		result, err := uc.ImportCSV(ctx, input.UserID, bytes.NewReader(input.RawBody), usecase.StudyLogImportOptions{
+			Mapping: usecase.StudyLogImportMapping{
+				Date:       input.DateColumn,
+				Time:       input.TimeColumn,
+				Duration:   input.DurationColumn,
+				Project:    input.ProjectColumn,
+				Note:       input.NoteColumn,
+				ExternalID: input.ExternalIDColumn,
+			},
+			Timezone: input.TZ,
+			DryRun:   input.DryRun,
+		})
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &importStudyLogsOutput{Body: dto.ToStudyLogImportResponse(result)}, nil
+	})
+}
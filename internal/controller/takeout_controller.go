@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+// takeoutWriteTimeout bounds how long writing one chunk of a takeout archive may take. The server's
+// write timeout would cut off large archives, so the deadline is moved forward as the archive is written.
+const takeoutWriteTimeout = 30 * time.Second
+
+type exportUserDataInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+// RegisterTakeoutRoutes registers personal data export routes to the Huma API.
+func RegisterTakeoutRoutes(api huma.API, uc *usecase.TakeoutUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-user-data",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/export",
+		Summary:     "Export all of a user's data as a ZIP archive",
+		Description: "Streams a ZIP archive with the profile, projects, study logs, goals and notes as JSON, study logs as CSV and notes as Markdown files. " +
+			"manifest.json lists every file and the schema version of the archive.",
+		Tags: []string{"Users"},
+		Security: bearerAuth(
+			domain.ScopeUsersRead,
+			domain.ScopeProjectsRead,
+			domain.ScopeStudyLogsRead,
+			domain.ScopeGoalsRead,
+			domain.ScopeNotesRead,
+		),
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "ZIP archive",
+				Content: map[string]*huma.MediaType{
+					"application/zip": {Schema: &huma.Schema{Type: "string", Format: "binary"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *exportUserDataInput) (*huma.StreamResponse, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		takeout, err := uc.PrepareTakeout(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		filename := "studytrack-takeout-" + takeout.ExportedAt().UTC().Format("20060102-150405") + ".zip"
+		return &huma.StreamResponse{
+			Body: func(hctx huma.Context) {
+				hctx.SetHeader("Content-Type", "application/zip")
+				hctx.SetHeader("Content-Disposition", `attachment; filename="`+filename+`"`)
+				_, w := humachi.Unwrap(hctx)
+				zw := zip.NewWriter(&deadlineWriter{w: hctx.BodyWriter(), rc: http.NewResponseController(w)})
+				if err := takeout.WriteTo(hctx.Context(), zw); err != nil {
+					// The status has already been sent. Leaving out the central directory
+					// makes the truncated archive fail to open instead of looking complete.
+					return
+				}
+				_ = zw.Close()
+			},
+		}, nil
+	})
+}
+
+// deadlineWriter moves the write deadline of a response forward before every write, so a response
+// may take as long as it keeps making progress.
+type deadlineWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	// Writers that do not support deadlines, such as test recorders, have none to move.
+	_ = d.rc.SetWriteDeadline(time.Now().Add(takeoutWriteTimeout))
+	return d.w.Write(p)
+}
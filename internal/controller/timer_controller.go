@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type startTimerInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.StartTimerRequest
+}
+
+type timerInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type timerOutput struct {
+	Body dto.TimerResponse
+}
+
+type stopTimerInput struct {
+	UserID string                `path:"userId" doc:"User ID"`
+	Body   *dto.StopTimerRequest `required:"false"`
+}
+
+type stopTimerOutput struct {
+	Body dto.StudyLogResponse
+}
+
+// RegisterTimerRoutes registers live study timer routes to the Huma API.
+func RegisterTimerRoutes(api huma.API, uc *usecase.TimerUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "start-timer",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/timer/start",
+		Summary:       "Start a study timer",
+		Description:   "A user can have one timer at a time. Timers that run for 1440 minutes are stopped automatically.",
+		Tags:          []string{"Timer"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *startTimerInput) (*timerOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		timer, err := uc.StartTimer(ctx, input.UserID, input.Body.ProjectID, input.Body.Note)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &timerOutput{Body: dto.ToTimerResponse(timer, time.Now())}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-timer",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/timer",
+		Summary:     "Get the active study timer",
+		Tags:        []string{"Timer"},
+		Security:    bearerAuth(domain.ScopeStudyLogsRead),
+	}, func(ctx context.Context, input *timerInput) (*timerOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		timer, err := uc.GetTimer(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &timerOutput{Body: dto.ToTimerResponse(timer, time.Now())}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "pause-timer",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/timer/pause",
+		Summary:     "Pause the study timer",
+		Tags:        []string{"Timer"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *timerInput) (*timerOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		timer, err := uc.PauseTimer(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &timerOutput{Body: dto.ToTimerResponse(timer, time.Now())}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resume-timer",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/timer/resume",
+		Summary:     "Resume the study timer",
+		Tags:        []string{"Timer"},
+		Security:    bearerAuth(domain.ScopeStudyLogsWrite),
+	}, func(ctx context.Context, input *timerInput) (*timerOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		timer, err := uc.ResumeTimer(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &timerOutput{Body: dto.ToTimerResponse(timer, time.Now())}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "stop-timer",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/timer/stop",
+		Summary:       "Stop the study timer",
+		Description:   "Records the time the timer ran, excluding pauses and rounded to the nearest minute, as a study log.",
+		Tags:          []string{"Timer"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *stopTimerInput) (*stopTimerOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		var note *string
+		if input.Body != nil {
+			note = input.Body.Note
+		}
+		log, err := uc.StopTimer(ctx, input.UserID, note)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &stopTimerOutput{Body: dto.ToStudyLogResponse(log)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "discard-timer",
+		Method:        http.MethodDelete,
+		Path:          "/users/{userId}/timer",
+		Summary:       "Discard the study timer",
+		Description:   "Deletes the timer without recording a study log.",
+		Tags:          []string{"Timer"},
+		Security:      bearerAuth(domain.ScopeStudyLogsWrite),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *timerInput) (*struct{}, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		if err := uc.DiscardTimer(ctx, input.UserID); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type listTrashInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type listTrashOutput struct {
+	Body dto.TrashResponse
+}
+
+// RegisterTrashRoutes registers trash-related routes to the Huma API.
+// Items are restored through the restore routes of their kind.
+func RegisterTrashRoutes(api huma.API, uc *usecase.TrashUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-trash",
+		Method:      http.MethodGet,
+		Path:        "/users/{userId}/trash",
+		Summary:     "List a user's trashed projects, notes and study logs",
+		Description: "Notes and study logs that were trashed together with their project are not listed separately; " +
+			"they are restored with the project. Trashed items are deleted permanently after the retention period.",
+		Tags: []string{"Trash"},
+		Security: bearerAuth(
+			domain.ScopeProjectsRead,
+			domain.ScopeNotesRead,
+			domain.ScopeStudyLogsRead,
+		),
+	}, func(ctx context.Context, input *listTrashInput) (*listTrashOutput, error) {
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		trash, err := uc.ListTrash(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &listTrashOutput{Body: dto.ToTrashResponse(trash)}, nil
+	})
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type startTOTPInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+}
+
+type startTOTPOutput struct {
+	Body dto.TOTPEnrollmentResponse
+}
+
+type totpCodeInput struct {
+	UserID string `path:"userId" doc:"User ID"`
+	Body   dto.TOTPCodeRequest
+}
+
+type confirmTOTPOutput struct {
+	Body dto.RecoveryCodesResponse
+}
+
+// RegisterTwoFactorRoutes registers two-factor authentication routes to the Huma API.
+// Two-factor settings can only be changed after logging in with a password.
+func RegisterTwoFactorRoutes(api huma.API, uc *usecase.TwoFactorUsecase) {
+	huma.Register(api, huma.Operation{
+		OperationID: "start-totp-enrollment",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/two-factor/totp",
+		Summary:     "Start TOTP enrollment",
+		Description: "Generates a new secret. Two-factor authentication is not enabled until a code is confirmed.",
+		Tags:        []string{"Two-Factor"},
+		Security:    bearerAuth(),
+	}, func(ctx context.Context, input *startTOTPInput) (*startTOTPOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		enrollment, err := uc.StartTOTPEnrollment(ctx, input.UserID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &startTOTPOutput{Body: dto.ToTOTPEnrollmentResponse(enrollment)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "confirm-totp",
+		Method:      http.MethodPost,
+		Path:        "/users/{userId}/two-factor/totp/confirm",
+		Summary:     "Confirm TOTP enrollment",
+		Description: "Enables two-factor authentication once the first code is verified and returns the recovery codes.",
+		Tags:        []string{"Two-Factor"},
+		Security:    bearerAuth(),
+	}, func(ctx context.Context, input *totpCodeInput) (*confirmTOTPOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		codes, err := uc.ConfirmTOTP(ctx, input.UserID, input.Body.Code)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &confirmTOTPOutput{Body: dto.RecoveryCodesResponse{RecoveryCodes: codes}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "disable-totp",
+		Method:        http.MethodPost,
+		Path:          "/users/{userId}/two-factor/totp/disable",
+		Summary:       "Disable TOTP two-factor authentication",
+		Tags:          []string{"Two-Factor"},
+		Security:      bearerAuth(),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *totpCodeInput) (*struct{}, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+		if err := uc.DisableTOTP(ctx, input.UserID, input.Body.Code); err != nil {
+			return nil, toHTTPError(err)
+		}
+		return nil, nil
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/shnaki/studytrack-api/internal/controller/dto"
+	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
 )
 
@@ -26,6 +27,33 @@ type getUserOutput struct {
 	Body dto.UserResponse
 }
 
+type updateUserInput struct {
+	ID   string `path:"id" doc:"User ID"`
+	Body dto.UpdateUserRequest
+}
+
+type updateUserOutput struct {
+	Body dto.UserResponse
+}
+
+type previewUserDeletionInput struct {
+	ID string `path:"id" doc:"User ID"`
+}
+
+type previewUserDeletionOutput struct {
+	Body dto.UserDeletionPreviewResponse
+}
+
+type deleteUserInput struct {
+	ID                string `path:"id" doc:"User ID"`
+	ConfirmationToken string `query:"confirmationToken" doc:"Confirmation token from the deletion preview"`
+	Force             bool   `query:"force" doc:"Delete without a confirmation token"`
+}
+
+type deleteUserOutput struct {
+	Body dto.UserDeletionResponse
+}
+
 // RegisterUserRoutes registers user-related routes to the Huma API.
 func RegisterUserRoutes(api huma.API, uc *usecase.UserUsecase) {
 	huma.Register(api, huma.Operation{
@@ -36,7 +64,7 @@ func RegisterUserRoutes(api huma.API, uc *usecase.UserUsecase) {
 		Tags:          []string{"Users"},
 		DefaultStatus: http.StatusCreated,
 	}, func(ctx context.Context, input *createUserInput) (*createUserOutput, error) {
-		user, err := uc.CreateUser(ctx, input.Body.Name)
+		user, err := uc.CreateUser(ctx, input.Body.Name, input.Body.Email, input.Body.Password)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
@@ -49,11 +77,74 @@ func RegisterUserRoutes(api huma.API, uc *usecase.UserUsecase) {
 		Path:        "/users/{id}",
 		Summary:     "Get a user by ID",
 		Tags:        []string{"Users"},
+		Security:    bearerAuth(domain.ScopeUsersRead),
 	}, func(ctx context.Context, input *getUserInput) (*getUserOutput, error) {
+		if err := requireUser(ctx, input.ID); err != nil {
+			return nil, err
+		}
 		user, err := uc.GetUser(ctx, input.ID)
 		if err != nil {
 			return nil, toHTTPError(err)
 		}
 		return &getUserOutput{Body: dto.ToUserResponse(user)}, nil
 	})
+	huma.Register(api, huma.Operation{
+		OperationID: "update-user",
+		Method:      http.MethodPut,
+		Path:        "/users/{id}",
+		Summary:     "Update a user's name and preferences",
+		Tags:        []string{"Users"},
+		Security:    bearerAuth(domain.ScopeUsersWrite),
+	}, func(ctx context.Context, input *updateUserInput) (*updateUserOutput, error) {
+		if err := requireUser(ctx, input.ID); err != nil {
+			return nil, err
+		}
+		user, err := uc.UpdateUser(ctx, input.ID, input.Body.Name, input.Body.Preferences.ToDomain())
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &updateUserOutput{Body: dto.ToUserResponse(user)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-user-deletion",
+		Method:      http.MethodGet,
+		Path:        "/users/{id}/deletion-preview",
+		Summary:     "Preview what deleting a user would remove",
+		Description: "Reports the user's projects, study logs, goals and notes that deleting the user would remove, and the token that confirms the deletion.",
+		Tags:        []string{"Users"},
+		Security:    bearerAuth(domain.ScopeUsersRead),
+	}, func(ctx context.Context, input *previewUserDeletionInput) (*previewUserDeletionOutput, error) {
+		if err := requireUser(ctx, input.ID); err != nil {
+			return nil, err
+		}
+		impact, err := uc.PreviewUserDeletion(ctx, input.ID)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &previewUserDeletionOutput{Body: dto.ToUserDeletionPreviewResponse(input.ID, impact)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-user",
+		Method:      http.MethodDelete,
+		Path:        "/users/{id}",
+		Summary:     "Delete a user and all of their data",
+		Description: "Deletes the user's projects, study logs, goals and notes in a single transaction and returns how many of each were removed. Requires a password login. " +
+			"The deletion must be confirmed with the confirmationToken from the deletion preview (400 without it, 409 if the data has changed since the preview), or forced with force=true.",
+		Tags:     []string{"Users"},
+		Security: bearerAuth(),
+	}, func(ctx context.Context, input *deleteUserInput) (*deleteUserOutput, error) {
+		if err := requirePasswordLogin(ctx); err != nil {
+			return nil, err
+		}
+		if err := requireUser(ctx, input.ID); err != nil {
+			return nil, err
+		}
+		summary, err := uc.DeleteUser(ctx, input.ID, input.ConfirmationToken, input.Force)
+		if err != nil {
+			return nil, toHTTPError(err)
+		}
+		return &deleteUserOutput{Body: dto.ToUserDeletionResponse(input.ID, summary)}, nil
+	})
 }
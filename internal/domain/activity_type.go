@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// ActivityType is a kind of study, such as reading or exercises, from a user's own catalogue.
+// A study log may be tagged with one so study time can be split by activity.
+type ActivityType struct {
+	ID        string
+	UserID    string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewActivityType creates a new ActivityType entity.
+func NewActivityType(id, userID, name string) (*ActivityType, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if err := validateActivityTypeName(name); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &ActivityType{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ReconstructActivityType reconstructs an ActivityType entity from existing data.
+func ReconstructActivityType(id, userID, name string, createdAt, updatedAt time.Time) *ActivityType {
+	return &ActivityType{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// Rename updates the name of the activity type.
+func (a *ActivityType) Rename(name string) error {
+	if err := validateActivityTypeName(name); err != nil {
+		return err
+	}
+	a.Name = name
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func validateActivityTypeName(name string) error {
+	if name == "" {
+		return ErrValidation("activity type name is required")
+	}
+	if len(name) > 50 {
+		return ErrValidation("activity type name must be 50 characters or less")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestNewActivityType(t *testing.T) {
+	activityType, err := domain.NewActivityType("type-1", "user-1", "reading")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if activityType.Name != "reading" || activityType.UserID != "user-1" {
+		t.Errorf("unexpected activity type: %+v", activityType)
+	}
+	if activityType.CreatedAt.IsZero() || activityType.UpdatedAt.IsZero() {
+		t.Error("expected timestamps to be set")
+	}
+}
+
+func TestNewActivityType_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		input  string
+	}{
+		{"empty name", "user-1", ""},
+		{"name too long", "user-1", strings.Repeat("a", 51)},
+		{"no user", "", "reading"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := domain.NewActivityType("type-1", tt.userID, tt.input); !domain.IsValidation(err) {
+				t.Errorf("expected validation error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestActivityType_Rename(t *testing.T) {
+	activityType, _ := domain.NewActivityType("type-1", "user-1", "reading")
+	if err := activityType.Rename("textbook"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if activityType.Name != "textbook" {
+		t.Errorf("expected name 'textbook', got '%s'", activityType.Name)
+	}
+	if err := activityType.Rename(""); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+	if activityType.Name != "textbook" {
+		t.Errorf("expected name to remain 'textbook', got '%s'", activityType.Name)
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// AuthToken represents the credentials issued to a user after a successful login or refresh.
+type AuthToken struct {
+	UserID           string
+	SessionID        string
+	AccessToken      string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// Principal represents the authenticated caller of a request.
+// Callers that signed in with a password have every scope; callers using a
+// personal access token are limited to the scopes granted to that token.
+type Principal struct {
+	UserID string
+	// SessionID is empty unless the caller authenticated with an access token issued for a session.
+	SessionID string
+	// PersonalAccessTokenID is empty unless the caller authenticated with a personal access token.
+	PersonalAccessTokenID string
+	Scopes                []string
+}
+
+// IsPersonalAccessToken reports whether the caller authenticated with a personal access token.
+func (p *Principal) IsPersonalAccessToken() bool {
+	return p.PersonalAccessTokenID != ""
+}
+
+// HasScope reports whether the caller is allowed to act within the given scope.
+func (p *Principal) HasScope(scope string) bool {
+	if !p.IsPersonalAccessToken() {
+		return true
+	}
+	return slices.Contains(p.Scopes, scope)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// DateLayout is the layout of calendar dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without a time zone, such as a day chosen in a date picker.
+// It becomes an instant only once a time zone is applied.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses a YYYY-MM-DD string.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, ErrValidation("invalid date format, expected YYYY-MM-DD")
+	}
+	return DateOf(t, time.UTC), nil
+}
+
+// DateOf returns the calendar date of t as observed in loc.
+func DateOf(t time.Time, loc *time.Location) Date {
+	y, m, d := t.In(loc).Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// StartIn returns the first instant of the date in loc.
+// On days where a DST transition skips midnight, this is the first wall-clock time that exists.
+func (d Date) StartIn(loc *time.Location) time.Time {
+	t := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+	if DateOf(t, loc) != d {
+		// The skipped midnight was normalised to the previous day; the date starts at the transition.
+		_, end := t.ZoneBounds()
+		return end
+	}
+	return t
+}
+
+// AddDays returns the date n calendar days later (or earlier when n is negative).
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC), time.UTC)
+}
+
+// DaysUntil returns the number of calendar days from d to e, negative when e is before d.
+func (d Date) DaysUntil(e Date) int {
+	return int(e.StartIn(time.UTC).Sub(d.StartIn(time.UTC)).Hours() / 24)
+}
+
+// String formats the date as YYYY-MM-DD.
+func (d Date) String() string {
+	return d.StartIn(time.UTC).Format(DateLayout)
+}
+
+// LoadLocation loads an IANA time zone by name.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrValidation("timezone is required")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrValidation("unknown timezone: " + name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestParseDate(t *testing.T) {
+	d, err := domain.ParseDate("2024-02-29")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d != (domain.Date{Year: 2024, Month: time.February, Day: 29}) {
+		t.Errorf("unexpected date: %+v", d)
+	}
+	if d.String() != "2024-02-29" {
+		t.Errorf("expected '2024-02-29', got '%s'", d.String())
+	}
+	if _, err := domain.ParseDate("2024/02/29"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestDate_StartIn_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	start := domain.Date{Year: 2024, Month: time.March, Day: 10}.StartIn(ny)
+	end := domain.Date{Year: 2024, Month: time.March, Day: 10}.AddDays(1).StartIn(ny)
+	if end.Sub(start) != 23*time.Hour {
+		t.Errorf("expected the spring-forward day to be 23h long, got %v", end.Sub(start))
+	}
+	if got := domain.DateOf(end.Add(-time.Minute), ny); got.Day != 10 {
+		t.Errorf("expected 23:59 to fall on the 10th, got %v", got)
+	}
+}
+
+func TestDate_StartIn_DSTAtMidnight(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	d := domain.Date{Year: 2018, Month: time.November, Day: 4}
+	start := d.StartIn(saoPaulo)
+	if got := domain.DateOf(start, saoPaulo); got != d {
+		t.Errorf("expected the start to fall on %v, got %v", d, got)
+	}
+	if want := time.Date(2018, time.November, 4, 3, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("expected the start at the transition %v, got %v", want, start)
+	}
+	if got := domain.DateOf(start.Add(-time.Nanosecond), saoPaulo); got != d.AddDays(-1) {
+		t.Errorf("expected the instant before the start to fall on the previous day, got %v", got)
+	}
+	if end := d.AddDays(1).StartIn(saoPaulo); end.Sub(start) != 23*time.Hour {
+		t.Errorf("expected the spring-forward day to be 23h long, got %v", end.Sub(start))
+	}
+}
+
+func TestDate_AddDays_AcrossMonth(t *testing.T) {
+	d := domain.Date{Year: 2024, Month: time.December, Day: 30}.AddDays(3)
+	if d != (domain.Date{Year: 2025, Month: time.January, Day: 2}) {
+		t.Errorf("unexpected date: %v", d)
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	if _, err := domain.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"", "Local", "Mars/Base"} {
+		if _, err := domain.LoadLocation(name); !domain.IsValidation(err) {
+			t.Errorf("expected validation error for %q, got: %v", name, err)
+		}
+	}
+}
+
+func TestDate_DaysUntil(t *testing.T) {
+	d := domain.Date{Year: 2024, Month: time.February, Day: 27}
+	if got := d.DaysUntil(domain.Date{Year: 2024, Month: time.March, Day: 2}); got != 4 {
+		t.Errorf("expected 4 days, got %d", got)
+	}
+	if got := d.DaysUntil(domain.Date{Year: 2024, Month: time.February, Day: 20}); got != -7 {
+		t.Errorf("expected -7 days, got %d", got)
+	}
+}
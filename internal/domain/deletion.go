@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// DeletionImpact describes what deleting a project or a user would remove, so the deletion can be
+// reviewed before it is confirmed.
+type DeletionImpact struct {
+	// Projects is the number of projects deleted with a user; it is zero for a project.
+	Projects     int
+	StudyLogs    int
+	TotalMinutes int
+	// OtherMembersStudyLogs is how many of StudyLogs other members wrote in the projects of a user
+	// being deleted; it is zero for a project.
+	OtherMembersStudyLogs int
+	Notes                 int
+	// OtherMembersNotes is how many of Notes other members wrote in the projects of a user being
+	// deleted; it is zero for a project.
+	OtherMembersNotes int
+	Goals             int
+	// Goal is the goal of the project being deleted; it is nil for a user or a project without a goal.
+	Goal *Goal
+	// ConfirmationToken confirms the deletion until ConfirmationExpiresAt; it is set for a preview.
+	ConfirmationToken     string
+	ConfirmationExpiresAt time.Time
+}
+
+// Digest returns a digest of what deleting the project or user with the given ID would remove.
+// It changes whenever what the deletion would remove changes, so a deletion confirmed from an
+// outdated preview is refused.
+func (i *DeletionImpact) Digest(id string) string {
+	h := sha256.New()
+	for _, part := range []string{
+		id,
+		strconv.Itoa(i.Projects),
+		strconv.Itoa(i.StudyLogs),
+		strconv.Itoa(i.TotalMinutes),
+		strconv.Itoa(i.OtherMembersStudyLogs),
+		strconv.Itoa(i.Notes),
+		strconv.Itoa(i.OtherMembersNotes),
+		strconv.Itoa(i.Goals),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// CheckDigest allows a deletion of the project or user with the given ID that was previewed with
+// the given digest, as long as what the deletion would remove has not changed since.
+func (i *DeletionImpact) CheckDigest(id, digest string) error {
+	if digest != i.Digest(id) {
+		return ErrConflict("what would be deleted has changed since the preview; preview the deletion again")
+	}
+	return nil
+}
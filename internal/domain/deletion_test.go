@@ -0,0 +1,24 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestDeletionImpact_CheckDigest(t *testing.T) {
+	impact := &domain.DeletionImpact{StudyLogs: 3, TotalMinutes: 90, Notes: 1}
+	digest := impact.Digest("proj-1")
+	if digest == impact.Digest("proj-2") {
+		t.Error("expected the digest to depend on the deleted project")
+	}
+
+	if err := impact.CheckDigest("proj-1", digest); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	impact.TotalMinutes += 30
+	if err := impact.CheckDigest("proj-1", digest); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error after the impact changed, got: %v", err)
+	}
+}
@@ -16,6 +16,10 @@ const (
 	ErrorTypeValidation
 	// ErrorTypeConflict indicates a conflict with the current state of the resource.
 	ErrorTypeConflict
+	// ErrorTypeUnauthorized indicates missing or invalid credentials.
+	ErrorTypeUnauthorized
+	// ErrorTypeForbidden indicates the caller is not allowed to perform the operation.
+	ErrorTypeForbidden
 )
 
 // Error is a custom error type for domain-specific errors.
@@ -53,6 +57,22 @@ func ErrConflict(message string) *Error {
 	}
 }
 
+// ErrUnauthorized creates a new Error of type ErrorTypeUnauthorized.
+func ErrUnauthorized(message string) *Error {
+	return &Error{
+		Type:    ErrorTypeUnauthorized,
+		Message: message,
+	}
+}
+
+// ErrForbidden creates a new Error of type ErrorTypeForbidden.
+func ErrForbidden(message string) *Error {
+	return &Error{
+		Type:    ErrorTypeForbidden,
+		Message: message,
+	}
+}
+
 // IsNotFound checks if the error is an Error of type ErrorTypeNotFound.
 func IsNotFound(err error) bool {
 	var domErr *Error
@@ -79,3 +99,21 @@ func IsConflict(err error) bool {
 	}
 	return false
 }
+
+// IsUnauthorized checks if the error is an Error of type ErrorTypeUnauthorized.
+func IsUnauthorized(err error) bool {
+	var domErr *Error
+	if errors.As(err, &domErr) {
+		return domErr.Type == ErrorTypeUnauthorized
+	}
+	return false
+}
+
+// IsForbidden checks if the error is an Error of type ErrorTypeForbidden.
+func IsForbidden(err error) bool {
+	var domErr *Error
+	if errors.As(err, &domErr) {
+		return domErr.Type == ErrorTypeForbidden
+	}
+	return false
+}
@@ -102,3 +102,29 @@ func TestErrorsAs_WrappedError(t *testing.T) {
 		t.Error("expected IsNotFound to return true for wrapped ErrNotFound")
 	}
 }
+
+func TestErrUnauthorized(t *testing.T) {
+	err := domain.ErrUnauthorized("invalid token")
+	if err.Error() != "invalid token" {
+		t.Errorf("expected 'invalid token', got '%s'", err.Error())
+	}
+	if !domain.IsUnauthorized(err) {
+		t.Error("expected IsUnauthorized to return true for ErrUnauthorized")
+	}
+	if domain.IsForbidden(err) {
+		t.Error("expected IsForbidden to return false for ErrUnauthorized")
+	}
+}
+
+func TestErrForbidden(t *testing.T) {
+	err := domain.ErrForbidden("access denied")
+	if err.Error() != "access denied" {
+		t.Errorf("expected 'access denied', got '%s'", err.Error())
+	}
+	if !domain.IsForbidden(err) {
+		t.Error("expected IsForbidden to return true for ErrForbidden")
+	}
+	if domain.IsUnauthorized(err) {
+		t.Error("expected IsUnauthorized to return false for ErrForbidden")
+	}
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// MaxChecklistItems is the most checklist items a milestone can have.
+const MaxChecklistItems = 100
+
+// Milestone is a step towards completing a project, such as finishing a chapter. The milestones
+// of a project are kept in the order the user arranged them, and each may break down into a checklist.
+type Milestone struct {
+	ID        string
+	ProjectID string
+	UserID    string
+	Title     string
+	// DueDate is the day the milestone should be completed by; nil if it has no due date.
+	DueDate *Date
+	// Position is the place of the milestone among those of its project, starting at 0.
+	Position int
+	// CompletedAt is when the milestone was completed; it is nil until then.
+	CompletedAt *time.Time
+	// Items are the checklist items in order.
+	Items     []ChecklistItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ChecklistItem is a single task within a milestone.
+type ChecklistItem struct {
+	ID    string
+	Title string
+	Done  bool
+}
+
+// NewMilestone creates a new Milestone entity at the given position in its project.
+func NewMilestone(id, userID, projectID, title string, dueDate *Date, position int) (*Milestone, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if projectID == "" {
+		return nil, ErrValidation("project ID is required")
+	}
+	if err := validateMilestoneTitle(title); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &Milestone{
+		ID:        id,
+		ProjectID: projectID,
+		UserID:    userID,
+		Title:     title,
+		DueDate:   dueDate,
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ReconstructMilestone reconstructs a Milestone entity from existing data.
+func ReconstructMilestone(id, projectID, userID, title string, position int, createdAt, updatedAt time.Time) *Milestone {
+	return &Milestone{
+		ID:        id,
+		ProjectID: projectID,
+		UserID:    userID,
+		Title:     title,
+		Position:  position,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// Update replaces the title and due date of the milestone.
+func (m *Milestone) Update(title string, dueDate *Date) error {
+	if err := validateMilestoneTitle(title); err != nil {
+		return err
+	}
+	m.Title = title
+	m.DueDate = dueDate
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsCompleted reports whether the milestone has been completed.
+func (m *Milestone) IsCompleted() bool {
+	return m.CompletedAt != nil
+}
+
+// Complete marks the milestone as completed at now.
+func (m *Milestone) Complete(now time.Time) error {
+	if m.IsCompleted() {
+		return ErrConflict("milestone is already completed")
+	}
+	m.CompletedAt = &now
+	m.UpdatedAt = now
+	return nil
+}
+
+// Reopen marks a completed milestone as not completed again.
+func (m *Milestone) Reopen(now time.Time) error {
+	if !m.IsCompleted() {
+		return ErrConflict("milestone is not completed")
+	}
+	m.CompletedAt = nil
+	m.UpdatedAt = now
+	return nil
+}
+
+// AddItem appends an item to the checklist.
+func (m *Milestone) AddItem(id, title string) (ChecklistItem, error) {
+	if err := validateChecklistItemTitle(title); err != nil {
+		return ChecklistItem{}, err
+	}
+	if len(m.Items) >= MaxChecklistItems {
+		return ChecklistItem{}, ErrValidation("a milestone can have at most 100 checklist items")
+	}
+	item := ChecklistItem{ID: id, Title: title}
+	m.Items = append(m.Items, item)
+	m.UpdatedAt = time.Now()
+	return item, nil
+}
+
+// UpdateItem replaces the title and done flag of a checklist item.
+func (m *Milestone) UpdateItem(id, title string, done bool) error {
+	if err := validateChecklistItemTitle(title); err != nil {
+		return err
+	}
+	i := m.itemIndex(id)
+	if i < 0 {
+		return ErrNotFound("checklist item")
+	}
+	m.Items[i].Title = title
+	m.Items[i].Done = done
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveItem removes a checklist item.
+func (m *Milestone) RemoveItem(id string) error {
+	i := m.itemIndex(id)
+	if i < 0 {
+		return ErrNotFound("checklist item")
+	}
+	m.Items = append(m.Items[:i], m.Items[i+1:]...)
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *Milestone) itemIndex(id string) int {
+	for i, item := range m.Items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Progress returns how far the milestone is towards completion as a percentage. A completed
+// milestone is 100; otherwise it is the share of its checklist items that are done, or 0 without a checklist.
+func (m *Milestone) Progress() float64 {
+	if m.IsCompleted() {
+		return 100
+	}
+	if len(m.Items) == 0 {
+		return 0
+	}
+	var done int
+	for _, item := range m.Items {
+		if item.Done {
+			done++
+		}
+	}
+	return float64(done) / float64(len(m.Items)) * 100
+}
+
+// IsOverdueAt reports whether, at t, the milestone's due date has passed in loc without it being completed.
+func (m *Milestone) IsOverdueAt(t time.Time, loc *time.Location) bool {
+	if m.DueDate == nil {
+		return false
+	}
+	if m.CompletedAt != nil && !m.CompletedAt.After(t) {
+		return false
+	}
+	return !m.DueDate.AddDays(1).StartIn(loc).After(t)
+}
+
+// ProjectProgress returns the completion percentage of a project as the average progress of
+// its milestones, or 0 if it has none.
+func ProjectProgress(milestones []*Milestone) float64 {
+	if len(milestones) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, m := range milestones {
+		sum += m.Progress()
+	}
+	return sum / float64(len(milestones))
+}
+
+func validateMilestoneTitle(title string) error {
+	if title == "" {
+		return ErrValidation("milestone title is required")
+	}
+	if utf8.RuneCountInString(title) > 200 {
+		return ErrValidation("milestone title must be 200 characters or less")
+	}
+	return nil
+}
+
+func validateChecklistItemTitle(title string) error {
+	if title == "" {
+		return ErrValidation("checklist item title is required")
+	}
+	if utf8.RuneCountInString(title) > 200 {
+		return ErrValidation("checklist item title must be 200 characters or less")
+	}
+	return nil
+}
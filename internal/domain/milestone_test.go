@@ -0,0 +1,153 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestNewMilestone_Valid(t *testing.T) {
+	due := domain.Date{Year: 2024, Month: time.March, Day: 31}
+	m, err := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", &due, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Title != "Finish chapter 3" || m.Position != 2 || *m.DueDate != due {
+		t.Errorf("unexpected milestone: %+v", m)
+	}
+	if m.IsCompleted() {
+		t.Error("expected a new milestone not to be completed")
+	}
+}
+
+func TestNewMilestone_InvalidTitle(t *testing.T) {
+	for _, title := range []string{"", strings.Repeat("a", 201)} {
+		_, err := domain.NewMilestone("ms-1", "user-1", "proj-1", title, nil, 0)
+		if !domain.IsValidation(err) {
+			t.Errorf("title of %d characters: expected validation error, got: %v", len(title), err)
+		}
+	}
+}
+
+func TestMilestone_CompleteAndReopen(t *testing.T) {
+	m, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", nil, 0)
+	now := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	if err := m.Reopen(now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict reopening an open milestone, got: %v", err)
+	}
+	if err := m.Complete(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !m.IsCompleted() || !m.CompletedAt.Equal(now) {
+		t.Errorf("expected milestone completed at %v, got %v", now, m.CompletedAt)
+	}
+	if err := m.Complete(now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict completing a completed milestone, got: %v", err)
+	}
+	if err := m.Reopen(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.IsCompleted() {
+		t.Error("expected milestone to be reopened")
+	}
+}
+
+func TestMilestone_Checklist(t *testing.T) {
+	m, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", nil, 0)
+	for _, id := range []string{"item-1", "item-2", "item-3", "item-4"} {
+		if _, err := m.AddItem(id, "Section "+id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := m.AddItem("item-5", ""); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for an empty title, got: %v", err)
+	}
+	if err := m.UpdateItem("item-1", "Section 3.1", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Items[0].Title != "Section 3.1" || !m.Items[0].Done {
+		t.Errorf("unexpected item: %+v", m.Items[0])
+	}
+	if err := m.UpdateItem("missing", "Section", true); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if err := m.RemoveItem("item-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.Items) != 3 || m.Items[1].ID != "item-3" {
+		t.Errorf("expected item-2 to be removed, got %+v", m.Items)
+	}
+	if err := m.RemoveItem("item-2"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestMilestone_ChecklistLimit(t *testing.T) {
+	m, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", nil, 0)
+	for i := range domain.MaxChecklistItems {
+		if _, err := m.AddItem(strings.Repeat("x", i+1), "Item"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := m.AddItem("one-too-many", "Item"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestMilestone_Progress(t *testing.T) {
+	m, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", nil, 0)
+	if got := m.Progress(); got != 0 {
+		t.Errorf("expected 0 without a checklist, got %v", got)
+	}
+	_, _ = m.AddItem("item-1", "Section 3.1")
+	_, _ = m.AddItem("item-2", "Section 3.2")
+	_, _ = m.AddItem("item-3", "Section 3.3")
+	_, _ = m.AddItem("item-4", "Section 3.4")
+	_ = m.UpdateItem("item-1", "Section 3.1", true)
+	if got := m.Progress(); got != 25 {
+		t.Errorf("expected 25 with one of four items done, got %v", got)
+	}
+	_ = m.Complete(time.Now())
+	if got := m.Progress(); got != 100 {
+		t.Errorf("expected 100 once completed, got %v", got)
+	}
+
+	other, _ := domain.NewMilestone("ms-2", "user-1", "proj-1", "Finish chapter 4", nil, 1)
+	if got := domain.ProjectProgress([]*domain.Milestone{m, other}); got != 50 {
+		t.Errorf("expected project progress 50, got %v", got)
+	}
+	if got := domain.ProjectProgress(nil); got != 0 {
+		t.Errorf("expected project progress 0 without milestones, got %v", got)
+	}
+}
+
+func TestMilestone_IsOverdueAt(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	due := domain.Date{Year: 2024, Month: time.March, Day: 20}
+	m, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Finish chapter 3", &due, 0)
+
+	lastMoment := time.Date(2024, 3, 20, 23, 59, 0, 0, tokyo)
+	nextDay := time.Date(2024, 3, 21, 0, 0, 0, 0, tokyo)
+	if m.IsOverdueAt(lastMoment, tokyo) {
+		t.Error("expected milestone not to be overdue on its due date")
+	}
+	if !m.IsOverdueAt(nextDay, tokyo) {
+		t.Error("expected milestone to be overdue the day after its due date")
+	}
+
+	_ = m.Complete(time.Date(2024, 3, 25, 12, 0, 0, 0, tokyo))
+	if !m.IsOverdueAt(nextDay, tokyo) {
+		t.Error("expected milestone completed later to have been overdue before it was completed")
+	}
+	if m.IsOverdueAt(time.Date(2024, 3, 26, 0, 0, 0, 0, tokyo), tokyo) {
+		t.Error("expected milestone not to be overdue once completed")
+	}
+
+	undated, _ := domain.NewMilestone("ms-2", "user-1", "proj-1", "Someday", nil, 1)
+	if undated.IsOverdueAt(nextDay, tokyo) {
+		t.Error("expected milestone without a due date never to be overdue")
+	}
+}
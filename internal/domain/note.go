@@ -12,6 +12,10 @@ type Note struct {
 	Tags      []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeletedAt is when the note was moved to the trash; it is nil unless the note is trashed.
+	DeletedAt *time.Time
+	// StudyLogs are the study sessions the note was written in, ordered by start time.
+	StudyLogs []LinkedStudyLog
 }
 
 // NewNote creates a new Note entity.
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// LinkedNote is the summary of a note that is linked to a study log.
+type LinkedNote struct {
+	ID        string
+	ProjectID string
+	Title     string
+}
+
+// LinkedStudyLog is the summary of a study log that is linked to a note.
+type LinkedStudyLog struct {
+	ID        string
+	ProjectID string
+	StudiedAt time.Time
+	Minutes   int
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxPageLimit is the largest page size list endpoints accept.
+const MaxPageLimit = 200
+
+// Sort keys that lists can be ordered by.
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "createdAt"
+	SortByUpdatedAt = "updatedAt"
+	SortByStudiedAt = "studiedAt"
+)
+
+// SortOrder orders a list by a sort key, with the item ID breaking ties.
+type SortOrder struct {
+	Key        string
+	Descending bool
+}
+
+// ParseSortOrder parses a sort key such as "name", prefixed with "-" for descending order.
+// An empty value selects fallback. Only the given keys are accepted.
+func ParseSortOrder(value string, keys []string, fallback SortOrder) (SortOrder, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	order := SortOrder{Key: strings.TrimPrefix(value, "-"), Descending: strings.HasPrefix(value, "-")}
+	if !slices.Contains(keys, order.Key) {
+		return SortOrder{}, ErrValidation("sort must be one of: " + strings.Join(keys, ", ") + " (prefix with - for descending order)")
+	}
+	return order, nil
+}
+
+// String returns the sort order in the form accepted by ParseSortOrder.
+func (s SortOrder) String() string {
+	if s.Descending {
+		return "-" + s.Key
+	}
+	return s.Key
+}
+
+// Cursor marks the last item of a page, so that the next page starts right after it.
+// It holds the item's sort key value and ID and is handed to clients as an opaque string.
+type Cursor struct {
+	// Sort is the sort order the cursor was issued for.
+	Sort string `json:"s"`
+	// Time holds the sort key value for keys that are times.
+	Time time.Time `json:"t,omitzero"`
+	// Text holds the sort key value for keys that are text.
+	Text string `json:"x,omitempty"`
+	ID   string `json:"i"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe string.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode.
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrValidation("invalid cursor")
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrValidation("invalid cursor")
+	}
+	return &c, nil
+}
@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	keys := []string{domain.SortByName, domain.SortByCreatedAt}
+	fallback := domain.SortOrder{Key: domain.SortByName}
+
+	order, err := domain.ParseSortOrder("-createdAt", keys, fallback)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order != (domain.SortOrder{Key: domain.SortByCreatedAt, Descending: true}) {
+		t.Errorf("unexpected sort order: %+v", order)
+	}
+	if order.String() != "-createdAt" {
+		t.Errorf("expected '-createdAt', got '%s'", order.String())
+	}
+	if order, _ := domain.ParseSortOrder("", keys, fallback); order != fallback {
+		t.Errorf("expected fallback, got %+v", order)
+	}
+	if _, err := domain.ParseSortOrder("studiedAt", keys, fallback); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := domain.Cursor{Sort: "-studiedAt", Time: time.Date(2024, 1, 15, 10, 0, 0, 123000, time.UTC), ID: "log-1"}
+
+	decoded, err := domain.DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Sort != cursor.Sort || !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+		t.Errorf("expected %+v, got %+v", cursor, *decoded)
+	}
+	for _, value := range []string{"not a cursor!", "e30"} {
+		if _, err := domain.DecodeCursor(value); !domain.IsValidation(err) {
+			t.Errorf("expected validation error for %q, got: %v", value, err)
+		}
+	}
+}
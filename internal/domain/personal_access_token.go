@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// PersonalAccessTokenPrefix marks personal access tokens so they can be told apart from session tokens.
+const PersonalAccessTokenPrefix = "stpat_"
+
+// Scopes that can be granted to a personal access token.
+const (
+	ScopeUsersRead      = "users:read"
+	ScopeUsersWrite     = "users:write"
+	ScopeProjectsRead   = "projects:read"
+	ScopeProjectsWrite  = "projects:write"
+	ScopeStudyLogsRead  = "studylogs:read"
+	ScopeStudyLogsWrite = "studylogs:write"
+	ScopeGoalsRead      = "goals:read"
+	ScopeGoalsWrite     = "goals:write"
+	ScopeStatsRead      = "stats:read"
+	ScopeNotesRead      = "notes:read"
+	ScopeNotesWrite     = "notes:write"
+)
+
+// Scopes lists every scope that can be granted to a personal access token.
+var Scopes = []string{
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeProjectsRead,
+	ScopeProjectsWrite,
+	ScopeStudyLogsRead,
+	ScopeStudyLogsWrite,
+	ScopeGoalsRead,
+	ScopeGoalsWrite,
+	ScopeStatsRead,
+	ScopeNotesRead,
+	ScopeNotesWrite,
+}
+
+// PersonalAccessToken represents a named, revocable token a user issues for scripts and integrations.
+// Only a hash of the token is kept; the plaintext is shown once when the token is created.
+type PersonalAccessToken struct {
+	ID         string
+	UserID     string
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewPersonalAccessToken creates a new PersonalAccessToken entity.
+func NewPersonalAccessToken(id, userID, name, tokenHash string, scopes []string, expiresAt *time.Time) (*PersonalAccessToken, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if err := validatePersonalAccessTokenName(name); err != nil {
+		return nil, err
+	}
+	if tokenHash == "" {
+		return nil, ErrValidation("token hash is required")
+	}
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrValidation("expiry must be in the future")
+	}
+	return &PersonalAccessToken{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		TokenHash: tokenHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+// ReconstructPersonalAccessToken reconstructs a PersonalAccessToken entity from existing data.
+func ReconstructPersonalAccessToken(id, userID, name, tokenHash string, scopes []string, lastUsedAt, expiresAt *time.Time, createdAt time.Time) *PersonalAccessToken {
+	return &PersonalAccessToken{
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		TokenHash:  tokenHash,
+		Scopes:     scopes,
+		LastUsedAt: lastUsedAt,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  createdAt,
+	}
+}
+
+// IsExpired reports whether the token has expired at the given time.
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+func validatePersonalAccessTokenName(name string) error {
+	if name == "" {
+		return ErrValidation("token name is required")
+	}
+	if len(name) > 100 {
+		return ErrValidation("token name must be 100 characters or less")
+	}
+	return nil
+}
+
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return ErrValidation("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return ErrValidation("unknown scope: " + scope)
+		}
+	}
+	return nil
+}
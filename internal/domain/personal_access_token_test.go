@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestNewPersonalAccessToken_Valid(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	token, err := domain.NewPersonalAccessToken("pat-1", "user-1", "cli", "hash", []string{domain.ScopeStudyLogsWrite}, &expiresAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Name != "cli" {
+		t.Errorf("expected Name 'cli', got '%s'", token.Name)
+	}
+	if token.LastUsedAt != nil {
+		t.Error("expected LastUsedAt to be nil")
+	}
+	if token.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+}
+
+func TestNewPersonalAccessToken_EmptyName(t *testing.T) {
+	_, err := domain.NewPersonalAccessToken("pat-1", "user-1", "", "hash", []string{domain.ScopeStatsRead}, nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestNewPersonalAccessToken_NameTooLong(t *testing.T) {
+	_, err := domain.NewPersonalAccessToken("pat-1", "user-1", strings.Repeat("a", 101), "hash", []string{domain.ScopeStatsRead}, nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestNewPersonalAccessToken_NoScopes(t *testing.T) {
+	_, err := domain.NewPersonalAccessToken("pat-1", "user-1", "cli", "hash", nil, nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestNewPersonalAccessToken_UnknownScope(t *testing.T) {
+	_, err := domain.NewPersonalAccessToken("pat-1", "user-1", "cli", "hash", []string{"admin"}, nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestNewPersonalAccessToken_ExpiryInPast(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	_, err := domain.NewPersonalAccessToken("pat-1", "user-1", "cli", "hash", []string{domain.ScopeStatsRead}, &past)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestPersonalAccessToken_IsExpired(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	token := &domain.PersonalAccessToken{ExpiresAt: &expiresAt}
+
+	if token.IsExpired(now) {
+		t.Error("expected token not to be expired before expiry")
+	}
+	if !token.IsExpired(expiresAt) {
+		t.Error("expected token to be expired at expiry")
+	}
+	if (&domain.PersonalAccessToken{}).IsExpired(now) {
+		t.Error("expected token without expiry never to expire")
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// PomodoroPhase is the part of the cycle a pomodoro session is in.
+type PomodoroPhase string
+
+// Phases of a pomodoro session. A session alternates work and break phases and is
+// finished after its last work phase or when it is stopped.
+const (
+	PomodoroPhaseWork     PomodoroPhase = "work"
+	PomodoroPhaseBreak    PomodoroPhase = "break"
+	PomodoroPhaseFinished PomodoroPhase = "finished"
+)
+
+// PomodoroSettings holds the lengths of the phases of a pomodoro session and how many work phases it has.
+type PomodoroSettings struct {
+	WorkMinutes  int
+	BreakMinutes int
+	Cycles       int
+}
+
+// DefaultPomodoroSettings returns the classic 25/5 minute pomodoro repeated four times.
+func DefaultPomodoroSettings() PomodoroSettings {
+	return PomodoroSettings{WorkMinutes: 25, BreakMinutes: 5, Cycles: 4}
+}
+
+// PomodoroSession represents a user's running pomodoro session. A user has at most one.
+type PomodoroSession struct {
+	UserID             string
+	ProjectID          string
+	Note               string
+	Settings           PomodoroSettings
+	Phase              PomodoroPhase
+	PhaseStartedAt     time.Time
+	CompletedPomodoros int
+	StartedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// PomodoroInterval is a work phase that has ended and should be recorded as a study log.
+type PomodoroInterval struct {
+	StartedAt time.Time
+	Minutes   int
+	// Completed is false for a work phase cut short by stopping the session.
+	Completed bool
+}
+
+// NewPomodoroSession creates a new PomodoroSession entity starting with a work phase.
+func NewPomodoroSession(userID, projectID, note string, settings PomodoroSettings, now time.Time) (*PomodoroSession, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if projectID == "" {
+		return nil, ErrValidation("project ID is required")
+	}
+	if err := validatePomodoroSettings(settings); err != nil {
+		return nil, err
+	}
+	return &PomodoroSession{
+		UserID:         userID,
+		ProjectID:      projectID,
+		Note:           note,
+		Settings:       settings,
+		Phase:          PomodoroPhaseWork,
+		PhaseStartedAt: now,
+		StartedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// ReconstructPomodoroSession reconstructs a PomodoroSession entity from existing data.
+func ReconstructPomodoroSession(userID, projectID, note string, settings PomodoroSettings, phase PomodoroPhase, phaseStartedAt time.Time, completedPomodoros int, startedAt, updatedAt time.Time) *PomodoroSession {
+	return &PomodoroSession{
+		UserID:             userID,
+		ProjectID:          projectID,
+		Note:               note,
+		Settings:           settings,
+		Phase:              phase,
+		PhaseStartedAt:     phaseStartedAt,
+		CompletedPomodoros: completedPomodoros,
+		StartedAt:          startedAt,
+		UpdatedAt:          updatedAt,
+	}
+}
+
+// IsFinished reports whether the session has no phases left.
+func (s *PomodoroSession) IsFinished() bool {
+	return s.Phase == PomodoroPhaseFinished
+}
+
+// PhaseEndsAt returns when the current phase ends. It is the zero time for a finished session.
+func (s *PomodoroSession) PhaseEndsAt() time.Time {
+	switch s.Phase {
+	case PomodoroPhaseWork:
+		return s.PhaseStartedAt.Add(time.Duration(s.Settings.WorkMinutes) * time.Minute)
+	case PomodoroPhaseBreak:
+		return s.PhaseStartedAt.Add(time.Duration(s.Settings.BreakMinutes) * time.Minute)
+	default:
+		return time.Time{}
+	}
+}
+
+// Advance moves the session through every phase that has ended by now and
+// returns the work phases completed on the way.
+func (s *PomodoroSession) Advance(now time.Time) []PomodoroInterval {
+	var completed []PomodoroInterval
+	for !s.IsFinished() {
+		end := s.PhaseEndsAt()
+		if now.Before(end) {
+			break
+		}
+		if s.Phase == PomodoroPhaseWork {
+			completed = append(completed, PomodoroInterval{StartedAt: s.PhaseStartedAt, Minutes: s.Settings.WorkMinutes, Completed: true})
+			s.CompletedPomodoros++
+			if s.CompletedPomodoros >= s.Settings.Cycles {
+				s.Phase = PomodoroPhaseFinished
+			} else {
+				s.Phase = PomodoroPhaseBreak
+			}
+		} else {
+			s.Phase = PomodoroPhaseWork
+		}
+		s.PhaseStartedAt = end
+		s.UpdatedAt = now
+	}
+	return completed
+}
+
+// SkipBreak ends the current break early and starts the next work phase.
+// The session should be advanced to now first.
+func (s *PomodoroSession) SkipBreak(now time.Time) error {
+	if s.Phase != PomodoroPhaseBreak {
+		return ErrConflict("pomodoro session is not on a break")
+	}
+	s.Phase = PomodoroPhaseWork
+	s.PhaseStartedAt = now
+	s.UpdatedAt = now
+	return nil
+}
+
+// Stop finishes the session. A work phase in progress for at least a minute is returned
+// so the time is not lost; it does not count as a completed pomodoro.
+// The session should be advanced to now first.
+func (s *PomodoroSession) Stop(now time.Time) *PomodoroInterval {
+	var partial *PomodoroInterval
+	if s.Phase == PomodoroPhaseWork {
+		if minutes := int(math.Round(now.Sub(s.PhaseStartedAt).Minutes())); minutes >= 1 {
+			partial = &PomodoroInterval{StartedAt: s.PhaseStartedAt, Minutes: minutes}
+		}
+	}
+	s.Phase = PomodoroPhaseFinished
+	s.PhaseStartedAt = now
+	s.UpdatedAt = now
+	return partial
+}
+
+func validatePomodoroSettings(settings PomodoroSettings) error {
+	if settings.WorkMinutes < 1 || settings.WorkMinutes > 180 {
+		return ErrValidation("work minutes must be between 1 and 180")
+	}
+	if settings.BreakMinutes < 1 || settings.BreakMinutes > 60 {
+		return ErrValidation("break minutes must be between 1 and 60")
+	}
+	if settings.Cycles < 1 || settings.Cycles > 24 {
+		return ErrValidation("cycles must be between 1 and 24")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func newTestPomodoro(t *testing.T, start time.Time) *domain.PomodoroSession {
+	t.Helper()
+	session, err := domain.NewPomodoroSession("user-1", "project-1", "", domain.PomodoroSettings{WorkMinutes: 25, BreakMinutes: 5, Cycles: 2}, start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return session
+}
+
+func TestNewPomodoroSession_InvalidSettings(t *testing.T) {
+	for _, settings := range []domain.PomodoroSettings{
+		{WorkMinutes: 0, BreakMinutes: 5, Cycles: 4},
+		{WorkMinutes: 25, BreakMinutes: 0, Cycles: 4},
+		{WorkMinutes: 25, BreakMinutes: 5, Cycles: 0},
+		{WorkMinutes: 181, BreakMinutes: 5, Cycles: 4},
+	} {
+		_, err := domain.NewPomodoroSession("user-1", "project-1", "", settings, time.Now())
+		if !domain.IsValidation(err) {
+			t.Errorf("expected validation error for %+v, got: %v", settings, err)
+		}
+	}
+}
+
+func TestPomodoroSession_Advance(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := newTestPomodoro(t, start)
+
+	if got := session.Advance(start.Add(24 * time.Minute)); len(got) != 0 {
+		t.Errorf("expected no completed intervals during the first work phase, got %v", got)
+	}
+
+	got := session.Advance(start.Add(27 * time.Minute))
+	if len(got) != 1 || !got[0].Completed || got[0].Minutes != 25 || !got[0].StartedAt.Equal(start) {
+		t.Fatalf("expected the first work phase to be completed, got %v", got)
+	}
+	if session.Phase != domain.PomodoroPhaseBreak || session.CompletedPomodoros != 1 {
+		t.Errorf("expected a break after 1 pomodoro, got %s after %d", session.Phase, session.CompletedPomodoros)
+	}
+	if want := start.Add(30 * time.Minute); !session.PhaseEndsAt().Equal(want) {
+		t.Errorf("expected the break to end at %v, got %v", want, session.PhaseEndsAt())
+	}
+
+	got = session.Advance(start.Add(3 * time.Hour))
+	if len(got) != 1 || !got[0].StartedAt.Equal(start.Add(30*time.Minute)) {
+		t.Fatalf("expected the second work phase to start after the break, got %v", got)
+	}
+	if !session.IsFinished() || session.CompletedPomodoros != 2 {
+		t.Errorf("expected the session to finish after 2 pomodoros, got %s after %d", session.Phase, session.CompletedPomodoros)
+	}
+}
+
+func TestPomodoroSession_AdvanceSeveralPhasesAtOnce(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := newTestPomodoro(t, start)
+
+	got := session.Advance(start.Add(time.Hour))
+	if len(got) != 2 || !session.IsFinished() {
+		t.Errorf("expected both work phases to complete, got %v and phase %s", got, session.Phase)
+	}
+}
+
+func TestPomodoroSession_SkipBreak(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := newTestPomodoro(t, start)
+
+	if err := session.SkipBreak(start.Add(10 * time.Minute)); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error during work, got: %v", err)
+	}
+	session.Advance(start.Add(26 * time.Minute))
+	if err := session.SkipBreak(start.Add(26 * time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Phase != domain.PomodoroPhaseWork || !session.PhaseStartedAt.Equal(start.Add(26*time.Minute)) {
+		t.Errorf("expected the next work phase to start now, got %s at %v", session.Phase, session.PhaseStartedAt)
+	}
+}
+
+func TestPomodoroSession_Stop(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := newTestPomodoro(t, start)
+
+	partial := session.Stop(start.Add(12 * time.Minute))
+	if partial == nil || partial.Completed || partial.Minutes != 12 {
+		t.Errorf("expected a 12 minute partial interval, got %+v", partial)
+	}
+	if !session.IsFinished() {
+		t.Error("expected the session to be finished")
+	}
+}
+
+func TestPomodoroSession_StopDuringBreak(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := newTestPomodoro(t, start)
+	session.Advance(start.Add(26 * time.Minute))
+
+	if partial := session.Stop(start.Add(27 * time.Minute)); partial != nil {
+		t.Errorf("expected nothing to record during a break, got %+v", partial)
+	}
+}
@@ -1,14 +1,68 @@
 package domain
 
-import "time"
+import (
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+// ProjectStatus is where a project is in its lifecycle.
+type ProjectStatus string
+
+// Project lifecycle statuses. New projects are active unless another status is given.
+const (
+	ProjectStatusPlanned ProjectStatus = "planned"
+	ProjectStatusActive  ProjectStatus = "active"
+	ProjectStatusPaused  ProjectStatus = "paused"
+	ProjectStatusDone    ProjectStatus = "done"
+)
+
+// ParseProjectStatus validates a project status; an empty string is the active status.
+func ParseProjectStatus(s string) (ProjectStatus, error) {
+	switch status := ProjectStatus(s); status {
+	case "":
+		return ProjectStatusActive, nil
+	case ProjectStatusPlanned, ProjectStatusActive, ProjectStatusPaused, ProjectStatusDone:
+		return status, nil
+	}
+	return "", ErrValidation("status must be one of planned, active, paused or done")
+}
+
+// ProjectDetails are the descriptive attributes of a project besides its name.
+type ProjectDetails struct {
+	Description string
+	// Color is a hex color such as #1e90ff; it may be empty.
+	Color string
+	// Icon is an emoji or a short icon name; it may be empty.
+	Icon string
+	// Status is empty for the active status.
+	Status ProjectStatus
+	// TargetDate is when the project is planned to be completed; nil if there is no target.
+	TargetDate *Date
+}
+
+var projectColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
 
 // Project represents a learning project.
 type Project struct {
-	ID        string
-	UserID    string
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID     string
+	UserID string
+	Name   string
+	// ParentID is the project this one is a sub-topic of; it is empty for a top-level project.
+	ParentID    string
+	Description string
+	Color       string
+	Icon        string
+	Status      ProjectStatus
+	TargetDate  *Date
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// DeletedAt is when the project was moved to the trash; it is nil unless the project is trashed.
+	DeletedAt *time.Time
+	// ArchivedAt is when the project was archived; it is nil unless the project is archived.
+	ArchivedAt *time.Time
+	// Progress is the completion percentage of the project's milestones; see ProjectProgress.
+	Progress float64
 }
 
 // NewProject creates a new Project entity.
@@ -24,6 +78,7 @@ func NewProject(id, userID, name string) (*Project, error) {
 		ID:        id,
 		UserID:    userID,
 		Name:      name,
+		Status:    ProjectStatusActive,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
@@ -50,6 +105,63 @@ func (p *Project) UpdateName(name string) error {
 	return nil
 }
 
+// IsArchived reports whether the project has been archived.
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// Archive marks a finished project as archived. Its study logs are kept, but no new study time can be recorded in it.
+func (p *Project) Archive(now time.Time) error {
+	if p.IsArchived() {
+		return ErrConflict("project is already archived")
+	}
+	p.ArchivedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// Unarchive makes an archived project active again.
+func (p *Project) Unarchive(now time.Time) error {
+	if !p.IsArchived() {
+		return ErrConflict("project is not archived")
+	}
+	p.ArchivedAt = nil
+	p.UpdatedAt = now
+	return nil
+}
+
+// CheckRecordable returns a conflict error if study time cannot be recorded in the project.
+func (p *Project) CheckRecordable() error {
+	if p.IsArchived() {
+		return ErrConflict("the project is archived; unarchive it to record study time")
+	}
+	return nil
+}
+
+// UpdateDetails replaces the description, color, icon, status and target date of the project.
+func (p *Project) UpdateDetails(details ProjectDetails) error {
+	if utf8.RuneCountInString(details.Description) > 2000 {
+		return ErrValidation("project description must be 2000 characters or less")
+	}
+	if details.Color != "" && !projectColorPattern.MatchString(details.Color) {
+		return ErrValidation("project color must be a hex color such as #1e90ff")
+	}
+	if utf8.RuneCountInString(details.Icon) > 32 {
+		return ErrValidation("project icon must be 32 characters or less")
+	}
+	status, err := ParseProjectStatus(string(details.Status))
+	if err != nil {
+		return err
+	}
+	p.Description = details.Description
+	p.Color = details.Color
+	p.Icon = details.Icon
+	p.Status = status
+	p.TargetDate = details.TargetDate
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
 func validateProjectName(name string) error {
 	if name == "" {
 		return ErrValidation("project name is required")
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ProjectCopy is a project copied from another one as a template, together with the notes, goal and
+// milestones chosen to be copied with it. Study logs are never copied.
+type ProjectCopy struct {
+	Project *Project
+	Notes   []*Note
+	// Goal is nil when the goal was not copied or the source project has none.
+	Goal       *Goal
+	Milestones []*Milestone
+}
+
+// CopyName returns the name to try for a copy of a project: the name itself on the first attempt,
+// then the name with a number such as "Math (2)", shortened so that the number fits.
+func CopyName(name string, attempt int) string {
+	if attempt <= 1 {
+		return name
+	}
+	suffix := fmt.Sprintf(" (%d)", attempt)
+	for len(name)+len(suffix) > 200 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name + suffix
+}
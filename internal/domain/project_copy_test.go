@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestCopyName(t *testing.T) {
+	if got := domain.CopyName("Math", 1); got != "Math" {
+		t.Errorf("expected 'Math', got '%s'", got)
+	}
+	if got := domain.CopyName("Math", 2); got != "Math (2)" {
+		t.Errorf("expected 'Math (2)', got '%s'", got)
+	}
+}
+
+func TestCopyName_ShortensLongName(t *testing.T) {
+	name := strings.Repeat("あ", 66) + "ab" // 200 bytes
+	got := domain.CopyName(name, 12)
+	if len(got) > 200 {
+		t.Errorf("expected at most 200 bytes, got %d", len(got))
+	}
+	if !strings.HasSuffix(got, " (12)") || !strings.HasPrefix(got, strings.Repeat("あ", 65)) {
+		t.Errorf("unexpected name: %s", got)
+	}
+	if _, err := domain.NewProject("p", "u", got); err != nil {
+		t.Errorf("expected a valid project name, got: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// ProjectRole is what a user may do in a project shared with a study group.
+type ProjectRole string
+
+// Project roles. The owner is the user who created the project; everyone else joins it by invitation
+// as an editor, who records study time and writes notes in it, or as a viewer, who only reads.
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+// ParseMemberRole validates the role of an invited member; members cannot be made owners.
+func ParseMemberRole(s string) (ProjectRole, error) {
+	switch role := ProjectRole(s); role {
+	case ProjectRoleEditor, ProjectRoleViewer:
+		return role, nil
+	}
+	return "", ErrValidation("role must be editor or viewer")
+}
+
+// CanWrite reports whether the role may record study time and write notes in the project.
+func (r ProjectRole) CanWrite() bool {
+	return r == ProjectRoleOwner || r == ProjectRoleEditor
+}
+
+// ProjectMember is a user invited to another user's project. The invitation is pending until the
+// user accepts it, and only then can they see the project.
+type ProjectMember struct {
+	ProjectID string
+	UserID    string
+	Role      ProjectRole
+	InvitedBy string
+	InvitedAt time.Time
+	// AcceptedAt is when the user accepted the invitation; it is nil while the invitation is pending.
+	AcceptedAt *time.Time
+	// UserName and ProjectName are filled in when members or memberships are listed.
+	UserName    string
+	ProjectName string
+}
+
+// NewProjectInvitation invites a user to a project with the given role.
+func NewProjectInvitation(project *Project, userID string, role ProjectRole, now time.Time) (*ProjectMember, error) {
+	if userID == project.UserID {
+		return nil, ErrConflict("the owner is already a member of the project")
+	}
+	if role != ProjectRoleEditor && role != ProjectRoleViewer {
+		return nil, ErrValidation("role must be editor or viewer")
+	}
+	return &ProjectMember{
+		ProjectID: project.ID,
+		UserID:    userID,
+		Role:      role,
+		InvitedBy: project.UserID,
+		InvitedAt: now,
+	}, nil
+}
+
+// IsAccepted reports whether the user has accepted the invitation.
+func (m *ProjectMember) IsAccepted() bool {
+	return m.AcceptedAt != nil
+}
+
+// Accept records that the user accepted the invitation.
+func (m *ProjectMember) Accept(now time.Time) error {
+	if m.IsAccepted() {
+		return ErrConflict("the invitation has already been accepted")
+	}
+	m.AcceptedAt = &now
+	return nil
+}
+
+// MemberStudyStats is the time one member studied in a shared project.
+type MemberStudyStats struct {
+	UserID       string
+	UserName     string
+	Role         ProjectRole
+	TotalMinutes int
+	Pomodoros    int
+}
+
+// ProjectMemberStats breaks the time studied in a shared project down by member.
+type ProjectMemberStats struct {
+	ProjectID string
+	// Timezone is the IANA time zone whose midnights bound the dates of the period.
+	Timezone string
+	// Members lists the owner first, then the members who have accepted their invitations.
+	Members        []MemberStudyStats
+	TotalMinutes   int
+	TotalPomodoros int
+}
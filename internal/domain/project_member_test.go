@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestParseMemberRole(t *testing.T) {
+	for _, s := range []string{"editor", "viewer"} {
+		role, err := domain.ParseMemberRole(s)
+		if err != nil || string(role) != s {
+			t.Errorf("ParseMemberRole(%q) = %q, %v", s, role, err)
+		}
+	}
+	for _, s := range []string{"owner", "", "admin"} {
+		if _, err := domain.ParseMemberRole(s); !domain.IsValidation(err) {
+			t.Errorf("ParseMemberRole(%q): expected validation error, got: %v", s, err)
+		}
+	}
+}
+
+func TestProjectRole_CanWrite(t *testing.T) {
+	if !domain.ProjectRoleOwner.CanWrite() || !domain.ProjectRoleEditor.CanWrite() {
+		t.Error("expected owners and editors to be able to write")
+	}
+	if domain.ProjectRoleViewer.CanWrite() {
+		t.Error("expected viewers not to be able to write")
+	}
+}
+
+func TestNewProjectInvitation(t *testing.T) {
+	project := &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	now := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	member, err := domain.NewProjectInvitation(project, "user-2", domain.ProjectRoleEditor, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.ProjectID != "proj-1" || member.UserID != "user-2" || member.InvitedBy != "user-1" || !member.InvitedAt.Equal(now) {
+		t.Errorf("unexpected invitation: %+v", member)
+	}
+	if member.IsAccepted() {
+		t.Error("expected a new invitation to be pending")
+	}
+
+	if _, err := domain.NewProjectInvitation(project, "user-1", domain.ProjectRoleEditor, now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict inviting the owner, got: %v", err)
+	}
+	if _, err := domain.NewProjectInvitation(project, "user-2", domain.ProjectRoleOwner, now); !domain.IsValidation(err) {
+		t.Errorf("expected validation error inviting an owner, got: %v", err)
+	}
+}
+
+func TestProjectMember_Accept(t *testing.T) {
+	project := &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	member, _ := domain.NewProjectInvitation(project, "user-2", domain.ProjectRoleViewer, time.Now())
+	now := time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC)
+
+	if err := member.Accept(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !member.IsAccepted() || !member.AcceptedAt.Equal(now) {
+		t.Errorf("expected invitation accepted at %v, got %v", now, member.AcceptedAt)
+	}
+	if err := member.Accept(now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict accepting twice, got: %v", err)
+	}
+}
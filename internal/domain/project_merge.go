@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// GoalMergeStrategy chooses the goal a project keeps when another project with a goal is merged into it.
+type GoalMergeStrategy string
+
+// Goal merge strategies.
+const (
+	GoalMergeKeepTarget GoalMergeStrategy = "keep-target"
+	GoalMergeKeepSource GoalMergeStrategy = "keep-source"
+	GoalMergeSum        GoalMergeStrategy = "sum"
+)
+
+// ParseGoalMergeStrategy validates a goal merge strategy; empty means none was chosen.
+func ParseGoalMergeStrategy(s string) (GoalMergeStrategy, error) {
+	switch strategy := GoalMergeStrategy(s); strategy {
+	case "", GoalMergeKeepTarget, GoalMergeKeepSource, GoalMergeSum:
+		return strategy, nil
+	}
+	return "", ErrValidation("goal strategy must be one of keep-target, keep-source or sum")
+}
+
+// ProjectMergeSummary reports what was moved into a project when another project was merged into it.
+// Every study log and note of the other project is moved, including those of its other members and
+// those in the trash, so they are not deleted with it; the Other* and Trashed* counts are part of
+// StudyLogs and Notes.
+type ProjectMergeSummary struct {
+	// Target is the project the other project was merged into.
+	Target *Project
+	// Goal is the goal of the target after the merge; it is nil if neither project had one.
+	Goal                  *Goal
+	StudyLogs             int
+	OtherMembersStudyLogs int
+	TrashedStudyLogs      int
+	Notes                 int
+	OtherMembersNotes     int
+	TrashedNotes          int
+	Milestones            int
+	// Goals is the number of goals of the other project that were merged into the target's goal.
+	Goals int
+}
+
+// MergeGoals returns the goal the target project has after the source project is merged into it.
+// If only one of the projects has a goal, that goal is kept; if both do, the strategy decides
+// whether the target's goal is kept, replaced by the source's, or the two are summed over the
+// period covering both. It returns nil if neither project has a goal.
+func MergeGoals(targetProjectID string, target, source *Goal, strategy GoalMergeStrategy, now time.Time) (*Goal, error) {
+	if source == nil {
+		return target, nil
+	}
+	if target == nil {
+		merged := *source
+		merged.ProjectID = targetProjectID
+		merged.UpdatedAt = now
+		return &merged, nil
+	}
+	merged := *target
+	merged.UpdatedAt = now
+	switch strategy {
+	case GoalMergeKeepTarget:
+		return target, nil
+	case GoalMergeKeepSource:
+		merged.TargetMinutesPerWeek = source.TargetMinutesPerWeek
+		merged.StartDate = source.StartDate
+		merged.EndDate = source.EndDate
+	case GoalMergeSum:
+		merged.TargetMinutesPerWeek += source.TargetMinutesPerWeek
+		if source.StartDate.Before(merged.StartDate) {
+			merged.StartDate = source.StartDate
+		}
+		if merged.EndDate != nil && (source.EndDate == nil || source.EndDate.After(*merged.EndDate)) {
+			merged.EndDate = source.EndDate
+		}
+	default:
+		return nil, ErrValidation("both projects have a goal; goal strategy must be keep-target, keep-source or sum")
+	}
+	return &merged, nil
+}
@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestParseGoalMergeStrategy(t *testing.T) {
+	for _, s := range []string{"", "keep-target", "keep-source", "sum"} {
+		if _, err := domain.ParseGoalMergeStrategy(s); err != nil {
+			t.Errorf("unexpected error for %q: %v", s, err)
+		}
+	}
+	if _, err := domain.ParseGoalMergeStrategy("average"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestMergeGoals(t *testing.T) {
+	now := time.Now()
+	day := func(d int) time.Time { return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC) }
+	end := day(31)
+	target, _ := domain.NewGoal("goal-1", "user-1", "proj-1", 300, day(10), &end)
+	source, _ := domain.NewGoal("goal-2", "user-1", "proj-2", 120, day(5), nil)
+
+	if merged, err := domain.MergeGoals("proj-1", nil, nil, "", now); err != nil || merged != nil {
+		t.Errorf("expected no goal, got %+v, %v", merged, err)
+	}
+	if merged, err := domain.MergeGoals("proj-1", target, nil, "", now); err != nil || merged != target {
+		t.Errorf("expected the target's goal, got %+v, %v", merged, err)
+	}
+	merged, err := domain.MergeGoals("proj-1", nil, source, "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.ID != "goal-2" || merged.ProjectID != "proj-1" || source.ProjectID != "proj-2" {
+		t.Errorf("expected the source's goal moved to the target, got %+v", merged)
+	}
+
+	if _, err := domain.MergeGoals("proj-1", target, source, "", now); !domain.IsValidation(err) {
+		t.Errorf("expected validation error without a strategy, got: %v", err)
+	}
+	if merged, _ := domain.MergeGoals("proj-1", target, source, domain.GoalMergeKeepTarget, now); merged != target {
+		t.Errorf("expected the target's goal, got %+v", merged)
+	}
+	merged, _ = domain.MergeGoals("proj-1", target, source, domain.GoalMergeKeepSource, now)
+	if merged.ID != "goal-1" || merged.TargetMinutesPerWeek != 120 || !merged.StartDate.Equal(day(5)) || merged.EndDate != nil {
+		t.Errorf("expected the target's goal with the source's values, got %+v", merged)
+	}
+	merged, _ = domain.MergeGoals("proj-1", target, source, domain.GoalMergeSum, now)
+	if merged.TargetMinutesPerWeek != 420 || !merged.StartDate.Equal(day(5)) || merged.EndDate != nil {
+		t.Errorf("expected the summed goal over both periods, got %+v", merged)
+	}
+	if target.TargetMinutesPerWeek != 300 {
+		t.Error("expected the target's goal to be left alone")
+	}
+}
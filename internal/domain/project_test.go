@@ -151,3 +151,107 @@ func TestProject_UpdateName_TooLong(t *testing.T) {
 		t.Errorf("expected name to remain 'Math', got '%s'", project.Name)
 	}
 }
+
+func TestProject_ArchiveAndUnarchive(t *testing.T) {
+	project, err := domain.NewProject("proj-1", "user-1", "Math")
+	if err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	if project.IsArchived() {
+		t.Fatal("expected a new project not to be archived")
+	}
+	if err := project.CheckRecordable(); err != nil {
+		t.Errorf("expected an active project to be recordable, got: %v", err)
+	}
+
+	now := time.Date(2024, 6, 15, 14, 30, 0, 0, time.UTC)
+	if err := project.Archive(now); err != nil {
+		t.Fatalf("unexpected error archiving: %v", err)
+	}
+	if !project.IsArchived() || !project.ArchivedAt.Equal(now) {
+		t.Errorf("expected ArchivedAt %v, got %v", now, project.ArchivedAt)
+	}
+	if !project.UpdatedAt.Equal(now) {
+		t.Errorf("expected UpdatedAt %v, got %v", now, project.UpdatedAt)
+	}
+	if err := project.Archive(now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict archiving twice, got: %v", err)
+	}
+	if err := project.CheckRecordable(); !domain.IsConflict(err) {
+		t.Errorf("expected conflict recording in an archived project, got: %v", err)
+	}
+
+	if err := project.Unarchive(now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error unarchiving: %v", err)
+	}
+	if project.IsArchived() {
+		t.Error("expected project not to be archived after Unarchive")
+	}
+	if err := project.Unarchive(now); !domain.IsConflict(err) {
+		t.Errorf("expected conflict unarchiving an active project, got: %v", err)
+	}
+}
+
+func TestNewProject_DefaultStatus(t *testing.T) {
+	project, err := domain.NewProject("proj-1", "user-1", "Math")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project.Status != domain.ProjectStatusActive {
+		t.Errorf("expected status active, got %s", project.Status)
+	}
+}
+
+func TestProject_UpdateDetails(t *testing.T) {
+	target := domain.Date{Year: 2025, Month: time.March, Day: 31}
+	valid := domain.ProjectDetails{
+		Description: "Solutions Architect Associate",
+		Color:       "#FF9900",
+		Icon:        "☁️",
+		Status:      domain.ProjectStatusPaused,
+		TargetDate:  &target,
+	}
+	project, _ := domain.NewProject("proj-1", "user-1", "AWS")
+	if err := project.UpdateDetails(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project.Description != valid.Description || project.Color != "#FF9900" || project.Icon != "☁️" {
+		t.Errorf("unexpected details: %+v", project)
+	}
+	if project.Status != domain.ProjectStatusPaused || *project.TargetDate != target {
+		t.Errorf("expected paused with target %v, got %s and %v", target, project.Status, project.TargetDate)
+	}
+
+	tests := []struct {
+		name    string
+		details domain.ProjectDetails
+	}{
+		{"description too long", domain.ProjectDetails{Description: strings.Repeat("a", 2001)}},
+		{"color name", domain.ProjectDetails{Color: "orange"}},
+		{"short hex color", domain.ProjectDetails{Color: "#f90"}},
+		{"icon too long", domain.ProjectDetails{Icon: strings.Repeat("x", 33)}},
+		{"unknown status", domain.ProjectDetails{Status: "finished"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := project.UpdateDetails(tt.details); !domain.IsValidation(err) {
+				t.Errorf("expected validation error, got: %v", err)
+			}
+			if project.Color != "#FF9900" {
+				t.Error("expected the details to be unchanged after a rejected update")
+			}
+		})
+	}
+}
+
+func TestParseProjectStatus(t *testing.T) {
+	if status, err := domain.ParseProjectStatus(""); err != nil || status != domain.ProjectStatusActive {
+		t.Errorf("expected an empty status to be active, got %q, %v", status, err)
+	}
+	if status, err := domain.ParseProjectStatus("done"); err != nil || status != domain.ProjectStatusDone {
+		t.Errorf("expected done, got %q, %v", status, err)
+	}
+	if _, err := domain.ParseProjectStatus("Done"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
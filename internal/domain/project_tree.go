@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxProjectDepth is the maximum number of levels of a project hierarchy, counting the top-level project.
+const MaxProjectDepth = 4
+
+// ProjectTree is the hierarchy of a user's projects.
+type ProjectTree struct {
+	byID     map[string]*Project
+	children map[string][]*Project
+}
+
+// NewProjectTree builds the hierarchy of the given projects. A project whose parent is not
+// among them is treated as a top-level project.
+func NewProjectTree(projects []*Project) *ProjectTree {
+	t := &ProjectTree{
+		byID:     make(map[string]*Project, len(projects)),
+		children: make(map[string][]*Project),
+	}
+	for _, p := range projects {
+		t.byID[p.ID] = p
+	}
+	for _, p := range projects {
+		if _, ok := t.byID[p.ParentID]; ok {
+			t.children[p.ParentID] = append(t.children[p.ParentID], p)
+		}
+	}
+	return t
+}
+
+// Children returns the direct sub-projects of the project.
+func (t *ProjectTree) Children(id string) []*Project {
+	return t.children[id]
+}
+
+// Subtree returns the IDs of the project and all of its descendants.
+func (t *ProjectTree) Subtree(id string) []string {
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			ids = append(ids, child.ID)
+		}
+	}
+	return ids
+}
+
+// Depth returns the level of the project in the hierarchy, 1 for a top-level project.
+func (t *ProjectTree) Depth(id string) int {
+	return len(t.ancestors(id)) + 1
+}
+
+// ancestors returns the IDs of the project's parent, its parent's parent and so on up to the top level.
+func (t *ProjectTree) ancestors(id string) []string {
+	var ids []string
+	// The bound stops the walk should the stored hierarchy ever contain a cycle.
+	for p := t.byID[id]; p != nil && len(ids) < len(t.byID); p = t.byID[p.ParentID] {
+		if _, ok := t.byID[p.ParentID]; !ok {
+			break
+		}
+		ids = append(ids, p.ParentID)
+	}
+	return ids
+}
+
+// height returns the number of levels of the project's subtree, 1 for a project without sub-projects.
+func (t *ProjectTree) height(id string) int {
+	height := 0
+	for _, child := range t.children[id] {
+		height = max(height, t.height(child.ID))
+	}
+	return height + 1
+}
+
+// CheckParent checks that the project can be placed under parentID, which may be empty to make
+// it a top-level project. The project does not need to be in the tree yet. It returns a not
+// found error if the parent is not one of the tree's projects, and a validation error if the
+// move would make the project its own ancestor or nest projects deeper than MaxProjectDepth.
+func (t *ProjectTree) CheckParent(id, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	if _, ok := t.byID[parentID]; !ok {
+		return ErrNotFound("parent project")
+	}
+	ancestors := t.ancestors(parentID)
+	if parentID == id || slices.Contains(ancestors, id) {
+		return ErrValidation("a project cannot be placed under itself or one of its sub-projects")
+	}
+	if len(ancestors)+1+t.height(id) > MaxProjectDepth {
+		return ErrValidation(fmt.Sprintf("projects can be nested at most %d levels deep", MaxProjectDepth))
+	}
+	return nil
+}
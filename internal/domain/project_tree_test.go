@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+// newTestProjectTree builds aws > iam > policies, aws > vpc and a separate go project.
+func newTestProjectTree() *domain.ProjectTree {
+	return domain.NewProjectTree([]*domain.Project{
+		{ID: "aws", Name: "AWS certification"},
+		{ID: "iam", Name: "IAM", ParentID: "aws"},
+		{ID: "policies", Name: "Policies", ParentID: "iam"},
+		{ID: "vpc", Name: "VPC", ParentID: "aws"},
+		{ID: "go", Name: "Go"},
+	})
+}
+
+func TestProjectTree_Hierarchy(t *testing.T) {
+	tree := newTestProjectTree()
+
+	subtree := tree.Subtree("aws")
+	slices.Sort(subtree)
+	if !slices.Equal(subtree, []string{"aws", "iam", "policies", "vpc"}) {
+		t.Errorf("unexpected subtree of aws: %v", subtree)
+	}
+	if got := tree.Subtree("go"); !slices.Equal(got, []string{"go"}) {
+		t.Errorf("expected a leaf's subtree to be itself, got %v", got)
+	}
+	if got := len(tree.Children("aws")); got != 2 {
+		t.Errorf("expected 2 children of aws, got %d", got)
+	}
+	if got := tree.Depth("aws"); got != 1 {
+		t.Errorf("expected depth 1 for a top-level project, got %d", got)
+	}
+	if got := tree.Depth("policies"); got != 3 {
+		t.Errorf("expected depth 3, got %d", got)
+	}
+}
+
+func TestProjectTree_UnknownParentIsTopLevel(t *testing.T) {
+	tree := domain.NewProjectTree([]*domain.Project{{ID: "iam", ParentID: "trashed"}})
+	if got := tree.Depth("iam"); got != 1 {
+		t.Errorf("expected a project with a missing parent to be top-level, got depth %d", got)
+	}
+}
+
+func TestProjectTree_CheckParent(t *testing.T) {
+	tree := newTestProjectTree()
+
+	tests := []struct {
+		name     string
+		id       string
+		parentID string
+		check    func(error) bool
+	}{
+		{"top level", "iam", "", func(err error) bool { return err == nil }},
+		{"new sub-project", "new", "policies", func(err error) bool { return err == nil }},
+		{"move subtree", "iam", "go", func(err error) bool { return err == nil }},
+		{"itself", "aws", "aws", domain.IsValidation},
+		{"own descendant", "aws", "policies", domain.IsValidation},
+		{"deepest level", "go", "policies", func(err error) bool { return err == nil }},
+		{"unknown parent", "go", "missing", domain.IsNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tree.CheckParent(tt.id, tt.parentID); !tt.check(err) {
+				t.Errorf("unexpected result: %v", err)
+			}
+		})
+	}
+
+	deep := domain.NewProjectTree([]*domain.Project{
+		{ID: "l1"}, {ID: "l2", ParentID: "l1"}, {ID: "l3", ParentID: "l2"}, {ID: "l4", ParentID: "l3"},
+		{ID: "x"}, {ID: "y", ParentID: "x"},
+	})
+	if err := deep.CheckParent("l5", "l4"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for a fifth level, got: %v", err)
+	}
+	if err := deep.CheckParent("x", "l3"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error when a moved subtree reaches a fifth level, got: %v", err)
+	}
+}
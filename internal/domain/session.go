@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// maxUserAgentLength bounds the device description stored with a session, in bytes.
+const maxUserAgentLength = 512
+
+// Session represents a login on one device. It holds the hash of the current refresh token;
+// every refresh rotates the token, so all tokens issued for a session form one family.
+type Session struct {
+	ID               string
+	UserID           string
+	RefreshTokenHash string
+	UserAgent        string
+	IPAddress        string
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+}
+
+// NewSession creates a new Session entity that expires after ttl unless refreshed.
+func NewSession(id, userID, refreshTokenHash, userAgent, ipAddress string, ttl time.Duration) (*Session, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if refreshTokenHash == "" {
+		return nil, ErrValidation("refresh token hash is required")
+	}
+	if len(userAgent) > maxUserAgentLength {
+		// Cut at the start of a character so a multi-byte one is not split into invalid UTF-8.
+		end := maxUserAgentLength
+		for end > 0 && !utf8.RuneStart(userAgent[end]) {
+			end--
+		}
+		userAgent = userAgent[:end]
+	}
+	now := time.Now()
+	return &Session{
+		ID:               id,
+		UserID:           userID,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(ttl),
+	}, nil
+}
+
+// ReconstructSession reconstructs a Session entity from existing data.
+func ReconstructSession(id, userID, refreshTokenHash, userAgent, ipAddress string, createdAt, lastUsedAt, expiresAt time.Time) *Session {
+	return &Session{
+		ID:               id,
+		UserID:           userID,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		CreatedAt:        createdAt,
+		LastUsedAt:       lastUsedAt,
+		ExpiresAt:        expiresAt,
+	}
+}
+
+// IsExpired reports whether the session has expired at the given time.
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Rotate replaces the refresh token of the session and extends its lifetime by ttl.
+func (s *Session) Rotate(refreshTokenHash, ipAddress string, ttl time.Duration) {
+	now := time.Now()
+	s.RefreshTokenHash = refreshTokenHash
+	s.IPAddress = ipAddress
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(ttl)
+}
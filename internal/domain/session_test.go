@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestNewSession_Valid(t *testing.T) {
+	session, err := domain.NewSession("s1", "user-1", "hash", "agent", "127.0.0.1", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.ExpiresAt.Sub(session.CreatedAt) != time.Hour {
+		t.Errorf("expected session to expire after 1h, got %v", session.ExpiresAt.Sub(session.CreatedAt))
+	}
+	if session.IsExpired(time.Now()) {
+		t.Error("expected new session not to be expired")
+	}
+}
+
+func TestNewSession_MissingHash(t *testing.T) {
+	_, err := domain.NewSession("s1", "user-1", "", "agent", "127.0.0.1", time.Hour)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestNewSession_TruncatesUserAgent(t *testing.T) {
+	session, err := domain.NewSession("s1", "user-1", "hash", strings.Repeat("a", 1000), "", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(session.UserAgent) != 512 {
+		t.Errorf("expected user agent truncated to 512, got %d", len(session.UserAgent))
+	}
+}
+
+func TestNewSession_TruncatesMultiByteUserAgent(t *testing.T) {
+	// 171 three-byte characters take 513 bytes, so the limit falls inside the last one.
+	session, err := domain.NewSession("s1", "user-1", "hash", strings.Repeat("端", 171), "", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !utf8.ValidString(session.UserAgent) {
+		t.Errorf("expected truncated user agent to be valid UTF-8, got %q", session.UserAgent)
+	}
+	if session.UserAgent != strings.Repeat("端", 170) {
+		t.Errorf("expected user agent truncated to 170 characters, got %d bytes", len(session.UserAgent))
+	}
+}
+
+func TestSession_Rotate(t *testing.T) {
+	session := &domain.Session{RefreshTokenHash: "old", ExpiresAt: time.Now().Add(time.Minute)}
+
+	session.Rotate("new", "10.0.0.1", time.Hour)
+
+	if session.RefreshTokenHash != "new" {
+		t.Errorf("expected hash 'new', got '%s'", session.RefreshTokenHash)
+	}
+	if session.IPAddress != "10.0.0.1" {
+		t.Errorf("expected IP '10.0.0.1', got '%s'", session.IPAddress)
+	}
+	if session.ExpiresAt.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("expected expiry to be extended, got %v", session.ExpiresAt)
+	}
+}
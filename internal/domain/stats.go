@@ -1,19 +1,50 @@
 package domain
 
-import "time"
-
 // WeeklyStats represents study statistics for a specific week.
 type WeeklyStats struct {
-	WeekStart    time.Time
-	Projects     []ProjectWeeklyStats
-	TotalMinutes int
+	WeekStart Date
+	// Timezone is the IANA time zone whose midnights bound the week.
+	Timezone       string
+	Projects       []ProjectWeeklyStats
+	TotalMinutes   int
+	TotalPomodoros int
+	// OverdueMilestones are the milestones of the listed projects that were overdue by the end of
+	// the week, or by now for the current week, ordered by due date.
+	OverdueMilestones []OverdueMilestone
 }
 
 // ProjectWeeklyStats represents study statistics for a specific project in a week.
+// TotalMinutes and Pomodoros count the logs of the project itself; the subtree totals add
+// those of all of its sub-projects.
 type ProjectWeeklyStats struct {
 	ProjectID            string
 	ProjectName          string
+	ParentID             string
 	TotalMinutes         int
+	Pomodoros            int
+	SubtreeMinutes       int
+	SubtreePomodoros     int
 	TargetMinutesPerWeek int
-	AchievementRate      float64
+	// AchievementRate compares the subtree minutes with the target.
+	AchievementRate float64
+	// Activities splits the project's minutes by activity type; activity types the project
+	// was not studied with are left out.
+	Activities []ActivityWeeklyStats
+}
+
+// ActivityWeeklyStats represents the minutes spent on one activity type of a project in a week.
+type ActivityWeeklyStats struct {
+	// ActivityTypeID is empty for the logs that have no activity type.
+	ActivityTypeID   string
+	ActivityTypeName string
+	TotalMinutes     int
+}
+
+// OverdueMilestone is a milestone whose due date passed without it being completed.
+type OverdueMilestone struct {
+	MilestoneID string
+	ProjectID   string
+	ProjectName string
+	Title       string
+	DueDate     Date
 }
@@ -2,15 +2,33 @@ package domain
 
 import "time"
 
+// MaxStudyMinutesPerDay caps the total minutes of the study logs that start on one calendar day
+// in the user's timezone.
+const MaxStudyMinutesPerDay = 1440
+
 // StudyLog represents a record of study time for a specific project.
 type StudyLog struct {
 	ID        string
 	UserID    string
 	ProjectID string
-	StudiedAt time.Time
-	Minutes   int
-	Note      string
+	// ActivityTypeID is the ID of the activity type from the user's catalogue; it is empty
+	// when the log has none.
+	ActivityTypeID string
+	StudiedAt      time.Time
+	Minutes        int
+	Note           string
+	// Pomodoros is the number of completed pomodoros the log records; it is 0 unless
+	// the log was recorded by a pomodoro session.
+	Pomodoros int
+	// ImportKey identifies the source row of an imported log so the same row is not imported twice.
+	// It is empty for logs that were not imported and is only written, never read back.
+	ImportKey string
 	CreatedAt time.Time
+	UpdatedAt time.Time
+	// DeletedAt is when the log was moved to the trash; it is nil unless the log is trashed.
+	DeletedAt *time.Time
+	// Notes are the notes written during the session, ordered by title.
+	Notes []LinkedNote
 }
 
 // NewStudyLog creates a new StudyLog entity.
@@ -18,12 +36,10 @@ func NewStudyLog(id, userID, projectID string, studiedAt time.Time, minutes int,
 	if userID == "" {
 		return nil, ErrValidation("user ID is required")
 	}
-	if projectID == "" {
-		return nil, ErrValidation("project ID is required")
-	}
-	if err := validateMinutes(minutes); err != nil {
+	if err := validateStudyLog(projectID, minutes); err != nil {
 		return nil, err
 	}
+	now := time.Now()
 	return &StudyLog{
 		ID:        id,
 		UserID:    userID,
@@ -31,21 +47,82 @@ func NewStudyLog(id, userID, projectID string, studiedAt time.Time, minutes int,
 		StudiedAt: studiedAt,
 		Minutes:   minutes,
 		Note:      note,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}, nil
 }
 
+// StudyLogBatchResult reports the outcome of creating one study log of a batch.
+type StudyLogBatchResult struct {
+	// Log is the created study log; nil when the item failed.
+	Log *StudyLog
+	// Err explains why the item was not created; nil when it was.
+	Err *Error
+}
+
 // ReconstructStudyLog reconstructs a StudyLog entity from existing data.
-func ReconstructStudyLog(id, userID, projectID string, studiedAt time.Time, minutes int, note string, createdAt time.Time) *StudyLog {
+func ReconstructStudyLog(id, userID, projectID, activityTypeID string, studiedAt time.Time, minutes int, note string, pomodoros int, createdAt, updatedAt time.Time) *StudyLog {
 	return &StudyLog{
-		ID:        id,
-		UserID:    userID,
-		ProjectID: projectID,
-		StudiedAt: studiedAt,
-		Minutes:   minutes,
-		Note:      note,
-		CreatedAt: createdAt,
+		ID:             id,
+		UserID:         userID,
+		ProjectID:      projectID,
+		ActivityTypeID: activityTypeID,
+		StudiedAt:      studiedAt,
+		Minutes:        minutes,
+		Note:           note,
+		Pomodoros:      pomodoros,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// StudyLogMinutes returns the length of a session given either its minutes or its end time.
+// The end time is rounded to whole minutes after the start; when both are given they must agree.
+// Zero minutes means none were given.
+func StudyLogMinutes(studiedAt time.Time, minutes int, endedAt *time.Time) (int, error) {
+	if endedAt == nil {
+		return minutes, nil
+	}
+	derived := int(endedAt.Sub(studiedAt).Round(time.Minute) / time.Minute)
+	if derived <= 0 {
+		return 0, ErrValidation("endedAt must be at least a minute after studiedAt")
+	}
+	if minutes != 0 && minutes != derived {
+		return 0, ErrValidation("minutes does not match the time between studiedAt and endedAt")
+	}
+	return derived, nil
+}
+
+// EndedAt returns when the study session ended.
+func (l *StudyLog) EndedAt() time.Time {
+	return l.StudiedAt.Add(time.Duration(l.Minutes) * time.Minute)
+}
+
+// Overlaps reports whether the sessions of two study logs share any time.
+// A session that starts as the other ends does not overlap it.
+func (l *StudyLog) Overlaps(other *StudyLog) bool {
+	return l.StudiedAt.Before(other.EndedAt()) && other.StudiedAt.Before(l.EndedAt())
+}
+
+// Update updates the project, activity type, time, duration, and note of the study log.
+func (l *StudyLog) Update(projectID, activityTypeID string, studiedAt time.Time, minutes int, note string) error {
+	if err := validateStudyLog(projectID, minutes); err != nil {
+		return err
+	}
+	l.ProjectID = projectID
+	l.ActivityTypeID = activityTypeID
+	l.StudiedAt = studiedAt
+	l.Minutes = minutes
+	l.Note = note
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+func validateStudyLog(projectID string, minutes int) error {
+	if projectID == "" {
+		return ErrValidation("project ID is required")
 	}
+	return validateMinutes(minutes)
 }
 
 func validateMinutes(minutes int) error {
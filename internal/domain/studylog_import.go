@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StudyLogImportResult reports the outcome of importing study logs from a file.
+type StudyLogImportResult struct {
+	DryRun bool
+	// Rows is the number of data rows read, excluding the header.
+	Rows       int
+	Imported   int
+	Duplicates int
+	// CreatedProjects lists the projects created for unknown project names
+	// (or that would be created, in a dry run).
+	CreatedProjects []string
+	Errors          []StudyLogImportError
+}
+
+// StudyLogImportError describes why a single row was not imported.
+type StudyLogImportError struct {
+	// Line is the line number of the row in the file, counting the header as line 1.
+	Line    int
+	Message string
+}
+
+// StudyLogImportKey returns the key used to recognise a row that has already been imported.
+// A non-empty external ID from the source system is used as is; otherwise the key is a hash
+// of the row's content, so re-importing an unchanged export skips every row.
+func StudyLogImportKey(externalID string, studiedAt time.Time, minutes int, projectName, note string) string {
+	if externalID = strings.TrimSpace(externalID); externalID != "" {
+		return "ext:" + externalID
+	}
+	h := sha256.New()
+	for _, part := range []string{
+		studiedAt.UTC().Format(time.RFC3339),
+		strconv.Itoa(minutes),
+		strings.ToLower(strings.TrimSpace(projectName)),
+		strings.TrimSpace(note),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// ParseDurationMinutes parses a duration given as whole minutes ("90") or as
+// hours and minutes ("1:30" or "01:30:00"). Seconds are rounded to the nearest minute.
+func ParseDurationMinutes(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrValidation("duration is required")
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, ErrValidation("invalid duration: " + s)
+	}
+	values := make([]int, len(parts))
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 || (i > 0 && (v > 59 || len(p) != 2)) {
+			return 0, ErrValidation("invalid duration: " + s)
+		}
+		values[i] = v
+	}
+	switch len(values) {
+	case 1:
+		return values[0], nil
+	case 2:
+		return values[0]*60 + values[1], nil
+	default:
+		return (values[0]*3600 + values[1]*60 + values[2] + 30) / 60, nil
+	}
+}
@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestStudyLogImportKey_ExternalID(t *testing.T) {
+	at := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	key := domain.StudyLogImportKey(" toggl-42 ", at, 30, "Math", "")
+	if key != "ext:toggl-42" {
+		t.Errorf("expected 'ext:toggl-42', got '%s'", key)
+	}
+	if other := domain.StudyLogImportKey("toggl-42", at.Add(time.Hour), 45, "Physics", "x"); other != key {
+		t.Error("expected the external ID alone to determine the key")
+	}
+}
+
+func TestStudyLogImportKey_Content(t *testing.T) {
+	at := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	key := domain.StudyLogImportKey("", at, 30, "Math", "limits")
+	if !strings.HasPrefix(key, "sha256:") {
+		t.Fatalf("expected a content hash, got '%s'", key)
+	}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	if same := domain.StudyLogImportKey("", at.In(tokyo), 30, " math ", "limits"); same != key {
+		t.Error("expected the same key for the same instant and project name in any case")
+	}
+	if other := domain.StudyLogImportKey("", at, 31, "Math", "limits"); other == key {
+		t.Error("expected a different key for different minutes")
+	}
+}
+
+func TestParseDurationMinutes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"90", 90},
+		{" 45 ", 45},
+		{"1:30", 90},
+		{"0:05", 5},
+		{"01:30:00", 90},
+		{"00:24:29", 24},
+		{"00:24:30", 25},
+	}
+	for _, tt := range tests {
+		got, err := domain.ParseDurationMinutes(tt.in)
+		if err != nil {
+			t.Errorf("ParseDurationMinutes(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDurationMinutes(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDurationMinutes_Invalid(t *testing.T) {
+	for _, in := range []string{"", "abc", "-5", "1:5", "1:60", "1:00:00:00", "1.5"} {
+		_, err := domain.ParseDurationMinutes(in)
+		if !domain.IsValidation(err) {
+			t.Errorf("ParseDurationMinutes(%q): expected validation error, got: %v", in, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// StudyLogRevision records the values a study log had before an edit.
+type StudyLogRevision struct {
+	ID         string
+	StudyLogID string
+	// EditedBy is the user who made the edit; it is empty if that user has since been deleted.
+	EditedBy  string
+	ProjectID string
+	// ActivityTypeID is empty if the log had no activity type or it has since been deleted.
+	ActivityTypeID string
+	StudiedAt      time.Time
+	Minutes        int
+	Note           string
+	// CreatedAt is when the edit was made.
+	CreatedAt time.Time
+}
+
+// NewStudyLogRevision creates a revision holding the current values of the study log,
+// to be saved when the given user edits it.
+func NewStudyLogRevision(id string, log *StudyLog, editedBy string) *StudyLogRevision {
+	return &StudyLogRevision{
+		ID:             id,
+		StudyLogID:     log.ID,
+		EditedBy:       editedBy,
+		ProjectID:      log.ProjectID,
+		ActivityTypeID: log.ActivityTypeID,
+		StudiedAt:      log.StudiedAt,
+		Minutes:        log.Minutes,
+		Note:           log.Note,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// ReconstructStudyLogRevision reconstructs a StudyLogRevision entity from existing data.
+func ReconstructStudyLogRevision(id, studyLogID, editedBy, projectID, activityTypeID string, studiedAt time.Time, minutes int, note string, createdAt time.Time) *StudyLogRevision {
+	return &StudyLogRevision{
+		ID:             id,
+		StudyLogID:     studyLogID,
+		EditedBy:       editedBy,
+		ProjectID:      projectID,
+		ActivityTypeID: activityTypeID,
+		StudiedAt:      studiedAt,
+		Minutes:        minutes,
+		Note:           note,
+		CreatedAt:      createdAt,
+	}
+}
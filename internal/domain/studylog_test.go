@@ -55,8 +55,9 @@ func TestNewStudyLog_EmptyUserID(t *testing.T) {
 func TestReconstructStudyLog(t *testing.T) {
 	studiedAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
 	createdAt := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 3, 16, 8, 0, 0, 0, time.UTC)
 
-	log := domain.ReconstructStudyLog("log-1", "user-1", "project-1", studiedAt, 90, "chapter 5", createdAt)
+	log := domain.ReconstructStudyLog("log-1", "user-1", "project-1", "type-1", studiedAt, 90, "chapter 5", 2, createdAt, updatedAt)
 
 	if log.ID != "log-1" {
 		t.Errorf("expected ID 'log-1', got '%s'", log.ID)
@@ -67,6 +68,9 @@ func TestReconstructStudyLog(t *testing.T) {
 	if log.ProjectID != "project-1" {
 		t.Errorf("expected ProjectID 'project-1', got '%s'", log.ProjectID)
 	}
+	if log.ActivityTypeID != "type-1" {
+		t.Errorf("expected ActivityTypeID 'type-1', got '%s'", log.ActivityTypeID)
+	}
 	if !log.StudiedAt.Equal(studiedAt) {
 		t.Errorf("expected StudiedAt %v, got %v", studiedAt, log.StudiedAt)
 	}
@@ -76,7 +80,108 @@ func TestReconstructStudyLog(t *testing.T) {
 	if log.Note != "chapter 5" {
 		t.Errorf("expected Note 'chapter 5', got '%s'", log.Note)
 	}
+	if log.Pomodoros != 2 {
+		t.Errorf("expected Pomodoros 2, got %d", log.Pomodoros)
+	}
 	if !log.CreatedAt.Equal(createdAt) {
 		t.Errorf("expected CreatedAt %v, got %v", createdAt, log.CreatedAt)
 	}
+	if !log.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("expected UpdatedAt %v, got %v", updatedAt, log.UpdatedAt)
+	}
+}
+
+func TestStudyLog_Update(t *testing.T) {
+	log, _ := domain.NewStudyLog("log-1", "user-1", "project-1", time.Now(), 60, "typo")
+	createdAt := log.CreatedAt
+	studiedAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	if err := log.Update("project-2", "type-1", studiedAt, 45, "fixed"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.ProjectID != "project-2" || log.ActivityTypeID != "type-1" || !log.StudiedAt.Equal(studiedAt) || log.Minutes != 45 || log.Note != "fixed" {
+		t.Errorf("unexpected study log after update: %+v", log)
+	}
+	if log.ID != "log-1" || !log.CreatedAt.Equal(createdAt) {
+		t.Error("expected ID and CreatedAt to be kept")
+	}
+	if log.UpdatedAt.Before(createdAt) {
+		t.Error("expected UpdatedAt to be refreshed")
+	}
+}
+
+func TestStudyLog_Update_Invalid(t *testing.T) {
+	log, _ := domain.NewStudyLog("log-1", "user-1", "project-1", time.Now(), 60, "")
+
+	for _, minutes := range []int{0, 1441} {
+		if err := log.Update("project-1", "", log.StudiedAt, minutes, ""); !domain.IsValidation(err) {
+			t.Errorf("expected validation error for %d minutes, got: %v", minutes, err)
+		}
+	}
+	if err := log.Update("", "", log.StudiedAt, 30, ""); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for empty project ID, got: %v", err)
+	}
+	if log.Minutes != 60 {
+		t.Errorf("expected a failed update to leave the log unchanged, got %d minutes", log.Minutes)
+	}
+}
+
+func TestNewStudyLogRevision(t *testing.T) {
+	studiedAt := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	log := domain.ReconstructStudyLog("log-1", "user-1", "project-1", "type-1", studiedAt, 90, "chapter 5", 0, studiedAt, studiedAt)
+
+	rev := domain.NewStudyLogRevision("rev-1", log, "user-2")
+	if err := log.Update("project-2", "", time.Now(), 30, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rev.StudyLogID != "log-1" || rev.EditedBy != "user-2" {
+		t.Errorf("unexpected revision: %+v", rev)
+	}
+	if rev.ProjectID != "project-1" || rev.ActivityTypeID != "type-1" || !rev.StudiedAt.Equal(studiedAt) || rev.Minutes != 90 || rev.Note != "chapter 5" {
+		t.Errorf("expected the revision to keep the previous values, got %+v", rev)
+	}
+}
+
+func TestStudyLogMinutes(t *testing.T) {
+	start := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90*time.Minute + 20*time.Second)
+
+	if minutes, err := domain.StudyLogMinutes(start, 45, nil); err != nil || minutes != 45 {
+		t.Errorf("expected 45 minutes, got %d (%v)", minutes, err)
+	}
+	if minutes, err := domain.StudyLogMinutes(start, 0, &end); err != nil || minutes != 90 {
+		t.Errorf("expected 90 minutes, got %d (%v)", minutes, err)
+	}
+	if _, err := domain.StudyLogMinutes(start, 60, &end); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for mismatched minutes, got: %v", err)
+	}
+	if _, err := domain.StudyLogMinutes(start, 0, &start); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for an empty session, got: %v", err)
+	}
+}
+
+func TestStudyLog_Overlaps(t *testing.T) {
+	start := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	log := domain.ReconstructStudyLog("log-1", "user-1", "project-1", "", start, 60, "", 0, start, start)
+
+	if !log.EndedAt().Equal(start.Add(time.Hour)) {
+		t.Errorf("expected the log to end an hour after it started, got %v", log.EndedAt())
+	}
+	tests := []struct {
+		start   time.Time
+		minutes int
+		want    bool
+	}{
+		{start.Add(30 * time.Minute), 60, true},
+		{start.Add(-30 * time.Minute), 45, true},
+		{start.Add(10 * time.Minute), 10, true},
+		{start.Add(time.Hour), 30, false},
+		{start.Add(-30 * time.Minute), 30, false},
+	}
+	for _, tt := range tests {
+		other := domain.ReconstructStudyLog("log-2", "user-1", "project-1", "", tt.start, tt.minutes, "", 0, start, start)
+		if got := log.Overlaps(other); got != tt.want {
+			t.Errorf("Overlaps(%v, %d min) = %v, want %v", tt.start, tt.minutes, got, tt.want)
+		}
+	}
 }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MaxTimerDuration is how long a timer may run before it is stopped automatically;
+// it equals the longest study log that can be recorded.
+const MaxTimerDuration = 1440 * time.Minute
+
+// Timer represents a user's live study timer. A user has at most one timer;
+// stopping it records the time it ran, excluding pauses, as a study log.
+type Timer struct {
+	UserID    string
+	ProjectID string
+	Note      string
+	StartedAt time.Time
+	// ResumedAt is when the timer last started running; it is nil while the timer is paused.
+	ResumedAt *time.Time
+	// Elapsed is the running time accumulated before ResumedAt.
+	Elapsed   time.Duration
+	UpdatedAt time.Time
+}
+
+// NewTimer creates a new running Timer entity.
+func NewTimer(userID, projectID, note string, now time.Time) (*Timer, error) {
+	if userID == "" {
+		return nil, ErrValidation("user ID is required")
+	}
+	if projectID == "" {
+		return nil, ErrValidation("project ID is required")
+	}
+	return &Timer{
+		UserID:    userID,
+		ProjectID: projectID,
+		Note:      note,
+		StartedAt: now,
+		ResumedAt: &now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ReconstructTimer reconstructs a Timer entity from existing data.
+func ReconstructTimer(userID, projectID, note string, startedAt time.Time, resumedAt *time.Time, elapsed time.Duration, updatedAt time.Time) *Timer {
+	return &Timer{
+		UserID:    userID,
+		ProjectID: projectID,
+		Note:      note,
+		StartedAt: startedAt,
+		ResumedAt: resumedAt,
+		Elapsed:   elapsed,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// IsRunning reports whether the timer is running rather than paused.
+func (t *Timer) IsRunning() bool {
+	return t.ResumedAt != nil
+}
+
+// Pause stops the timer from accumulating time until it is resumed.
+func (t *Timer) Pause(now time.Time) error {
+	if !t.IsRunning() {
+		return ErrConflict("timer is already paused")
+	}
+	t.Elapsed = t.ElapsedAt(now)
+	t.ResumedAt = nil
+	t.UpdatedAt = now
+	return nil
+}
+
+// Resume starts a paused timer again.
+func (t *Timer) Resume(now time.Time) error {
+	if t.IsRunning() {
+		return ErrConflict("timer is already running")
+	}
+	t.ResumedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// ElapsedAt returns the running time at the given time, excluding pauses and capped at MaxTimerDuration.
+func (t *Timer) ElapsedAt(now time.Time) time.Duration {
+	elapsed := t.Elapsed
+	if t.ResumedAt != nil && now.After(*t.ResumedAt) {
+		elapsed += now.Sub(*t.ResumedAt)
+	}
+	return min(elapsed, MaxTimerDuration)
+}
+
+// IsExpired reports whether the timer has reached MaxTimerDuration and must be stopped.
+func (t *Timer) IsExpired(now time.Time) bool {
+	return t.ElapsedAt(now) >= MaxTimerDuration
+}
+
+// MinutesAt returns the running time at the given time rounded to the nearest minute.
+func (t *Timer) MinutesAt(now time.Time) int {
+	return int(math.Round(t.ElapsedAt(now).Minutes()))
+}
@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestNewTimer_Valid(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	timer, err := domain.NewTimer("user-1", "project-1", "chapter 1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !timer.IsRunning() {
+		t.Error("expected a new timer to be running")
+	}
+	if got := timer.MinutesAt(now.Add(25 * time.Minute)); got != 25 {
+		t.Errorf("expected 25 minutes, got %d", got)
+	}
+}
+
+func TestNewTimer_EmptyProjectID(t *testing.T) {
+	_, err := domain.NewTimer("user-1", "", "", time.Now())
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestTimer_PauseAndResume(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	timer, _ := domain.NewTimer("user-1", "project-1", "", start)
+
+	if err := timer.Pause(start.Add(20 * time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timer.IsRunning() {
+		t.Error("expected the timer to be paused")
+	}
+	if got := timer.MinutesAt(start.Add(2 * time.Hour)); got != 20 {
+		t.Errorf("expected paused time not to count, got %d minutes", got)
+	}
+	if err := timer.Pause(start.Add(time.Hour)); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error when pausing twice, got: %v", err)
+	}
+
+	if err := timer.Resume(start.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := timer.Resume(start.Add(time.Hour)); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error when resuming twice, got: %v", err)
+	}
+	if got := timer.MinutesAt(start.Add(time.Hour + 10*time.Minute + 40*time.Second)); got != 31 {
+		t.Errorf("expected 31 minutes, got %d", got)
+	}
+}
+
+func TestTimer_Expiry(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	timer, _ := domain.NewTimer("user-1", "project-1", "", start)
+
+	if timer.IsExpired(start.Add(23 * time.Hour)) {
+		t.Error("expected a timer under 1440 minutes not to be expired")
+	}
+	if !timer.IsExpired(start.Add(24 * time.Hour)) {
+		t.Error("expected a timer at 1440 minutes to be expired")
+	}
+	if got := timer.MinutesAt(start.Add(30 * time.Hour)); got != 1440 {
+		t.Errorf("expected elapsed time to be capped at 1440 minutes, got %d", got)
+	}
+}
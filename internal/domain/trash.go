@@ -0,0 +1,9 @@
+package domain
+
+// Trash holds a user's trashed items. Notes and study logs trashed together with their project
+// are not listed separately; restoring the project brings them back.
+type Trash struct {
+	Projects  []*Project
+	Notes     []*Note
+	StudyLogs []*StudyLog
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// RecoveryCodeCount is the number of one-time recovery codes issued when TOTP is enabled.
+const RecoveryCodeCount = 10
+
+// MaxMFAAttempts is the number of codes that can be tried against one MFA challenge; after that
+// the user has to log in with their password again.
+const MaxMFAAttempts = 5
+
+// TwoFactor holds the optional TOTP (RFC 6238) second factor of a user.
+// Enrollment stores a secret first; the factor is only enabled once a code generated
+// from that secret has been confirmed.
+type TwoFactor struct {
+	TOTPSecret  string
+	TOTPEnabled bool
+	// TOTPLastStep is the time step of the last accepted code, so a code cannot be used twice.
+	TOTPLastStep       int64
+	RecoveryCodeHashes []string
+}
+
+// MFAChallenge is returned instead of tokens when a password login needs a second factor.
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// LoginResult is the outcome of a password login: either tokens or a second-factor challenge.
+type LoginResult struct {
+	Token        *AuthToken
+	MFAChallenge *MFAChallenge
+}
+
+// TOTPEnrollment holds what a user needs to add the account to an authenticator app.
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// StartTOTPEnrollment stores a new, unconfirmed TOTP secret for the user.
+func (u *User) StartTOTPEnrollment(secret string) error {
+	if u.TwoFactor.TOTPEnabled {
+		return ErrConflict("two-factor authentication is already enabled")
+	}
+	if secret == "" {
+		return ErrValidation("TOTP secret is required")
+	}
+	u.TwoFactor = TwoFactor{TOTPSecret: secret}
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// EnableTOTP turns on the second factor after the first code at step has been verified.
+func (u *User) EnableTOTP(step int64, recoveryCodeHashes []string) error {
+	if u.TwoFactor.TOTPEnabled {
+		return ErrConflict("two-factor authentication is already enabled")
+	}
+	if u.TwoFactor.TOTPSecret == "" {
+		return ErrValidation("two-factor enrollment has not been started")
+	}
+	u.TwoFactor.TOTPEnabled = true
+	u.TwoFactor.TOTPLastStep = step
+	u.TwoFactor.RecoveryCodeHashes = recoveryCodeHashes
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// DisableTOTP removes the second factor and all recovery codes.
+func (u *User) DisableTOTP() {
+	u.TwoFactor = TwoFactor{}
+	u.UpdatedAt = time.Now()
+}
+
+// AcceptTOTPStep records a verified code at step. It returns false if a code at the
+// same or a later step was already accepted, which means the code is being replayed.
+func (u *User) AcceptTOTPStep(step int64) bool {
+	if step <= u.TwoFactor.TOTPLastStep {
+		return false
+	}
+	u.TwoFactor.TOTPLastStep = step
+	u.UpdatedAt = time.Now()
+	return true
+}
+
+// UseRecoveryCode consumes the recovery code with the given hash. It returns false if
+// no unused recovery code matches.
+func (u *User) UseRecoveryCode(hash string) bool {
+	i := slices.Index(u.TwoFactor.RecoveryCodeHashes, hash)
+	if i < 0 {
+		return false
+	}
+	u.TwoFactor.RecoveryCodeHashes = slices.Delete(slices.Clone(u.TwoFactor.RecoveryCodeHashes), i, i+1)
+	u.UpdatedAt = time.Now()
+	return true
+}
@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+)
+
+func TestUser_EnableTOTP(t *testing.T) {
+	user := &domain.User{ID: "user-1"}
+	if err := user.EnableTOTP(1, nil); !domain.IsValidation(err) {
+		t.Errorf("expected validation error before enrollment, got: %v", err)
+	}
+	if err := user.StartTOTPEnrollment("SECRET"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := user.EnableTOTP(10, []string{"h1", "h2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !user.TwoFactor.TOTPEnabled {
+		t.Error("expected TOTP to be enabled")
+	}
+	if err := user.StartTOTPEnrollment("OTHER"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestUser_AcceptTOTPStep_RejectsReplay(t *testing.T) {
+	user := &domain.User{TwoFactor: domain.TwoFactor{TOTPLastStep: 10}}
+	if user.AcceptTOTPStep(10) {
+		t.Error("expected same step to be rejected")
+	}
+	if user.AcceptTOTPStep(9) {
+		t.Error("expected earlier step to be rejected")
+	}
+	if !user.AcceptTOTPStep(11) {
+		t.Error("expected later step to be accepted")
+	}
+}
+
+func TestUser_UseRecoveryCode(t *testing.T) {
+	user := &domain.User{TwoFactor: domain.TwoFactor{RecoveryCodeHashes: []string{"h1", "h2"}}}
+	if !user.UseRecoveryCode("h1") {
+		t.Fatal("expected recovery code to be accepted")
+	}
+	if user.UseRecoveryCode("h1") {
+		t.Error("expected recovery code to be single use")
+	}
+	if len(user.TwoFactor.RecoveryCodeHashes) != 1 {
+		t.Errorf("expected 1 remaining recovery code, got %d", len(user.TwoFactor.RecoveryCodeHashes))
+	}
+}
@@ -1,37 +1,102 @@
 package domain
 
-import "time"
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
 
 // User represents a system user.
 type User struct {
-	ID        string
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	Name         string
+	Email        string
+	PasswordHash string
+	TwoFactor    TwoFactor
+	Preferences  UserPreferences
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// UserDeletionSummary reports how many records were removed together with a user. StudyLogs and
+// Notes include those other members wrote in the user's projects, which are also counted separately.
+type UserDeletionSummary struct {
+	Projects              int
+	StudyLogs             int
+	OtherMembersStudyLogs int
+	Goals                 int
+	Notes                 int
+	OtherMembersNotes     int
 }
 
 // NewUser creates a new User entity.
-func NewUser(id, name string) (*User, error) {
+// The password must already be hashed; use ValidatePassword on the plain text beforehand.
+func NewUser(id, name, email, passwordHash string) (*User, error) {
 	if err := validateUserName(name); err != nil {
 		return nil, err
 	}
+	email = NormalizeEmail(email)
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+	if passwordHash == "" {
+		return nil, ErrValidation("password hash is required")
+	}
 	now := time.Now()
 	return &User{
-		ID:        id,
-		Name:      name,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:           id,
+		Name:         name,
+		Email:        email,
+		PasswordHash: passwordHash,
+		Preferences:  DefaultUserPreferences(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}, nil
 }
 
 // ReconstructUser reconstructs a User entity from existing data.
-func ReconstructUser(id, name string, createdAt, updatedAt time.Time) *User {
+func ReconstructUser(id, name, email, passwordHash string, twoFactor TwoFactor, preferences UserPreferences, createdAt, updatedAt time.Time) *User {
 	return &User{
-		ID:        id,
-		Name:      name,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:           id,
+		Name:         name,
+		Email:        email,
+		PasswordHash: passwordHash,
+		TwoFactor:    twoFactor,
+		Preferences:  preferences,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+	}
+}
+
+// Update renames the user and replaces their preferences.
+func (u *User) Update(name string, preferences UserPreferences) error {
+	if err := validateUserName(name); err != nil {
+		return err
+	}
+	if err := validateUserPreferences(preferences); err != nil {
+		return err
+	}
+	u.Name = name
+	u.Preferences = preferences
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// NormalizeEmail trims and lower-cases an email address so lookups are case-insensitive.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidatePassword checks a plain-text password before it is hashed.
+// The upper bound matches the 72-byte input limit of bcrypt.
+func ValidatePassword(password string) error {
+	if len(password) < 8 {
+		return ErrValidation("password must be at least 8 characters")
+	}
+	if len(password) > 72 {
+		return ErrValidation("password must be 72 bytes or less")
 	}
+	return nil
 }
 
 func validateUserName(name string) error {
@@ -43,3 +108,17 @@ func validateUserName(name string) error {
 	}
 	return nil
 }
+
+func validateEmail(email string) error {
+	if email == "" {
+		return ErrValidation("email is required")
+	}
+	if len(email) > 254 {
+		return ErrValidation("email must be 254 characters or less")
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrValidation("email is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// UserPreferences holds per-user settings that shape how study data is entered and reported.
+type UserPreferences struct {
+	// Timezone is an IANA time zone name such as "Asia/Tokyo".
+	Timezone  string
+	WeekStart time.Weekday
+	// Locale is a BCP 47 language tag such as "ja" or "en-US".
+	Locale string
+	// DefaultSessionMinutes is the length suggested for a new study session.
+	DefaultSessionMinutes int
+}
+
+// DefaultUserPreferences returns the preferences given to new users.
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		Timezone:              "UTC",
+		WeekStart:             time.Monday,
+		Locale:                "en",
+		DefaultSessionMinutes: 60,
+	}
+}
+
+// Location returns the time zone of the preferences, falling back to UTC if it cannot be loaded.
+func (p UserPreferences) Location() *time.Location {
+	loc, err := LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+func validateUserPreferences(p UserPreferences) error {
+	if _, err := LoadLocation(p.Timezone); err != nil {
+		return err
+	}
+	if p.WeekStart < time.Sunday || p.WeekStart > time.Saturday {
+		return ErrValidation("week start must be a day of the week")
+	}
+	if len(p.Locale) > 35 || !localePattern.MatchString(p.Locale) {
+		return ErrValidation("locale must be a language tag such as 'en' or 'ja-JP'")
+	}
+	if p.DefaultSessionMinutes <= 0 || p.DefaultSessionMinutes > 1440 {
+		return ErrValidation("default session length must be between 1 and 1440 minutes")
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func TestNewUser_Valid(t *testing.T) {
-	user, err := domain.NewUser("test-id", "Alice")
+	user, err := domain.NewUser("test-id", "Alice", "alice@example.com", "hashed")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -18,13 +18,16 @@ func TestNewUser_Valid(t *testing.T) {
 	if user.Name != "Alice" {
 		t.Errorf("expected Name 'Alice', got '%s'", user.Name)
 	}
+	if user.Email != "alice@example.com" {
+		t.Errorf("expected Email 'alice@example.com', got '%s'", user.Email)
+	}
 	if user.CreatedAt.IsZero() {
 		t.Error("expected CreatedAt to be set")
 	}
 }
 
 func TestNewUser_EmptyName(t *testing.T) {
-	_, err := domain.NewUser("test-id", "")
+	_, err := domain.NewUser("test-id", "", "alice@example.com", "hashed")
 	if err == nil {
 		t.Fatal("expected error for empty name")
 	}
@@ -38,7 +41,7 @@ func TestNewUser_NameTooLong(t *testing.T) {
 	for i := range longName {
 		longName[i] = 'a'
 	}
-	_, err := domain.NewUser("test-id", string(longName))
+	_, err := domain.NewUser("test-id", string(longName), "alice@example.com", "hashed")
 	if err == nil {
 		t.Fatal("expected error for long name")
 	}
@@ -51,7 +54,7 @@ func TestReconstructUser(t *testing.T) {
 	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
 	updatedAt := time.Date(2024, 6, 15, 14, 30, 0, 0, time.UTC)
 
-	user := domain.ReconstructUser("user-1", "Alice", createdAt, updatedAt)
+	user := domain.ReconstructUser("user-1", "Alice", "alice@example.com", "hashed", domain.TwoFactor{}, domain.DefaultUserPreferences(), createdAt, updatedAt)
 
 	if user.ID != "user-1" {
 		t.Errorf("expected ID 'user-1', got '%s'", user.ID)
@@ -66,3 +69,90 @@ func TestReconstructUser(t *testing.T) {
 		t.Errorf("expected UpdatedAt %v, got %v", updatedAt, user.UpdatedAt)
 	}
 }
+
+func TestNewUser_NormalizesEmail(t *testing.T) {
+	user, err := domain.NewUser("test-id", "Alice", "  Alice@Example.COM ", "hashed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Email != "alice@example.com" {
+		t.Errorf("expected normalized email 'alice@example.com', got '%s'", user.Email)
+	}
+}
+
+func TestNewUser_InvalidEmail(t *testing.T) {
+	for _, email := range []string{"", "not-an-email", "Alice <alice@example.com>"} {
+		_, err := domain.NewUser("test-id", "Alice", email, "hashed")
+		if err == nil {
+			t.Fatalf("expected error for email %q", email)
+		}
+		if !domain.IsValidation(err) {
+			t.Errorf("expected validation error for email %q, got: %v", email, err)
+		}
+	}
+}
+
+func TestNewUser_EmptyPasswordHash(t *testing.T) {
+	_, err := domain.NewUser("test-id", "Alice", "alice@example.com", "")
+	if err == nil {
+		t.Fatal("expected error for empty password hash")
+	}
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	if err := domain.ValidatePassword("correct horse"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := domain.ValidatePassword("short"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for short password, got: %v", err)
+	}
+	long := make([]byte, 73)
+	for i := range long {
+		long[i] = 'a'
+	}
+	if err := domain.ValidatePassword(string(long)); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for long password, got: %v", err)
+	}
+}
+
+func TestUser_Update(t *testing.T) {
+	user, _ := domain.NewUser("test-id", "Alice", "alice@example.com", "hashed")
+	prefs := domain.UserPreferences{Timezone: "America/New_York", WeekStart: time.Sunday, Locale: "en-US", DefaultSessionMinutes: 45}
+
+	if err := user.Update("Alicia", prefs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Name != "Alicia" || user.Preferences != prefs {
+		t.Errorf("expected user to be updated, got %+v", user)
+	}
+	if user.Preferences.Location().String() != "America/New_York" {
+		t.Errorf("expected location America/New_York, got %s", user.Preferences.Location())
+	}
+}
+
+func TestUser_Update_InvalidPreferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *domain.UserPreferences)
+	}{
+		{"unknown timezone", func(p *domain.UserPreferences) { p.Timezone = "Nowhere/City" }},
+		{"empty timezone", func(p *domain.UserPreferences) { p.Timezone = "" }},
+		{"week start out of range", func(p *domain.UserPreferences) { p.WeekStart = 7 }},
+		{"invalid locale", func(p *domain.UserPreferences) { p.Locale = "en_US!" }},
+		{"zero session length", func(p *domain.UserPreferences) { p.DefaultSessionMinutes = 0 }},
+		{"session length too long", func(p *domain.UserPreferences) { p.DefaultSessionMinutes = 1441 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, _ := domain.NewUser("test-id", "Alice", "alice@example.com", "hashed")
+			prefs := domain.DefaultUserPreferences()
+			tt.modify(&prefs)
+			if err := user.Update("Alice", prefs); !domain.IsValidation(err) {
+				t.Errorf("expected validation error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// opaqueTokenBytes is the amount of randomness in each generated token.
+const opaqueTokenBytes = 32
+
+type opaqueTokenGenerator struct{}
+
+// NewOpaqueTokenGenerator creates a new OpaqueTokenGenerator producing random URL-safe tokens
+// hashed with SHA-256. A fast hash is sufficient because the tokens carry 256 bits of entropy.
+func NewOpaqueTokenGenerator() port.OpaqueTokenGenerator {
+	return opaqueTokenGenerator{}
+}
+
+func (opaqueTokenGenerator) Generate() (string, error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (opaqueTokenGenerator) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,37 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
+)
+
+func TestOpaqueTokenGenerator_Generate(t *testing.T) {
+	g := auth.NewOpaqueTokenGenerator()
+
+	a, err := g.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := g.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == "" || a == b {
+		t.Errorf("expected distinct non-empty tokens, got %q and %q", a, b)
+	}
+}
+
+func TestOpaqueTokenGenerator_Hash(t *testing.T) {
+	g := auth.NewOpaqueTokenGenerator()
+
+	if g.Hash("token") != g.Hash("token") {
+		t.Error("expected hash to be deterministic")
+	}
+	if g.Hash("token") == g.Hash("other") {
+		t.Error("expected different tokens to hash differently")
+	}
+	if g.Hash("token") == "token" {
+		t.Error("expected token to be hashed")
+	}
+}
@@ -0,0 +1,41 @@
+// Package auth provides password hashing and token signing implementations.
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// ErrPasswordMismatch is returned when a password does not match its hash.
+var ErrPasswordMismatch = errors.New("password mismatch")
+
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a new PasswordHasher backed by bcrypt.
+// A cost of 0 uses bcrypt.DefaultCost.
+func NewBcryptHasher(cost int) port.PasswordHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &bcryptHasher{cost: cost}
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *bcryptHasher) Compare(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
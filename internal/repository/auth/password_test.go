@@ -0,0 +1,27 @@
+package auth_test
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
+)
+
+func TestBcryptHasher_HashAndCompare(t *testing.T) {
+	h := auth.NewBcryptHasher(bcrypt.MinCost)
+
+	hash, err := h.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash == "correct horse" {
+		t.Error("expected password to be hashed")
+	}
+	if err := h.Compare(hash, "correct horse"); err != nil {
+		t.Errorf("expected password to match, got: %v", err)
+	}
+	if err := h.Compare(hash, "wrong horse"); err == nil {
+		t.Error("expected mismatch for wrong password")
+	}
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// ErrInvalidToken is returned when a token is malformed, has a bad signature or is expired.
+var ErrInvalidToken = errors.New("invalid token")
+
+// jwtHeader is fixed because only HS256 tokens are issued and accepted.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// mfaChallengeTTL is how long a user has to enter the second factor after the password step.
+const mfaChallengeTTL = 5 * time.Minute
+
+// purposeMFA marks tokens that only allow completing a login with a second factor.
+const purposeMFA = "mfa"
+
+// deletionConfirmationTTL is how long a deletion preview can be confirmed before it has to be
+// previewed again.
+const deletionConfirmationTTL = 15 * time.Minute
+
+// purposeDeletion marks tokens that only allow confirming the deletion they were previewed for.
+const purposeDeletion = "delete"
+
+type tokenPayload struct {
+	Subject   string `json:"sub"`
+	SessionID string `json:"sid,omitempty"`
+	ID        string `json:"jti,omitempty"`
+	Purpose   string `json:"pur,omitempty"`
+	Digest    string `json:"dig,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type tokenManager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewTokenManager creates a new TokenManager issuing HS256-signed JWTs.
+func NewTokenManager(secret string, ttl time.Duration) port.TokenManager {
+	return &tokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (m *tokenManager) Issue(userID, sessionID string) (string, time.Time, error) {
+	return m.issue(tokenPayload{Subject: userID, SessionID: sessionID}, m.ttl)
+}
+
+func (m *tokenManager) Verify(token string) (*port.TokenClaims, error) {
+	payload, err := m.verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Purpose != "" {
+		return nil, ErrInvalidToken
+	}
+	return &port.TokenClaims{
+		UserID:    payload.Subject,
+		SessionID: payload.SessionID,
+		ExpiresAt: time.Unix(payload.ExpiresAt, 0),
+	}, nil
+}
+
+func (m *tokenManager) IssueMFAChallenge(userID, challengeID string) (string, time.Time, error) {
+	return m.issue(tokenPayload{Subject: userID, ID: challengeID, Purpose: purposeMFA}, mfaChallengeTTL)
+}
+
+func (m *tokenManager) VerifyMFAChallenge(token string) (string, string, error) {
+	payload, err := m.verify(token)
+	if err != nil {
+		return "", "", err
+	}
+	if payload.Purpose != purposeMFA || payload.ID == "" {
+		return "", "", ErrInvalidToken
+	}
+	return payload.Subject, payload.ID, nil
+}
+
+func (m *tokenManager) IssueDeletionConfirmation(userID, targetID, digest string) (string, time.Time, error) {
+	return m.issue(tokenPayload{Subject: userID, ID: targetID, Purpose: purposeDeletion, Digest: digest}, deletionConfirmationTTL)
+}
+
+func (m *tokenManager) VerifyDeletionConfirmation(token, userID string) (string, string, error) {
+	payload, err := m.verify(token)
+	if err != nil {
+		return "", "", err
+	}
+	if payload.Purpose != purposeDeletion || payload.Subject != userID || payload.ID == "" {
+		return "", "", ErrInvalidToken
+	}
+	return payload.ID, payload.Digest, nil
+}
+
+func (m *tokenManager) issue(payload tokenPayload, ttl time.Duration) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(ttl)
+	payload.IssuedAt = now.Unix()
+	payload.ExpiresAt = expiresAt.Unix()
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(raw)
+	return signingInput + "." + m.sign(signingInput), time.Unix(expiresAt.Unix(), 0), nil
+}
+
+func (m *tokenManager) verify(token string) (*tokenPayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, ErrInvalidToken
+	}
+	signingInput := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(m.sign(signingInput))) {
+		return nil, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var payload tokenPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if payload.Subject == "" || !m.now().Before(time.Unix(payload.ExpiresAt, 0)) {
+		return nil, ErrInvalidToken
+	}
+	return &payload, nil
+}
+
+func (m *tokenManager) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
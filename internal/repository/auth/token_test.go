@@ -0,0 +1,136 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
+)
+
+func TestTokenManager_IssueAndVerify(t *testing.T) {
+	m := auth.NewTokenManager("secret", time.Hour)
+
+	token, expiresAt, err := m.Issue("user-1", "session-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(token, ".") != 2 {
+		t.Errorf("expected a three-part token, got '%s'", token)
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("expected expiresAt in the future, got %v", expiresAt)
+	}
+
+	claims, err := m.Verify(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.UserID != "user-1" {
+		t.Errorf("expected UserID 'user-1', got '%s'", claims.UserID)
+	}
+	if claims.SessionID != "session-1" {
+		t.Errorf("expected SessionID 'session-1', got '%s'", claims.SessionID)
+	}
+	if !claims.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected ExpiresAt %v, got %v", expiresAt, claims.ExpiresAt)
+	}
+}
+
+func TestTokenManager_Verify_WrongSecret(t *testing.T) {
+	token, _, _ := auth.NewTokenManager("secret", time.Hour).Issue("user-1", "session-1")
+
+	if _, err := auth.NewTokenManager("other", time.Hour).Verify(token); err == nil {
+		t.Fatal("expected error for token signed with another secret")
+	}
+}
+
+func TestTokenManager_Verify_Expired(t *testing.T) {
+	m := auth.NewTokenManager("secret", -time.Minute)
+	token, _, _ := m.Issue("user-1", "session-1")
+
+	if _, err := m.Verify(token); err == nil {
+		t.Fatal("expected error for expired token")
+	}
+}
+
+func TestTokenManager_Verify_Tampered(t *testing.T) {
+	m := auth.NewTokenManager("secret", time.Hour)
+	token, _, _ := m.Issue("user-1", "session-1")
+	other, _, _ := m.Issue("user-2", "session-2")
+
+	parts := strings.Split(token, ".")
+	otherParts := strings.Split(other, ".")
+	tampered := parts[0] + "." + otherParts[1] + "." + parts[2]
+
+	if _, err := m.Verify(tampered); err == nil {
+		t.Fatal("expected error for tampered payload")
+	}
+	if _, err := m.Verify("not-a-token"); err == nil {
+		t.Fatal("expected error for malformed token")
+	}
+}
+
+func TestTokenManager_MFAChallenge(t *testing.T) {
+	m := auth.NewTokenManager("secret", time.Hour)
+
+	challenge, expiresAt, err := m.IssueMFAChallenge("user-1", "challenge-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expiresAt.After(time.Now().Add(10 * time.Minute)) {
+		t.Errorf("expected a short-lived challenge, got expiry %v", expiresAt)
+	}
+
+	userID, challengeID, err := m.VerifyMFAChallenge(challenge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userID != "user-1" || challengeID != "challenge-1" {
+		t.Errorf("expected 'user-1' and 'challenge-1', got '%s' and '%s'", userID, challengeID)
+	}
+
+	if _, err := m.Verify(challenge); err == nil {
+		t.Error("expected challenge not to be accepted as an access token")
+	}
+
+	access, _, _ := m.Issue("user-1", "session-1")
+	if _, _, err := m.VerifyMFAChallenge(access); err == nil {
+		t.Error("expected access token not to be accepted as a challenge")
+	}
+}
+
+func TestTokenManager_DeletionConfirmation(t *testing.T) {
+	m := auth.NewTokenManager("secret", time.Hour)
+
+	confirmation, expiresAt, err := m.IssueDeletionConfirmation("user-1", "proj-1", "digest-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expiresAt.After(time.Now().Add(30 * time.Minute)) {
+		t.Errorf("expected a short-lived confirmation, got expiry %v", expiresAt)
+	}
+
+	targetID, digest, err := m.VerifyDeletionConfirmation(confirmation, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if targetID != "proj-1" || digest != "digest-1" {
+		t.Errorf("expected 'proj-1' and 'digest-1', got '%s' and '%s'", targetID, digest)
+	}
+
+	if _, _, err := m.VerifyDeletionConfirmation(confirmation, "user-2"); err == nil {
+		t.Error("expected confirmation not to be accepted for another user")
+	}
+	if _, _, err := auth.NewTokenManager("other", time.Hour).VerifyDeletionConfirmation(confirmation, "user-1"); err == nil {
+		t.Error("expected error for confirmation signed with another secret")
+	}
+	if _, err := m.Verify(confirmation); err == nil {
+		t.Error("expected confirmation not to be accepted as an access token")
+	}
+
+	challenge, _, _ := m.IssueMFAChallenge("user-1", "proj-1")
+	if _, _, err := m.VerifyDeletionConfirmation(challenge, "user-1"); err == nil {
+		t.Error("expected challenge not to be accepted as a confirmation")
+	}
+}
@@ -0,0 +1,98 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/repository/auth"
+)
+
+// rfc6238Secret is the base32 encoding of the SHA1 test key "12345678901234567890" from RFC 6238.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPProvider_Validate_RFC6238Vectors(t *testing.T) {
+	p := auth.NewTOTPProvider("StudyTrack")
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		step, ok := p.Validate(rfc6238Secret, tt.code, time.Unix(tt.unix, 0))
+		if !ok {
+			t.Errorf("expected code %s to be valid at %d", tt.code, tt.unix)
+			continue
+		}
+		if step != tt.unix/30 {
+			t.Errorf("expected step %d, got %d", tt.unix/30, step)
+		}
+	}
+}
+
+func TestTOTPProvider_Validate_Skew(t *testing.T) {
+	p := auth.NewTOTPProvider("StudyTrack")
+
+	if _, ok := p.Validate(rfc6238Secret, "287082", time.Unix(59+30, 0)); !ok {
+		t.Error("expected previous step to be accepted")
+	}
+	if _, ok := p.Validate(rfc6238Secret, "287082", time.Unix(59+90, 0)); ok {
+		t.Error("expected code three steps old to be rejected")
+	}
+	if _, ok := p.Validate(rfc6238Secret, "000000", time.Unix(59, 0)); ok {
+		t.Error("expected wrong code to be rejected")
+	}
+}
+
+func TestTOTPProvider_GenerateSecret(t *testing.T) {
+	p := auth.NewTOTPProvider("StudyTrack")
+
+	secret, err := p.GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("expected 32-character base32 secret, got %q", secret)
+	}
+}
+
+func TestTOTPProvider_ProvisioningURI(t *testing.T) {
+	p := auth.NewTOTPProvider("StudyTrack")
+
+	uri := p.ProvisioningURI("SECRET", "alice@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/StudyTrack:alice@example.com?") {
+		t.Errorf("unexpected URI prefix: %s", uri)
+	}
+	for _, want := range []string{"secret=SECRET", "issuer=StudyTrack", "digits=6", "period=30"} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("expected URI to contain %q: %s", want, uri)
+		}
+	}
+}
+
+func TestTOTPProvider_GenerateRecoveryCodes(t *testing.T) {
+	p := auth.NewTOTPProvider("StudyTrack")
+
+	codes, err := p.GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("expected 10 codes, got %d", len(codes))
+	}
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != 19 || strings.Count(c, "-") != 3 {
+			t.Errorf("unexpected code format: %q", c)
+		}
+		if seen[c] {
+			t.Errorf("duplicate code: %q", c)
+		}
+		seen[c] = true
+	}
+}
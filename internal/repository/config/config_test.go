@@ -2,19 +2,28 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shnaki/studytrack-api/internal/repository/config"
 )
 
 func TestLoad_Defaults(t *testing.T) {
 	// Ensure relevant env vars are unset so defaults apply.
+	t.Setenv("APP_ENV", "")
 	t.Setenv("PORT", "")
 	t.Setenv("DB_URL", "")
 	t.Setenv("CORS_ORIGINS", "")
 	t.Setenv("LOG_LEVEL", "")
+	t.Setenv("AUTH_SECRET", "")
+	t.Setenv("ACCESS_TOKEN_TTL", "")
+	t.Setenv("REFRESH_TOKEN_TTL", "")
+	t.Setenv("TRASH_RETENTION", "")
 
 	cfg := config.Load()
 
+	if cfg.Env != "production" {
+		t.Errorf("expected default Env 'production', got '%s'", cfg.Env)
+	}
 	if cfg.Port != "8080" {
 		t.Errorf("expected default Port '8080', got '%s'", cfg.Port)
 	}
@@ -27,6 +36,18 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.LogLevel != "debug" {
 		t.Errorf("expected default LogLevel 'debug', got '%s'", cfg.LogLevel)
 	}
+	if cfg.AuthSecret != "dev-secret-change-me" {
+		t.Errorf("expected default AuthSecret, got '%s'", cfg.AuthSecret)
+	}
+	if cfg.AccessTokenTTL != time.Hour {
+		t.Errorf("expected default AccessTokenTTL 1h, got %v", cfg.AccessTokenTTL)
+	}
+	if cfg.RefreshTokenTTL != 30*24*time.Hour {
+		t.Errorf("expected default RefreshTokenTTL 720h, got %v", cfg.RefreshTokenTTL)
+	}
+	if cfg.TrashRetention != 30*24*time.Hour {
+		t.Errorf("expected default TrashRetention 720h, got %v", cfg.TrashRetention)
+	}
 }
 
 func TestLoad_CustomValues(t *testing.T) {
@@ -34,6 +55,10 @@ func TestLoad_CustomValues(t *testing.T) {
 	t.Setenv("DB_URL", "postgres://custom:pass@db:5432/mydb?sslmode=require")
 	t.Setenv("CORS_ORIGINS", "https://example.com,https://app.example.com")
 	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("AUTH_SECRET", "s3cret")
+	t.Setenv("ACCESS_TOKEN_TTL", "15m")
+	t.Setenv("REFRESH_TOKEN_TTL", "168h")
+	t.Setenv("TRASH_RETENTION", "72h")
 
 	cfg := config.Load()
 
@@ -55,6 +80,49 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.LogLevel != "info" {
 		t.Errorf("expected LogLevel 'info', got '%s'", cfg.LogLevel)
 	}
+	if cfg.AuthSecret != "s3cret" {
+		t.Errorf("expected AuthSecret 's3cret', got '%s'", cfg.AuthSecret)
+	}
+	if cfg.AccessTokenTTL != 15*time.Minute {
+		t.Errorf("expected AccessTokenTTL 15m, got %v", cfg.AccessTokenTTL)
+	}
+	if cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("expected RefreshTokenTTL 168h, got %v", cfg.RefreshTokenTTL)
+	}
+	if cfg.TrashRetention != 72*time.Hour {
+		t.Errorf("expected TrashRetention 72h, got %v", cfg.TrashRetention)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		secret  string
+		wantErr bool
+	}{
+		{"default secret in production", "production", config.DefaultAuthSecret, true},
+		{"default secret in development", config.EnvDevelopment, config.DefaultAuthSecret, false},
+		{"custom secret in production", "production", "s3cret", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Env: tt.env, AuthSecret: tt.secret}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_InvalidDurationFallsBack(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_TTL", "soon")
+
+	cfg := config.Load()
+
+	if cfg.AccessTokenTTL != time.Hour {
+		t.Errorf("expected fallback AccessTokenTTL 1h, got %v", cfg.AccessTokenTTL)
+	}
 }
 
 func TestLoad_SingleCORSOrigin(t *testing.T) {
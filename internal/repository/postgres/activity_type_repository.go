@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type activityTypeRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewActivityTypeRepository creates a new ActivityTypeRepository implementation using PostgreSQL.
+func NewActivityTypeRepository(pool *pgxpool.Pool) port.ActivityTypeRepository {
+	return &activityTypeRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *activityTypeRepository) Create(ctx context.Context, activityType *domain.ActivityType) error {
+	err := r.q.CreateActivityType(ctx, sqlcgen.CreateActivityTypeParams{
+		ID:        toPgUUID(activityType.ID),
+		UserID:    toPgUUID(activityType.UserID),
+		Name:      activityType.Name,
+		CreatedAt: toPgTimestamptz(activityType.CreatedAt),
+		UpdatedAt: toPgTimestamptz(activityType.UpdatedAt),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("activity type with this name already exists for this user")
+		}
+		return fmt.Errorf("insert activity type: %w", err)
+	}
+	return nil
+}
+
+func (r *activityTypeRepository) FindByID(ctx context.Context, id string) (*domain.ActivityType, error) {
+	row, err := r.q.GetActivityTypeByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("activity type")
+		}
+		return nil, fmt.Errorf("find activity type: %w", err)
+	}
+	return toDomainActivityType(row), nil
+}
+
+func (r *activityTypeRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.ActivityType, error) {
+	rows, err := r.q.ListActivityTypesByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find activity types: %w", err)
+	}
+	activityTypes := make([]*domain.ActivityType, 0, len(rows))
+	for _, row := range rows {
+		activityTypes = append(activityTypes, toDomainActivityType(row))
+	}
+	return activityTypes, nil
+}
+
+func (r *activityTypeRepository) Update(ctx context.Context, activityType *domain.ActivityType) error {
+	tag, err := r.q.UpdateActivityType(ctx, sqlcgen.UpdateActivityTypeParams{
+		Name:      activityType.Name,
+		UpdatedAt: toPgTimestamptz(activityType.UpdatedAt),
+		ID:        toPgUUID(activityType.ID),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("activity type with this name already exists for this user")
+		}
+		return fmt.Errorf("update activity type: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("activity type")
+	}
+	return nil
+}
+
+func (r *activityTypeRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.q.DeleteActivityType(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("delete activity type: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("activity type")
+	}
+	return nil
+}
+
+func toDomainActivityType(row sqlcgen.ActivityType) *domain.ActivityType {
+	return domain.ReconstructActivityType(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.UserID),
+		row.Name,
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
 )
 
 func toPgUUID(s string) pgtype.UUID {
@@ -17,6 +19,30 @@ func fromPgUUID(u pgtype.UUID) string {
 	return uuid.UUID(u.Bytes).String()
 }
 
+func toPgUUIDs(ids []string) []pgtype.UUID {
+	result := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		result[i] = toPgUUID(id)
+	}
+	return result
+}
+
+// toPgUUIDOrNull stores an empty string as NULL.
+func toPgUUIDOrNull(s string) pgtype.UUID {
+	if s == "" {
+		return pgtype.UUID{}
+	}
+	return toPgUUID(s)
+}
+
+// fromPgUUIDOrEmpty returns an empty string for NULL.
+func fromPgUUIDOrEmpty(u pgtype.UUID) string {
+	if !u.Valid {
+		return ""
+	}
+	return fromPgUUID(u)
+}
+
 func toPgTimestamptz(t time.Time) pgtype.Timestamptz {
 	return pgtype.Timestamptz{Time: t, Valid: true}
 }
@@ -43,3 +69,53 @@ func fromPgDatePtr(d pgtype.Date) *time.Time {
 	t := d.Time
 	return &t
 }
+
+func toPgDomainDatePtr(d *domain.Date) pgtype.Date {
+	if d == nil {
+		return pgtype.Date{Valid: false}
+	}
+	return pgtype.Date{Time: d.StartIn(time.UTC), Valid: true}
+}
+
+func fromPgDomainDatePtr(d pgtype.Date) *domain.Date {
+	if !d.Valid {
+		return nil
+	}
+	date := domain.DateOf(d.Time, time.UTC)
+	return &date
+}
+
+func toPgTimestamptzPtr(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func fromPgTimestamptzPtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// toPgTextOrNull stores an empty string as NULL.
+func toPgTextOrNull(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+// nonNilStrings returns an empty slice for nil so NOT NULL array columns receive '{}' instead of NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func toPgBoolPtr(b *bool) pgtype.Bool {
+	if b == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *b, Valid: true}
+}
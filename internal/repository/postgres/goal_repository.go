@@ -37,8 +37,15 @@ func (r *goalRepository) Upsert(ctx context.Context, goal *domain.Goal) error {
 	return nil
 }
 
-func (r *goalRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Goal, error) {
-	rows, err := r.q.ListGoalsByUserID(ctx, toPgUUID(userID))
+func (r *goalRepository) FindByUserID(ctx context.Context, userID string, page port.PageRequest) ([]*domain.Goal, error) {
+	params := sqlcgen.ListGoalsByCreatedAtParams{UserID: toPgUUID(userID), AfterCreatedAt: afterTime(page), AfterID: afterID(page), RowLimit: rowLimit(page)}
+	var rows []sqlcgen.Goal
+	var err error
+	if isSort(page, domain.SortByCreatedAt, true) {
+		rows, err = r.q.ListGoalsByCreatedAtDesc(ctx, sqlcgen.ListGoalsByCreatedAtDescParams(params))
+	} else {
+		rows, err = r.q.ListGoalsByCreatedAt(ctx, params)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("find goals: %w", err)
 	}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type mfaChallengeRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewMFAChallengeRepository creates a new MFAChallengeRepository implementation using PostgreSQL.
+func NewMFAChallengeRepository(pool *pgxpool.Pool) port.MFAChallengeRepository {
+	return &mfaChallengeRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *mfaChallengeRepository) Create(ctx context.Context, id, userID string, expiresAt time.Time) error {
+	err := r.q.CreateMFAChallenge(ctx, sqlcgen.CreateMFAChallengeParams{
+		ID:        toPgUUID(id),
+		UserID:    toPgUUID(userID),
+		ExpiresAt: toPgTimestamptz(expiresAt),
+	})
+	if err != nil {
+		return fmt.Errorf("insert mfa challenge: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaChallengeRepository) RecordAttempt(ctx context.Context, id string, now time.Time) (int, error) {
+	attempts, err := r.q.IncrementMFAChallengeAttempts(ctx, sqlcgen.IncrementMFAChallengeAttemptsParams{
+		ID:        toPgUUID(id),
+		ExpiresAt: toPgTimestamptz(now),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound("MFA challenge")
+		}
+		return 0, fmt.Errorf("record mfa attempt: %w", err)
+	}
+	return int(attempts), nil
+}
+
+func (r *mfaChallengeRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.q.DeleteMFAChallenge(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("delete mfa challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("MFA challenge")
+	}
+	return nil
+}
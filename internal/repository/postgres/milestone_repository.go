@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type milestoneRepository struct {
+	pool *pgxpool.Pool
+	q    *sqlcgen.Queries
+}
+
+// NewMilestoneRepository creates a new MilestoneRepository implementation using PostgreSQL.
+func NewMilestoneRepository(pool *pgxpool.Pool) port.MilestoneRepository {
+	return &milestoneRepository{pool: pool, q: sqlcgen.New(pool)}
+}
+
+func (r *milestoneRepository) Create(ctx context.Context, milestone *domain.Milestone) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin create milestone: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	err = q.CreateMilestone(ctx, sqlcgen.CreateMilestoneParams{
+		ID:          toPgUUID(milestone.ID),
+		ProjectID:   toPgUUID(milestone.ProjectID),
+		UserID:      toPgUUID(milestone.UserID),
+		Title:       milestone.Title,
+		DueDate:     toPgDomainDatePtr(milestone.DueDate),
+		Position:    int32(milestone.Position),
+		CompletedAt: toPgTimestamptzPtr(milestone.CompletedAt),
+		CreatedAt:   toPgTimestamptz(milestone.CreatedAt),
+		UpdatedAt:   toPgTimestamptz(milestone.UpdatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("insert milestone: %w", err)
+	}
+	if err := createChecklistItems(ctx, q, milestone); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit create milestone: %w", err)
+	}
+	return nil
+}
+
+func (r *milestoneRepository) FindByID(ctx context.Context, id string) (*domain.Milestone, error) {
+	row, err := r.q.GetMilestoneByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("milestone")
+		}
+		return nil, fmt.Errorf("find milestone: %w", err)
+	}
+	milestones, err := r.withChecklistItems(ctx, []sqlcgen.Milestone{row})
+	if err != nil {
+		return nil, err
+	}
+	return milestones[0], nil
+}
+
+func (r *milestoneRepository) FindByProjectIDs(ctx context.Context, projectIDs []string) ([]*domain.Milestone, error) {
+	rows, err := r.q.ListMilestonesByProjectIDs(ctx, toPgUUIDs(projectIDs))
+	if err != nil {
+		return nil, fmt.Errorf("find milestones: %w", err)
+	}
+	return r.withChecklistItems(ctx, rows)
+}
+
+func (r *milestoneRepository) Update(ctx context.Context, milestone *domain.Milestone) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin update milestone: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	tag, err := q.UpdateMilestone(ctx, sqlcgen.UpdateMilestoneParams{
+		Title:       milestone.Title,
+		DueDate:     toPgDomainDatePtr(milestone.DueDate),
+		CompletedAt: toPgTimestamptzPtr(milestone.CompletedAt),
+		UpdatedAt:   toPgTimestamptz(milestone.UpdatedAt),
+		ID:          toPgUUID(milestone.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("update milestone: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("milestone")
+	}
+	if err := q.DeleteChecklistItemsByMilestoneID(ctx, toPgUUID(milestone.ID)); err != nil {
+		return fmt.Errorf("delete checklist items: %w", err)
+	}
+	if err := createChecklistItems(ctx, q, milestone); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit update milestone: %w", err)
+	}
+	return nil
+}
+
+func (r *milestoneRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.q.DeleteMilestone(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("delete milestone: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("milestone")
+	}
+	return nil
+}
+
+func (r *milestoneRepository) Reorder(ctx context.Context, ids []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin reorder milestones: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	for i, id := range ids {
+		tag, err := q.UpdateMilestonePosition(ctx, sqlcgen.UpdateMilestonePositionParams{
+			Position: int32(i),
+			ID:       toPgUUID(id),
+		})
+		if err != nil {
+			return fmt.Errorf("update milestone position: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrNotFound("milestone")
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit reorder milestones: %w", err)
+	}
+	return nil
+}
+
+// withChecklistItems converts the rows to milestones and loads their checklist items in one query.
+func (r *milestoneRepository) withChecklistItems(ctx context.Context, rows []sqlcgen.Milestone) ([]*domain.Milestone, error) {
+	milestones := make([]*domain.Milestone, 0, len(rows))
+	if len(rows) == 0 {
+		return milestones, nil
+	}
+	byID := make(map[string]*domain.Milestone, len(rows))
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		milestone := toDomainMilestone(row)
+		milestones = append(milestones, milestone)
+		byID[milestone.ID] = milestone
+		ids = append(ids, milestone.ID)
+	}
+	items, err := r.q.ListChecklistItemsByMilestoneIDs(ctx, toPgUUIDs(ids))
+	if err != nil {
+		return nil, fmt.Errorf("find checklist items: %w", err)
+	}
+	for _, item := range items {
+		milestone := byID[fromPgUUID(item.MilestoneID)]
+		milestone.Items = append(milestone.Items, domain.ChecklistItem{
+			ID:    fromPgUUID(item.ID),
+			Title: item.Title,
+			Done:  item.Done,
+		})
+	}
+	return milestones, nil
+}
+
+func createChecklistItems(ctx context.Context, q *sqlcgen.Queries, milestone *domain.Milestone) error {
+	for i, item := range milestone.Items {
+		err := q.CreateChecklistItem(ctx, sqlcgen.CreateChecklistItemParams{
+			ID:          toPgUUID(item.ID),
+			MilestoneID: toPgUUID(milestone.ID),
+			Title:       item.Title,
+			Done:        item.Done,
+			Position:    int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("insert checklist item: %w", err)
+		}
+	}
+	return nil
+}
+
+func toDomainMilestone(row sqlcgen.Milestone) *domain.Milestone {
+	milestone := domain.ReconstructMilestone(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.ProjectID),
+		fromPgUUID(row.UserID),
+		row.Title,
+		int(row.Position),
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+	milestone.DueDate = fromPgDomainDatePtr(row.DueDate)
+	milestone.CompletedAt = fromPgTimestamptzPtr(row.CompletedAt)
+	return milestone
+}
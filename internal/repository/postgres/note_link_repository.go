@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type noteLinkRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewNoteLinkRepository creates a new NoteLinkRepository implementation using PostgreSQL.
+func NewNoteLinkRepository(pool *pgxpool.Pool) port.NoteLinkRepository {
+	return &noteLinkRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *noteLinkRepository) Link(ctx context.Context, noteID, studyLogID string) error {
+	err := r.q.LinkNoteStudyLog(ctx, sqlcgen.LinkNoteStudyLogParams{
+		NoteID:     toPgUUID(noteID),
+		StudyLogID: toPgUUID(studyLogID),
+		CreatedAt:  toPgTimestamptz(time.Now()),
+	})
+	if err != nil {
+		return fmt.Errorf("link note to study log: %w", err)
+	}
+	return nil
+}
+
+func (r *noteLinkRepository) Unlink(ctx context.Context, noteID, studyLogID string) error {
+	tag, err := r.q.UnlinkNoteStudyLog(ctx, sqlcgen.UnlinkNoteStudyLogParams{
+		NoteID:     toPgUUID(noteID),
+		StudyLogID: toPgUUID(studyLogID),
+	})
+	if err != nil {
+		return fmt.Errorf("unlink note from study log: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("note link")
+	}
+	return nil
+}
+
+func (r *noteLinkRepository) FindStudyLogsByNoteIDs(ctx context.Context, noteIDs []string) (map[string][]domain.LinkedStudyLog, error) {
+	rows, err := r.q.ListLinkedStudyLogsByNoteIDs(ctx, toPgUUIDs(noteIDs))
+	if err != nil {
+		return nil, fmt.Errorf("find linked study logs: %w", err)
+	}
+	result := make(map[string][]domain.LinkedStudyLog)
+	for _, row := range rows {
+		noteID := fromPgUUID(row.NoteID)
+		result[noteID] = append(result[noteID], domain.LinkedStudyLog{
+			ID:        fromPgUUID(row.ID),
+			ProjectID: fromPgUUID(row.ProjectID),
+			StudiedAt: fromPgTimestamptz(row.StudiedAt),
+			Minutes:   int(row.Minutes),
+		})
+	}
+	return result, nil
+}
+
+func (r *noteLinkRepository) FindNotesByStudyLogIDs(ctx context.Context, studyLogIDs []string) (map[string][]domain.LinkedNote, error) {
+	rows, err := r.q.ListLinkedNotesByStudyLogIDs(ctx, toPgUUIDs(studyLogIDs))
+	if err != nil {
+		return nil, fmt.Errorf("find linked notes: %w", err)
+	}
+	result := make(map[string][]domain.LinkedNote)
+	for _, row := range rows {
+		studyLogID := fromPgUUID(row.StudyLogID)
+		result[studyLogID] = append(result[studyLogID], domain.LinkedNote{
+			ID:        fromPgUUID(row.ID),
+			ProjectID: fromPgUUID(row.ProjectID),
+			Title:     row.Title,
+		})
+	}
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -59,8 +60,21 @@ func (r *noteRepository) FindByID(ctx context.Context, id string) (*domain.Note,
 	), nil
 }
 
-func (r *noteRepository) FindByProjectID(ctx context.Context, projectID string) ([]*domain.Note, error) {
-	rows, err := r.q.ListNotesByProjectID(ctx, toPgUUID(projectID))
+func (r *noteRepository) FindByProjectID(ctx context.Context, projectID string, page port.PageRequest) ([]*domain.Note, error) {
+	byUpdatedAt := sqlcgen.ListNotesByUpdatedAtParams{ProjectID: toPgUUID(projectID), AfterUpdatedAt: afterTime(page), AfterID: afterID(page), RowLimit: rowLimit(page)}
+	byCreatedAt := sqlcgen.ListNotesByCreatedAtParams{ProjectID: toPgUUID(projectID), AfterCreatedAt: afterTime(page), AfterID: afterID(page), RowLimit: rowLimit(page)}
+	var rows []sqlcgen.Note
+	var err error
+	switch {
+	case isSort(page, domain.SortByUpdatedAt, false):
+		rows, err = r.q.ListNotesByUpdatedAt(ctx, byUpdatedAt)
+	case isSort(page, domain.SortByCreatedAt, false):
+		rows, err = r.q.ListNotesByCreatedAt(ctx, byCreatedAt)
+	case isSort(page, domain.SortByCreatedAt, true):
+		rows, err = r.q.ListNotesByCreatedAtDesc(ctx, sqlcgen.ListNotesByCreatedAtDescParams(byCreatedAt))
+	default:
+		rows, err = r.q.ListNotesByUpdatedAtDesc(ctx, sqlcgen.ListNotesByUpdatedAtDescParams(byUpdatedAt))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("find notes: %w", err)
 	}
@@ -97,13 +111,70 @@ func (r *noteRepository) Update(ctx context.Context, note *domain.Note) error {
 	return nil
 }
 
-func (r *noteRepository) Delete(ctx context.Context, id string) error {
-	tag, err := r.q.DeleteNote(ctx, toPgUUID(id))
+func (r *noteRepository) Trash(ctx context.Context, id string, at time.Time) error {
+	tag, err := r.q.TrashNote(ctx, sqlcgen.TrashNoteParams{DeletedAt: toPgTimestamptz(at), ID: toPgUUID(id)})
+	if err != nil {
+		return fmt.Errorf("trash note: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("note")
+	}
+	return nil
+}
+
+func (r *noteRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Note, error) {
+	row, err := r.q.GetTrashedNoteByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("note")
+		}
+		return nil, fmt.Errorf("find trashed note: %w", err)
+	}
+	return toDomainNote(row), nil
+}
+
+func (r *noteRepository) FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.Note, error) {
+	rows, err := r.q.ListTrashedNotesByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find trashed notes: %w", err)
+	}
+	notes := make([]*domain.Note, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, toDomainNote(row))
+	}
+	return notes, nil
+}
+
+func (r *noteRepository) Restore(ctx context.Context, id string) error {
+	tag, err := r.q.RestoreNote(ctx, toPgUUID(id))
 	if err != nil {
-		return fmt.Errorf("delete note: %w", err)
+		return fmt.Errorf("restore note: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		return domain.ErrNotFound("note")
 	}
 	return nil
 }
+
+func (r *noteRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.PurgeTrashedNotes(ctx, toPgTimestamptz(before))
+	if err != nil {
+		return 0, fmt.Errorf("purge trashed notes: %w", err)
+	}
+	return n, nil
+}
+
+func toDomainNote(row sqlcgen.Note) *domain.Note {
+	note := domain.ReconstructNote(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.ProjectID),
+		fromPgUUID(row.UserID),
+		row.Title,
+		row.Content,
+		row.Tags,
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+	note.DeletedAt = fromPgTimestamptzPtr(row.DeletedAt)
+	return note
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// rowLimit returns the LIMIT for a page; NULL when every row is requested.
+func rowLimit(page port.PageRequest) pgtype.Int4 {
+	if page.Limit <= 0 {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(page.Limit), Valid: true}
+}
+
+// afterTime returns the time sort key of the page cursor; NULL on the first page.
+func afterTime(page port.PageRequest) pgtype.Timestamptz {
+	if page.After == nil {
+		return pgtype.Timestamptz{}
+	}
+	return toPgTimestamptz(page.After.Time)
+}
+
+// afterText returns the text sort key of the page cursor; NULL on the first page.
+func afterText(page port.PageRequest) pgtype.Text {
+	if page.After == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: page.After.Text, Valid: true}
+}
+
+// afterID returns the item ID of the page cursor; NULL on the first page.
+func afterID(page port.PageRequest) pgtype.UUID {
+	if page.After == nil {
+		return pgtype.UUID{}
+	}
+	return toPgUUIDOrNull(page.After.ID)
+}
+
+// isSort reports whether the page is in the given order.
+func isSort(page port.PageRequest, key string, descending bool) bool {
+	return page.Sort == domain.SortOrder{Key: key, Descending: descending}
+}
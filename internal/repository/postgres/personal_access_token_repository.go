@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type personalAccessTokenRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewPersonalAccessTokenRepository creates a new PersonalAccessTokenRepository implementation using PostgreSQL.
+func NewPersonalAccessTokenRepository(pool *pgxpool.Pool) port.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	err := r.q.CreatePersonalAccessToken(ctx, sqlcgen.CreatePersonalAccessTokenParams{
+		ID:         toPgUUID(token.ID),
+		UserID:     toPgUUID(token.UserID),
+		Name:       token.Name,
+		TokenHash:  token.TokenHash,
+		Scopes:     token.Scopes,
+		LastUsedAt: toPgTimestamptzPtr(token.LastUsedAt),
+		ExpiresAt:  toPgTimestamptzPtr(token.ExpiresAt),
+		CreatedAt:  toPgTimestamptz(token.CreatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("insert personal access token: %w", err)
+	}
+	return nil
+}
+
+func (r *personalAccessTokenRepository) FindByID(ctx context.Context, id string) (*domain.PersonalAccessToken, error) {
+	row, err := r.q.GetPersonalAccessTokenByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("personal access token")
+		}
+		return nil, fmt.Errorf("find personal access token: %w", err)
+	}
+	return toDomainPersonalAccessToken(row), nil
+}
+
+func (r *personalAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	row, err := r.q.GetPersonalAccessTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("personal access token")
+		}
+		return nil, fmt.Errorf("find personal access token: %w", err)
+	}
+	return toDomainPersonalAccessToken(row), nil
+}
+
+func (r *personalAccessTokenRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.PersonalAccessToken, error) {
+	rows, err := r.q.ListPersonalAccessTokensByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find personal access tokens: %w", err)
+	}
+	tokens := make([]*domain.PersonalAccessToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, toDomainPersonalAccessToken(row))
+	}
+	return tokens, nil
+}
+
+func (r *personalAccessTokenRepository) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	err := r.q.UpdatePersonalAccessTokenLastUsed(ctx, sqlcgen.UpdatePersonalAccessTokenLastUsedParams{
+		LastUsedAt: toPgTimestamptz(lastUsedAt),
+		ID:         toPgUUID(id),
+	})
+	if err != nil {
+		return fmt.Errorf("update personal access token: %w", err)
+	}
+	return nil
+}
+
+func (r *personalAccessTokenRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.q.DeletePersonalAccessToken(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("delete personal access token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("personal access token")
+	}
+	return nil
+}
+
+func toDomainPersonalAccessToken(row sqlcgen.PersonalAccessToken) *domain.PersonalAccessToken {
+	return domain.ReconstructPersonalAccessToken(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.UserID),
+		row.Name,
+		row.TokenHash,
+		row.Scopes,
+		fromPgTimestamptzPtr(row.LastUsedAt),
+		fromPgTimestamptzPtr(row.ExpiresAt),
+		fromPgTimestamptz(row.CreatedAt),
+	)
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type pomodoroRepository struct {
+	q    *sqlcgen.Queries
+	pool *pgxpool.Pool
+}
+
+// NewPomodoroRepository creates a new PomodoroRepository implementation using PostgreSQL.
+func NewPomodoroRepository(pool *pgxpool.Pool) port.PomodoroRepository {
+	return &pomodoroRepository{
+		q:    sqlcgen.New(pool),
+		pool: pool,
+	}
+}
+
+func (r *pomodoroRepository) Create(ctx context.Context, session *domain.PomodoroSession) error {
+	err := r.q.CreatePomodoroSession(ctx, sqlcgen.CreatePomodoroSessionParams{
+		UserID:             toPgUUID(session.UserID),
+		ProjectID:          toPgUUID(session.ProjectID),
+		Note:               session.Note,
+		WorkMinutes:        int32(session.Settings.WorkMinutes),
+		BreakMinutes:       int32(session.Settings.BreakMinutes),
+		Cycles:             int32(session.Settings.Cycles),
+		Phase:              string(session.Phase),
+		PhaseStartedAt:     toPgTimestamptz(session.PhaseStartedAt),
+		CompletedPomodoros: int32(session.CompletedPomodoros),
+		StartedAt:          toPgTimestamptz(session.StartedAt),
+		UpdatedAt:          toPgTimestamptz(session.UpdatedAt),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("a pomodoro session is already active for this user")
+		}
+		return fmt.Errorf("insert pomodoro session: %w", err)
+	}
+	return nil
+}
+
+func (r *pomodoroRepository) FindByUserID(ctx context.Context, userID string) (*domain.PomodoroSession, error) {
+	row, err := r.q.GetPomodoroSessionByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("pomodoro session")
+		}
+		return nil, fmt.Errorf("find pomodoro session: %w", err)
+	}
+	return toDomainPomodoroSession(row), nil
+}
+
+func (r *pomodoroRepository) FindDue(ctx context.Context, now time.Time) ([]*domain.PomodoroSession, error) {
+	rows, err := r.q.ListDuePomodoroSessions(ctx, toPgTimestamptz(now))
+	if err != nil {
+		return nil, fmt.Errorf("find due pomodoro sessions: %w", err)
+	}
+	sessions := make([]*domain.PomodoroSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toDomainPomodoroSession(row))
+	}
+	return sessions, nil
+}
+
+func (r *pomodoroRepository) Save(ctx context.Context, session *domain.PomodoroSession, previousUpdatedAt time.Time, logs []*domain.StudyLog) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin save pomodoro session: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	var tag pgconn.CommandTag
+	if session.IsFinished() {
+		tag, err = q.DeletePomodoroSession(ctx, sqlcgen.DeletePomodoroSessionParams{
+			UserID:            toPgUUID(session.UserID),
+			PreviousUpdatedAt: toPgTimestamptz(previousUpdatedAt),
+		})
+	} else {
+		tag, err = q.UpdatePomodoroSession(ctx, sqlcgen.UpdatePomodoroSessionParams{
+			Phase:              string(session.Phase),
+			PhaseStartedAt:     toPgTimestamptz(session.PhaseStartedAt),
+			CompletedPomodoros: int32(session.CompletedPomodoros),
+			UpdatedAt:          toPgTimestamptz(session.UpdatedAt),
+			UserID:             toPgUUID(session.UserID),
+			PreviousUpdatedAt:  toPgTimestamptz(previousUpdatedAt),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("save pomodoro session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrConflict("pomodoro session was changed concurrently")
+	}
+	for _, log := range logs {
+		if err := q.CreateStudyLog(ctx, toCreateStudyLogParams(log)); err != nil {
+			return fmt.Errorf("insert pomodoro study log: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit save pomodoro session: %w", err)
+	}
+	return nil
+}
+
+func toDomainPomodoroSession(row sqlcgen.PomodoroSession) *domain.PomodoroSession {
+	return domain.ReconstructPomodoroSession(
+		fromPgUUID(row.UserID),
+		fromPgUUID(row.ProjectID),
+		row.Note,
+		domain.PomodoroSettings{
+			WorkMinutes:  int(row.WorkMinutes),
+			BreakMinutes: int(row.BreakMinutes),
+			Cycles:       int(row.Cycles),
+		},
+		domain.PomodoroPhase(row.Phase),
+		fromPgTimestamptz(row.PhaseStartedAt),
+		int(row.CompletedPomodoros),
+		fromPgTimestamptz(row.StartedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+}
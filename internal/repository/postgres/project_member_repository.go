@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type projectMemberRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewProjectMemberRepository creates a new ProjectMemberRepository implementation using PostgreSQL.
+func NewProjectMemberRepository(pool *pgxpool.Pool) port.ProjectMemberRepository {
+	return &projectMemberRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *projectMemberRepository) Create(ctx context.Context, member *domain.ProjectMember) error {
+	err := r.q.CreateProjectMember(ctx, sqlcgen.CreateProjectMemberParams{
+		ProjectID:  toPgUUID(member.ProjectID),
+		UserID:     toPgUUID(member.UserID),
+		Role:       string(member.Role),
+		InvitedBy:  toPgUUID(member.InvitedBy),
+		InvitedAt:  toPgTimestamptz(member.InvitedAt),
+		AcceptedAt: toPgTimestamptzPtr(member.AcceptedAt),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("the user is already a member of the project or invited to it")
+		}
+		return fmt.Errorf("insert project member: %w", err)
+	}
+	return nil
+}
+
+func (r *projectMemberRepository) Find(ctx context.Context, projectID, userID string) (*domain.ProjectMember, error) {
+	row, err := r.q.GetProjectMember(ctx, sqlcgen.GetProjectMemberParams{
+		ProjectID: toPgUUID(projectID),
+		UserID:    toPgUUID(userID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("project member")
+		}
+		return nil, fmt.Errorf("find project member: %w", err)
+	}
+	return toDomainProjectMember(row), nil
+}
+
+func (r *projectMemberRepository) FindByProjectID(ctx context.Context, projectID string) ([]*domain.ProjectMember, error) {
+	rows, err := r.q.ListProjectMembersByProjectID(ctx, toPgUUID(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("find project members: %w", err)
+	}
+	return toDomainProjectMembers(rows), nil
+}
+
+func (r *projectMemberRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.ProjectMember, error) {
+	rows, err := r.q.ListProjectMembersByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find project memberships: %w", err)
+	}
+	return toDomainProjectMembers(rows), nil
+}
+
+func (r *projectMemberRepository) Update(ctx context.Context, member *domain.ProjectMember) error {
+	tag, err := r.q.UpdateProjectMember(ctx, sqlcgen.UpdateProjectMemberParams{
+		Role:       string(member.Role),
+		AcceptedAt: toPgTimestamptzPtr(member.AcceptedAt),
+		ProjectID:  toPgUUID(member.ProjectID),
+		UserID:     toPgUUID(member.UserID),
+	})
+	if err != nil {
+		return fmt.Errorf("update project member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("project member")
+	}
+	return nil
+}
+
+func (r *projectMemberRepository) Delete(ctx context.Context, projectID, userID string) error {
+	tag, err := r.q.DeleteProjectMember(ctx, sqlcgen.DeleteProjectMemberParams{
+		ProjectID: toPgUUID(projectID),
+		UserID:    toPgUUID(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete project member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("project member")
+	}
+	return nil
+}
+
+func toDomainProjectMembers(rows []sqlcgen.ProjectMember) []*domain.ProjectMember {
+	members := make([]*domain.ProjectMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, toDomainProjectMember(row))
+	}
+	return members
+}
+
+func toDomainProjectMember(row sqlcgen.ProjectMember) *domain.ProjectMember {
+	return &domain.ProjectMember{
+		ProjectID:  fromPgUUID(row.ProjectID),
+		UserID:     fromPgUUID(row.UserID),
+		Role:       domain.ProjectRole(row.Role),
+		InvitedBy:  fromPgUUID(row.InvitedBy),
+		InvitedAt:  fromPgTimestamptz(row.InvitedAt),
+		AcceptedAt: fromPgTimestamptzPtr(row.AcceptedAt),
+	}
+}
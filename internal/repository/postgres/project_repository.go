@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,21 +16,35 @@ import (
 )
 
 type projectRepository struct {
-	q *sqlcgen.Queries
+	q    *sqlcgen.Queries
+	pool *pgxpool.Pool
 }
 
 // NewProjectRepository creates a new ProjectRepository implementation using PostgreSQL.
 func NewProjectRepository(pool *pgxpool.Pool) port.ProjectRepository {
-	return &projectRepository{q: sqlcgen.New(pool)}
+	return &projectRepository{
+		q:    sqlcgen.New(pool),
+		pool: pool,
+	}
 }
 
 func (r *projectRepository) Create(ctx context.Context, project *domain.Project) error {
-	err := r.q.CreateProject(ctx, sqlcgen.CreateProjectParams{
-		ID:        toPgUUID(project.ID),
-		UserID:    toPgUUID(project.UserID),
-		Name:      project.Name,
-		CreatedAt: toPgTimestamptz(project.CreatedAt),
-		UpdatedAt: toPgTimestamptz(project.UpdatedAt),
+	return createProject(ctx, r.q, project)
+}
+
+func createProject(ctx context.Context, q *sqlcgen.Queries, project *domain.Project) error {
+	err := q.CreateProject(ctx, sqlcgen.CreateProjectParams{
+		ID:          toPgUUID(project.ID),
+		UserID:      toPgUUID(project.UserID),
+		ParentID:    toPgUUIDOrNull(project.ParentID),
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		Icon:        project.Icon,
+		Status:      string(project.Status),
+		TargetDate:  toPgDomainDatePtr(project.TargetDate),
+		CreatedAt:   toPgTimestamptz(project.CreatedAt),
+		UpdatedAt:   toPgTimestamptz(project.UpdatedAt),
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -49,38 +64,48 @@ func (r *projectRepository) FindByID(ctx context.Context, id string) (*domain.Pr
 		}
 		return nil, fmt.Errorf("find project: %w", err)
 	}
-	return domain.ReconstructProject(
-		fromPgUUID(row.ID),
-		fromPgUUID(row.UserID),
-		row.Name,
-		fromPgTimestamptz(row.CreatedAt),
-		fromPgTimestamptz(row.UpdatedAt),
-	), nil
+	return toDomainProject(row), nil
 }
 
-func (r *projectRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Project, error) {
-	rows, err := r.q.ListProjectsByUserID(ctx, toPgUUID(userID))
+func (r *projectRepository) FindByUserID(ctx context.Context, userID string, filter port.ProjectFilter, page port.PageRequest) ([]*domain.Project, error) {
+	archived := toPgBoolPtr(filter.Archived)
+	status := toPgTextOrNull(string(filter.Status))
+	byName := sqlcgen.ListProjectsByNameParams{UserID: toPgUUID(userID), AfterName: afterText(page), Archived: archived, Status: status, RowLimit: rowLimit(page)}
+	byCreatedAt := sqlcgen.ListProjectsByCreatedAtParams{UserID: toPgUUID(userID), AfterCreatedAt: afterTime(page), AfterID: afterID(page), Archived: archived, Status: status, RowLimit: rowLimit(page)}
+	var rows []sqlcgen.Project
+	var err error
+	switch {
+	case isSort(page, domain.SortByName, true):
+		rows, err = r.q.ListProjectsByNameDesc(ctx, sqlcgen.ListProjectsByNameDescParams(byName))
+	case isSort(page, domain.SortByCreatedAt, false):
+		rows, err = r.q.ListProjectsByCreatedAt(ctx, byCreatedAt)
+	case isSort(page, domain.SortByCreatedAt, true):
+		rows, err = r.q.ListProjectsByCreatedAtDesc(ctx, sqlcgen.ListProjectsByCreatedAtDescParams(byCreatedAt))
+	default:
+		rows, err = r.q.ListProjectsByName(ctx, byName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("find projects: %w", err)
 	}
 	projects := make([]*domain.Project, 0, len(rows))
 	for _, row := range rows {
-		projects = append(projects, domain.ReconstructProject(
-			fromPgUUID(row.ID),
-			fromPgUUID(row.UserID),
-			row.Name,
-			fromPgTimestamptz(row.CreatedAt),
-			fromPgTimestamptz(row.UpdatedAt),
-		))
+		projects = append(projects, toDomainProject(row))
 	}
 	return projects, nil
 }
 
 func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
 	tag, err := r.q.UpdateProject(ctx, sqlcgen.UpdateProjectParams{
-		Name:      project.Name,
-		UpdatedAt: toPgTimestamptz(project.UpdatedAt),
-		ID:        toPgUUID(project.ID),
+		Name:        project.Name,
+		ParentID:    toPgUUIDOrNull(project.ParentID),
+		Description: project.Description,
+		Color:       project.Color,
+		Icon:        project.Icon,
+		Status:      string(project.Status),
+		TargetDate:  toPgDomainDatePtr(project.TargetDate),
+		ArchivedAt:  toPgTimestamptzPtr(project.ArchivedAt),
+		UpdatedAt:   toPgTimestamptz(project.UpdatedAt),
+		ID:          toPgUUID(project.ID),
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -95,13 +120,280 @@ func (r *projectRepository) Update(ctx context.Context, project *domain.Project)
 	return nil
 }
 
-func (r *projectRepository) Delete(ctx context.Context, id string) error {
-	tag, err := r.q.DeleteProject(ctx, toPgUUID(id))
+func (r *projectRepository) CreateCopy(ctx context.Context, c *domain.ProjectCopy) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin create project copy: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	if err := createProject(ctx, q, c.Project); err != nil {
+		return err
+	}
+	for _, note := range c.Notes {
+		err := q.CreateNote(ctx, sqlcgen.CreateNoteParams{
+			ID:        toPgUUID(note.ID),
+			ProjectID: toPgUUID(note.ProjectID),
+			UserID:    toPgUUID(note.UserID),
+			Title:     note.Title,
+			Content:   note.Content,
+			Tags:      note.Tags,
+			CreatedAt: toPgTimestamptz(note.CreatedAt),
+			UpdatedAt: toPgTimestamptz(note.UpdatedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("insert copied note: %w", err)
+		}
+	}
+	if goal := c.Goal; goal != nil {
+		err := q.UpsertGoal(ctx, sqlcgen.UpsertGoalParams{
+			ID:                   toPgUUID(goal.ID),
+			UserID:               toPgUUID(goal.UserID),
+			ProjectID:            toPgUUID(goal.ProjectID),
+			TargetMinutesPerWeek: int32(goal.TargetMinutesPerWeek),
+			StartDate:            toPgDate(goal.StartDate),
+			EndDate:              toPgDatePtr(goal.EndDate),
+			CreatedAt:            toPgTimestamptz(goal.CreatedAt),
+			UpdatedAt:            toPgTimestamptz(goal.UpdatedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("insert copied goal: %w", err)
+		}
+	}
+	for _, milestone := range c.Milestones {
+		err := q.CreateMilestone(ctx, sqlcgen.CreateMilestoneParams{
+			ID:          toPgUUID(milestone.ID),
+			ProjectID:   toPgUUID(milestone.ProjectID),
+			UserID:      toPgUUID(milestone.UserID),
+			Title:       milestone.Title,
+			DueDate:     toPgDomainDatePtr(milestone.DueDate),
+			Position:    int32(milestone.Position),
+			CompletedAt: toPgTimestamptzPtr(milestone.CompletedAt),
+			CreatedAt:   toPgTimestamptz(milestone.CreatedAt),
+			UpdatedAt:   toPgTimestamptz(milestone.UpdatedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("insert copied milestone: %w", err)
+		}
+		if err := createChecklistItems(ctx, q, milestone); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit create project copy: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRepository) Merge(ctx context.Context, sourceID, targetID string, goal *domain.Goal) (*domain.ProjectMergeSummary, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin merge project: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	source, target := toPgUUID(sourceID), toPgUUID(targetID)
+	counts, err := q.GetProjectMergeCounts(ctx, source)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("project")
+		}
+		return nil, fmt.Errorf("count merged project rows: %w", err)
+	}
+	studyLogs, err := q.MoveStudyLogsToProject(ctx, sqlcgen.MoveStudyLogsToProjectParams{TargetID: target, SourceID: source})
+	if err != nil {
+		return nil, fmt.Errorf("move project study logs: %w", err)
+	}
+	notes, err := q.MoveNotesToProject(ctx, sqlcgen.MoveNotesToProjectParams{TargetID: target, SourceID: source})
+	if err != nil {
+		return nil, fmt.Errorf("move project notes: %w", err)
+	}
+	milestones, err := q.MoveMilestonesToProject(ctx, sqlcgen.MoveMilestonesToProjectParams{TargetID: target, SourceID: source})
+	if err != nil {
+		return nil, fmt.Errorf("move project milestones: %w", err)
+	}
+	if err := q.MoveTimersToProject(ctx, sqlcgen.MoveTimersToProjectParams{TargetID: target, SourceID: source}); err != nil {
+		return nil, fmt.Errorf("move project timers: %w", err)
+	}
+	if err := q.MovePomodoroSessionsToProject(ctx, sqlcgen.MovePomodoroSessionsToProjectParams{TargetID: target, SourceID: source}); err != nil {
+		return nil, fmt.Errorf("move project pomodoro sessions: %w", err)
+	}
+	// The source's goal is deleted before the merged goal is saved, as the merged goal may reuse its ID.
+	goals, err := q.DeleteGoalsByProjectID(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("delete source project goals: %w", err)
+	}
+	if goal != nil {
+		err := q.UpsertGoal(ctx, sqlcgen.UpsertGoalParams{
+			ID:                   toPgUUID(goal.ID),
+			UserID:               toPgUUID(goal.UserID),
+			ProjectID:            toPgUUID(goal.ProjectID),
+			TargetMinutesPerWeek: int32(goal.TargetMinutesPerWeek),
+			StartDate:            toPgDate(goal.StartDate),
+			EndDate:              toPgDatePtr(goal.EndDate),
+			CreatedAt:            toPgTimestamptz(goal.CreatedAt),
+			UpdatedAt:            toPgTimestamptz(goal.UpdatedAt),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("upsert merged goal: %w", err)
+		}
+	}
+	deleted, err := q.DeleteProject(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("delete source project: %w", err)
+	}
+	if deleted == 0 {
+		return nil, domain.ErrNotFound("project")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit merge project: %w", err)
+	}
+	return &domain.ProjectMergeSummary{
+		StudyLogs:             int(studyLogs),
+		OtherMembersStudyLogs: int(counts.OtherMembersStudyLogs),
+		TrashedStudyLogs:      int(counts.TrashedStudyLogs),
+		Notes:                 int(notes),
+		OtherMembersNotes:     int(counts.OtherMembersNotes),
+		TrashedNotes:          int(counts.TrashedNotes),
+		Milestones:            int(milestones),
+		Goals:                 int(goals),
+	}, nil
+}
+
+func (r *projectRepository) Trash(ctx context.Context, id string, at time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin trash project: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	deletedAt := toPgTimestamptz(at)
+	projectID := toPgUUID(id)
+	tag, err := q.TrashProject(ctx, sqlcgen.TrashProjectParams{DeletedAt: deletedAt, ID: projectID})
+	if err != nil {
+		return fmt.Errorf("trash project: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("project")
+	}
+	if err := q.TrashNotesByProjectID(ctx, sqlcgen.TrashNotesByProjectIDParams{DeletedAt: deletedAt, ProjectID: projectID}); err != nil {
+		return fmt.Errorf("trash project notes: %w", err)
+	}
+	if err := q.TrashStudyLogsByProjectID(ctx, sqlcgen.TrashStudyLogsByProjectIDParams{DeletedAt: deletedAt, ProjectID: projectID}); err != nil {
+		return fmt.Errorf("trash project study logs: %w", err)
+	}
+	if err := q.TrashGoalsByProjectID(ctx, sqlcgen.TrashGoalsByProjectIDParams{DeletedAt: deletedAt, ProjectID: projectID}); err != nil {
+		return fmt.Errorf("trash project goals: %w", err)
+	}
+	if err := q.DeleteTimersByProjectID(ctx, projectID); err != nil {
+		return fmt.Errorf("delete project timers: %w", err)
+	}
+	if err := q.DeletePomodoroSessionsByProjectID(ctx, projectID); err != nil {
+		return fmt.Errorf("delete project pomodoro sessions: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit trash project: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRepository) DeletionImpact(ctx context.Context, id string) (*domain.DeletionImpact, error) {
+	row, err := r.q.GetProjectDeletionImpact(ctx, toPgUUID(id))
+	if err != nil {
+		return nil, fmt.Errorf("count project deletion impact: %w", err)
+	}
+	return &domain.DeletionImpact{
+		StudyLogs:    int(row.StudyLogs),
+		TotalMinutes: int(row.TotalMinutes),
+		Notes:        int(row.Notes),
+		Goals:        int(row.Goals),
+	}, nil
+}
+
+func (r *projectRepository) FindTrashedByID(ctx context.Context, id string) (*domain.Project, error) {
+	row, err := r.q.GetTrashedProjectByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("project")
+		}
+		return nil, fmt.Errorf("find trashed project: %w", err)
+	}
+	return toDomainProject(row), nil
+}
+
+func (r *projectRepository) FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.Project, error) {
+	rows, err := r.q.ListTrashedProjectsByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find trashed projects: %w", err)
+	}
+	projects := make([]*domain.Project, 0, len(rows))
+	for _, row := range rows {
+		projects = append(projects, toDomainProject(row))
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) Restore(ctx context.Context, id string) error {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("delete project: %w", err)
+		return fmt.Errorf("begin restore project: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	projectID := toPgUUID(id)
+	if err := q.RestoreNotesByProjectID(ctx, projectID); err != nil {
+		return fmt.Errorf("restore project notes: %w", err)
+	}
+	if err := q.RestoreStudyLogsByProjectID(ctx, projectID); err != nil {
+		return fmt.Errorf("restore project study logs: %w", err)
+	}
+	if err := q.RestoreGoalsByProjectID(ctx, projectID); err != nil {
+		return fmt.Errorf("restore project goals: %w", err)
+	}
+	tag, err := q.RestoreProject(ctx, projectID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("project with this name already exists for this user")
+		}
+		return fmt.Errorf("restore project: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		return domain.ErrNotFound("project")
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit restore project: %w", err)
+	}
 	return nil
 }
+
+func (r *projectRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.PurgeTrashedProjects(ctx, toPgTimestamptz(before))
+	if err != nil {
+		return 0, fmt.Errorf("purge trashed projects: %w", err)
+	}
+	return n, nil
+}
+
+func toDomainProject(row sqlcgen.Project) *domain.Project {
+	project := domain.ReconstructProject(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.UserID),
+		row.Name,
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+	project.DeletedAt = fromPgTimestamptzPtr(row.DeletedAt)
+	project.ArchivedAt = fromPgTimestamptzPtr(row.ArchivedAt)
+	project.ParentID = fromPgUUIDOrEmpty(row.ParentID)
+	project.Description = row.Description
+	project.Color = row.Color
+	project.Icon = row.Icon
+	project.Status = domain.ProjectStatus(row.Status)
+	project.TargetDate = fromPgDomainDatePtr(row.TargetDate)
+	return project
+}
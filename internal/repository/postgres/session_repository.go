@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type sessionRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewSessionRepository creates a new SessionRepository implementation using PostgreSQL.
+func NewSessionRepository(pool *pgxpool.Pool) port.SessionRepository {
+	return &sessionRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	err := r.q.CreateSession(ctx, sqlcgen.CreateSessionParams{
+		ID:               toPgUUID(session.ID),
+		UserID:           toPgUUID(session.UserID),
+		RefreshTokenHash: session.RefreshTokenHash,
+		UserAgent:        session.UserAgent,
+		IpAddress:        session.IPAddress,
+		CreatedAt:        toPgTimestamptz(session.CreatedAt),
+		LastUsedAt:       toPgTimestamptz(session.LastUsedAt),
+		ExpiresAt:        toPgTimestamptz(session.ExpiresAt),
+	})
+	if err != nil {
+		return fmt.Errorf("insert session: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	row, err := r.q.GetSessionByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("session")
+		}
+		return nil, fmt.Errorf("find session: %w", err)
+	}
+	return toDomainSession(row), nil
+}
+
+func (r *sessionRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	rows, err := r.q.ListSessionsByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find sessions: %w", err)
+	}
+	sessions := make([]*domain.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toDomainSession(row))
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, session *domain.Session, previousHash string) error {
+	tag, err := r.q.RotateSession(ctx, sqlcgen.RotateSessionParams{
+		RefreshTokenHash:         session.RefreshTokenHash,
+		IpAddress:                session.IPAddress,
+		LastUsedAt:               toPgTimestamptz(session.LastUsedAt),
+		ExpiresAt:                toPgTimestamptz(session.ExpiresAt),
+		ID:                       toPgUUID(session.ID),
+		PreviousRefreshTokenHash: previousHash,
+	})
+	if err != nil {
+		return fmt.Errorf("rotate session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrConflict("refresh token was already used")
+	}
+	return nil
+}
+
+func (r *sessionRepository) IsRotatedToken(ctx context.Context, sessionID, tokenHash string) (bool, error) {
+	rotated, err := r.q.IsRotatedRefreshToken(ctx, sqlcgen.IsRotatedRefreshTokenParams{
+		SessionID: toPgUUID(sessionID),
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		return false, fmt.Errorf("find rotated refresh token: %w", err)
+	}
+	return rotated, nil
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.q.DeleteSession(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("session")
+	}
+	return nil
+}
+
+func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	if err := r.q.DeleteSessionsByUserID(ctx, toPgUUID(userID)); err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+	return nil
+}
+
+func toDomainSession(row sqlcgen.Session) *domain.Session {
+	return domain.ReconstructSession(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.UserID),
+		row.RefreshTokenHash,
+		row.UserAgent,
+		row.IpAddress,
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.LastUsedAt),
+		fromPgTimestamptz(row.ExpiresAt),
+	)
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
@@ -28,21 +31,54 @@ func NewStudyLogRepository(pool *pgxpool.Pool) port.StudyLogRepository {
 }
 
 func (r *studyLogRepository) Create(ctx context.Context, log *domain.StudyLog) error {
-	err := r.q.CreateStudyLog(ctx, sqlcgen.CreateStudyLogParams{
-		ID:        toPgUUID(log.ID),
-		UserID:    toPgUUID(log.UserID),
-		ProjectID: toPgUUID(log.ProjectID),
-		StudiedAt: toPgTimestamptz(log.StudiedAt),
-		Minutes:   int32(log.Minutes),
-		Note:      log.Note,
-		CreatedAt: toPgTimestamptz(log.CreatedAt),
-	})
+	err := r.q.CreateStudyLog(ctx, toCreateStudyLogParams(log))
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("study log has already been imported")
+		}
 		return fmt.Errorf("insert study log: %w", err)
 	}
 	return nil
 }
 
+func (r *studyLogRepository) CreateBatch(ctx context.Context, logs []*domain.StudyLog) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin create study logs: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	params := make([]sqlcgen.CreateStudyLogsParams, len(logs))
+	for i, log := range logs {
+		params[i] = sqlcgen.CreateStudyLogsParams{
+			ID:             toPgUUID(log.ID),
+			UserID:         toPgUUID(log.UserID),
+			ProjectID:      toPgUUID(log.ProjectID),
+			ActivityTypeID: toPgUUIDOrNull(log.ActivityTypeID),
+			StudiedAt:      toPgTimestamptz(log.StudiedAt),
+			Minutes:        int32(log.Minutes),
+			Note:           log.Note,
+			Pomodoros:      int32(log.Pomodoros),
+			CreatedAt:      toPgTimestamptz(log.CreatedAt),
+			UpdatedAt:      toPgTimestamptz(log.UpdatedAt),
+		}
+	}
+	var batchErr error
+	r.q.WithTx(tx).CreateStudyLogs(ctx, params).Exec(func(i int, err error) {
+		if err != nil && batchErr == nil {
+			batchErr = fmt.Errorf("insert study log %d: %w", i, err)
+		}
+	})
+	if batchErr != nil {
+		return batchErr
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit create study logs: %w", err)
+	}
+	return nil
+}
+
 func (r *studyLogRepository) FindByID(ctx context.Context, id string) (*domain.StudyLog, error) {
 	row, err := r.q.GetStudyLogByID(ctx, toPgUUID(id))
 	if err != nil {
@@ -55,17 +91,20 @@ func (r *studyLogRepository) FindByID(ctx context.Context, id string) (*domain.S
 		fromPgUUID(row.ID),
 		fromPgUUID(row.UserID),
 		fromPgUUID(row.ProjectID),
+		fromPgUUIDOrEmpty(row.ActivityTypeID),
 		fromPgTimestamptz(row.StudiedAt),
 		int(row.Minutes),
 		row.Note,
+		int(row.Pomodoros),
 		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
 	), nil
 }
 
 // FindByUserID uses dynamic SQL for flexible filtering, so it bypasses sqlcgen.
-func (r *studyLogRepository) FindByUserID(ctx context.Context, userID string, filter port.StudyLogFilter) ([]*domain.StudyLog, error) {
+func (r *studyLogRepository) FindByUserID(ctx context.Context, userID string, filter port.StudyLogFilter, page port.PageRequest) ([]*domain.StudyLog, error) {
 	query := strings.Builder{}
-	query.WriteString(`SELECT id, user_id, project_id, studied_at, minutes, note, created_at FROM study_logs WHERE user_id = $1`)
+	query.WriteString(`SELECT id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at FROM study_logs WHERE user_id = $1 AND deleted_at IS NULL`)
 
 	args := []any{userID}
 	paramIdx := 2
@@ -83,9 +122,34 @@ func (r *studyLogRepository) FindByUserID(ctx context.Context, userID string, fi
 	if filter.ProjectID != nil {
 		query.WriteString(fmt.Sprintf(` AND project_id = $%d`, paramIdx))
 		args = append(args, *filter.ProjectID)
+		paramIdx++
+	}
+	if filter.ActivityTypeID != nil {
+		query.WriteString(fmt.Sprintf(` AND activity_type_id = $%d`, paramIdx))
+		args = append(args, *filter.ActivityTypeID)
+		paramIdx++
 	}
 
-	query.WriteString(` ORDER BY studied_at DESC`)
+	// Oldest first only when asked for; keyset conditions and ordering follow idx_study_logs_user_studied_at.
+	ascending := isSort(page, domain.SortByStudiedAt, false)
+	if page.After != nil {
+		op := "<"
+		if ascending {
+			op = ">"
+		}
+		query.WriteString(fmt.Sprintf(` AND (studied_at, id) %s ($%d, $%d)`, op, paramIdx, paramIdx+1))
+		args = append(args, page.After.Time, toPgUUIDOrNull(page.After.ID))
+		paramIdx += 2
+	}
+	if ascending {
+		query.WriteString(` ORDER BY studied_at, id`)
+	} else {
+		query.WriteString(` ORDER BY studied_at DESC, id DESC`)
+	}
+	if page.Limit > 0 {
+		query.WriteString(fmt.Sprintf(` LIMIT $%d`, paramIdx))
+		args = append(args, page.Limit)
+	}
 
 	rows, err := r.pool.Query(ctx, query.String(), args...)
 	if err != nil {
@@ -96,21 +160,188 @@ func (r *studyLogRepository) FindByUserID(ctx context.Context, userID string, fi
 	var logs []*domain.StudyLog
 	for rows.Next() {
 		var l domain.StudyLog
-		if err := rows.Scan(&l.ID, &l.UserID, &l.ProjectID, &l.StudiedAt, &l.Minutes, &l.Note, &l.CreatedAt); err != nil {
+		var activityTypeID pgtype.UUID
+		if err := rows.Scan(&l.ID, &l.UserID, &l.ProjectID, &activityTypeID, &l.StudiedAt, &l.Minutes, &l.Note, &l.Pomodoros, &l.CreatedAt, &l.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("scan study log: %w", err)
 		}
-		logs = append(logs, domain.ReconstructStudyLog(l.ID, l.UserID, l.ProjectID, l.StudiedAt, l.Minutes, l.Note, l.CreatedAt))
+		logs = append(logs, domain.ReconstructStudyLog(l.ID, l.UserID, l.ProjectID, fromPgUUIDOrEmpty(activityTypeID), l.StudiedAt, l.Minutes, l.Note, l.Pomodoros, l.CreatedAt, l.UpdatedAt))
 	}
 	return logs, rows.Err()
 }
 
-func (r *studyLogRepository) Delete(ctx context.Context, id string) error {
-	tag, err := r.q.DeleteStudyLog(ctx, toPgUUID(id))
+func (r *studyLogRepository) FindImportKeys(ctx context.Context, userID string, keys []string) ([]string, error) {
+	found, err := r.q.ListStudyLogImportKeys(ctx, sqlcgen.ListStudyLogImportKeysParams{
+		UserID:     toPgUUID(userID),
+		ImportKeys: nonNilStrings(keys),
+	})
 	if err != nil {
-		return fmt.Errorf("delete study log: %w", err)
+		return nil, fmt.Errorf("find study log import keys: %w", err)
+	}
+	return found, nil
+}
+
+func (r *studyLogRepository) Update(ctx context.Context, log *domain.StudyLog, revision *domain.StudyLogRevision) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin update study log: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	tag, err := q.UpdateStudyLog(ctx, sqlcgen.UpdateStudyLogParams{
+		ProjectID:      toPgUUID(log.ProjectID),
+		ActivityTypeID: toPgUUIDOrNull(log.ActivityTypeID),
+		StudiedAt:      toPgTimestamptz(log.StudiedAt),
+		Minutes:        int32(log.Minutes),
+		Note:           log.Note,
+		UpdatedAt:      toPgTimestamptz(log.UpdatedAt),
+		ID:             toPgUUID(log.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("update study log: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		return domain.ErrNotFound("study log")
 	}
+	err = q.CreateStudyLogRevision(ctx, sqlcgen.CreateStudyLogRevisionParams{
+		ID:             toPgUUID(revision.ID),
+		StudyLogID:     toPgUUID(revision.StudyLogID),
+		EditedBy:       toPgUUIDOrNull(revision.EditedBy),
+		ProjectID:      toPgUUID(revision.ProjectID),
+		ActivityTypeID: toPgUUIDOrNull(revision.ActivityTypeID),
+		StudiedAt:      toPgTimestamptz(revision.StudiedAt),
+		Minutes:        int32(revision.Minutes),
+		Note:           revision.Note,
+		CreatedAt:      toPgTimestamptz(revision.CreatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("insert study log revision: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit update study log: %w", err)
+	}
 	return nil
 }
+
+func (r *studyLogRepository) FindRevisionByID(ctx context.Context, id string) (*domain.StudyLogRevision, error) {
+	row, err := r.q.GetStudyLogRevisionByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("study log revision")
+		}
+		return nil, fmt.Errorf("find study log revision: %w", err)
+	}
+	return toDomainStudyLogRevision(row), nil
+}
+
+func (r *studyLogRepository) FindRevisions(ctx context.Context, studyLogID string) ([]*domain.StudyLogRevision, error) {
+	rows, err := r.q.ListStudyLogRevisions(ctx, toPgUUID(studyLogID))
+	if err != nil {
+		return nil, fmt.Errorf("find study log revisions: %w", err)
+	}
+	revisions := make([]*domain.StudyLogRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, toDomainStudyLogRevision(row))
+	}
+	return revisions, nil
+}
+
+func toCreateStudyLogParams(log *domain.StudyLog) sqlcgen.CreateStudyLogParams {
+	return sqlcgen.CreateStudyLogParams{
+		ID:             toPgUUID(log.ID),
+		UserID:         toPgUUID(log.UserID),
+		ProjectID:      toPgUUID(log.ProjectID),
+		ActivityTypeID: toPgUUIDOrNull(log.ActivityTypeID),
+		StudiedAt:      toPgTimestamptz(log.StudiedAt),
+		Minutes:        int32(log.Minutes),
+		Note:           log.Note,
+		Pomodoros:      int32(log.Pomodoros),
+		ImportKey:      toPgTextOrNull(log.ImportKey),
+		CreatedAt:      toPgTimestamptz(log.CreatedAt),
+		UpdatedAt:      toPgTimestamptz(log.UpdatedAt),
+	}
+}
+
+func toDomainStudyLogRevision(row sqlcgen.StudyLogRevision) *domain.StudyLogRevision {
+	return domain.ReconstructStudyLogRevision(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.StudyLogID),
+		fromPgUUIDOrEmpty(row.EditedBy),
+		fromPgUUID(row.ProjectID),
+		fromPgUUIDOrEmpty(row.ActivityTypeID),
+		fromPgTimestamptz(row.StudiedAt),
+		int(row.Minutes),
+		row.Note,
+		fromPgTimestamptz(row.CreatedAt),
+	)
+}
+
+func (r *studyLogRepository) Trash(ctx context.Context, id string, at time.Time) error {
+	tag, err := r.q.TrashStudyLog(ctx, sqlcgen.TrashStudyLogParams{DeletedAt: toPgTimestamptz(at), ID: toPgUUID(id)})
+	if err != nil {
+		return fmt.Errorf("trash study log: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("study log")
+	}
+	return nil
+}
+
+func (r *studyLogRepository) FindTrashedByID(ctx context.Context, id string) (*domain.StudyLog, error) {
+	row, err := r.q.GetTrashedStudyLogByID(ctx, toPgUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("study log")
+		}
+		return nil, fmt.Errorf("find trashed study log: %w", err)
+	}
+	return toDomainTrashedStudyLog(sqlcgen.ListTrashedStudyLogsByUserIDRow(row)), nil
+}
+
+func (r *studyLogRepository) FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.StudyLog, error) {
+	rows, err := r.q.ListTrashedStudyLogsByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("find trashed study logs: %w", err)
+	}
+	logs := make([]*domain.StudyLog, 0, len(rows))
+	for _, row := range rows {
+		logs = append(logs, toDomainTrashedStudyLog(row))
+	}
+	return logs, nil
+}
+
+func (r *studyLogRepository) Restore(ctx context.Context, id string) error {
+	tag, err := r.q.RestoreStudyLog(ctx, toPgUUID(id))
+	if err != nil {
+		return fmt.Errorf("restore study log: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("study log")
+	}
+	return nil
+}
+
+func (r *studyLogRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.PurgeTrashedStudyLogs(ctx, toPgTimestamptz(before))
+	if err != nil {
+		return 0, fmt.Errorf("purge trashed study logs: %w", err)
+	}
+	return n, nil
+}
+
+func toDomainTrashedStudyLog(row sqlcgen.ListTrashedStudyLogsByUserIDRow) *domain.StudyLog {
+	log := domain.ReconstructStudyLog(
+		fromPgUUID(row.ID),
+		fromPgUUID(row.UserID),
+		fromPgUUID(row.ProjectID),
+		fromPgUUIDOrEmpty(row.ActivityTypeID),
+		fromPgTimestamptz(row.StudiedAt),
+		int(row.Minutes),
+		row.Note,
+		int(row.Pomodoros),
+		fromPgTimestamptz(row.CreatedAt),
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+	log.DeletedAt = fromPgTimestamptzPtr(row.DeletedAt)
+	return log
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/repository/sqlcgen"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+type timerRepository struct {
+	q *sqlcgen.Queries
+}
+
+// NewTimerRepository creates a new TimerRepository implementation using PostgreSQL.
+func NewTimerRepository(pool *pgxpool.Pool) port.TimerRepository {
+	return &timerRepository{q: sqlcgen.New(pool)}
+}
+
+func (r *timerRepository) Create(ctx context.Context, timer *domain.Timer) error {
+	err := r.q.CreateTimer(ctx, sqlcgen.CreateTimerParams{
+		UserID:         toPgUUID(timer.UserID),
+		ProjectID:      toPgUUID(timer.ProjectID),
+		Note:           timer.Note,
+		StartedAt:      toPgTimestamptz(timer.StartedAt),
+		ResumedAt:      toPgTimestamptzPtr(timer.ResumedAt),
+		ElapsedSeconds: int32(timer.Elapsed / time.Second),
+		UpdatedAt:      toPgTimestamptz(timer.UpdatedAt),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("a timer is already active for this user")
+		}
+		return fmt.Errorf("insert timer: %w", err)
+	}
+	return nil
+}
+
+func (r *timerRepository) FindByUserID(ctx context.Context, userID string) (*domain.Timer, error) {
+	row, err := r.q.GetTimerByUserID(ctx, toPgUUID(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("timer")
+		}
+		return nil, fmt.Errorf("find timer: %w", err)
+	}
+	return toDomainTimer(row), nil
+}
+
+func (r *timerRepository) FindExpired(ctx context.Context, now time.Time) ([]*domain.Timer, error) {
+	rows, err := r.q.ListExpiredTimers(ctx, sqlcgen.ListExpiredTimersParams{
+		Now:        toPgTimestamptz(now),
+		MaxSeconds: int32(domain.MaxTimerDuration / time.Second),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find expired timers: %w", err)
+	}
+	timers := make([]*domain.Timer, 0, len(rows))
+	for _, row := range rows {
+		timers = append(timers, toDomainTimer(row))
+	}
+	return timers, nil
+}
+
+func (r *timerRepository) Update(ctx context.Context, timer *domain.Timer) error {
+	tag, err := r.q.UpdateTimer(ctx, sqlcgen.UpdateTimerParams{
+		ResumedAt:      toPgTimestamptzPtr(timer.ResumedAt),
+		ElapsedSeconds: int32(timer.Elapsed / time.Second),
+		UpdatedAt:      toPgTimestamptz(timer.UpdatedAt),
+		UserID:         toPgUUID(timer.UserID),
+		StartedAt:      toPgTimestamptz(timer.StartedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("update timer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("timer")
+	}
+	return nil
+}
+
+func (r *timerRepository) Delete(ctx context.Context, timer *domain.Timer) error {
+	tag, err := r.q.DeleteTimer(ctx, sqlcgen.DeleteTimerParams{
+		UserID:    toPgUUID(timer.UserID),
+		StartedAt: toPgTimestamptz(timer.StartedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("delete timer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("timer")
+	}
+	return nil
+}
+
+func toDomainTimer(row sqlcgen.Timer) *domain.Timer {
+	return domain.ReconstructTimer(
+		fromPgUUID(row.UserID),
+		fromPgUUID(row.ProjectID),
+		row.Note,
+		fromPgTimestamptz(row.StartedAt),
+		fromPgTimestamptzPtr(row.ResumedAt),
+		time.Duration(row.ElapsedSeconds)*time.Second,
+		fromPgTimestamptz(row.UpdatedAt),
+	)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
@@ -14,22 +16,37 @@ import (
 )
 
 type userRepository struct {
-	q *sqlcgen.Queries
+	pool *pgxpool.Pool
+	q    *sqlcgen.Queries
 }
 
 // NewUserRepository creates a new UserRepository implementation using PostgreSQL.
 func NewUserRepository(pool *pgxpool.Pool) port.UserRepository {
-	return &userRepository{q: sqlcgen.New(pool)}
+	return &userRepository{pool: pool, q: sqlcgen.New(pool)}
 }
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	err := r.q.CreateUser(ctx, sqlcgen.CreateUserParams{
-		ID:        toPgUUID(user.ID),
-		Name:      user.Name,
-		CreatedAt: toPgTimestamptz(user.CreatedAt),
-		UpdatedAt: toPgTimestamptz(user.UpdatedAt),
+		ID:                    toPgUUID(user.ID),
+		Name:                  user.Name,
+		Email:                 user.Email,
+		PasswordHash:          user.PasswordHash,
+		TotpSecret:            user.TwoFactor.TOTPSecret,
+		TotpEnabled:           user.TwoFactor.TOTPEnabled,
+		TotpLastStep:          user.TwoFactor.TOTPLastStep,
+		RecoveryCodeHashes:    nonNilStrings(user.TwoFactor.RecoveryCodeHashes),
+		Timezone:              user.Preferences.Timezone,
+		WeekStart:             int16(user.Preferences.WeekStart),
+		Locale:                user.Preferences.Locale,
+		DefaultSessionMinutes: int32(user.Preferences.DefaultSessionMinutes),
+		CreatedAt:             toPgTimestamptz(user.CreatedAt),
+		UpdatedAt:             toPgTimestamptz(user.UpdatedAt),
 	})
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("user with this email already exists")
+		}
 		return fmt.Errorf("insert user: %w", err)
 	}
 	return nil
@@ -43,10 +60,138 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*domain.User,
 		}
 		return nil, fmt.Errorf("find user: %w", err)
 	}
+	return toDomainUser(row), nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	row, err := r.q.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("user")
+		}
+		return nil, fmt.Errorf("find user by email: %w", err)
+	}
+	return toDomainUser(row), nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	tag, err := r.q.UpdateUser(ctx, sqlcgen.UpdateUserParams{
+		Name:                  user.Name,
+		Email:                 user.Email,
+		PasswordHash:          user.PasswordHash,
+		TotpSecret:            user.TwoFactor.TOTPSecret,
+		TotpEnabled:           user.TwoFactor.TOTPEnabled,
+		TotpLastStep:          user.TwoFactor.TOTPLastStep,
+		RecoveryCodeHashes:    nonNilStrings(user.TwoFactor.RecoveryCodeHashes),
+		Timezone:              user.Preferences.Timezone,
+		WeekStart:             int16(user.Preferences.WeekStart),
+		Locale:                user.Preferences.Locale,
+		DefaultSessionMinutes: int32(user.Preferences.DefaultSessionMinutes),
+		UpdatedAt:             toPgTimestamptz(user.UpdatedAt),
+		ID:                    toPgUUID(user.ID),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrConflict("user with this email already exists")
+		}
+		return fmt.Errorf("update user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("user")
+	}
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) (*domain.UserDeletionSummary, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin delete user: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	q := r.q.WithTx(tx)
+	userID := toPgUUID(id)
+	// Delete children before projects so the counts are not hidden by cascades.
+	notes, err := q.DeleteNotesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user notes: %w", err)
+	}
+	studyLogs, err := q.DeleteStudyLogsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user study logs: %w", err)
+	}
+	otherNotes, err := q.DeleteNotesInProjectsOfUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete notes in user projects: %w", err)
+	}
+	otherStudyLogs, err := q.DeleteStudyLogsInProjectsOfUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete study logs in user projects: %w", err)
+	}
+	goals, err := q.DeleteGoalsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user goals: %w", err)
+	}
+	projects, err := q.DeleteProjectsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user projects: %w", err)
+	}
+	users, err := q.DeleteUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	if users == 0 {
+		return nil, domain.ErrNotFound("user")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit delete user: %w", err)
+	}
+	return &domain.UserDeletionSummary{
+		Projects:              int(projects),
+		StudyLogs:             int(studyLogs + otherStudyLogs),
+		OtherMembersStudyLogs: int(otherStudyLogs),
+		Goals:                 int(goals),
+		Notes:                 int(notes + otherNotes),
+		OtherMembersNotes:     int(otherNotes),
+	}, nil
+}
+
+func (r *userRepository) DeletionImpact(ctx context.Context, id string) (*domain.DeletionImpact, error) {
+	row, err := r.q.GetUserDeletionImpact(ctx, toPgUUID(id))
+	if err != nil {
+		return nil, fmt.Errorf("count user deletion impact: %w", err)
+	}
+	return &domain.DeletionImpact{
+		Projects:              int(row.Projects),
+		StudyLogs:             int(row.StudyLogs),
+		TotalMinutes:          int(row.TotalMinutes),
+		OtherMembersStudyLogs: int(row.OtherMembersStudyLogs),
+		Notes:                 int(row.Notes),
+		OtherMembersNotes:     int(row.OtherMembersNotes),
+		Goals:                 int(row.Goals),
+	}, nil
+}
+
+func toDomainUser(row sqlcgen.User) *domain.User {
 	return domain.ReconstructUser(
 		fromPgUUID(row.ID),
 		row.Name,
+		row.Email,
+		row.PasswordHash,
+		domain.TwoFactor{
+			TOTPSecret:         row.TotpSecret,
+			TOTPEnabled:        row.TotpEnabled,
+			TOTPLastStep:       row.TotpLastStep,
+			RecoveryCodeHashes: row.RecoveryCodeHashes,
+		},
+		domain.UserPreferences{
+			Timezone:              row.Timezone,
+			WeekStart:             time.Weekday(row.WeekStart),
+			Locale:                row.Locale,
+			DefaultSessionMinutes: int(row.DefaultSessionMinutes),
+		},
 		fromPgTimestamptz(row.CreatedAt),
 		fromPgTimestamptz(row.UpdatedAt),
-	), nil
+	)
 }
@@ -11,15 +11,92 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const listGoalsByUserID = `-- name: ListGoalsByUserID :many
-SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at
+const deleteGoalsByUserID = `-- name: DeleteGoalsByUserID :execrows
+DELETE FROM goals WHERE user_id = $1
+`
+
+func (q *Queries) DeleteGoalsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteGoalsByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listGoalsByCreatedAt = `-- name: ListGoalsByCreatedAt :many
+SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at, deleted_at
+FROM goals
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) > ($2::timestamptz, $3::uuid))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListGoalsByCreatedAtParams struct {
+	UserID         pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListGoalsByCreatedAt(ctx context.Context, arg ListGoalsByCreatedAtParams) ([]Goal, error) {
+	rows, err := q.db.Query(ctx, listGoalsByCreatedAt,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.TargetMinutesPerWeek,
+			&i.StartDate,
+			&i.EndDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGoalsByCreatedAtDesc = `-- name: ListGoalsByCreatedAtDesc :many
+SELECT id, user_id, project_id, target_minutes_per_week, start_date, end_date, created_at, updated_at, deleted_at
 FROM goals
-WHERE user_id = $1
-ORDER BY created_at
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
 `
 
-func (q *Queries) ListGoalsByUserID(ctx context.Context, userID pgtype.UUID) ([]Goal, error) {
-	rows, err := q.db.Query(ctx, listGoalsByUserID, userID)
+type ListGoalsByCreatedAtDescParams struct {
+	UserID         pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListGoalsByCreatedAtDesc(ctx context.Context, arg ListGoalsByCreatedAtDescParams) ([]Goal, error) {
+	rows, err := q.db.Query(ctx, listGoalsByCreatedAtDesc,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +113,7 @@ func (q *Queries) ListGoalsByUserID(ctx context.Context, userID pgtype.UUID) ([]
 			&i.EndDate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
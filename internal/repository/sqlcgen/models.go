@@ -8,6 +8,22 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ActivityType struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type ChecklistItem struct {
+	ID          pgtype.UUID
+	MilestoneID pgtype.UUID
+	Title       string
+	Done        bool
+	Position    int32
+}
+
 type Goal struct {
 	ID                   pgtype.UUID
 	UserID               pgtype.UUID
@@ -17,6 +33,26 @@ type Goal struct {
 	EndDate              pgtype.Date
 	CreatedAt            pgtype.Timestamptz
 	UpdatedAt            pgtype.Timestamptz
+	DeletedAt            pgtype.Timestamptz
+}
+
+type MfaChallenge struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	Attempts  int32
+	ExpiresAt pgtype.Timestamptz
+}
+
+type Milestone struct {
+	ID          pgtype.UUID
+	ProjectID   pgtype.UUID
+	UserID      pgtype.UUID
+	Title       string
+	DueDate     pgtype.Date
+	Position    int32
+	CompletedAt pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
 }
 
 type Note struct {
@@ -28,29 +64,132 @@ type Note struct {
 	Tags      []string
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+}
+
+type NoteStudyLog struct {
+	NoteID     pgtype.UUID
+	StudyLogID pgtype.UUID
+	CreatedAt  pgtype.Timestamptz
+}
+
+type PersonalAccessToken struct {
+	ID         pgtype.UUID
+	UserID     pgtype.UUID
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	LastUsedAt pgtype.Timestamptz
+	ExpiresAt  pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+}
+
+type PomodoroSession struct {
+	UserID             pgtype.UUID
+	ProjectID          pgtype.UUID
+	Note               string
+	WorkMinutes        int32
+	BreakMinutes       int32
+	Cycles             int32
+	Phase              string
+	PhaseStartedAt     pgtype.Timestamptz
+	CompletedPomodoros int32
+	StartedAt          pgtype.Timestamptz
+	UpdatedAt          pgtype.Timestamptz
 }
 
 type Project struct {
-	ID        pgtype.UUID
-	UserID    pgtype.UUID
-	Name      string
-	CreatedAt pgtype.Timestamptz
-	UpdatedAt pgtype.Timestamptz
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Name        string
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	DeletedAt   pgtype.Timestamptz
+	ArchivedAt  pgtype.Timestamptz
+	ParentID    pgtype.UUID
+	Description string
+	Color       string
+	Icon        string
+	Status      string
+	TargetDate  pgtype.Date
+}
+
+type ProjectMember struct {
+	ProjectID  pgtype.UUID
+	UserID     pgtype.UUID
+	Role       string
+	InvitedBy  pgtype.UUID
+	InvitedAt  pgtype.Timestamptz
+	AcceptedAt pgtype.Timestamptz
+}
+
+type RotatedRefreshToken struct {
+	TokenHash string
+	SessionID pgtype.UUID
+	RotatedAt pgtype.Timestamptz
+}
+
+type Session struct {
+	ID               pgtype.UUID
+	UserID           pgtype.UUID
+	RefreshTokenHash string
+	UserAgent        string
+	IpAddress        string
+	CreatedAt        pgtype.Timestamptz
+	LastUsedAt       pgtype.Timestamptz
+	ExpiresAt        pgtype.Timestamptz
 }
 
 type StudyLog struct {
-	ID        pgtype.UUID
-	UserID    pgtype.UUID
-	ProjectID pgtype.UUID
-	StudiedAt pgtype.Timestamptz
-	Minutes   int32
-	Note      string
-	CreatedAt pgtype.Timestamptz
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	CreatedAt      pgtype.Timestamptz
+	ImportKey      pgtype.Text
+	UpdatedAt      pgtype.Timestamptz
+	Pomodoros      int32
+	ActivityTypeID pgtype.UUID
+	DeletedAt      pgtype.Timestamptz
+}
+
+type StudyLogRevision struct {
+	ID             pgtype.UUID
+	StudyLogID     pgtype.UUID
+	EditedBy       pgtype.UUID
+	ProjectID      pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	CreatedAt      pgtype.Timestamptz
+	ActivityTypeID pgtype.UUID
+}
+
+type Timer struct {
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	Note           string
+	StartedAt      pgtype.Timestamptz
+	ResumedAt      pgtype.Timestamptz
+	ElapsedSeconds int32
+	UpdatedAt      pgtype.Timestamptz
 }
 
 type User struct {
-	ID        pgtype.UUID
-	Name      string
-	CreatedAt pgtype.Timestamptz
-	UpdatedAt pgtype.Timestamptz
+	ID                    pgtype.UUID
+	Name                  string
+	CreatedAt             pgtype.Timestamptz
+	UpdatedAt             pgtype.Timestamptz
+	Email                 string
+	PasswordHash          string
+	TotpSecret            string
+	TotpEnabled           bool
+	TotpLastStep          int64
+	RecoveryCodeHashes    []string
+	Timezone              string
+	WeekStart             int16
+	Locale                string
+	DefaultSessionMinutes int32
 }
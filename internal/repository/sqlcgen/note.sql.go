@@ -42,18 +42,35 @@ func (q *Queries) CreateNote(ctx context.Context, arg CreateNoteParams) error {
 	return err
 }
 
-const deleteNote = `-- name: DeleteNote :execresult
-DELETE FROM notes WHERE id = $1
+const deleteNotesByUserID = `-- name: DeleteNotesByUserID :execrows
+DELETE FROM notes WHERE user_id = $1
 `
 
-func (q *Queries) DeleteNote(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, deleteNote, id)
+func (q *Queries) DeleteNotesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotesByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteNotesInProjectsOfUser = `-- name: DeleteNotesInProjectsOfUser :execrows
+DELETE FROM notes WHERE project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)
+`
+
+// Deletes the notes other members wrote in the user's projects, once the user's own are deleted.
+func (q *Queries) DeleteNotesInProjectsOfUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotesInProjectsOfUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getNoteByID = `-- name: GetNoteByID :one
-SELECT id, project_id, user_id, title, content, tags, created_at, updated_at
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
 FROM notes
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetNoteByID(ctx context.Context, id pgtype.UUID) (Note, error) {
@@ -68,19 +85,57 @@ func (q *Queries) GetNoteByID(ctx context.Context, id pgtype.UUID) (Note, error)
 		&i.Tags,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const listNotesByProjectID = `-- name: ListNotesByProjectID :many
-SELECT id, project_id, user_id, title, content, tags, created_at, updated_at
+const getTrashedNoteByID = `-- name: GetTrashedNoteByID :one
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
 FROM notes
-WHERE project_id = $1
-ORDER BY updated_at DESC
+WHERE id = $1 AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) ListNotesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]Note, error) {
-	rows, err := q.db.Query(ctx, listNotesByProjectID, projectID)
+func (q *Queries) GetTrashedNoteByID(ctx context.Context, id pgtype.UUID) (Note, error) {
+	row := q.db.QueryRow(ctx, getTrashedNoteByID, id)
+	var i Note
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.Tags,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listNotesByCreatedAt = `-- name: ListNotesByCreatedAt :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) > ($2::timestamptz, $3::uuid))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListNotesByCreatedAtParams struct {
+	ProjectID      pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListNotesByCreatedAt(ctx context.Context, arg ListNotesByCreatedAtParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listNotesByCreatedAt,
+		arg.ProjectID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +152,7 @@ func (q *Queries) ListNotesByProjectID(ctx context.Context, projectID pgtype.UUI
 			&i.Tags,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -108,8 +164,233 @@ func (q *Queries) ListNotesByProjectID(ctx context.Context, projectID pgtype.UUI
 	return items, nil
 }
 
+const listNotesByCreatedAtDesc = `-- name: ListNotesByCreatedAtDesc :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+
+type ListNotesByCreatedAtDescParams struct {
+	ProjectID      pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListNotesByCreatedAtDesc(ctx context.Context, arg ListNotesByCreatedAtDescParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listNotesByCreatedAtDesc,
+		arg.ProjectID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Tags,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotesByUpdatedAt = `-- name: ListNotesByUpdatedAt :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (updated_at, id) > ($2::timestamptz, $3::uuid))
+ORDER BY updated_at, id
+LIMIT $4
+`
+
+type ListNotesByUpdatedAtParams struct {
+	ProjectID      pgtype.UUID
+	AfterUpdatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListNotesByUpdatedAt(ctx context.Context, arg ListNotesByUpdatedAtParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listNotesByUpdatedAt,
+		arg.ProjectID,
+		arg.AfterUpdatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Tags,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotesByUpdatedAtDesc = `-- name: ListNotesByUpdatedAtDesc :many
+SELECT id, project_id, user_id, title, content, tags, created_at, updated_at, deleted_at
+FROM notes
+WHERE project_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (updated_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY updated_at DESC, id DESC
+LIMIT $4
+`
+
+type ListNotesByUpdatedAtDescParams struct {
+	ProjectID      pgtype.UUID
+	AfterUpdatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListNotesByUpdatedAtDesc(ctx context.Context, arg ListNotesByUpdatedAtDescParams) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listNotesByUpdatedAtDesc,
+		arg.ProjectID,
+		arg.AfterUpdatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Tags,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedNotesByUserID = `-- name: ListTrashedNotesByUserID :many
+SELECT n.id, n.project_id, n.user_id, n.title, n.content, n.tags, n.created_at, n.updated_at, n.deleted_at
+FROM notes n
+JOIN projects p ON p.id = n.project_id
+WHERE n.user_id = $1 AND n.deleted_at IS NOT NULL AND p.deleted_at IS NULL
+ORDER BY n.deleted_at DESC, n.id
+`
+
+// Notes trashed with their project are left out; they come back when the project is restored.
+func (q *Queries) ListTrashedNotesByUserID(ctx context.Context, userID pgtype.UUID) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listTrashedNotesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Tags,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeTrashedNotes = `-- name: PurgeTrashedNotes :execrows
+DELETE FROM notes WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeTrashedNotes(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedNotes, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreNote = `-- name: RestoreNote :execresult
+UPDATE notes SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreNote(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, restoreNote, id)
+}
+
+const trashNote = `-- name: TrashNote :execresult
+UPDATE notes SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL
+`
+
+type TrashNoteParams struct {
+	DeletedAt pgtype.Timestamptz
+	ID        pgtype.UUID
+}
+
+func (q *Queries) TrashNote(ctx context.Context, arg TrashNoteParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, trashNote, arg.DeletedAt, arg.ID)
+}
+
 const updateNote = `-- name: UpdateNote :execresult
-UPDATE notes SET title = $1, content = $2, tags = $3, updated_at = $4 WHERE id = $5
+UPDATE notes SET title = $1, content = $2, tags = $3, updated_at = $4 WHERE id = $5 AND deleted_at IS NULL
 `
 
 type UpdateNoteParams struct {
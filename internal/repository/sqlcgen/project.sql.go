@@ -13,41 +13,99 @@ import (
 )
 
 const createProject = `-- name: CreateProject :exec
-INSERT INTO projects (id, user_id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO projects (id, user_id, parent_id, name, description, color, icon, status, target_date, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateProjectParams struct {
-	ID        pgtype.UUID
-	UserID    pgtype.UUID
-	Name      string
-	CreatedAt pgtype.Timestamptz
-	UpdatedAt pgtype.Timestamptz
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	ParentID    pgtype.UUID
+	Name        string
+	Description string
+	Color       string
+	Icon        string
+	Status      string
+	TargetDate  pgtype.Date
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) error {
 	_, err := q.db.Exec(ctx, createProject,
 		arg.ID,
 		arg.UserID,
+		arg.ParentID,
 		arg.Name,
+		arg.Description,
+		arg.Color,
+		arg.Icon,
+		arg.Status,
+		arg.TargetDate,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
 	return err
 }
 
-const deleteProject = `-- name: DeleteProject :execresult
-DELETE FROM projects WHERE id = $1
+const deleteGoalsByProjectID = `-- name: DeleteGoalsByProjectID :execrows
+DELETE FROM goals WHERE project_id = $1
+`
+
+func (q *Queries) DeleteGoalsByProjectID(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteGoalsByProjectID, projectID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePomodoroSessionsByProjectID = `-- name: DeletePomodoroSessionsByProjectID :exec
+DELETE FROM pomodoro_sessions WHERE project_id = $1
+`
+
+func (q *Queries) DeletePomodoroSessionsByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deletePomodoroSessionsByProjectID, projectID)
+	return err
+}
+
+const deleteProject = `-- name: DeleteProject :execrows
+DELETE FROM projects WHERE id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, deleteProject, id)
+func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProject, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProjectsByUserID = `-- name: DeleteProjectsByUserID :execrows
+DELETE FROM projects WHERE user_id = $1
+`
+
+func (q *Queries) DeleteProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProjectsByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTimersByProjectID = `-- name: DeleteTimersByProjectID :exec
+DELETE FROM timers WHERE project_id = $1
+`
+
+func (q *Queries) DeleteTimersByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTimersByProjectID, projectID)
+	return err
 }
 
 const getProjectByID = `-- name: GetProjectByID :one
-SELECT id, user_id, name, created_at, updated_at
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
 FROM projects
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (Project, error) {
@@ -59,19 +117,353 @@ func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (Project,
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Status,
+		&i.TargetDate,
+	)
+	return i, err
+}
+
+const getProjectDeletionImpact = `-- name: GetProjectDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NULL) AS study_logs,
+    (SELECT COALESCE(SUM(s.minutes), 0) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NULL)::bigint AS total_minutes,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.deleted_at IS NULL) AS notes,
+    (SELECT COUNT(*) FROM goals g WHERE g.project_id = $1 AND g.deleted_at IS NULL) AS goals
+`
+
+type GetProjectDeletionImpactRow struct {
+	StudyLogs    int64
+	TotalMinutes int64
+	Notes        int64
+	Goals        int64
+}
+
+// Counts what trashing the project would move to the trash with it.
+func (q *Queries) GetProjectDeletionImpact(ctx context.Context, projectID pgtype.UUID) (GetProjectDeletionImpactRow, error) {
+	row := q.db.QueryRow(ctx, getProjectDeletionImpact, projectID)
+	var i GetProjectDeletionImpactRow
+	err := row.Scan(
+		&i.StudyLogs,
+		&i.TotalMinutes,
+		&i.Notes,
+		&i.Goals,
+	)
+	return i, err
+}
+
+const getProjectMergeCounts = `-- name: GetProjectMergeCounts :one
+SELECT
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.user_id <> p.user_id) AS other_members_study_logs,
+    (SELECT COUNT(*) FROM study_logs s WHERE s.project_id = $1 AND s.deleted_at IS NOT NULL) AS trashed_study_logs,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.user_id <> p.user_id) AS other_members_notes,
+    (SELECT COUNT(*) FROM notes n WHERE n.project_id = $1 AND n.deleted_at IS NOT NULL) AS trashed_notes
+FROM projects p
+WHERE p.id = $1
+`
+
+type GetProjectMergeCountsRow struct {
+	OtherMembersStudyLogs int64
+	TrashedStudyLogs      int64
+	OtherMembersNotes     int64
+	TrashedNotes          int64
+}
+
+// Counts the study logs and notes that merging the project moves although they belong to members
+// other than the owner, or are in the trash.
+func (q *Queries) GetProjectMergeCounts(ctx context.Context, projectID pgtype.UUID) (GetProjectMergeCountsRow, error) {
+	row := q.db.QueryRow(ctx, getProjectMergeCounts, projectID)
+	var i GetProjectMergeCountsRow
+	err := row.Scan(
+		&i.OtherMembersStudyLogs,
+		&i.TrashedStudyLogs,
+		&i.OtherMembersNotes,
+		&i.TrashedNotes,
+	)
+	return i, err
+}
+
+const getTrashedProjectByID = `-- name: GetTrashedProjectByID :one
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetTrashedProjectByID(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, getTrashedProjectByID, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Description,
+		&i.Color,
+		&i.Icon,
+		&i.Status,
+		&i.TargetDate,
 	)
 	return i, err
 }
 
-const listProjectsByUserID = `-- name: ListProjectsByUserID :many
-SELECT id, user_id, name, created_at, updated_at
+const listProjectsByCreatedAt = `-- name: ListProjectsByCreatedAt :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) > ($2::timestamptz, $3::uuid))
+  AND ($4::boolean IS NULL OR (archived_at IS NOT NULL) = $4::boolean)
+  AND ($5::text IS NULL OR status = $5::text)
+ORDER BY created_at, id
+LIMIT $6
+`
+
+type ListProjectsByCreatedAtParams struct {
+	UserID         pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	Archived       pgtype.Bool
+	Status         pgtype.Text
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListProjectsByCreatedAt(ctx context.Context, arg ListProjectsByCreatedAtParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByCreatedAt,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Archived,
+		arg.Status,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Status,
+			&i.TargetDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectsByCreatedAtDesc = `-- name: ListProjectsByCreatedAtDesc :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+  AND ($4::boolean IS NULL OR (archived_at IS NOT NULL) = $4::boolean)
+  AND ($5::text IS NULL OR status = $5::text)
+ORDER BY created_at DESC, id DESC
+LIMIT $6
+`
+
+type ListProjectsByCreatedAtDescParams struct {
+	UserID         pgtype.UUID
+	AfterCreatedAt pgtype.Timestamptz
+	AfterID        pgtype.UUID
+	Archived       pgtype.Bool
+	Status         pgtype.Text
+	RowLimit       pgtype.Int4
+}
+
+func (q *Queries) ListProjectsByCreatedAtDesc(ctx context.Context, arg ListProjectsByCreatedAtDescParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByCreatedAtDesc,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Archived,
+		arg.Status,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Status,
+			&i.TargetDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectsByName = `-- name: ListProjectsByName :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
 FROM projects
-WHERE user_id = $1
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::text IS NULL OR name > $2::text)
+  AND ($3::boolean IS NULL OR (archived_at IS NOT NULL) = $3::boolean)
+  AND ($4::text IS NULL OR status = $4::text)
 ORDER BY name
+LIMIT $5
+`
+
+type ListProjectsByNameParams struct {
+	UserID    pgtype.UUID
+	AfterName pgtype.Text
+	Archived  pgtype.Bool
+	Status    pgtype.Text
+	RowLimit  pgtype.Int4
+}
+
+func (q *Queries) ListProjectsByName(ctx context.Context, arg ListProjectsByNameParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByName,
+		arg.UserID,
+		arg.AfterName,
+		arg.Archived,
+		arg.Status,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Status,
+			&i.TargetDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectsByNameDesc = `-- name: ListProjectsByNameDesc :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = $1 AND deleted_at IS NULL
+  AND ($2::text IS NULL OR name < $2::text)
+  AND ($3::boolean IS NULL OR (archived_at IS NOT NULL) = $3::boolean)
+  AND ($4::text IS NULL OR status = $4::text)
+ORDER BY name DESC
+LIMIT $5
+`
+
+type ListProjectsByNameDescParams struct {
+	UserID    pgtype.UUID
+	AfterName pgtype.Text
+	Archived  pgtype.Bool
+	Status    pgtype.Text
+	RowLimit  pgtype.Int4
+}
+
+func (q *Queries) ListProjectsByNameDesc(ctx context.Context, arg ListProjectsByNameDescParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByNameDesc,
+		arg.UserID,
+		arg.AfterName,
+		arg.Archived,
+		arg.Status,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Status,
+			&i.TargetDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedProjectsByUserID = `-- name: ListTrashedProjectsByUserID :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at, archived_at, parent_id, description, color, icon, status, target_date
+FROM projects
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
 `
 
-func (q *Queries) ListProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]Project, error) {
-	rows, err := q.db.Query(ctx, listProjectsByUserID, userID)
+func (q *Queries) ListTrashedProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listTrashedProjectsByUserID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +477,14 @@ func (q *Queries) ListProjectsByUserID(ctx context.Context, userID pgtype.UUID)
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Description,
+			&i.Color,
+			&i.Icon,
+			&i.Status,
+			&i.TargetDate,
 		); err != nil {
 			return nil, err
 		}
@@ -96,16 +496,225 @@ func (q *Queries) ListProjectsByUserID(ctx context.Context, userID pgtype.UUID)
 	return items, nil
 }
 
+const moveMilestonesToProject = `-- name: MoveMilestonesToProject :execrows
+UPDATE milestones
+SET project_id = $1,
+    position = position + (SELECT COALESCE(MAX(m.position) + 1, 0) FROM milestones m WHERE m.project_id = $1)
+WHERE milestones.project_id = $2
+`
+
+type MoveMilestonesToProjectParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Moved milestones are placed after the target's own, keeping their order.
+func (q *Queries) MoveMilestonesToProject(ctx context.Context, arg MoveMilestonesToProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveMilestonesToProject, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveNotesToProject = `-- name: MoveNotesToProject :execrows
+UPDATE notes SET project_id = $1 WHERE project_id = $2
+`
+
+type MoveNotesToProjectParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) MoveNotesToProject(ctx context.Context, arg MoveNotesToProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveNotesToProject, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const movePomodoroSessionsToProject = `-- name: MovePomodoroSessionsToProject :exec
+UPDATE pomodoro_sessions SET project_id = $1 WHERE project_id = $2
+`
+
+type MovePomodoroSessionsToProjectParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) MovePomodoroSessionsToProject(ctx context.Context, arg MovePomodoroSessionsToProjectParams) error {
+	_, err := q.db.Exec(ctx, movePomodoroSessionsToProject, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveStudyLogsToProject = `-- name: MoveStudyLogsToProject :execrows
+UPDATE study_logs SET project_id = $1 WHERE project_id = $2
+`
+
+type MoveStudyLogsToProjectParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) MoveStudyLogsToProject(ctx context.Context, arg MoveStudyLogsToProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveStudyLogsToProject, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveTimersToProject = `-- name: MoveTimersToProject :exec
+UPDATE timers SET project_id = $1 WHERE project_id = $2
+`
+
+type MoveTimersToProjectParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) MoveTimersToProject(ctx context.Context, arg MoveTimersToProjectParams) error {
+	_, err := q.db.Exec(ctx, moveTimersToProject, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const purgeTrashedProjects = `-- name: PurgeTrashedProjects :execrows
+DELETE FROM projects WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeTrashedProjects(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedProjects, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreGoalsByProjectID = `-- name: RestoreGoalsByProjectID :exec
+UPDATE goals SET deleted_at = NULL
+WHERE project_id = $1 AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = $1)
+`
+
+func (q *Queries) RestoreGoalsByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, restoreGoalsByProjectID, projectID)
+	return err
+}
+
+const restoreNotesByProjectID = `-- name: RestoreNotesByProjectID :exec
+UPDATE notes SET deleted_at = NULL
+WHERE project_id = $1 AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = $1)
+`
+
+// Children are restored before the project, while its deleted_at still tells which of them were trashed with it.
+func (q *Queries) RestoreNotesByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, restoreNotesByProjectID, projectID)
+	return err
+}
+
+const restoreProject = `-- name: RestoreProject :execresult
+UPDATE projects SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreProject(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, restoreProject, id)
+}
+
+const restoreStudyLogsByProjectID = `-- name: RestoreStudyLogsByProjectID :exec
+UPDATE study_logs SET deleted_at = NULL
+WHERE project_id = $1 AND deleted_at = (SELECT p.deleted_at FROM projects p WHERE p.id = $1)
+`
+
+func (q *Queries) RestoreStudyLogsByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, restoreStudyLogsByProjectID, projectID)
+	return err
+}
+
+const trashGoalsByProjectID = `-- name: TrashGoalsByProjectID :exec
+UPDATE goals SET deleted_at = $1 WHERE project_id = $2 AND deleted_at IS NULL
+`
+
+type TrashGoalsByProjectIDParams struct {
+	DeletedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+}
+
+func (q *Queries) TrashGoalsByProjectID(ctx context.Context, arg TrashGoalsByProjectIDParams) error {
+	_, err := q.db.Exec(ctx, trashGoalsByProjectID, arg.DeletedAt, arg.ProjectID)
+	return err
+}
+
+const trashNotesByProjectID = `-- name: TrashNotesByProjectID :exec
+UPDATE notes SET deleted_at = $1 WHERE project_id = $2 AND deleted_at IS NULL
+`
+
+type TrashNotesByProjectIDParams struct {
+	DeletedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+}
+
+func (q *Queries) TrashNotesByProjectID(ctx context.Context, arg TrashNotesByProjectIDParams) error {
+	_, err := q.db.Exec(ctx, trashNotesByProjectID, arg.DeletedAt, arg.ProjectID)
+	return err
+}
+
+const trashProject = `-- name: TrashProject :execresult
+UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL
+`
+
+type TrashProjectParams struct {
+	DeletedAt pgtype.Timestamptz
+	ID        pgtype.UUID
+}
+
+func (q *Queries) TrashProject(ctx context.Context, arg TrashProjectParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, trashProject, arg.DeletedAt, arg.ID)
+}
+
+const trashStudyLogsByProjectID = `-- name: TrashStudyLogsByProjectID :exec
+UPDATE study_logs SET deleted_at = $1 WHERE project_id = $2 AND deleted_at IS NULL
+`
+
+type TrashStudyLogsByProjectIDParams struct {
+	DeletedAt pgtype.Timestamptz
+	ProjectID pgtype.UUID
+}
+
+func (q *Queries) TrashStudyLogsByProjectID(ctx context.Context, arg TrashStudyLogsByProjectIDParams) error {
+	_, err := q.db.Exec(ctx, trashStudyLogsByProjectID, arg.DeletedAt, arg.ProjectID)
+	return err
+}
+
 const updateProject = `-- name: UpdateProject :execresult
-UPDATE projects SET name = $1, updated_at = $2 WHERE id = $3
+UPDATE projects
+SET name = $1, parent_id = $2, description = $3, color = $4, icon = $5, status = $6, target_date = $7, archived_at = $8, updated_at = $9
+WHERE id = $10 AND deleted_at IS NULL
 `
 
 type UpdateProjectParams struct {
-	Name      string
-	UpdatedAt pgtype.Timestamptz
-	ID        pgtype.UUID
+	Name        string
+	ParentID    pgtype.UUID
+	Description string
+	Color       string
+	Icon        string
+	Status      string
+	TargetDate  pgtype.Date
+	ArchivedAt  pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	ID          pgtype.UUID
 }
 
 func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, updateProject, arg.Name, arg.UpdatedAt, arg.ID)
+	return q.db.Exec(ctx, updateProject,
+		arg.Name,
+		arg.ParentID,
+		arg.Description,
+		arg.Color,
+		arg.Icon,
+		arg.Status,
+		arg.TargetDate,
+		arg.ArchivedAt,
+		arg.UpdatedAt,
+		arg.ID,
+	)
 }
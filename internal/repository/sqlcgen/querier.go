@@ -12,22 +12,137 @@ import (
 )
 
 type Querier interface {
+	CreateActivityType(ctx context.Context, arg CreateActivityTypeParams) error
+	CreateChecklistItem(ctx context.Context, arg CreateChecklistItemParams) error
+	// A user has at most one challenge; logging in again replaces it.
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error
+	CreateMilestone(ctx context.Context, arg CreateMilestoneParams) error
 	CreateNote(ctx context.Context, arg CreateNoteParams) error
+	CreatePersonalAccessToken(ctx context.Context, arg CreatePersonalAccessTokenParams) error
+	CreatePomodoroSession(ctx context.Context, arg CreatePomodoroSessionParams) error
 	CreateProject(ctx context.Context, arg CreateProjectParams) error
+	CreateProjectMember(ctx context.Context, arg CreateProjectMemberParams) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) error
 	CreateStudyLog(ctx context.Context, arg CreateStudyLogParams) error
+	CreateStudyLogRevision(ctx context.Context, arg CreateStudyLogRevisionParams) error
+	CreateStudyLogs(ctx context.Context, arg []CreateStudyLogsParams) *CreateStudyLogsBatchResults
+	CreateTimer(ctx context.Context, arg CreateTimerParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
-	DeleteNote(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
-	DeleteProject(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
-	DeleteStudyLog(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeleteActivityType(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeleteChecklistItemsByMilestoneID(ctx context.Context, milestoneID pgtype.UUID) error
+	DeleteGoalsByProjectID(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	DeleteGoalsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteMFAChallenge(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeleteMilestone(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeleteNotesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// Deletes the notes other members wrote in the user's projects, once the user's own are deleted.
+	DeleteNotesInProjectsOfUser(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeletePersonalAccessToken(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeletePomodoroSession(ctx context.Context, arg DeletePomodoroSessionParams) (pgconn.CommandTag, error)
+	DeletePomodoroSessionsByProjectID(ctx context.Context, projectID pgtype.UUID) error
+	DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteProjectMember(ctx context.Context, arg DeleteProjectMemberParams) (pgconn.CommandTag, error)
+	DeleteProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteSession(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	DeleteSessionsByUserID(ctx context.Context, userID pgtype.UUID) error
+	DeleteStudyLogsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// Deletes the study logs other members wrote in the user's projects, once the user's own are deleted.
+	DeleteStudyLogsInProjectsOfUser(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteTimer(ctx context.Context, arg DeleteTimerParams) (pgconn.CommandTag, error)
+	DeleteTimersByProjectID(ctx context.Context, projectID pgtype.UUID) error
+	DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	GetActivityTypeByID(ctx context.Context, id pgtype.UUID) (ActivityType, error)
+	GetMilestoneByID(ctx context.Context, id pgtype.UUID) (Milestone, error)
 	GetNoteByID(ctx context.Context, id pgtype.UUID) (Note, error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+	GetPersonalAccessTokenByID(ctx context.Context, id pgtype.UUID) (PersonalAccessToken, error)
+	GetPomodoroSessionByUserID(ctx context.Context, userID pgtype.UUID) (PomodoroSession, error)
 	GetProjectByID(ctx context.Context, id pgtype.UUID) (Project, error)
-	GetStudyLogByID(ctx context.Context, id pgtype.UUID) (StudyLog, error)
+	// Counts what trashing the project would move to the trash with it.
+	GetProjectDeletionImpact(ctx context.Context, projectID pgtype.UUID) (GetProjectDeletionImpactRow, error)
+	GetProjectMember(ctx context.Context, arg GetProjectMemberParams) (ProjectMember, error)
+	// Counts the study logs and notes that merging the project moves although they belong to members
+	// other than the owner, or are in the trash.
+	GetProjectMergeCounts(ctx context.Context, projectID pgtype.UUID) (GetProjectMergeCountsRow, error)
+	GetSessionByID(ctx context.Context, id pgtype.UUID) (Session, error)
+	GetStudyLogByID(ctx context.Context, id pgtype.UUID) (GetStudyLogByIDRow, error)
+	GetStudyLogRevisionByID(ctx context.Context, id pgtype.UUID) (StudyLogRevision, error)
+	GetTimerByUserID(ctx context.Context, userID pgtype.UUID) (Timer, error)
+	GetTrashedNoteByID(ctx context.Context, id pgtype.UUID) (Note, error)
+	GetTrashedProjectByID(ctx context.Context, id pgtype.UUID) (Project, error)
+	GetTrashedStudyLogByID(ctx context.Context, id pgtype.UUID) (GetTrashedStudyLogByIDRow, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
-	ListGoalsByUserID(ctx context.Context, userID pgtype.UUID) ([]Goal, error)
-	ListNotesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]Note, error)
-	ListProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]Project, error)
+	// Counts what deleting the user would remove, as DeleteUser's transaction does: the user's own
+	// study logs and notes, and those other members wrote in the user's projects.
+	GetUserDeletionImpact(ctx context.Context, userID pgtype.UUID) (GetUserDeletionImpactRow, error)
+	IncrementMFAChallengeAttempts(ctx context.Context, arg IncrementMFAChallengeAttemptsParams) (int32, error)
+	IsRotatedRefreshToken(ctx context.Context, arg IsRotatedRefreshTokenParams) (bool, error)
+	LinkNoteStudyLog(ctx context.Context, arg LinkNoteStudyLogParams) error
+	ListActivityTypesByUserID(ctx context.Context, userID pgtype.UUID) ([]ActivityType, error)
+	ListChecklistItemsByMilestoneIDs(ctx context.Context, milestoneIds []pgtype.UUID) ([]ChecklistItem, error)
+	ListDuePomodoroSessions(ctx context.Context, now pgtype.Timestamptz) ([]PomodoroSession, error)
+	ListExpiredTimers(ctx context.Context, arg ListExpiredTimersParams) ([]Timer, error)
+	ListGoalsByCreatedAt(ctx context.Context, arg ListGoalsByCreatedAtParams) ([]Goal, error)
+	ListGoalsByCreatedAtDesc(ctx context.Context, arg ListGoalsByCreatedAtDescParams) ([]Goal, error)
+	ListLinkedNotesByStudyLogIDs(ctx context.Context, studyLogIds []pgtype.UUID) ([]ListLinkedNotesByStudyLogIDsRow, error)
+	ListLinkedStudyLogsByNoteIDs(ctx context.Context, noteIds []pgtype.UUID) ([]ListLinkedStudyLogsByNoteIDsRow, error)
+	ListMilestonesByProjectIDs(ctx context.Context, projectIds []pgtype.UUID) ([]Milestone, error)
+	ListNotesByCreatedAt(ctx context.Context, arg ListNotesByCreatedAtParams) ([]Note, error)
+	ListNotesByCreatedAtDesc(ctx context.Context, arg ListNotesByCreatedAtDescParams) ([]Note, error)
+	ListNotesByUpdatedAt(ctx context.Context, arg ListNotesByUpdatedAtParams) ([]Note, error)
+	ListNotesByUpdatedAtDesc(ctx context.Context, arg ListNotesByUpdatedAtDescParams) ([]Note, error)
+	ListPersonalAccessTokensByUserID(ctx context.Context, userID pgtype.UUID) ([]PersonalAccessToken, error)
+	ListProjectMembersByProjectID(ctx context.Context, projectID pgtype.UUID) ([]ProjectMember, error)
+	ListProjectMembersByUserID(ctx context.Context, userID pgtype.UUID) ([]ProjectMember, error)
+	ListProjectsByCreatedAt(ctx context.Context, arg ListProjectsByCreatedAtParams) ([]Project, error)
+	ListProjectsByCreatedAtDesc(ctx context.Context, arg ListProjectsByCreatedAtDescParams) ([]Project, error)
+	ListProjectsByName(ctx context.Context, arg ListProjectsByNameParams) ([]Project, error)
+	ListProjectsByNameDesc(ctx context.Context, arg ListProjectsByNameDescParams) ([]Project, error)
+	ListSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]Session, error)
+	ListStudyLogImportKeys(ctx context.Context, arg ListStudyLogImportKeysParams) ([]string, error)
+	ListStudyLogRevisions(ctx context.Context, studyLogID pgtype.UUID) ([]StudyLogRevision, error)
+	// Notes trashed with their project are left out; they come back when the project is restored.
+	ListTrashedNotesByUserID(ctx context.Context, userID pgtype.UUID) ([]Note, error)
+	ListTrashedProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]Project, error)
+	// Logs trashed with their project are left out; they come back when the project is restored.
+	ListTrashedStudyLogsByUserID(ctx context.Context, userID pgtype.UUID) ([]ListTrashedStudyLogsByUserIDRow, error)
+	// Moved milestones are placed after the target's own, keeping their order.
+	MoveMilestonesToProject(ctx context.Context, arg MoveMilestonesToProjectParams) (int64, error)
+	MoveNotesToProject(ctx context.Context, arg MoveNotesToProjectParams) (int64, error)
+	MovePomodoroSessionsToProject(ctx context.Context, arg MovePomodoroSessionsToProjectParams) error
+	MoveStudyLogsToProject(ctx context.Context, arg MoveStudyLogsToProjectParams) (int64, error)
+	MoveTimersToProject(ctx context.Context, arg MoveTimersToProjectParams) error
+	PurgeTrashedNotes(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	PurgeTrashedProjects(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	PurgeTrashedStudyLogs(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	RestoreGoalsByProjectID(ctx context.Context, projectID pgtype.UUID) error
+	RestoreNote(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	// Children are restored before the project, while its deleted_at still tells which of them were trashed with it.
+	RestoreNotesByProjectID(ctx context.Context, projectID pgtype.UUID) error
+	RestoreProject(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	RestoreStudyLog(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error)
+	RestoreStudyLogsByProjectID(ctx context.Context, projectID pgtype.UUID) error
+	// Replaces the refresh token and records the previous one as rotated out, in a single statement.
+	RotateSession(ctx context.Context, arg RotateSessionParams) (pgconn.CommandTag, error)
+	TrashGoalsByProjectID(ctx context.Context, arg TrashGoalsByProjectIDParams) error
+	TrashNote(ctx context.Context, arg TrashNoteParams) (pgconn.CommandTag, error)
+	TrashNotesByProjectID(ctx context.Context, arg TrashNotesByProjectIDParams) error
+	TrashProject(ctx context.Context, arg TrashProjectParams) (pgconn.CommandTag, error)
+	TrashStudyLog(ctx context.Context, arg TrashStudyLogParams) (pgconn.CommandTag, error)
+	TrashStudyLogsByProjectID(ctx context.Context, arg TrashStudyLogsByProjectIDParams) error
+	UnlinkNoteStudyLog(ctx context.Context, arg UnlinkNoteStudyLogParams) (pgconn.CommandTag, error)
+	UpdateActivityType(ctx context.Context, arg UpdateActivityTypeParams) (pgconn.CommandTag, error)
+	UpdateMilestone(ctx context.Context, arg UpdateMilestoneParams) (pgconn.CommandTag, error)
+	UpdateMilestonePosition(ctx context.Context, arg UpdateMilestonePositionParams) (pgconn.CommandTag, error)
 	UpdateNote(ctx context.Context, arg UpdateNoteParams) (pgconn.CommandTag, error)
+	UpdatePersonalAccessTokenLastUsed(ctx context.Context, arg UpdatePersonalAccessTokenLastUsedParams) error
+	UpdatePomodoroSession(ctx context.Context, arg UpdatePomodoroSessionParams) (pgconn.CommandTag, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (pgconn.CommandTag, error)
+	UpdateProjectMember(ctx context.Context, arg UpdateProjectMemberParams) (pgconn.CommandTag, error)
+	UpdateStudyLog(ctx context.Context, arg UpdateStudyLogParams) (pgconn.CommandTag, error)
+	UpdateTimer(ctx context.Context, arg UpdateTimerParams) (pgconn.CommandTag, error)
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (pgconn.CommandTag, error)
 	UpsertGoal(ctx context.Context, arg UpsertGoalParams) error
 }
 
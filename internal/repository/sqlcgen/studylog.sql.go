@@ -13,18 +13,22 @@ import (
 )
 
 const createStudyLog = `-- name: CreateStudyLog :exec
-INSERT INTO study_logs (id, user_id, project_id, studied_at, minutes, note, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO study_logs (id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, import_key, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateStudyLogParams struct {
-	ID        pgtype.UUID
-	UserID    pgtype.UUID
-	ProjectID pgtype.UUID
-	StudiedAt pgtype.Timestamptz
-	Minutes   int32
-	Note      string
-	CreatedAt pgtype.Timestamptz
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	Pomodoros      int32
+	ImportKey      pgtype.Text
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
 }
 
 func (q *Queries) CreateStudyLog(ctx context.Context, arg CreateStudyLogParams) error {
@@ -32,39 +36,352 @@ func (q *Queries) CreateStudyLog(ctx context.Context, arg CreateStudyLogParams)
 		arg.ID,
 		arg.UserID,
 		arg.ProjectID,
+		arg.ActivityTypeID,
 		arg.StudiedAt,
 		arg.Minutes,
 		arg.Note,
+		arg.Pomodoros,
+		arg.ImportKey,
 		arg.CreatedAt,
+		arg.UpdatedAt,
 	)
 	return err
 }
 
-const deleteStudyLog = `-- name: DeleteStudyLog :execresult
-DELETE FROM study_logs WHERE id = $1
+const createStudyLogRevision = `-- name: CreateStudyLogRevision :exec
+INSERT INTO study_log_revisions (id, study_log_id, edited_by, project_id, activity_type_id, studied_at, minutes, note, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
-func (q *Queries) DeleteStudyLog(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, deleteStudyLog, id)
+type CreateStudyLogRevisionParams struct {
+	ID             pgtype.UUID
+	StudyLogID     pgtype.UUID
+	EditedBy       pgtype.UUID
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	CreatedAt      pgtype.Timestamptz
+}
+
+func (q *Queries) CreateStudyLogRevision(ctx context.Context, arg CreateStudyLogRevisionParams) error {
+	_, err := q.db.Exec(ctx, createStudyLogRevision,
+		arg.ID,
+		arg.StudyLogID,
+		arg.EditedBy,
+		arg.ProjectID,
+		arg.ActivityTypeID,
+		arg.StudiedAt,
+		arg.Minutes,
+		arg.Note,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteStudyLogsByUserID = `-- name: DeleteStudyLogsByUserID :execrows
+DELETE FROM study_logs WHERE user_id = $1
+`
+
+func (q *Queries) DeleteStudyLogsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStudyLogsByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStudyLogsInProjectsOfUser = `-- name: DeleteStudyLogsInProjectsOfUser :execrows
+DELETE FROM study_logs WHERE project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)
+`
+
+// Deletes the study logs other members wrote in the user's projects, once the user's own are deleted.
+func (q *Queries) DeleteStudyLogsInProjectsOfUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStudyLogsInProjectsOfUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getStudyLogByID = `-- name: GetStudyLogByID :one
-SELECT id, user_id, project_id, studied_at, minutes, note, created_at
+SELECT id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at
 FROM study_logs
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetStudyLogByID(ctx context.Context, id pgtype.UUID) (StudyLog, error) {
+type GetStudyLogByIDRow struct {
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	Pomodoros      int32
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+func (q *Queries) GetStudyLogByID(ctx context.Context, id pgtype.UUID) (GetStudyLogByIDRow, error) {
 	row := q.db.QueryRow(ctx, getStudyLogByID, id)
-	var i StudyLog
+	var i GetStudyLogByIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.ProjectID,
+		&i.ActivityTypeID,
 		&i.StudiedAt,
 		&i.Minutes,
 		&i.Note,
+		&i.Pomodoros,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
+
+const getStudyLogRevisionByID = `-- name: GetStudyLogRevisionByID :one
+SELECT id, study_log_id, edited_by, project_id, studied_at, minutes, note, created_at, activity_type_id
+FROM study_log_revisions
+WHERE id = $1
+`
+
+func (q *Queries) GetStudyLogRevisionByID(ctx context.Context, id pgtype.UUID) (StudyLogRevision, error) {
+	row := q.db.QueryRow(ctx, getStudyLogRevisionByID, id)
+	var i StudyLogRevision
+	err := row.Scan(
+		&i.ID,
+		&i.StudyLogID,
+		&i.EditedBy,
+		&i.ProjectID,
+		&i.StudiedAt,
+		&i.Minutes,
+		&i.Note,
+		&i.CreatedAt,
+		&i.ActivityTypeID,
+	)
+	return i, err
+}
+
+const getTrashedStudyLogByID = `-- name: GetTrashedStudyLogByID :one
+SELECT id, user_id, project_id, activity_type_id, studied_at, minutes, note, pomodoros, created_at, updated_at, deleted_at
+FROM study_logs
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+type GetTrashedStudyLogByIDRow struct {
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	Pomodoros      int32
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	DeletedAt      pgtype.Timestamptz
+}
+
+func (q *Queries) GetTrashedStudyLogByID(ctx context.Context, id pgtype.UUID) (GetTrashedStudyLogByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTrashedStudyLogByID, id)
+	var i GetTrashedStudyLogByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.ActivityTypeID,
+		&i.StudiedAt,
+		&i.Minutes,
+		&i.Note,
+		&i.Pomodoros,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listStudyLogImportKeys = `-- name: ListStudyLogImportKeys :many
+SELECT import_key::text
+FROM study_logs
+WHERE user_id = $1 AND import_key = ANY($2::text[]) AND deleted_at IS NULL
+`
+
+type ListStudyLogImportKeysParams struct {
+	UserID     pgtype.UUID
+	ImportKeys []string
+}
+
+func (q *Queries) ListStudyLogImportKeys(ctx context.Context, arg ListStudyLogImportKeysParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listStudyLogImportKeys, arg.UserID, arg.ImportKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var import_key string
+		if err := rows.Scan(&import_key); err != nil {
+			return nil, err
+		}
+		items = append(items, import_key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStudyLogRevisions = `-- name: ListStudyLogRevisions :many
+SELECT id, study_log_id, edited_by, project_id, studied_at, minutes, note, created_at, activity_type_id
+FROM study_log_revisions
+WHERE study_log_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListStudyLogRevisions(ctx context.Context, studyLogID pgtype.UUID) ([]StudyLogRevision, error) {
+	rows, err := q.db.Query(ctx, listStudyLogRevisions, studyLogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StudyLogRevision
+	for rows.Next() {
+		var i StudyLogRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudyLogID,
+			&i.EditedBy,
+			&i.ProjectID,
+			&i.StudiedAt,
+			&i.Minutes,
+			&i.Note,
+			&i.CreatedAt,
+			&i.ActivityTypeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedStudyLogsByUserID = `-- name: ListTrashedStudyLogsByUserID :many
+SELECT l.id, l.user_id, l.project_id, l.activity_type_id, l.studied_at, l.minutes, l.note, l.pomodoros, l.created_at, l.updated_at, l.deleted_at
+FROM study_logs l
+JOIN projects p ON p.id = l.project_id
+WHERE l.user_id = $1 AND l.deleted_at IS NOT NULL AND p.deleted_at IS NULL
+ORDER BY l.deleted_at DESC, l.id
+`
+
+type ListTrashedStudyLogsByUserIDRow struct {
+	ID             pgtype.UUID
+	UserID         pgtype.UUID
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	Pomodoros      int32
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	DeletedAt      pgtype.Timestamptz
+}
+
+// Logs trashed with their project are left out; they come back when the project is restored.
+func (q *Queries) ListTrashedStudyLogsByUserID(ctx context.Context, userID pgtype.UUID) ([]ListTrashedStudyLogsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listTrashedStudyLogsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTrashedStudyLogsByUserIDRow
+	for rows.Next() {
+		var i ListTrashedStudyLogsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ActivityTypeID,
+			&i.StudiedAt,
+			&i.Minutes,
+			&i.Note,
+			&i.Pomodoros,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeTrashedStudyLogs = `-- name: PurgeTrashedStudyLogs :execrows
+DELETE FROM study_logs WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeTrashedStudyLogs(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedStudyLogs, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreStudyLog = `-- name: RestoreStudyLog :execresult
+UPDATE study_logs SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreStudyLog(ctx context.Context, id pgtype.UUID) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, restoreStudyLog, id)
+}
+
+const trashStudyLog = `-- name: TrashStudyLog :execresult
+UPDATE study_logs SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL
+`
+
+type TrashStudyLogParams struct {
+	DeletedAt pgtype.Timestamptz
+	ID        pgtype.UUID
+}
+
+func (q *Queries) TrashStudyLog(ctx context.Context, arg TrashStudyLogParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, trashStudyLog, arg.DeletedAt, arg.ID)
+}
+
+const updateStudyLog = `-- name: UpdateStudyLog :execresult
+UPDATE study_logs SET project_id = $1, activity_type_id = $2, studied_at = $3, minutes = $4, note = $5, updated_at = $6 WHERE id = $7 AND deleted_at IS NULL
+`
+
+type UpdateStudyLogParams struct {
+	ProjectID      pgtype.UUID
+	ActivityTypeID pgtype.UUID
+	StudiedAt      pgtype.Timestamptz
+	Minutes        int32
+	Note           string
+	UpdatedAt      pgtype.Timestamptz
+	ID             pgtype.UUID
+}
+
+func (q *Queries) UpdateStudyLog(ctx context.Context, arg UpdateStudyLogParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updateStudyLog,
+		arg.ProjectID,
+		arg.ActivityTypeID,
+		arg.StudiedAt,
+		arg.Minutes,
+		arg.Note,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+}
@@ -8,33 +8,94 @@ package sqlcgen
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (id, name, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 `
 
 type CreateUserParams struct {
-	ID        pgtype.UUID
-	Name      string
-	CreatedAt pgtype.Timestamptz
-	UpdatedAt pgtype.Timestamptz
+	ID                    pgtype.UUID
+	Name                  string
+	Email                 string
+	PasswordHash          string
+	TotpSecret            string
+	TotpEnabled           bool
+	TotpLastStep          int64
+	RecoveryCodeHashes    []string
+	Timezone              string
+	WeekStart             int16
+	Locale                string
+	DefaultSessionMinutes int32
+	CreatedAt             pgtype.Timestamptz
+	UpdatedAt             pgtype.Timestamptz
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	_, err := q.db.Exec(ctx, createUser,
 		arg.ID,
 		arg.Name,
+		arg.Email,
+		arg.PasswordHash,
+		arg.TotpSecret,
+		arg.TotpEnabled,
+		arg.TotpLastStep,
+		arg.RecoveryCodeHashes,
+		arg.Timezone,
+		arg.WeekStart,
+		arg.Locale,
+		arg.DefaultSessionMinutes,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
 	return err
 }
 
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, created_at, updated_at, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes
+FROM users
+WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.PasswordHash,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.TotpLastStep,
+		&i.RecoveryCodeHashes,
+		&i.Timezone,
+		&i.WeekStart,
+		&i.Locale,
+		&i.DefaultSessionMinutes,
+	)
+	return i, err
+}
+
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, email, password_hash, totp_secret, totp_enabled, totp_last_step, recovery_code_hashes, timezone, week_start, locale, default_session_minutes
 FROM users
 WHERE id = $1
 `
@@ -47,6 +108,102 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Email,
+		&i.PasswordHash,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.TotpLastStep,
+		&i.RecoveryCodeHashes,
+		&i.Timezone,
+		&i.WeekStart,
+		&i.Locale,
+		&i.DefaultSessionMinutes,
+	)
+	return i, err
+}
+
+const getUserDeletionImpact = `-- name: GetUserDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM projects p WHERE p.user_id = $1) AS projects,
+    (SELECT COUNT(*) FROM study_logs s
+     WHERE s.user_id = $1 OR s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS study_logs,
+    (SELECT COALESCE(SUM(s.minutes), 0) FROM study_logs s
+     WHERE s.user_id = $1 OR s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1))::bigint AS total_minutes,
+    (SELECT COUNT(*) FROM study_logs s
+     WHERE s.user_id <> $1 AND s.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS other_members_study_logs,
+    (SELECT COUNT(*) FROM notes n
+     WHERE n.user_id = $1 OR n.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS notes,
+    (SELECT COUNT(*) FROM notes n
+     WHERE n.user_id <> $1 AND n.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = $1)) AS other_members_notes,
+    (SELECT COUNT(*) FROM goals g WHERE g.user_id = $1) AS goals
+`
+
+type GetUserDeletionImpactRow struct {
+	Projects              int64
+	StudyLogs             int64
+	TotalMinutes          int64
+	OtherMembersStudyLogs int64
+	Notes                 int64
+	OtherMembersNotes     int64
+	Goals                 int64
+}
+
+// Counts what deleting the user would remove, as DeleteUser's transaction does: the user's own
+// study logs and notes, and those other members wrote in the user's projects.
+func (q *Queries) GetUserDeletionImpact(ctx context.Context, userID pgtype.UUID) (GetUserDeletionImpactRow, error) {
+	row := q.db.QueryRow(ctx, getUserDeletionImpact, userID)
+	var i GetUserDeletionImpactRow
+	err := row.Scan(
+		&i.Projects,
+		&i.StudyLogs,
+		&i.TotalMinutes,
+		&i.OtherMembersStudyLogs,
+		&i.Notes,
+		&i.OtherMembersNotes,
+		&i.Goals,
 	)
 	return i, err
 }
+
+const updateUser = `-- name: UpdateUser :execresult
+UPDATE users
+SET name = $1, email = $2, password_hash = $3,
+    totp_secret = $4, totp_enabled = $5, totp_last_step = $6, recovery_code_hashes = $7,
+    timezone = $8, week_start = $9, locale = $10, default_session_minutes = $11,
+    updated_at = $12
+WHERE id = $13
+`
+
+type UpdateUserParams struct {
+	Name                  string
+	Email                 string
+	PasswordHash          string
+	TotpSecret            string
+	TotpEnabled           bool
+	TotpLastStep          int64
+	RecoveryCodeHashes    []string
+	Timezone              string
+	WeekStart             int16
+	Locale                string
+	DefaultSessionMinutes int32
+	UpdatedAt             pgtype.Timestamptz
+	ID                    pgtype.UUID
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updateUser,
+		arg.Name,
+		arg.Email,
+		arg.PasswordHash,
+		arg.TotpSecret,
+		arg.TotpEnabled,
+		arg.TotpLastStep,
+		arg.RecoveryCodeHashes,
+		arg.Timezone,
+		arg.WeekStart,
+		arg.Locale,
+		arg.DefaultSessionMinutes,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+}
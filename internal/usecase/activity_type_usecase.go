@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// ActivityTypeUsecase provides methods for managing a user's catalogue of activity types.
+type ActivityTypeUsecase struct {
+	activityTypeRepo port.ActivityTypeRepository
+	userRepo         port.UserRepository
+}
+
+// NewActivityTypeUsecase creates a new ActivityTypeUsecase.
+func NewActivityTypeUsecase(activityTypeRepo port.ActivityTypeRepository, userRepo port.UserRepository) *ActivityTypeUsecase {
+	return &ActivityTypeUsecase{
+		activityTypeRepo: activityTypeRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// CreateActivityType adds an activity type to a user's catalogue.
+func (u *ActivityTypeUsecase) CreateActivityType(ctx context.Context, userID, name string) (*domain.ActivityType, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	activityType, err := domain.NewActivityType(uuid.New().String(), userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.activityTypeRepo.Create(ctx, activityType); err != nil {
+		return nil, err
+	}
+	return activityType, nil
+}
+
+// ListActivityTypes returns a user's activity types ordered by name.
+func (u *ActivityTypeUsecase) ListActivityTypes(ctx context.Context, userID string) ([]*domain.ActivityType, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.activityTypeRepo.FindByUserID(ctx, userID)
+}
+
+// RenameActivityType renames an activity type owned by the user.
+func (u *ActivityTypeUsecase) RenameActivityType(ctx context.Context, userID, id, name string) (*domain.ActivityType, error) {
+	activityType, err := findOwnedActivityType(ctx, u.activityTypeRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := activityType.Rename(name); err != nil {
+		return nil, err
+	}
+	if err := u.activityTypeRepo.Update(ctx, activityType); err != nil {
+		return nil, err
+	}
+	return activityType, nil
+}
+
+// DeleteActivityType deletes an activity type owned by the user. The study logs tagged with it
+// are kept without an activity type.
+func (u *ActivityTypeUsecase) DeleteActivityType(ctx context.Context, userID, id string) error {
+	if _, err := findOwnedActivityType(ctx, u.activityTypeRepo, userID, id); err != nil {
+		return err
+	}
+	return u.activityTypeRepo.Delete(ctx, id)
+}
+
+// findOwnedActivityType reports activity types of other users as not found so their existence is not leaked.
+func findOwnedActivityType(ctx context.Context, activityTypeRepo port.ActivityTypeRepository, userID, id string) (*domain.ActivityType, error) {
+	activityType, err := activityTypeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if activityType.UserID != userID {
+		return nil, domain.ErrNotFound("activity type")
+	}
+	return activityType, nil
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupActivityTypeTest() (*usecase.ActivityTypeUsecase, *mockUserRepository, *mockActivityTypeRepository) {
+	userRepo := newMockUserRepository()
+	activityTypeRepo := newMockActivityTypeRepository()
+	uc := usecase.NewActivityTypeUsecase(activityTypeRepo, userRepo)
+	return uc, userRepo, activityTypeRepo
+}
+
+func TestCreateActivityType_Success(t *testing.T) {
+	uc, userRepo, _ := setupActivityTypeTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	if _, err := uc.CreateActivityType(context.Background(), "user-1", "Reading"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateActivityType(context.Background(), "user-1", "Exercises"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	activityTypes, err := uc.ListActivityTypes(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(activityTypes) != 2 || activityTypes[0].Name != "Exercises" || activityTypes[1].Name != "Reading" {
+		t.Errorf("expected activity types ordered by name, got %+v", activityTypes)
+	}
+}
+
+func TestCreateActivityType_UserNotFound(t *testing.T) {
+	uc, _, _ := setupActivityTypeTest()
+
+	_, err := uc.CreateActivityType(context.Background(), "nonexistent", "Reading")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestCreateActivityType_DuplicateName(t *testing.T) {
+	uc, userRepo, _ := setupActivityTypeTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	if _, err := uc.CreateActivityType(context.Background(), "user-1", "Reading"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := uc.CreateActivityType(context.Background(), "user-1", "Reading")
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestRenameActivityType(t *testing.T) {
+	uc, userRepo, _ := setupActivityTypeTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	activityType, err := uc.CreateActivityType(context.Background(), "user-1", "Reading")
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	renamed, err := uc.RenameActivityType(context.Background(), "user-1", activityType.ID, "Textbook reading")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if renamed.Name != "Textbook reading" {
+		t.Errorf("expected name 'Textbook reading', got '%s'", renamed.Name)
+	}
+
+	_, err = uc.RenameActivityType(context.Background(), "user-1", activityType.ID, "")
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestDeleteActivityType_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, activityTypeRepo := setupActivityTypeTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	activityTypeRepo.activityTypes["type-1"] = &domain.ActivityType{ID: "type-1", UserID: "user-2", Name: "Reading"}
+
+	err := uc.DeleteActivityType(context.Background(), "user-1", "type-1")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if _, ok := activityTypeRepo.activityTypes["type-1"]; !ok {
+		t.Error("expected the activity type of another user to be kept")
+	}
+}
+
+func TestCreateStudyLog_WithActivityType(t *testing.T) {
+	userRepo := newMockUserRepository()
+	projectRepo := newMockProjectRepository()
+	activityTypeRepo := newMockActivityTypeRepository()
+	studyLogRepo := newMockStudyLogRepository()
+	uc := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, activityTypeRepo, newMockNoteLinkRepository(newMockNoteRepository(), studyLogRepo), newMockProjectMemberRepository())
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	activityTypeRepo.activityTypes["type-1"] = &domain.ActivityType{ID: "type-1", UserID: "user-1", Name: "Reading"}
+	activityTypeRepo.activityTypes["type-2"] = &domain.ActivityType{ID: "type-2", UserID: "user-2", Name: "Reading"}
+
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	log, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "type-1", day, 60, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.ActivityTypeID != "type-1" {
+		t.Errorf("expected ActivityTypeID 'type-1', got '%s'", log.ActivityTypeID)
+	}
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", day.Add(2*time.Hour), 30, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "type-2", day.Add(4*time.Hour), 30, "")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's activity type, got: %v", err)
+	}
+
+	activityTypeID := "type-1"
+	logs, _, err := uc.ListStudyLogs(context.Background(), "user-1", usecase.StudyLogListFilter{
+		ActivityTypeID: &activityTypeID,
+	}, usecase.PageOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logs) != 1 || logs[0].ID != log.ID {
+		t.Errorf("expected only the log tagged with type-1, got %d logs", len(logs))
+	}
+}
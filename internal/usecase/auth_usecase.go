@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// lastUsedResolution limits how often the last-used timestamp of a personal access token is written.
+const lastUsedResolution = time.Minute
+
+// AuthUsecase provides methods for authenticating users.
+type AuthUsecase struct {
+	userRepo    port.UserRepository
+	patRepo     port.PersonalAccessTokenRepository
+	sessionRepo port.SessionRepository
+	mfaRepo     port.MFAChallengeRepository
+	hasher      port.PasswordHasher
+	tokens      port.TokenManager
+	generator   port.OpaqueTokenGenerator
+	totp        port.TOTPProvider
+	refreshTTL  time.Duration
+}
+
+// NewAuthUsecase creates a new AuthUsecase. Sessions expire when they have not been refreshed for refreshTTL.
+func NewAuthUsecase(userRepo port.UserRepository, patRepo port.PersonalAccessTokenRepository, sessionRepo port.SessionRepository, mfaRepo port.MFAChallengeRepository, hasher port.PasswordHasher, tokens port.TokenManager, generator port.OpaqueTokenGenerator, totp port.TOTPProvider, refreshTTL time.Duration) *AuthUsecase {
+	return &AuthUsecase{
+		userRepo:    userRepo,
+		patRepo:     patRepo,
+		sessionRepo: sessionRepo,
+		mfaRepo:     mfaRepo,
+		hasher:      hasher,
+		tokens:      tokens,
+		generator:   generator,
+		totp:        totp,
+		refreshTTL:  refreshTTL,
+	}
+}
+
+// Login verifies the email and password. Without two-factor authentication it starts a new session
+// and issues an access token and a refresh token; otherwise it returns a challenge to be completed
+// with CompleteMFALogin. Unknown emails and wrong passwords return the same error so accounts cannot be enumerated.
+func (u *AuthUsecase) Login(ctx context.Context, email, password, userAgent, ipAddress string) (*domain.LoginResult, error) {
+	user, err := u.userRepo.FindByEmail(ctx, domain.NormalizeEmail(email))
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid email or password")
+		}
+		return nil, err
+	}
+	if user.PasswordHash == "" || u.hasher.Compare(user.PasswordHash, password) != nil {
+		return nil, domain.ErrUnauthorized("invalid email or password")
+	}
+
+	if user.TwoFactor.TOTPEnabled {
+		challengeID := uuid.New().String()
+		challenge, expiresAt, err := u.tokens.IssueMFAChallenge(user.ID, challengeID)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.mfaRepo.Create(ctx, challengeID, user.ID, expiresAt); err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{MFAChallenge: &domain.MFAChallenge{Token: challenge, ExpiresAt: expiresAt}}, nil
+	}
+
+	token, err := u.startSession(ctx, user.ID, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LoginResult{Token: token}, nil
+}
+
+// CompleteMFALogin finishes a login that required a second factor. code is either a TOTP code
+// or one of the user's unused recovery codes. A challenge completes one login, and only
+// domain.MaxMFAAttempts codes can be tried against it, so the code cannot be guessed.
+func (u *AuthUsecase) CompleteMFALogin(ctx context.Context, mfaToken, code, userAgent, ipAddress string) (*domain.AuthToken, error) {
+	userID, challengeID, err := u.tokens.VerifyMFAChallenge(mfaToken)
+	if err != nil {
+		return nil, domain.ErrUnauthorized("invalid or expired MFA token")
+	}
+	// The attempt is counted before the code is checked, so codes tried concurrently count too.
+	attempts, err := u.mfaRepo.RecordAttempt(ctx, challengeID, time.Now())
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired MFA token")
+		}
+		return nil, err
+	}
+	if attempts > domain.MaxMFAAttempts {
+		return nil, u.abandonMFAChallenge(ctx, challengeID)
+	}
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired MFA token")
+		}
+		return nil, err
+	}
+	if !user.TwoFactor.TOTPEnabled || !verifySecondFactor(user, code, u.totp, u.generator) {
+		if attempts == domain.MaxMFAAttempts {
+			return nil, u.abandonMFAChallenge(ctx, challengeID)
+		}
+		return nil, domain.ErrUnauthorized("invalid verification code")
+	}
+	if err := u.mfaRepo.Delete(ctx, challengeID); err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired MFA token")
+		}
+		return nil, err
+	}
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return u.startSession(ctx, user.ID, userAgent, ipAddress)
+}
+
+func (u *AuthUsecase) startSession(ctx context.Context, userID, userAgent, ipAddress string) (*domain.AuthToken, error) {
+	id := uuid.New().String()
+	refreshToken, refreshHash, err := u.newRefreshToken(id)
+	if err != nil {
+		return nil, err
+	}
+	session, err := domain.NewSession(id, userID, refreshHash, userAgent, ipAddress, u.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return u.issue(session, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// Presenting a refresh token that has already been rotated indicates it was leaked,
+// so the whole session is revoked and every token issued for it stops working. Any other
+// token that does not match is merely rejected, so a garbage token cannot log a session out.
+func (u *AuthUsecase) Refresh(ctx context.Context, refreshToken, ipAddress string) (*domain.AuthToken, error) {
+	sessionID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || uuid.Validate(sessionID) != nil {
+		return nil, domain.ErrUnauthorized("invalid or expired refresh token")
+	}
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired refresh token")
+		}
+		return nil, err
+	}
+	if session.IsExpired(time.Now()) {
+		return nil, domain.ErrUnauthorized("invalid or expired refresh token")
+	}
+
+	previousHash := session.RefreshTokenHash
+	if hash := u.generator.Hash(refreshToken); hash != previousHash {
+		rotated, err := u.sessionRepo.IsRotatedToken(ctx, session.ID, hash)
+		if err != nil {
+			return nil, err
+		}
+		if rotated {
+			return nil, u.revokeReusedSession(ctx, session.ID)
+		}
+		return nil, domain.ErrUnauthorized("invalid or expired refresh token")
+	}
+
+	newToken, newHash, err := u.newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.Rotate(newHash, ipAddress, u.refreshTTL)
+	if err := u.sessionRepo.Rotate(ctx, session, previousHash); err != nil {
+		if domain.IsConflict(err) {
+			return nil, u.revokeReusedSession(ctx, session.ID)
+		}
+		return nil, err
+	}
+	return u.issue(session, newToken)
+}
+
+// Authenticate verifies an access token or a personal access token and returns the caller it identifies.
+func (u *AuthUsecase) Authenticate(ctx context.Context, token string) (*domain.Principal, error) {
+	if strings.HasPrefix(token, domain.PersonalAccessTokenPrefix) {
+		return u.authenticatePersonalAccessToken(ctx, token)
+	}
+
+	claims, err := u.tokens.Verify(token)
+	if err != nil {
+		return nil, domain.ErrUnauthorized("invalid or expired token")
+	}
+	// Sessions are deleted with their user, so an active session also proves the user still exists.
+	session, err := u.sessionRepo.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired token")
+		}
+		return nil, err
+	}
+	if session.UserID != claims.UserID || session.IsExpired(time.Now()) {
+		return nil, domain.ErrUnauthorized("invalid or expired token")
+	}
+	return &domain.Principal{UserID: claims.UserID, SessionID: session.ID}, nil
+}
+
+// newRefreshToken generates a refresh token for a session. The session ID is embedded so the
+// session can be found, and the random part makes the token unguessable.
+func (u *AuthUsecase) newRefreshToken(sessionID string) (token, hash string, err error) {
+	secret, err := u.generator.Generate()
+	if err != nil {
+		return "", "", err
+	}
+	token = sessionID + "." + secret
+	return token, u.generator.Hash(token), nil
+}
+
+func (u *AuthUsecase) issue(session *domain.Session, refreshToken string) (*domain.AuthToken, error) {
+	accessToken, expiresAt, err := u.tokens.Issue(session.UserID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AuthToken{
+		UserID:           session.UserID,
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// abandonMFAChallenge deletes a challenge that has used up its attempts.
+func (u *AuthUsecase) abandonMFAChallenge(ctx context.Context, challengeID string) error {
+	if err := u.mfaRepo.Delete(ctx, challengeID); err != nil && !domain.IsNotFound(err) {
+		return err
+	}
+	return domain.ErrUnauthorized("too many invalid verification codes; log in again")
+}
+
+func (u *AuthUsecase) revokeReusedSession(ctx context.Context, sessionID string) error {
+	if err := u.sessionRepo.Delete(ctx, sessionID); err != nil && !domain.IsNotFound(err) {
+		return err
+	}
+	return domain.ErrUnauthorized("refresh token reuse detected; session revoked")
+}
+
+func (u *AuthUsecase) authenticatePersonalAccessToken(ctx context.Context, token string) (*domain.Principal, error) {
+	pat, err := u.patRepo.FindByHash(ctx, u.generator.Hash(token))
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrUnauthorized("invalid or expired token")
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if pat.IsExpired(now) {
+		return nil, domain.ErrUnauthorized("invalid or expired token")
+	}
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) >= lastUsedResolution {
+		if err := u.patRepo.UpdateLastUsed(ctx, pat.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.Principal{
+		UserID:                pat.UserID,
+		PersonalAccessTokenID: pat.ID,
+		Scopes:                pat.Scopes,
+	}, nil
+}
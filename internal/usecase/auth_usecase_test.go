@@ -0,0 +1,378 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupAuthTest() (*usecase.AuthUsecase, *mockSessionRepository, *mockPersonalAccessTokenRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com", PasswordHash: "hashed:password123"}
+	sessionRepo := newMockSessionRepository()
+	patRepo := newMockPersonalAccessTokenRepository()
+	uc := usecase.NewAuthUsecase(userRepo, patRepo, sessionRepo, newMockMFAChallengeRepository(), mockPasswordHasher{}, mockTokenManager{}, &mockOpaqueTokenGenerator{}, mockTOTPProvider{}, 24*time.Hour)
+	return uc, sessionRepo, patRepo
+}
+
+// setupMFAAuthTest enables two-factor authentication for user-1 with a single recovery code "rc1".
+func setupMFAAuthTest() (*usecase.AuthUsecase, *mockUserRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{
+		ID:           "user-1",
+		Name:         "Alice",
+		Email:        "alice@example.com",
+		PasswordHash: "hashed:password123",
+		TwoFactor: domain.TwoFactor{
+			TOTPSecret:         "SECRET",
+			TOTPEnabled:        true,
+			TOTPLastStep:       100,
+			RecoveryCodeHashes: []string{"hash:rc1"},
+		},
+	}
+	uc := usecase.NewAuthUsecase(userRepo, newMockPersonalAccessTokenRepository(), newMockSessionRepository(), newMockMFAChallengeRepository(), mockPasswordHasher{}, mockTokenManager{}, &mockOpaqueTokenGenerator{}, mockTOTPProvider{}, 24*time.Hour)
+	return uc, userRepo
+}
+
+func login(t *testing.T, uc *usecase.AuthUsecase) *domain.AuthToken {
+	t.Helper()
+	result, err := uc.Login(context.Background(), "alice@example.com", "password123", "test-agent", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("setup: login failed: %v", err)
+	}
+	return result.Token
+}
+
+// mfaChallenge logs in as user-1 with two-factor authentication enabled and returns the MFA token.
+func mfaChallenge(t *testing.T, uc *usecase.AuthUsecase) string {
+	t.Helper()
+	result, err := uc.Login(context.Background(), "alice@example.com", "password123", "", "")
+	if err != nil || result.MFAChallenge == nil {
+		t.Fatalf("setup: expected an MFA challenge, got %+v, %v", result, err)
+	}
+	return result.MFAChallenge.Token
+}
+
+func TestLogin_Success(t *testing.T) {
+	uc, sessionRepo, _ := setupAuthTest()
+
+	result, err := uc.Login(context.Background(), "Alice@Example.com", "password123", "test-agent", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MFAChallenge != nil {
+		t.Fatal("expected no MFA challenge without two-factor authentication")
+	}
+	token := result.Token
+	if token.UserID != "user-1" {
+		t.Errorf("expected UserID 'user-1', got '%s'", token.UserID)
+	}
+	if token.AccessToken != "token:user-1:"+token.SessionID {
+		t.Errorf("expected access token for session '%s', got '%s'", token.SessionID, token.AccessToken)
+	}
+	if token.RefreshToken == "" {
+		t.Error("expected refresh token to be set")
+	}
+	session, ok := sessionRepo.sessions[token.SessionID]
+	if !ok {
+		t.Fatal("expected session to be saved")
+	}
+	if session.UserAgent != "test-agent" {
+		t.Errorf("expected UserAgent 'test-agent', got '%s'", session.UserAgent)
+	}
+}
+
+func TestLogin_WrongPassword(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	_, err := uc.Login(context.Background(), "alice@example.com", "wrong-password", "", "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestLogin_UnknownEmail(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	_, err := uc.Login(context.Background(), "nobody@example.com", "password123", "", "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestAuthenticate_Success(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	token := login(t, uc)
+
+	principal, err := uc.Authenticate(context.Background(), token.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal.UserID != "user-1" {
+		t.Errorf("expected 'user-1', got '%s'", principal.UserID)
+	}
+	if principal.SessionID != token.SessionID {
+		t.Errorf("expected SessionID '%s', got '%s'", token.SessionID, principal.SessionID)
+	}
+	if principal.IsPersonalAccessToken() {
+		t.Error("expected session principal")
+	}
+	if !principal.HasScope(domain.ScopeStatsRead) {
+		t.Error("expected session principal to have every scope")
+	}
+}
+
+func TestAuthenticate_InvalidToken(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	_, err := uc.Authenticate(context.Background(), "garbage")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestAuthenticate_RevokedSession(t *testing.T) {
+	uc, sessionRepo, _ := setupAuthTest()
+	token := login(t, uc)
+	delete(sessionRepo.sessions, token.SessionID)
+
+	_, err := uc.Authenticate(context.Background(), token.AccessToken)
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestAuthenticate_PersonalAccessToken(t *testing.T) {
+	uc, _, patRepo := setupAuthTest()
+	patRepo.tokens["pat-1"] = &domain.PersonalAccessToken{
+		ID:        "pat-1",
+		UserID:    "user-1",
+		Name:      "cli",
+		TokenHash: "hash:stpat_secret",
+		Scopes:    []string{domain.ScopeStudyLogsWrite},
+	}
+
+	principal, err := uc.Authenticate(context.Background(), "stpat_secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal.UserID != "user-1" || principal.PersonalAccessTokenID != "pat-1" {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+	if !principal.HasScope(domain.ScopeStudyLogsWrite) {
+		t.Error("expected granted scope to be allowed")
+	}
+	if principal.HasScope(domain.ScopeStatsRead) {
+		t.Error("expected ungranted scope to be denied")
+	}
+	if patRepo.tokens["pat-1"].LastUsedAt == nil {
+		t.Error("expected last-used timestamp to be recorded")
+	}
+}
+
+func TestAuthenticate_ExpiredPersonalAccessToken(t *testing.T) {
+	uc, _, patRepo := setupAuthTest()
+	expired := time.Now().Add(-time.Minute)
+	patRepo.tokens["pat-1"] = &domain.PersonalAccessToken{
+		ID:        "pat-1",
+		UserID:    "user-1",
+		Name:      "cli",
+		TokenHash: "hash:stpat_secret",
+		Scopes:    []string{domain.ScopeStudyLogsWrite},
+		ExpiresAt: &expired,
+	}
+
+	_, err := uc.Authenticate(context.Background(), "stpat_secret")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestAuthenticate_UnknownPersonalAccessToken(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	_, err := uc.Authenticate(context.Background(), "stpat_unknown")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+	first := login(t, uc)
+
+	second, err := uc.Refresh(context.Background(), first.RefreshToken, "127.0.0.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("expected refresh token to be rotated")
+	}
+	if second.SessionID != first.SessionID {
+		t.Errorf("expected same session, got '%s' and '%s'", first.SessionID, second.SessionID)
+	}
+
+	if _, err := uc.Refresh(context.Background(), second.RefreshToken, "127.0.0.2"); err != nil {
+		t.Fatalf("expected rotated token to be usable, got: %v", err)
+	}
+}
+
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
+	uc, sessionRepo, _ := setupAuthTest()
+	first := login(t, uc)
+
+	second, err := uc.Refresh(context.Background(), first.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = uc.Refresh(context.Background(), first.RefreshToken, "")
+	if !domain.IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error on reuse, got: %v", err)
+	}
+	if _, ok := sessionRepo.sessions[first.SessionID]; ok {
+		t.Error("expected session to be revoked after reuse")
+	}
+
+	if _, err := uc.Refresh(context.Background(), second.RefreshToken, ""); !domain.IsUnauthorized(err) {
+		t.Errorf("expected latest refresh token to be revoked too, got: %v", err)
+	}
+	if _, err := uc.Authenticate(context.Background(), second.AccessToken); !domain.IsUnauthorized(err) {
+		t.Errorf("expected access token to be revoked too, got: %v", err)
+	}
+}
+
+func TestRefresh_UnknownTokenKeepsSession(t *testing.T) {
+	uc, sessionRepo, _ := setupAuthTest()
+	token := login(t, uc)
+
+	_, err := uc.Refresh(context.Background(), token.SessionID+".garbage", "")
+	if !domain.IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got: %v", err)
+	}
+	if _, ok := sessionRepo.sessions[token.SessionID]; !ok {
+		t.Fatal("expected the session to survive a token that was never issued")
+	}
+	if _, err := uc.Refresh(context.Background(), token.RefreshToken, ""); err != nil {
+		t.Errorf("expected the current refresh token to still work, got: %v", err)
+	}
+}
+
+func TestRefresh_ExpiredSession(t *testing.T) {
+	uc, sessionRepo, _ := setupAuthTest()
+	token := login(t, uc)
+	sessionRepo.sessions[token.SessionID].ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err := uc.Refresh(context.Background(), token.RefreshToken, "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestRefresh_MalformedToken(t *testing.T) {
+	uc, _, _ := setupAuthTest()
+
+	_, err := uc.Refresh(context.Background(), "not-a-refresh-token", "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestLogin_MFARequired(t *testing.T) {
+	uc, _ := setupMFAAuthTest()
+
+	result, err := uc.Login(context.Background(), "alice@example.com", "password123", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Token != nil {
+		t.Error("expected no tokens before the second factor")
+	}
+	if result.MFAChallenge == nil || !strings.HasPrefix(result.MFAChallenge.Token, "mfa:user-1:") {
+		t.Fatalf("expected MFA challenge, got %+v", result.MFAChallenge)
+	}
+}
+
+func TestCompleteMFALogin_TOTPCode(t *testing.T) {
+	uc, userRepo := setupMFAAuthTest()
+
+	token, err := uc.CompleteMFALogin(context.Background(), mfaChallenge(t, uc), "000101", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken == "" || token.RefreshToken == "" {
+		t.Error("expected tokens to be issued")
+	}
+	if userRepo.users["user-1"].TwoFactor.TOTPLastStep != 101 {
+		t.Errorf("expected last step 101, got %d", userRepo.users["user-1"].TwoFactor.TOTPLastStep)
+	}
+}
+
+func TestCompleteMFALogin_ReplayedCode(t *testing.T) {
+	uc, _ := setupMFAAuthTest()
+
+	_, err := uc.CompleteMFALogin(context.Background(), mfaChallenge(t, uc), "000100", "", "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestCompleteMFALogin_RecoveryCodeIsSingleUse(t *testing.T) {
+	uc, userRepo := setupMFAAuthTest()
+	ctx := context.Background()
+
+	if _, err := uc.CompleteMFALogin(ctx, mfaChallenge(t, uc), "RC-1", "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(userRepo.users["user-1"].TwoFactor.RecoveryCodeHashes) != 0 {
+		t.Error("expected recovery code to be consumed")
+	}
+	if _, err := uc.CompleteMFALogin(ctx, mfaChallenge(t, uc), "rc1", "", ""); !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error on reuse, got: %v", err)
+	}
+}
+
+func TestCompleteMFALogin_ChallengeIsSingleUse(t *testing.T) {
+	uc, _ := setupMFAAuthTest()
+	ctx := context.Background()
+	challenge := mfaChallenge(t, uc)
+
+	if _, err := uc.CompleteMFALogin(ctx, challenge, "000101", "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CompleteMFALogin(ctx, challenge, "000102", "", ""); !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error for a completed challenge, got: %v", err)
+	}
+}
+
+func TestCompleteMFALogin_TooManyAttempts(t *testing.T) {
+	uc, _ := setupMFAAuthTest()
+	ctx := context.Background()
+	challenge := mfaChallenge(t, uc)
+
+	for i := 0; i < domain.MaxMFAAttempts; i++ {
+		if _, err := uc.CompleteMFALogin(ctx, challenge, "abcdef", "", ""); !domain.IsUnauthorized(err) {
+			t.Fatalf("attempt %d: expected unauthorized error, got: %v", i+1, err)
+		}
+	}
+	if _, err := uc.CompleteMFALogin(ctx, challenge, "000101", "", ""); !domain.IsUnauthorized(err) {
+		t.Errorf("expected the challenge to be invalidated after %d wrong codes, got: %v", domain.MaxMFAAttempts, err)
+	}
+	if _, err := uc.CompleteMFALogin(ctx, mfaChallenge(t, uc), "000101", "", ""); err != nil {
+		t.Errorf("expected a new login to get a fresh challenge, got: %v", err)
+	}
+}
+
+func TestCompleteMFALogin_InvalidChallenge(t *testing.T) {
+	uc, _ := setupMFAAuthTest()
+
+	_, err := uc.CompleteMFALogin(context.Background(), "token:user-1:s1", "000101", "", "")
+	if !domain.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
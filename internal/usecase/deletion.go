@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// issueDeletionConfirmation sets the token with which userID confirms deleting the project or user
+// with the given ID while its impact stays the same.
+func issueDeletionConfirmation(tokens port.TokenManager, userID, id string, impact *domain.DeletionImpact) error {
+	token, expiresAt, err := tokens.IssueDeletionConfirmation(userID, id, impact.Digest(id))
+	if err != nil {
+		return err
+	}
+	impact.ConfirmationToken = token
+	impact.ConfirmationExpiresAt = expiresAt
+	return nil
+}
+
+// checkDeletionConfirmation allows a deletion that is forced or confirmed with a token issued to
+// userID for the project or user with the given ID whose impact has not changed since.
+func checkDeletionConfirmation(tokens port.TokenManager, userID, id, token string, impact *domain.DeletionImpact, force bool) error {
+	if force {
+		return nil
+	}
+	if token == "" {
+		return domain.ErrValidation("preview the deletion and confirm it with its confirmation token, or force it")
+	}
+	targetID, digest, err := tokens.VerifyDeletionConfirmation(token, userID)
+	if err != nil || targetID != id {
+		return domain.ErrConflict("the confirmation token is invalid or has expired; preview the deletion again")
+	}
+	return impact.CheckDigest(id, digest)
+}
@@ -55,10 +55,24 @@ func (u *GoalUsecase) UpsertGoal(ctx context.Context, userID, projectID string,
 	return goal, nil
 }
 
-// ListGoals returns all goals for a user.
-func (u *GoalUsecase) ListGoals(ctx context.Context, userID string) ([]*domain.Goal, error) {
+// goalSortKeys are the keys goals can be sorted by; they are sorted oldest first by default.
+var goalSortKeys = []string{domain.SortByCreatedAt}
+
+// ListGoals returns a page of a user's goals and the cursor of the next page.
+func (u *GoalUsecase) ListGoals(ctx context.Context, userID string, opts PageOptions) ([]*domain.Goal, string, error) {
+	page, err := pageRequest(opts, goalSortKeys, domain.SortOrder{Key: domain.SortByCreatedAt})
+	if err != nil {
+		return nil, "", err
+	}
 	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	goals, err := u.goalRepo.FindByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, "", err
 	}
-	return u.goalRepo.FindByUserID(ctx, userID)
+	goals, next := nextPage(goals, page, func(g *domain.Goal) domain.Cursor {
+		return domain.Cursor{Time: g.CreatedAt, ID: g.ID}
+	})
+	return goals, next, nil
 }
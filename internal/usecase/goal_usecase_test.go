@@ -132,7 +132,7 @@ func TestListGoals_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	goals, err := uc.ListGoals(context.Background(), "user-1")
+	goals, _, err := uc.ListGoals(context.Background(), "user-1", usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -144,7 +144,7 @@ func TestListGoals_Success(t *testing.T) {
 func TestListGoals_UserNotFound(t *testing.T) {
 	uc, _, _, _ := setupGoalTest()
 
-	_, err := uc.ListGoals(context.Background(), "nonexistent")
+	_, _, err := uc.ListGoals(context.Background(), "nonexistent", usecase.PageOptions{})
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// MilestoneUsecase provides methods for managing the milestones of projects and their checklists.
+type MilestoneUsecase struct {
+	milestoneRepo port.MilestoneRepository
+	projectRepo   port.ProjectRepository
+	userRepo      port.UserRepository
+}
+
+// NewMilestoneUsecase creates a new MilestoneUsecase.
+func NewMilestoneUsecase(
+	milestoneRepo port.MilestoneRepository,
+	projectRepo port.ProjectRepository,
+	userRepo port.UserRepository,
+) *MilestoneUsecase {
+	return &MilestoneUsecase{
+		milestoneRepo: milestoneRepo,
+		projectRepo:   projectRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// CreateMilestone adds a milestone at the end of a project's milestones, with a checklist item for
+// each of the given titles.
+func (u *MilestoneUsecase) CreateMilestone(ctx context.Context, userID, projectID, title string, dueDate *domain.Date, items []string) (*domain.Milestone, error) {
+	if _, err := u.findOwnedProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	existing, err := u.milestoneRepo.FindByProjectIDs(ctx, []string{projectID})
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := domain.NewMilestone(uuid.New().String(), userID, projectID, title, dueDate, len(existing))
+	if err != nil {
+		return nil, err
+	}
+	for _, itemTitle := range items {
+		if _, err := milestone.AddItem(uuid.New().String(), itemTitle); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.milestoneRepo.Create(ctx, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// ListMilestones returns the milestones of a project owned by the user in order.
+func (u *MilestoneUsecase) ListMilestones(ctx context.Context, userID, projectID string) ([]*domain.Milestone, error) {
+	if _, err := u.findOwnedProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	return u.milestoneRepo.FindByProjectIDs(ctx, []string{projectID})
+}
+
+// UpdateMilestone replaces the title and due date of a milestone owned by the user.
+func (u *MilestoneUsecase) UpdateMilestone(ctx context.Context, userID, id, title string, dueDate *domain.Date) (*domain.Milestone, error) {
+	return u.updateMilestone(ctx, userID, id, func(m *domain.Milestone) error {
+		return m.Update(title, dueDate)
+	})
+}
+
+// CompleteMilestone records that a milestone owned by the user was completed now.
+func (u *MilestoneUsecase) CompleteMilestone(ctx context.Context, userID, id string) (*domain.Milestone, error) {
+	return u.updateMilestone(ctx, userID, id, func(m *domain.Milestone) error {
+		return m.Complete(time.Now())
+	})
+}
+
+// ReopenMilestone marks a completed milestone owned by the user as not completed again.
+func (u *MilestoneUsecase) ReopenMilestone(ctx context.Context, userID, id string) (*domain.Milestone, error) {
+	return u.updateMilestone(ctx, userID, id, func(m *domain.Milestone) error {
+		return m.Reopen(time.Now())
+	})
+}
+
+// DeleteMilestone deletes a milestone owned by the user together with its checklist.
+// The positions of the remaining milestones keep their order.
+func (u *MilestoneUsecase) DeleteMilestone(ctx context.Context, userID, id string) error {
+	if _, err := u.findOwnedMilestone(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.milestoneRepo.Delete(ctx, id)
+}
+
+// ReorderMilestones arranges the milestones of a project owned by the user in the order of ids,
+// which must list each of the project's milestones exactly once.
+func (u *MilestoneUsecase) ReorderMilestones(ctx context.Context, userID, projectID string, ids []string) ([]*domain.Milestone, error) {
+	if _, err := u.findOwnedProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	milestones, err := u.milestoneRepo.FindByProjectIDs(ctx, []string{projectID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(milestones) {
+		return nil, domain.ErrValidation("milestone IDs must list each of the project's milestones exactly once")
+	}
+	byID := make(map[string]*domain.Milestone, len(milestones))
+	for _, m := range milestones {
+		byID[m.ID] = m
+	}
+	ordered := make([]*domain.Milestone, len(ids))
+	for i, id := range ids {
+		m, ok := byID[id]
+		if !ok {
+			return nil, domain.ErrValidation("milestone IDs must list each of the project's milestones exactly once")
+		}
+		delete(byID, id)
+		m.Position = i
+		ordered[i] = m
+	}
+	if err := u.milestoneRepo.Reorder(ctx, ids); err != nil {
+		return nil, err
+	}
+	return ordered, nil
+}
+
+// AddChecklistItem appends an item to the checklist of a milestone owned by the user.
+func (u *MilestoneUsecase) AddChecklistItem(ctx context.Context, userID, milestoneID, title string) (*domain.Milestone, error) {
+	return u.updateMilestone(ctx, userID, milestoneID, func(m *domain.Milestone) error {
+		_, err := m.AddItem(uuid.New().String(), title)
+		return err
+	})
+}
+
+// UpdateChecklistItem replaces the title and done flag of a checklist item of a milestone owned by the user.
+func (u *MilestoneUsecase) UpdateChecklistItem(ctx context.Context, userID, milestoneID, itemID, title string, done bool) (*domain.Milestone, error) {
+	return u.updateMilestone(ctx, userID, milestoneID, func(m *domain.Milestone) error {
+		return m.UpdateItem(itemID, title, done)
+	})
+}
+
+// RemoveChecklistItem removes an item from the checklist of a milestone owned by the user.
+func (u *MilestoneUsecase) RemoveChecklistItem(ctx context.Context, userID, milestoneID, itemID string) error {
+	_, err := u.updateMilestone(ctx, userID, milestoneID, func(m *domain.Milestone) error {
+		return m.RemoveItem(itemID)
+	})
+	return err
+}
+
+// updateMilestone applies change to a milestone owned by the user and saves it.
+func (u *MilestoneUsecase) updateMilestone(ctx context.Context, userID, id string, change func(*domain.Milestone) error) (*domain.Milestone, error) {
+	milestone, err := u.findOwnedMilestone(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(milestone); err != nil {
+		return nil, err
+	}
+	if err := u.milestoneRepo.Update(ctx, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// findOwnedMilestone reports milestones of other users, and those of trashed projects, as not found.
+func (u *MilestoneUsecase) findOwnedMilestone(ctx context.Context, userID, id string) (*domain.Milestone, error) {
+	milestone, err := u.milestoneRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if milestone.UserID != userID {
+		return nil, domain.ErrNotFound("milestone")
+	}
+	if _, err := u.projectRepo.FindByID(ctx, milestone.ProjectID); err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrNotFound("milestone")
+		}
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// findOwnedProject reports projects of other users as not found so their existence is not leaked.
+func (u *MilestoneUsecase) findOwnedProject(ctx context.Context, userID, id string) (*domain.Project, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	project, err := u.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID != userID {
+		return nil, domain.ErrNotFound("project")
+	}
+	return project, nil
+}
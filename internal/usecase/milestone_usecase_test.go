@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupMilestoneTest() (*usecase.MilestoneUsecase, *mockProjectRepository, *mockMilestoneRepository) {
+	userRepo := newMockUserRepository()
+	projectRepo := newMockProjectRepository()
+	milestoneRepo := newMockMilestoneRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestUser(userRepo, "user-2", "Bob")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	uc := usecase.NewMilestoneUsecase(milestoneRepo, projectRepo, userRepo)
+	return uc, projectRepo, milestoneRepo
+}
+
+func TestCreateMilestone_AppendsWithChecklist(t *testing.T) {
+	uc, _, _ := setupMilestoneTest()
+	ctx := context.Background()
+
+	first, err := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	due := domain.Date{Year: 2024, Month: time.March, Day: 31}
+	second, err := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 2", &due, []string{"Read", "Exercises"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Position != 0 || second.Position != 1 {
+		t.Errorf("expected positions 0 and 1, got %d and %d", first.Position, second.Position)
+	}
+	if len(second.Items) != 2 || second.Items[0].Title != "Read" || second.Items[1].Title != "Exercises" {
+		t.Errorf("unexpected checklist: %+v", second.Items)
+	}
+
+	if _, err := uc.CreateMilestone(ctx, "user-2", "proj-1", "Chapter 3", nil, nil); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's project, got: %v", err)
+	}
+	if _, err := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 3", nil, []string{""}); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for an empty item title, got: %v", err)
+	}
+}
+
+func TestCompleteMilestone(t *testing.T) {
+	uc, _, _ := setupMilestoneTest()
+	ctx := context.Background()
+	m, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 1", nil, nil)
+
+	completed, err := uc.CompleteMilestone(ctx, "user-1", m.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if completed.CompletedAt == nil {
+		t.Error("expected completion time to be recorded")
+	}
+	if _, err := uc.CompleteMilestone(ctx, "user-1", m.ID); !domain.IsConflict(err) {
+		t.Errorf("expected conflict completing twice, got: %v", err)
+	}
+	if _, err := uc.CompleteMilestone(ctx, "user-2", m.ID); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's milestone, got: %v", err)
+	}
+	reopened, err := uc.ReopenMilestone(ctx, "user-1", m.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reopened.CompletedAt != nil {
+		t.Error("expected completion time to be cleared")
+	}
+}
+
+func TestMilestone_TrashedProject(t *testing.T) {
+	uc, projectRepo, _ := setupMilestoneTest()
+	ctx := context.Background()
+	m, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 1", nil, nil)
+
+	if err := projectRepo.Trash(ctx, "proj-1", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.UpdateMilestone(ctx, "user-1", m.ID, "Chapter one", nil); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a milestone of a trashed project, got: %v", err)
+	}
+}
+
+func TestReorderMilestones(t *testing.T) {
+	uc, _, _ := setupMilestoneTest()
+	ctx := context.Background()
+	a, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "A", nil, nil)
+	b, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "B", nil, nil)
+	c, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "C", nil, nil)
+
+	for _, ids := range [][]string{{c.ID, a.ID}, {c.ID, a.ID, a.ID}, {c.ID, a.ID, "missing"}} {
+		if _, err := uc.ReorderMilestones(ctx, "user-1", "proj-1", ids); !domain.IsValidation(err) {
+			t.Errorf("ids %v: expected validation error, got: %v", ids, err)
+		}
+	}
+
+	if _, err := uc.ReorderMilestones(ctx, "user-1", "proj-1", []string{c.ID, a.ID, b.ID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	milestones, err := uc.ListMilestones(ctx, "user-1", "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var titles []string
+	for _, m := range milestones {
+		titles = append(titles, m.Title)
+	}
+	if len(titles) != 3 || titles[0] != "C" || titles[1] != "A" || titles[2] != "B" {
+		t.Errorf("expected order C, A, B, got %v", titles)
+	}
+}
+
+func TestChecklistItems(t *testing.T) {
+	uc, _, _ := setupMilestoneTest()
+	ctx := context.Background()
+	m, _ := uc.CreateMilestone(ctx, "user-1", "proj-1", "Chapter 1", nil, []string{"Read"})
+
+	m, err := uc.AddChecklistItem(ctx, "user-1", m.ID, "Exercises")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, err = uc.UpdateChecklistItem(ctx, "user-1", m.ID, m.Items[0].ID, "Read", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.Progress(); got != 50 {
+		t.Errorf("expected progress 50, got %v", got)
+	}
+	if err := uc.RemoveChecklistItem(ctx, "user-1", m.ID, m.Items[1].ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.RemoveChecklistItem(ctx, "user-1", m.ID, "missing"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestListProjects_Progress(t *testing.T) {
+	userRepo := newMockUserRepository()
+	projectRepo := newMockProjectRepository()
+	milestoneRepo := newMockMilestoneRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-1", Name: "Physics"}
+	completedAt := time.Now()
+	milestoneRepo.milestones["ms-1"] = &domain.Milestone{ID: "ms-1", ProjectID: "proj-1", UserID: "user-1", CompletedAt: &completedAt}
+	milestoneRepo.milestones["ms-2"] = &domain.Milestone{ID: "ms-2", ProjectID: "proj-1", UserID: "user-1", Position: 1}
+	uc := usecase.NewProjectUsecase(projectRepo, userRepo, milestoneRepo, newMockGoalRepository(), mockTokenManager{})
+
+	projects, _, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{}, usecase.PageOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Progress != 50 || projects[1].Progress != 0 {
+		t.Errorf("expected progress 50 for Math and 0 for Physics, got %+v", projects)
+	}
+}
@@ -2,6 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase/port"
@@ -30,14 +36,77 @@ func (m *mockUserRepository) FindByID(_ context.Context, id string) (*domain.Use
 	return u, nil
 }
 
-// --- Mock ProjectRepository (map-based, with duplicate check) ---
+func (m *mockUserRepository) Update(_ context.Context, user *domain.User) error {
+	if _, ok := m.users[user.ID]; !ok {
+		return domain.ErrNotFound("user")
+	}
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepository) Delete(_ context.Context, id string) (*domain.UserDeletionSummary, error) {
+	if _, ok := m.users[id]; !ok {
+		return nil, domain.ErrNotFound("user")
+	}
+	delete(m.users, id)
+	return &domain.UserDeletionSummary{}, nil
+}
+
+func (m *mockUserRepository) DeletionImpact(_ context.Context, _ string) (*domain.DeletionImpact, error) {
+	return &domain.DeletionImpact{}, nil
+}
+
+func (m *mockUserRepository) FindByEmail(_ context.Context, email string) (*domain.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, domain.ErrNotFound("user")
+}
+
+// --- Mock ProjectRepository (map-based, with duplicate check; trashing does not reach the project's contents) ---
+
+// mockPage sorts items in the order of the page and returns those after its cursor, up to its limit.
+func mockPage[T any](items []T, page port.PageRequest, cursorOf func(T) domain.Cursor) []T {
+	compare := func(a, b domain.Cursor) int {
+		c := a.Time.Compare(b.Time)
+		if c == 0 {
+			c = strings.Compare(a.Text, b.Text)
+		}
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if page.Sort.Descending {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(items, func(a, b T) int { return compare(cursorOf(a), cursorOf(b)) })
+	if page.After != nil {
+		items = slices.DeleteFunc(items, func(item T) bool { return compare(cursorOf(item), *page.After) <= 0 })
+	}
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return items
+}
 
 type mockProjectRepository struct {
 	projects map[string]*domain.Project
+	trashed  map[string]*domain.Project
+	copies   map[string]*domain.ProjectCopy
+	// impacts are the deletion impacts reported for projects; other projects have nothing in them.
+	impacts map[string]domain.DeletionImpact
 }
 
 func newMockProjectRepository() *mockProjectRepository {
-	return &mockProjectRepository{projects: make(map[string]*domain.Project)}
+	return &mockProjectRepository{
+		projects: make(map[string]*domain.Project),
+		trashed:  make(map[string]*domain.Project),
+		copies:   make(map[string]*domain.ProjectCopy),
+		impacts:  make(map[string]domain.DeletionImpact),
+	}
 }
 
 func (m *mockProjectRepository) Create(_ context.Context, p *domain.Project) error {
@@ -59,14 +128,19 @@ func (m *mockProjectRepository) FindByID(_ context.Context, id string) (*domain.
 	return p, nil
 }
 
-func (m *mockProjectRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Project, error) {
+func (m *mockProjectRepository) FindByUserID(_ context.Context, userID string, filter port.ProjectFilter, page port.PageRequest) ([]*domain.Project, error) {
 	var result []*domain.Project
 	for _, p := range m.projects {
-		if p.UserID == userID {
+		if p.UserID == userID && (filter.Archived == nil || p.IsArchived() == *filter.Archived) && (filter.Status == "" || p.Status == filter.Status) {
 			result = append(result, p)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(p *domain.Project) domain.Cursor {
+		if page.Sort.Key == domain.SortByCreatedAt {
+			return domain.Cursor{Time: p.CreatedAt, ID: p.ID}
+		}
+		return domain.Cursor{Text: p.Name, ID: p.ID}
+	}), nil
 }
 
 func (m *mockProjectRepository) Update(_ context.Context, project *domain.Project) error {
@@ -77,18 +151,298 @@ func (m *mockProjectRepository) Update(_ context.Context, project *domain.Projec
 	return nil
 }
 
-func (m *mockProjectRepository) Delete(_ context.Context, id string) error {
-	if _, ok := m.projects[id]; !ok {
+func (m *mockProjectRepository) CreateCopy(ctx context.Context, c *domain.ProjectCopy) error {
+	if err := m.Create(ctx, c.Project); err != nil {
+		return err
+	}
+	m.copies[c.Project.ID] = c
+	return nil
+}
+
+func (m *mockProjectRepository) Merge(_ context.Context, sourceID, _ string, _ *domain.Goal) (*domain.ProjectMergeSummary, error) {
+	if _, ok := m.projects[sourceID]; !ok {
+		return nil, domain.ErrNotFound("project")
+	}
+	delete(m.projects, sourceID)
+	return &domain.ProjectMergeSummary{}, nil
+}
+
+func (m *mockProjectRepository) Trash(_ context.Context, id string, at time.Time) error {
+	p, ok := m.projects[id]
+	if !ok {
 		return domain.ErrNotFound("project")
 	}
+	p.DeletedAt = &at
 	delete(m.projects, id)
+	m.trashed[id] = p
+	return nil
+}
+
+func (m *mockProjectRepository) DeletionImpact(_ context.Context, id string) (*domain.DeletionImpact, error) {
+	impact := m.impacts[id]
+	return &impact, nil
+}
+
+func (m *mockProjectRepository) FindTrashedByID(_ context.Context, id string) (*domain.Project, error) {
+	p, ok := m.trashed[id]
+	if !ok {
+		return nil, domain.ErrNotFound("project")
+	}
+	return p, nil
+}
+
+func (m *mockProjectRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.Project, error) {
+	var result []*domain.Project
+	for _, p := range m.trashed {
+		if p.UserID == userID {
+			result = append(result, p)
+		}
+	}
+	return mockTrashOrder(result, func(p *domain.Project) (time.Time, string) { return *p.DeletedAt, p.ID }), nil
+}
+
+func (m *mockProjectRepository) Restore(_ context.Context, id string) error {
+	p, ok := m.trashed[id]
+	if !ok {
+		return domain.ErrNotFound("project")
+	}
+	for _, existing := range m.projects {
+		if existing.UserID == p.UserID && existing.Name == p.Name {
+			return domain.ErrConflict("project with this name already exists for this user")
+		}
+	}
+	p.DeletedAt = nil
+	delete(m.trashed, id)
+	m.projects[id] = p
+	return nil
+}
+
+func (m *mockProjectRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, p := range m.trashed {
+		if p.DeletedAt.Before(before) {
+			delete(m.trashed, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// mockTrashOrder sorts trashed items by deletion time, most recent first.
+func mockTrashOrder[T any](items []T, keyOf func(T) (time.Time, string)) []T {
+	slices.SortFunc(items, func(a, b T) int {
+		at, aID := keyOf(a)
+		bt, bID := keyOf(b)
+		if c := bt.Compare(at); c != 0 {
+			return c
+		}
+		return strings.Compare(aID, bID)
+	})
+	return items
+}
+
+// --- Mock MilestoneRepository (map-based) ---
+
+type mockMilestoneRepository struct {
+	milestones map[string]*domain.Milestone
+}
+
+func newMockMilestoneRepository() *mockMilestoneRepository {
+	return &mockMilestoneRepository{milestones: make(map[string]*domain.Milestone)}
+}
+
+func (m *mockMilestoneRepository) Create(_ context.Context, milestone *domain.Milestone) error {
+	m.milestones[milestone.ID] = milestone
+	return nil
+}
+
+func (m *mockMilestoneRepository) FindByID(_ context.Context, id string) (*domain.Milestone, error) {
+	milestone, ok := m.milestones[id]
+	if !ok {
+		return nil, domain.ErrNotFound("milestone")
+	}
+	return milestone, nil
+}
+
+func (m *mockMilestoneRepository) FindByProjectIDs(_ context.Context, projectIDs []string) ([]*domain.Milestone, error) {
+	var result []*domain.Milestone
+	for _, milestone := range m.milestones {
+		if slices.Contains(projectIDs, milestone.ProjectID) {
+			result = append(result, milestone)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.Milestone) int {
+		if c := strings.Compare(a.ProjectID, b.ProjectID); c != 0 {
+			return c
+		}
+		return a.Position - b.Position
+	})
+	return result, nil
+}
+
+func (m *mockMilestoneRepository) Update(_ context.Context, milestone *domain.Milestone) error {
+	if _, ok := m.milestones[milestone.ID]; !ok {
+		return domain.ErrNotFound("milestone")
+	}
+	m.milestones[milestone.ID] = milestone
+	return nil
+}
+
+func (m *mockMilestoneRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.milestones[id]; !ok {
+		return domain.ErrNotFound("milestone")
+	}
+	delete(m.milestones, id)
+	return nil
+}
+
+func (m *mockMilestoneRepository) Reorder(_ context.Context, ids []string) error {
+	for i, id := range ids {
+		milestone, ok := m.milestones[id]
+		if !ok {
+			return domain.ErrNotFound("milestone")
+		}
+		milestone.Position = i
+	}
+	return nil
+}
+
+// --- Mock ProjectMemberRepository (map-based) ---
+
+type mockProjectMemberRepository struct {
+	members map[string]*domain.ProjectMember
+}
+
+func newMockProjectMemberRepository() *mockProjectMemberRepository {
+	return &mockProjectMemberRepository{members: make(map[string]*domain.ProjectMember)}
+}
+
+func projectMemberKey(projectID, userID string) string {
+	return projectID + "/" + userID
+}
+
+func (m *mockProjectMemberRepository) Create(_ context.Context, member *domain.ProjectMember) error {
+	key := projectMemberKey(member.ProjectID, member.UserID)
+	if _, ok := m.members[key]; ok {
+		return domain.ErrConflict("the user is already a member of the project or invited to it")
+	}
+	m.members[key] = member
+	return nil
+}
+
+func (m *mockProjectMemberRepository) Find(_ context.Context, projectID, userID string) (*domain.ProjectMember, error) {
+	member, ok := m.members[projectMemberKey(projectID, userID)]
+	if !ok {
+		return nil, domain.ErrNotFound("project member")
+	}
+	return member, nil
+}
+
+func (m *mockProjectMemberRepository) FindByProjectID(_ context.Context, projectID string) ([]*domain.ProjectMember, error) {
+	return m.filter(func(member *domain.ProjectMember) bool { return member.ProjectID == projectID }), nil
+}
+
+func (m *mockProjectMemberRepository) FindByUserID(_ context.Context, userID string) ([]*domain.ProjectMember, error) {
+	return m.filter(func(member *domain.ProjectMember) bool { return member.UserID == userID }), nil
+}
+
+func (m *mockProjectMemberRepository) filter(match func(*domain.ProjectMember) bool) []*domain.ProjectMember {
+	result := []*domain.ProjectMember{}
+	for _, member := range m.members {
+		if match(member) {
+			result = append(result, member)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.ProjectMember) int {
+		if c := a.InvitedAt.Compare(b.InvitedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return result
+}
+
+func (m *mockProjectMemberRepository) Update(_ context.Context, member *domain.ProjectMember) error {
+	key := projectMemberKey(member.ProjectID, member.UserID)
+	if _, ok := m.members[key]; !ok {
+		return domain.ErrNotFound("project member")
+	}
+	m.members[key] = member
+	return nil
+}
+
+func (m *mockProjectMemberRepository) Delete(_ context.Context, projectID, userID string) error {
+	key := projectMemberKey(projectID, userID)
+	if _, ok := m.members[key]; !ok {
+		return domain.ErrNotFound("project member")
+	}
+	delete(m.members, key)
+	return nil
+}
+
+// --- Mock ActivityTypeRepository (map-based) ---
+
+type mockActivityTypeRepository struct {
+	activityTypes map[string]*domain.ActivityType
+}
+
+func newMockActivityTypeRepository() *mockActivityTypeRepository {
+	return &mockActivityTypeRepository{activityTypes: make(map[string]*domain.ActivityType)}
+}
+
+func (m *mockActivityTypeRepository) Create(_ context.Context, a *domain.ActivityType) error {
+	for _, existing := range m.activityTypes {
+		if existing.UserID == a.UserID && existing.Name == a.Name {
+			return domain.ErrConflict("activity type with this name already exists for this user")
+		}
+	}
+	m.activityTypes[a.ID] = a
+	return nil
+}
+
+func (m *mockActivityTypeRepository) FindByID(_ context.Context, id string) (*domain.ActivityType, error) {
+	a, ok := m.activityTypes[id]
+	if !ok {
+		return nil, domain.ErrNotFound("activity type")
+	}
+	return a, nil
+}
+
+func (m *mockActivityTypeRepository) FindByUserID(_ context.Context, userID string) ([]*domain.ActivityType, error) {
+	var result []*domain.ActivityType
+	for _, a := range m.activityTypes {
+		if a.UserID == userID {
+			result = append(result, a)
+		}
+	}
+	slices.SortFunc(result, func(a, b *domain.ActivityType) int { return strings.Compare(a.Name, b.Name) })
+	return result, nil
+}
+
+func (m *mockActivityTypeRepository) Update(_ context.Context, a *domain.ActivityType) error {
+	if _, ok := m.activityTypes[a.ID]; !ok {
+		return domain.ErrNotFound("activity type")
+	}
+	m.activityTypes[a.ID] = a
+	return nil
+}
+
+func (m *mockActivityTypeRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.activityTypes[id]; !ok {
+		return domain.ErrNotFound("activity type")
+	}
+	delete(m.activityTypes, id)
 	return nil
 }
 
 // --- Mock StudyLogRepository (slice-based) ---
 
 type mockStudyLogRepository struct {
-	logs []*domain.StudyLog
+	logs      []*domain.StudyLog
+	trashed   []*domain.StudyLog
+	revisions []*domain.StudyLogRevision
+	// findCalls counts the calls to FindByUserID.
+	findCalls int
 }
 
 func newMockStudyLogRepository() *mockStudyLogRepository {
@@ -100,6 +454,11 @@ func (m *mockStudyLogRepository) Create(_ context.Context, l *domain.StudyLog) e
 	return nil
 }
 
+func (m *mockStudyLogRepository) CreateBatch(_ context.Context, logs []*domain.StudyLog) error {
+	m.logs = append(m.logs, logs...)
+	return nil
+}
+
 func (m *mockStudyLogRepository) FindByID(_ context.Context, id string) (*domain.StudyLog, error) {
 	for _, l := range m.logs {
 		if l.ID == id {
@@ -109,7 +468,8 @@ func (m *mockStudyLogRepository) FindByID(_ context.Context, id string) (*domain
 	return nil, domain.ErrNotFound("study log")
 }
 
-func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string, filter port.StudyLogFilter) ([]*domain.StudyLog, error) {
+func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string, filter port.StudyLogFilter, page port.PageRequest) ([]*domain.StudyLog, error) {
+	m.findCalls++
 	var result []*domain.StudyLog
 	for _, l := range m.logs {
 		if l.UserID != userID {
@@ -124,21 +484,105 @@ func (m *mockStudyLogRepository) FindByUserID(_ context.Context, userID string,
 		if filter.ProjectID != nil && l.ProjectID != *filter.ProjectID {
 			continue
 		}
+		if filter.ActivityTypeID != nil && l.ActivityTypeID != *filter.ActivityTypeID {
+			continue
+		}
 		result = append(result, l)
 	}
+	return mockPage(result, page, func(l *domain.StudyLog) domain.Cursor {
+		return domain.Cursor{Time: l.StudiedAt, ID: l.ID}
+	}), nil
+}
+
+func (m *mockStudyLogRepository) FindImportKeys(_ context.Context, userID string, keys []string) ([]string, error) {
+	var found []string
+	for _, l := range m.logs {
+		if l.UserID == userID && l.ImportKey != "" && slices.Contains(keys, l.ImportKey) {
+			found = append(found, l.ImportKey)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockStudyLogRepository) Update(_ context.Context, l *domain.StudyLog, revision *domain.StudyLogRevision) error {
+	for i, existing := range m.logs {
+		if existing.ID == l.ID {
+			m.logs[i] = l
+			m.revisions = append(m.revisions, revision)
+			return nil
+		}
+	}
+	return domain.ErrNotFound("study log")
+}
+
+func (m *mockStudyLogRepository) FindRevisionByID(_ context.Context, id string) (*domain.StudyLogRevision, error) {
+	for _, r := range m.revisions {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, domain.ErrNotFound("study log revision")
+}
+
+func (m *mockStudyLogRepository) FindRevisions(_ context.Context, studyLogID string) ([]*domain.StudyLogRevision, error) {
+	var result []*domain.StudyLogRevision
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if m.revisions[i].StudyLogID == studyLogID {
+			result = append(result, m.revisions[i])
+		}
+	}
 	return result, nil
 }
 
-func (m *mockStudyLogRepository) Delete(_ context.Context, id string) error {
+func (m *mockStudyLogRepository) Trash(_ context.Context, id string, at time.Time) error {
 	for i, l := range m.logs {
 		if l.ID == id {
+			l.DeletedAt = &at
 			m.logs = append(m.logs[:i], m.logs[i+1:]...)
+			m.trashed = append(m.trashed, l)
+			return nil
+		}
+	}
+	return domain.ErrNotFound("study log")
+}
+
+func (m *mockStudyLogRepository) FindTrashedByID(_ context.Context, id string) (*domain.StudyLog, error) {
+	for _, l := range m.trashed {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, domain.ErrNotFound("study log")
+}
+
+func (m *mockStudyLogRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.StudyLog, error) {
+	var result []*domain.StudyLog
+	for _, l := range m.trashed {
+		if l.UserID == userID {
+			result = append(result, l)
+		}
+	}
+	return mockTrashOrder(result, func(l *domain.StudyLog) (time.Time, string) { return *l.DeletedAt, l.ID }), nil
+}
+
+func (m *mockStudyLogRepository) Restore(_ context.Context, id string) error {
+	for i, l := range m.trashed {
+		if l.ID == id {
+			l.DeletedAt = nil
+			m.trashed = append(m.trashed[:i], m.trashed[i+1:]...)
+			m.logs = append(m.logs, l)
 			return nil
 		}
 	}
 	return domain.ErrNotFound("study log")
 }
 
+func (m *mockStudyLogRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	n := len(m.trashed)
+	m.trashed = slices.DeleteFunc(m.trashed, func(l *domain.StudyLog) bool { return l.DeletedAt.Before(before) })
+	return int64(n - len(m.trashed)), nil
+}
+
 // --- Mock GoalRepository (slice-based, with upsert logic) ---
 
 type mockGoalRepository struct {
@@ -161,24 +605,30 @@ func (m *mockGoalRepository) Upsert(_ context.Context, g *domain.Goal) error {
 	return nil
 }
 
-func (m *mockGoalRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Goal, error) {
+func (m *mockGoalRepository) FindByUserID(_ context.Context, userID string, page port.PageRequest) ([]*domain.Goal, error) {
 	var result []*domain.Goal
 	for _, g := range m.goals {
 		if g.UserID == userID {
 			result = append(result, g)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(g *domain.Goal) domain.Cursor {
+		return domain.Cursor{Time: g.CreatedAt, ID: g.ID}
+	}), nil
 }
 
 // --- Mock NoteRepository (map-based) ---
 
 type mockNoteRepository struct {
-	notes map[string]*domain.Note
+	notes   map[string]*domain.Note
+	trashed map[string]*domain.Note
 }
 
 func newMockNoteRepository() *mockNoteRepository {
-	return &mockNoteRepository{notes: make(map[string]*domain.Note)}
+	return &mockNoteRepository{
+		notes:   make(map[string]*domain.Note),
+		trashed: make(map[string]*domain.Note),
+	}
 }
 
 func (m *mockNoteRepository) Create(_ context.Context, note *domain.Note) error {
@@ -194,14 +644,19 @@ func (m *mockNoteRepository) FindByID(_ context.Context, id string) (*domain.Not
 	return n, nil
 }
 
-func (m *mockNoteRepository) FindByProjectID(_ context.Context, projectID string) ([]*domain.Note, error) {
+func (m *mockNoteRepository) FindByProjectID(_ context.Context, projectID string, page port.PageRequest) ([]*domain.Note, error) {
 	var result []*domain.Note
 	for _, n := range m.notes {
 		if n.ProjectID == projectID {
 			result = append(result, n)
 		}
 	}
-	return result, nil
+	return mockPage(result, page, func(n *domain.Note) domain.Cursor {
+		if page.Sort.Key == domain.SortByCreatedAt {
+			return domain.Cursor{Time: n.CreatedAt, ID: n.ID}
+		}
+		return domain.Cursor{Time: n.UpdatedAt, ID: n.ID}
+	}), nil
 }
 
 func (m *mockNoteRepository) Update(_ context.Context, note *domain.Note) error {
@@ -212,10 +667,483 @@ func (m *mockNoteRepository) Update(_ context.Context, note *domain.Note) error
 	return nil
 }
 
-func (m *mockNoteRepository) Delete(_ context.Context, id string) error {
-	if _, ok := m.notes[id]; !ok {
+func (m *mockNoteRepository) Trash(_ context.Context, id string, at time.Time) error {
+	n, ok := m.notes[id]
+	if !ok {
 		return domain.ErrNotFound("note")
 	}
+	n.DeletedAt = &at
 	delete(m.notes, id)
+	m.trashed[id] = n
+	return nil
+}
+
+func (m *mockNoteRepository) FindTrashedByID(_ context.Context, id string) (*domain.Note, error) {
+	n, ok := m.trashed[id]
+	if !ok {
+		return nil, domain.ErrNotFound("note")
+	}
+	return n, nil
+}
+
+func (m *mockNoteRepository) FindTrashedByUserID(_ context.Context, userID string) ([]*domain.Note, error) {
+	var result []*domain.Note
+	for _, n := range m.trashed {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return mockTrashOrder(result, func(n *domain.Note) (time.Time, string) { return *n.DeletedAt, n.ID }), nil
+}
+
+func (m *mockNoteRepository) Restore(_ context.Context, id string) error {
+	n, ok := m.trashed[id]
+	if !ok {
+		return domain.ErrNotFound("note")
+	}
+	n.DeletedAt = nil
+	delete(m.trashed, id)
+	m.notes[id] = n
+	return nil
+}
+
+func (m *mockNoteRepository) PurgeTrashed(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, note := range m.trashed {
+		if note.DeletedAt.Before(before) {
+			delete(m.trashed, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// --- Mock NoteLinkRepository (map-based) ---
+
+type mockNoteLinkRepository struct {
+	noteRepo     *mockNoteRepository
+	studyLogRepo *mockStudyLogRepository
+	links        map[[2]string]bool // keyed by note ID and study log ID
+}
+
+func newMockNoteLinkRepository(noteRepo *mockNoteRepository, studyLogRepo *mockStudyLogRepository) *mockNoteLinkRepository {
+	return &mockNoteLinkRepository{noteRepo: noteRepo, studyLogRepo: studyLogRepo, links: make(map[[2]string]bool)}
+}
+
+func (m *mockNoteLinkRepository) Link(_ context.Context, noteID, studyLogID string) error {
+	m.links[[2]string{noteID, studyLogID}] = true
+	return nil
+}
+
+func (m *mockNoteLinkRepository) Unlink(_ context.Context, noteID, studyLogID string) error {
+	if !m.links[[2]string{noteID, studyLogID}] {
+		return domain.ErrNotFound("note link")
+	}
+	delete(m.links, [2]string{noteID, studyLogID})
+	return nil
+}
+
+func (m *mockNoteLinkRepository) FindStudyLogsByNoteIDs(ctx context.Context, noteIDs []string) (map[string][]domain.LinkedStudyLog, error) {
+	result := make(map[string][]domain.LinkedStudyLog)
+	for link := range m.links {
+		if !slices.Contains(noteIDs, link[0]) {
+			continue
+		}
+		l, err := m.studyLogRepo.FindByID(ctx, link[1])
+		if err != nil {
+			continue
+		}
+		result[link[0]] = append(result[link[0]], domain.LinkedStudyLog{ID: l.ID, ProjectID: l.ProjectID, StudiedAt: l.StudiedAt, Minutes: l.Minutes})
+	}
+	for _, logs := range result {
+		slices.SortFunc(logs, func(a, b domain.LinkedStudyLog) int { return a.StudiedAt.Compare(b.StudiedAt) })
+	}
+	return result, nil
+}
+
+func (m *mockNoteLinkRepository) FindNotesByStudyLogIDs(_ context.Context, studyLogIDs []string) (map[string][]domain.LinkedNote, error) {
+	result := make(map[string][]domain.LinkedNote)
+	for link := range m.links {
+		if !slices.Contains(studyLogIDs, link[1]) {
+			continue
+		}
+		n, ok := m.noteRepo.notes[link[0]]
+		if !ok {
+			continue
+		}
+		result[link[1]] = append(result[link[1]], domain.LinkedNote{ID: n.ID, ProjectID: n.ProjectID, Title: n.Title})
+	}
+	for _, notes := range result {
+		slices.SortFunc(notes, func(a, b domain.LinkedNote) int { return strings.Compare(a.Title, b.Title) })
+	}
+	return result, nil
+}
+
+// --- Mock PersonalAccessTokenRepository (map-based) ---
+
+type mockPersonalAccessTokenRepository struct {
+	tokens map[string]*domain.PersonalAccessToken
+}
+
+func newMockPersonalAccessTokenRepository() *mockPersonalAccessTokenRepository {
+	return &mockPersonalAccessTokenRepository{tokens: make(map[string]*domain.PersonalAccessToken)}
+}
+
+func (m *mockPersonalAccessTokenRepository) Create(_ context.Context, token *domain.PersonalAccessToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByID(_ context.Context, id string) (*domain.PersonalAccessToken, error) {
+	t, ok := m.tokens[id]
+	if !ok {
+		return nil, domain.ErrNotFound("personal access token")
+	}
+	return t, nil
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByHash(_ context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrNotFound("personal access token")
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByUserID(_ context.Context, userID string) ([]*domain.PersonalAccessToken, error) {
+	var result []*domain.PersonalAccessToken
+	for _, t := range m.tokens {
+		if t.UserID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPersonalAccessTokenRepository) UpdateLastUsed(_ context.Context, id string, lastUsedAt time.Time) error {
+	t, ok := m.tokens[id]
+	if !ok {
+		return domain.ErrNotFound("personal access token")
+	}
+	t.LastUsedAt = &lastUsedAt
+	return nil
+}
+
+func (m *mockPersonalAccessTokenRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.tokens[id]; !ok {
+		return domain.ErrNotFound("personal access token")
+	}
+	delete(m.tokens, id)
 	return nil
 }
+
+// --- Mock SessionRepository (map-based) ---
+
+type mockSessionRepository struct {
+	sessions map[string]*domain.Session
+	// rotated maps the hashes of rotated-out refresh tokens to their session IDs.
+	rotated map[string]string
+}
+
+func newMockSessionRepository() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*domain.Session), rotated: make(map[string]string)}
+}
+
+func (m *mockSessionRepository) Create(_ context.Context, session *domain.Session) error {
+	saved := *session
+	m.sessions[session.ID] = &saved
+	return nil
+}
+
+func (m *mockSessionRepository) FindByID(_ context.Context, id string) (*domain.Session, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, domain.ErrNotFound("session")
+	}
+	found := *s
+	return &found, nil
+}
+
+func (m *mockSessionRepository) FindByUserID(_ context.Context, userID string) ([]*domain.Session, error) {
+	var result []*domain.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) Rotate(_ context.Context, session *domain.Session, previousHash string) error {
+	s, ok := m.sessions[session.ID]
+	if !ok || s.RefreshTokenHash != previousHash {
+		return domain.ErrConflict("refresh token was already used")
+	}
+	saved := *session
+	m.rotated[previousHash] = session.ID
+	m.sessions[session.ID] = &saved
+	return nil
+}
+
+func (m *mockSessionRepository) IsRotatedToken(_ context.Context, sessionID, tokenHash string) (bool, error) {
+	return m.rotated[tokenHash] == sessionID, nil
+}
+
+func (m *mockSessionRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.sessions[id]; !ok {
+		return domain.ErrNotFound("session")
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteByUserID(_ context.Context, userID string) error {
+	for id, s := range m.sessions {
+		if s.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
+
+// --- Mock MFAChallengeRepository (map-based, counting attempts per challenge) ---
+
+type mockMFAChallengeRepository struct {
+	attempts map[string]int
+}
+
+func newMockMFAChallengeRepository() *mockMFAChallengeRepository {
+	return &mockMFAChallengeRepository{attempts: make(map[string]int)}
+}
+
+func (m *mockMFAChallengeRepository) Create(_ context.Context, id, _ string, _ time.Time) error {
+	m.attempts[id] = 0
+	return nil
+}
+
+func (m *mockMFAChallengeRepository) RecordAttempt(_ context.Context, id string, _ time.Time) (int, error) {
+	n, ok := m.attempts[id]
+	if !ok {
+		return 0, domain.ErrNotFound("MFA challenge")
+	}
+	m.attempts[id] = n + 1
+	return n + 1, nil
+}
+
+func (m *mockMFAChallengeRepository) Delete(_ context.Context, id string) error {
+	if _, ok := m.attempts[id]; !ok {
+		return domain.ErrNotFound("MFA challenge")
+	}
+	delete(m.attempts, id)
+	return nil
+}
+
+// --- Mock TimerRepository (map-based, keyed by user ID) ---
+
+type mockTimerRepository struct {
+	timers map[string]*domain.Timer
+}
+
+func newMockTimerRepository() *mockTimerRepository {
+	return &mockTimerRepository{timers: make(map[string]*domain.Timer)}
+}
+
+func (m *mockTimerRepository) Create(_ context.Context, timer *domain.Timer) error {
+	if _, ok := m.timers[timer.UserID]; ok {
+		return domain.ErrConflict("a timer is already active for this user")
+	}
+	m.timers[timer.UserID] = timer
+	return nil
+}
+
+func (m *mockTimerRepository) FindByUserID(_ context.Context, userID string) (*domain.Timer, error) {
+	timer, ok := m.timers[userID]
+	if !ok {
+		return nil, domain.ErrNotFound("timer")
+	}
+	copied := *timer
+	return &copied, nil
+}
+
+func (m *mockTimerRepository) FindExpired(_ context.Context, now time.Time) ([]*domain.Timer, error) {
+	var result []*domain.Timer
+	for _, timer := range m.timers {
+		if timer.IsExpired(now) {
+			copied := *timer
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockTimerRepository) Update(_ context.Context, timer *domain.Timer) error {
+	existing, ok := m.timers[timer.UserID]
+	if !ok || !existing.StartedAt.Equal(timer.StartedAt) {
+		return domain.ErrNotFound("timer")
+	}
+	m.timers[timer.UserID] = timer
+	return nil
+}
+
+func (m *mockTimerRepository) Delete(_ context.Context, timer *domain.Timer) error {
+	existing, ok := m.timers[timer.UserID]
+	if !ok || !existing.StartedAt.Equal(timer.StartedAt) {
+		return domain.ErrNotFound("timer")
+	}
+	delete(m.timers, timer.UserID)
+	return nil
+}
+
+// --- Mock PomodoroRepository (map-based, keyed by user ID; saved logs go to studyLogRepo) ---
+
+type mockPomodoroRepository struct {
+	sessions     map[string]*domain.PomodoroSession
+	studyLogRepo *mockStudyLogRepository
+}
+
+func newMockPomodoroRepository(studyLogRepo *mockStudyLogRepository) *mockPomodoroRepository {
+	return &mockPomodoroRepository{sessions: make(map[string]*domain.PomodoroSession), studyLogRepo: studyLogRepo}
+}
+
+func (m *mockPomodoroRepository) Create(_ context.Context, session *domain.PomodoroSession) error {
+	if _, ok := m.sessions[session.UserID]; ok {
+		return domain.ErrConflict("a pomodoro session is already active for this user")
+	}
+	m.sessions[session.UserID] = session
+	return nil
+}
+
+func (m *mockPomodoroRepository) FindByUserID(_ context.Context, userID string) (*domain.PomodoroSession, error) {
+	session, ok := m.sessions[userID]
+	if !ok {
+		return nil, domain.ErrNotFound("pomodoro session")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (m *mockPomodoroRepository) FindDue(_ context.Context, now time.Time) ([]*domain.PomodoroSession, error) {
+	var result []*domain.PomodoroSession
+	for _, session := range m.sessions {
+		if !now.Before(session.PhaseEndsAt()) {
+			copied := *session
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPomodoroRepository) Save(_ context.Context, session *domain.PomodoroSession, previousUpdatedAt time.Time, logs []*domain.StudyLog) error {
+	existing, ok := m.sessions[session.UserID]
+	if !ok || !existing.UpdatedAt.Equal(previousUpdatedAt) {
+		return domain.ErrConflict("pomodoro session was changed concurrently")
+	}
+	if session.IsFinished() {
+		delete(m.sessions, session.UserID)
+	} else {
+		m.sessions[session.UserID] = session
+	}
+	m.studyLogRepo.logs = append(m.studyLogRepo.logs, logs...)
+	return nil
+}
+
+// --- Mock PasswordHasher (prefix-based, not secure) ---
+
+type mockPasswordHasher struct{}
+
+func (mockPasswordHasher) Hash(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (mockPasswordHasher) Compare(hash, password string) error {
+	if hash != "hashed:"+password {
+		return errors.New("password mismatch")
+	}
+	return nil
+}
+
+// --- Mock TokenManager (token is "token:<userID>:<sessionID>") ---
+
+type mockTokenManager struct{}
+
+func (mockTokenManager) Issue(userID, sessionID string) (string, time.Time, error) {
+	return "token:" + userID + ":" + sessionID, time.Now().Add(time.Hour), nil
+}
+
+func (mockTokenManager) Verify(token string) (*port.TokenClaims, error) {
+	rest, ok := strings.CutPrefix(token, "token:")
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	userID, sessionID, _ := strings.Cut(rest, ":")
+	return &port.TokenClaims{UserID: userID, SessionID: sessionID, ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func (mockTokenManager) IssueMFAChallenge(userID, challengeID string) (string, time.Time, error) {
+	return "mfa:" + userID + ":" + challengeID, time.Now().Add(5 * time.Minute), nil
+}
+
+func (mockTokenManager) VerifyMFAChallenge(token string) (string, string, error) {
+	rest, ok := strings.CutPrefix(token, "mfa:")
+	if !ok {
+		return "", "", errors.New("invalid token")
+	}
+	userID, challengeID, _ := strings.Cut(rest, ":")
+	return userID, challengeID, nil
+}
+
+func (mockTokenManager) IssueDeletionConfirmation(userID, targetID, digest string) (string, time.Time, error) {
+	return "delete:" + userID + ":" + targetID + ":" + digest, time.Now().Add(15 * time.Minute), nil
+}
+
+func (mockTokenManager) VerifyDeletionConfirmation(token, userID string) (string, string, error) {
+	parts := strings.SplitN(token, ":", 4)
+	if len(parts) != 4 || parts[0] != "delete" || parts[1] != userID {
+		return "", "", errors.New("invalid token")
+	}
+	return parts[2], parts[3], nil
+}
+
+// --- Mock OpaqueTokenGenerator (sequential tokens, hash is "hash:<token>") ---
+
+type mockOpaqueTokenGenerator struct {
+	n int
+}
+
+func (g *mockOpaqueTokenGenerator) Generate() (string, error) {
+	g.n++
+	return fmt.Sprintf("secret-%d", g.n), nil
+}
+
+func (g *mockOpaqueTokenGenerator) Hash(token string) string {
+	return "hash:" + token
+}
+
+// --- Mock TOTPProvider (any six-digit code is valid; its value is the time step) ---
+
+type mockTOTPProvider struct{}
+
+func (mockTOTPProvider) GenerateSecret() (string, error) {
+	return "SECRET", nil
+}
+
+func (mockTOTPProvider) ProvisioningURI(secret, accountName string) string {
+	return "otpauth://totp/Test:" + accountName + "?secret=" + secret
+}
+
+func (mockTOTPProvider) Validate(secret, code string, _ time.Time) (int64, bool) {
+	step, err := strconv.ParseInt(code, 10, 64)
+	if secret == "" || len(code) != 6 || err != nil {
+		return 0, false
+	}
+	return step, true
+}
+
+func (mockTOTPProvider) GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("code-%d", i)
+	}
+	return codes, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,32 +12,41 @@ import (
 
 // NoteUsecase provides methods for managing notes.
 type NoteUsecase struct {
-	noteRepo    port.NoteRepository
-	projectRepo port.ProjectRepository
-	userRepo    port.UserRepository
+	noteRepo     port.NoteRepository
+	projectRepo  port.ProjectRepository
+	userRepo     port.UserRepository
+	studyLogRepo port.StudyLogRepository
+	noteLinkRepo port.NoteLinkRepository
+	memberRepo   port.ProjectMemberRepository
 }
 
 // NewNoteUsecase creates a new NoteUsecase.
-func NewNoteUsecase(noteRepo port.NoteRepository, projectRepo port.ProjectRepository, userRepo port.UserRepository) *NoteUsecase {
+func NewNoteUsecase(
+	noteRepo port.NoteRepository,
+	projectRepo port.ProjectRepository,
+	userRepo port.UserRepository,
+	studyLogRepo port.StudyLogRepository,
+	noteLinkRepo port.NoteLinkRepository,
+	memberRepo port.ProjectMemberRepository,
+) *NoteUsecase {
 	return &NoteUsecase{
-		noteRepo:    noteRepo,
-		projectRepo: projectRepo,
-		userRepo:    userRepo,
+		noteRepo:     noteRepo,
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		studyLogRepo: studyLogRepo,
+		noteLinkRepo: noteLinkRepo,
+		memberRepo:   memberRepo,
 	}
 }
 
-// CreateNote creates a new note for a project.
+// CreateNote creates a new note for a project the user owns or has joined as an editor.
 func (u *NoteUsecase) CreateNote(ctx context.Context, userID, projectID, title, content string, tags []string) (*domain.Note, error) {
 	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
 		return nil, err
 	}
-	project, err := u.projectRepo.FindByID(ctx, projectID)
-	if err != nil {
+	if _, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID); err != nil {
 		return nil, err
 	}
-	if project.UserID != userID {
-		return nil, domain.ErrNotFound("project")
-	}
 
 	id := uuid.New().String()
 	note, err := domain.NewNote(id, projectID, userID, title, content, tags)
@@ -49,45 +59,176 @@ func (u *NoteUsecase) CreateNote(ctx context.Context, userID, projectID, title,
 	return note, nil
 }
 
-// GetNote returns a note by ID.
-func (u *NoteUsecase) GetNote(ctx context.Context, id string) (*domain.Note, error) {
-	return u.noteRepo.FindByID(ctx, id)
+// GetNote returns a note by ID, with its linked study logs, if the user wrote it or can read its project.
+func (u *NoteUsecase) GetNote(ctx context.Context, userID, id string) (*domain.Note, error) {
+	note, err := u.findReadableNote(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachLinkedStudyLogs(ctx, u.noteLinkRepo, note); err != nil {
+		return nil, err
+	}
+	return note, nil
 }
 
-// ListNotes returns all notes for a project.
-func (u *NoteUsecase) ListNotes(ctx context.Context, userID, projectID string) ([]*domain.Note, error) {
+// noteSortKeys are the keys notes can be sorted by; they are sorted most recently updated first by default.
+var noteSortKeys = []string{domain.SortByUpdatedAt, domain.SortByCreatedAt}
+
+// ListNotes returns a page of the notes of a project the user can read, written by any of its
+// members, and the cursor of the next page.
+func (u *NoteUsecase) ListNotes(ctx context.Context, userID, projectID string, opts PageOptions) ([]*domain.Note, string, error) {
+	page, err := pageRequest(opts, noteSortKeys, domain.SortOrder{Key: domain.SortByUpdatedAt, Descending: true})
+	if err != nil {
+		return nil, "", err
+	}
 	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, "", err
+	}
+	if _, _, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID); err != nil {
+		return nil, "", err
+	}
+	notes, err := u.noteRepo.FindByProjectID(ctx, projectID, page)
+	if err != nil {
+		return nil, "", err
+	}
+	notes, next := nextPage(notes, page, func(n *domain.Note) domain.Cursor {
+		if page.Sort.Key == domain.SortByCreatedAt {
+			return domain.Cursor{Time: n.CreatedAt, ID: n.ID}
+		}
+		return domain.Cursor{Time: n.UpdatedAt, ID: n.ID}
+	})
+	if err := attachLinkedStudyLogs(ctx, u.noteLinkRepo, notes...); err != nil {
+		return nil, "", err
+	}
+	return notes, next, nil
+}
+
+// UpdateNote updates an existing note owned by the user.
+func (u *NoteUsecase) UpdateNote(ctx context.Context, userID, id, title, content string, tags []string) (*domain.Note, error) {
+	note, err := u.findOwnedNote(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := note.Update(title, content, tags); err != nil {
+		return nil, err
+	}
+	if err := u.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+	if err := attachLinkedStudyLogs(ctx, u.noteLinkRepo, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// DeleteNote moves a note owned by the user to the trash.
+func (u *NoteUsecase) DeleteNote(ctx context.Context, userID, id string) error {
+	if _, err := u.findOwnedNote(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.noteRepo.Trash(ctx, id, time.Now())
+}
+
+// RestoreNote takes a trashed note owned by the user out of the trash. A note in a trashed
+// project comes back with the project instead.
+func (u *NoteUsecase) RestoreNote(ctx context.Context, userID, id string) (*domain.Note, error) {
+	note, err := u.noteRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.UserID != userID {
+		return nil, domain.ErrNotFound("note")
+	}
+	if err := checkProjectNotTrashed(ctx, u.projectRepo, note.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := u.noteRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	note.DeletedAt = nil
+	if err := attachLinkedStudyLogs(ctx, u.noteLinkRepo, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// LinkStudyLog links a note to a study log it was written in and returns the note with its linked
+// study logs. Both must belong to the user; linking them again does nothing.
+func (u *NoteUsecase) LinkStudyLog(ctx context.Context, userID, noteID, studyLogID string) (*domain.Note, error) {
+	note, err := u.findOwnedNote(ctx, userID, noteID)
+	if err != nil {
 		return nil, err
 	}
-	project, err := u.projectRepo.FindByID(ctx, projectID)
+	log, err := u.studyLogRepo.FindByID(ctx, studyLogID)
 	if err != nil {
 		return nil, err
 	}
-	if project.UserID != userID {
-		return nil, domain.ErrNotFound("project")
+	if log.UserID != userID {
+		return nil, domain.ErrNotFound("study log")
+	}
+	if err := u.noteLinkRepo.Link(ctx, noteID, studyLogID); err != nil {
+		return nil, err
+	}
+	if err := attachLinkedStudyLogs(ctx, u.noteLinkRepo, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// UnlinkStudyLog removes the link between a note that belongs to the user and a study log.
+func (u *NoteUsecase) UnlinkStudyLog(ctx context.Context, userID, noteID, studyLogID string) error {
+	if _, err := u.findOwnedNote(ctx, userID, noteID); err != nil {
+		return err
 	}
-	return u.noteRepo.FindByProjectID(ctx, projectID)
+	return u.noteLinkRepo.Unlink(ctx, noteID, studyLogID)
 }
 
-// UpdateNote updates an existing note.
-func (u *NoteUsecase) UpdateNote(ctx context.Context, id, title, content string, tags []string) (*domain.Note, error) {
+// findOwnedNote reports notes of other users as not found so their existence is not leaked.
+func (u *NoteUsecase) findOwnedNote(ctx context.Context, userID, id string) (*domain.Note, error) {
 	note, err := u.noteRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if err := note.Update(title, content, tags); err != nil {
+	if note.UserID != userID {
+		return nil, domain.ErrNotFound("note")
+	}
+	return note, nil
+}
+
+// findReadableNote returns a note the user wrote, or one in a project the user can read. Other notes
+// are reported as not found.
+func (u *NoteUsecase) findReadableNote(ctx context.Context, userID, id string) (*domain.Note, error) {
+	note, err := u.noteRepo.FindByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
-	if err := u.noteRepo.Update(ctx, note); err != nil {
+	if note.UserID == userID {
+		return note, nil
+	}
+	if _, _, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, note.ProjectID); err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrNotFound("note")
+		}
 		return nil, err
 	}
 	return note, nil
 }
 
-// DeleteNote deletes a note by ID.
-func (u *NoteUsecase) DeleteNote(ctx context.Context, id string) error {
-	if _, err := u.noteRepo.FindByID(ctx, id); err != nil {
+// attachLinkedStudyLogs fills in the study logs linked to each of the notes.
+func attachLinkedStudyLogs(ctx context.Context, noteLinkRepo port.NoteLinkRepository, notes ...*domain.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+	ids := make([]string, len(notes))
+	for i, n := range notes {
+		ids[i] = n.ID
+	}
+	links, err := noteLinkRepo.FindStudyLogsByNoteIDs(ctx, ids)
+	if err != nil {
 		return err
 	}
-	return u.noteRepo.Delete(ctx, id)
+	for _, n := range notes {
+		n.StudyLogs = links[n.ID]
+	}
+	return nil
 }
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
@@ -12,7 +13,8 @@ func setupNoteTest() (*usecase.NoteUsecase, *mockUserRepository, *mockProjectRep
 	userRepo := newMockUserRepository()
 	projectRepo := newMockProjectRepository()
 	noteRepo := newMockNoteRepository()
-	uc := usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo)
+	studyLogRepo := newMockStudyLogRepository()
+	uc := usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo, studyLogRepo, newMockNoteLinkRepository(noteRepo, studyLogRepo), newMockProjectMemberRepository())
 	return uc, userRepo, projectRepo, noteRepo
 }
 
@@ -114,7 +116,7 @@ func TestGetNote_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	note, err := uc.GetNote(context.Background(), created.ID)
+	note, err := uc.GetNote(context.Background(), "user-1", created.ID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -126,7 +128,7 @@ func TestGetNote_Success(t *testing.T) {
 func TestGetNote_NotFound(t *testing.T) {
 	uc, _, _, _ := setupNoteTest()
 
-	_, err := uc.GetNote(context.Background(), "nonexistent")
+	_, err := uc.GetNote(context.Background(), "user-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent note")
 	}
@@ -149,7 +151,7 @@ func TestListNotes_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	notes, err := uc.ListNotes(context.Background(), "user-1", "proj-1")
+	notes, _, err := uc.ListNotes(context.Background(), "user-1", "proj-1", usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -161,7 +163,7 @@ func TestListNotes_Success(t *testing.T) {
 func TestListNotes_UserNotFound(t *testing.T) {
 	uc, _, _, _ := setupNoteTest()
 
-	_, err := uc.ListNotes(context.Background(), "nonexistent", "proj-1")
+	_, _, err := uc.ListNotes(context.Background(), "nonexistent", "proj-1", usecase.PageOptions{})
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -176,7 +178,7 @@ func TestListNotes_ProjectNotOwnedByUser(t *testing.T) {
 	createTestUser(userRepo, "user-2", "Bob")
 	createTestProject(projectRepo, "proj-1", "user-2", "Math")
 
-	_, err := uc.ListNotes(context.Background(), "user-1", "proj-1")
+	_, _, err := uc.ListNotes(context.Background(), "user-1", "proj-1", usecase.PageOptions{})
 	if err == nil {
 		t.Fatal("expected error for project not owned by user")
 	}
@@ -195,7 +197,7 @@ func TestUpdateNote_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	updated, err := uc.UpdateNote(context.Background(), created.ID, "New Title", "new content", []string{"new", "updated"})
+	updated, err := uc.UpdateNote(context.Background(), "user-1", created.ID, "New Title", "new content", []string{"new", "updated"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -213,7 +215,7 @@ func TestUpdateNote_Success(t *testing.T) {
 func TestUpdateNote_NotFound(t *testing.T) {
 	uc, _, _, _ := setupNoteTest()
 
-	_, err := uc.UpdateNote(context.Background(), "nonexistent", "Title", "content", nil)
+	_, err := uc.UpdateNote(context.Background(), "user-1", "nonexistent", "Title", "content", nil)
 	if err == nil {
 		t.Fatal("expected error for nonexistent note")
 	}
@@ -232,7 +234,7 @@ func TestUpdateNote_EmptyTitle(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	_, err = uc.UpdateNote(context.Background(), created.ID, "", "content", nil)
+	_, err = uc.UpdateNote(context.Background(), "user-1", created.ID, "", "content", nil)
 	if err == nil {
 		t.Fatal("expected error for empty title")
 	}
@@ -251,7 +253,7 @@ func TestDeleteNote_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	err = uc.DeleteNote(context.Background(), created.ID)
+	err = uc.DeleteNote(context.Background(), "user-1", created.ID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -259,12 +261,51 @@ func TestDeleteNote_Success(t *testing.T) {
 	if _, ok := noteRepo.notes[created.ID]; ok {
 		t.Error("expected note to be deleted from repository")
 	}
+	if _, ok := noteRepo.trashed[created.ID]; !ok {
+		t.Error("expected note to be in the trash")
+	}
+}
+
+func TestRestoreNote_Success(t *testing.T) {
+	uc, userRepo, projectRepo, noteRepo := setupNoteTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+	created, _ := uc.CreateNote(context.Background(), "user-1", "proj-1", "Title", "content", nil)
+	_ = uc.DeleteNote(context.Background(), "user-1", created.ID)
+
+	restored, err := uc.RestoreNote(context.Background(), "user-1", created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("expected restored note not to have a deletion time")
+	}
+	if _, ok := noteRepo.notes[created.ID]; !ok {
+		t.Error("expected note to be back in repository")
+	}
+}
+
+func TestRestoreNote_ProjectInTrash(t *testing.T) {
+	uc, userRepo, projectRepo, noteRepo := setupNoteTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+	created, _ := uc.CreateNote(context.Background(), "user-1", "proj-1", "Title", "content", nil)
+	_ = uc.DeleteNote(context.Background(), "user-1", created.ID)
+	_ = projectRepo.Trash(context.Background(), "proj-1", time.Now())
+
+	_, err := uc.RestoreNote(context.Background(), "user-1", created.ID)
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if _, ok := noteRepo.trashed[created.ID]; !ok {
+		t.Error("expected note to stay in the trash")
+	}
 }
 
 func TestDeleteNote_NotFound(t *testing.T) {
 	uc, _, _, _ := setupNoteTest()
 
-	err := uc.DeleteNote(context.Background(), "nonexistent")
+	err := uc.DeleteNote(context.Background(), "user-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent note")
 	}
@@ -272,3 +313,135 @@ func TestDeleteNote_NotFound(t *testing.T) {
 		t.Errorf("expected not found error, got: %v", err)
 	}
 }
+
+func TestGetNote_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, projectRepo, _ := setupNoteTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+
+	created, err := uc.CreateNote(context.Background(), "user-1", "proj-1", "Private", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = uc.GetNote(context.Background(), "user-2", created.ID)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's note, got: %v", err)
+	}
+}
+
+func TestDeleteNote_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, projectRepo, noteRepo := setupNoteTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+
+	created, err := uc.CreateNote(context.Background(), "user-1", "proj-1", "Private", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = uc.DeleteNote(context.Background(), "user-2", created.ID)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's note, got: %v", err)
+	}
+	if _, ok := noteRepo.notes[created.ID]; !ok {
+		t.Error("expected note to remain in repository")
+	}
+}
+
+func setupNoteLinkTest() (*usecase.NoteUsecase, *usecase.StudyLogUsecase, *mockNoteLinkRepository) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestUser(userRepo, "user-2", "Bob")
+	projectRepo := newMockProjectRepository()
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+	noteRepo := newMockNoteRepository()
+	noteRepo.notes["note-1"] = &domain.Note{ID: "note-1", ProjectID: "proj-1", UserID: "user-1", Title: "Limits"}
+	noteRepo.notes["note-2"] = &domain.Note{ID: "note-2", ProjectID: "proj-1", UserID: "user-1", Title: "Derivatives"}
+	studyLogRepo := newMockStudyLogRepository()
+	studyLogRepo.logs = append(studyLogRepo.logs,
+		&domain.StudyLog{ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), Minutes: 60},
+		&domain.StudyLog{ID: "log-2", UserID: "user-1", ProjectID: "proj-1", StudiedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Minutes: 30},
+		&domain.StudyLog{ID: "log-3", UserID: "user-2", ProjectID: "proj-2", StudiedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Minutes: 30},
+	)
+	noteLinkRepo := newMockNoteLinkRepository(noteRepo, studyLogRepo)
+	noteUC := usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo, studyLogRepo, noteLinkRepo, newMockProjectMemberRepository())
+	studyLogUC := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, newMockActivityTypeRepository(), noteLinkRepo, newMockProjectMemberRepository())
+	return noteUC, studyLogUC, noteLinkRepo
+}
+
+func TestLinkStudyLog_Success(t *testing.T) {
+	noteUC, studyLogUC, _ := setupNoteLinkTest()
+	ctx := context.Background()
+
+	if _, err := noteUC.LinkStudyLog(ctx, "user-1", "note-1", "log-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Linking the same pair again does nothing.
+	if _, err := noteUC.LinkStudyLog(ctx, "user-1", "note-1", "log-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	note, err := noteUC.LinkStudyLog(ctx, "user-1", "note-1", "log-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(note.StudyLogs) != 2 || note.StudyLogs[0].ID != "log-2" || note.StudyLogs[1].ID != "log-1" {
+		t.Errorf("expected the linked study logs ordered by start time, got %+v", note.StudyLogs)
+	}
+	if _, err := noteUC.LinkStudyLog(ctx, "user-1", "note-2", "log-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs, _, err := studyLogUC.ListStudyLogs(ctx, "user-1", usecase.StudyLogListFilter{}, usecase.PageOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, l := range logs {
+		if l.ID != "log-1" {
+			continue
+		}
+		if len(l.Notes) != 2 || l.Notes[0].Title != "Derivatives" || l.Notes[1].Title != "Limits" {
+			t.Errorf("expected the linked notes ordered by title, got %+v", l.Notes)
+		}
+	}
+}
+
+func TestLinkStudyLog_OtherUsersStudyLog(t *testing.T) {
+	noteUC, _, noteLinkRepo := setupNoteLinkTest()
+
+	_, err := noteUC.LinkStudyLog(context.Background(), "user-1", "note-1", "log-3")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	_, err = noteUC.LinkStudyLog(context.Background(), "user-2", "note-1", "log-3")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's note, got: %v", err)
+	}
+	if len(noteLinkRepo.links) != 0 {
+		t.Errorf("expected no links, got %v", noteLinkRepo.links)
+	}
+}
+
+func TestUnlinkStudyLog(t *testing.T) {
+	noteUC, _, _ := setupNoteLinkTest()
+	ctx := context.Background()
+	if _, err := noteUC.LinkStudyLog(ctx, "user-1", "note-1", "log-1"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	if err := noteUC.UnlinkStudyLog(ctx, "user-1", "note-1", "log-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	note, err := noteUC.GetNote(ctx, "user-1", "note-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(note.StudyLogs) != 0 {
+		t.Errorf("expected no linked study logs, got %+v", note.StudyLogs)
+	}
+
+	err = noteUC.UnlinkStudyLog(ctx, "user-1", "note-1", "log-1")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a missing link, got: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// PageOptions holds the pagination parameters of a list request.
+type PageOptions struct {
+	// Limit is the maximum number of items to return; zero returns every item.
+	Limit int
+	// Cursor is the opaque cursor returned with the previous page; empty starts from the first item.
+	Cursor string
+	// Sort is a sort key, prefixed with "-" for descending order; empty selects the list's default order.
+	Sort string
+}
+
+// pageRequest validates the options against the sort keys a list supports. The request asks
+// for one item more than the limit, so that nextPage can tell whether another page follows.
+func pageRequest(opts PageOptions, keys []string, fallback domain.SortOrder) (port.PageRequest, error) {
+	if opts.Limit < 0 || opts.Limit > domain.MaxPageLimit {
+		return port.PageRequest{}, domain.ErrValidation(fmt.Sprintf("limit must be between 1 and %d", domain.MaxPageLimit))
+	}
+	sort, err := domain.ParseSortOrder(opts.Sort, keys, fallback)
+	if err != nil {
+		return port.PageRequest{}, err
+	}
+	page := port.PageRequest{Sort: sort}
+	if opts.Limit > 0 {
+		page.Limit = opts.Limit + 1
+	}
+	if opts.Cursor != "" {
+		cursor, err := domain.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return port.PageRequest{}, err
+		}
+		if cursor.Sort != sort.String() {
+			return port.PageRequest{}, domain.ErrValidation("cursor was issued for a different sort order")
+		}
+		page.After = cursor
+	}
+	return page, nil
+}
+
+// nextPage drops the extra item fetched for a page request and returns the cursor of the
+// next page, or an empty string when there is none.
+func nextPage[T any](items []T, page port.PageRequest, cursorOf func(T) domain.Cursor) ([]T, string) {
+	if page.Limit == 0 || len(items) < page.Limit {
+		return items, ""
+	}
+	items = items[:page.Limit-1]
+	cursor := cursorOf(items[len(items)-1])
+	cursor.Sort = page.Sort.String()
+	return items, cursor.Encode()
+}
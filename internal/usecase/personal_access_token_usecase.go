@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// PersonalAccessTokenUsecase provides methods for managing personal access tokens.
+type PersonalAccessTokenUsecase struct {
+	tokenRepo port.PersonalAccessTokenRepository
+	userRepo  port.UserRepository
+	generator port.OpaqueTokenGenerator
+}
+
+// NewPersonalAccessTokenUsecase creates a new PersonalAccessTokenUsecase.
+func NewPersonalAccessTokenUsecase(tokenRepo port.PersonalAccessTokenRepository, userRepo port.UserRepository, generator port.OpaqueTokenGenerator) *PersonalAccessTokenUsecase {
+	return &PersonalAccessTokenUsecase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		generator: generator,
+	}
+}
+
+// CreateToken issues a new personal access token and returns it together with its plaintext value.
+// The plaintext is not stored and cannot be retrieved again.
+func (u *PersonalAccessTokenUsecase) CreateToken(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := u.generator.Generate()
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext := domain.PersonalAccessTokenPrefix + secret
+
+	id := uuid.New().String()
+	token, err := domain.NewPersonalAccessToken(id, userID, name, u.generator.Hash(plaintext), scopes, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := u.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, plaintext, nil
+}
+
+// ListTokens returns all personal access tokens of a user.
+func (u *PersonalAccessTokenUsecase) ListTokens(ctx context.Context, userID string) ([]*domain.PersonalAccessToken, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.tokenRepo.FindByUserID(ctx, userID)
+}
+
+// RevokeToken deletes a personal access token by ID if it belongs to the user.
+func (u *PersonalAccessTokenUsecase) RevokeToken(ctx context.Context, userID, id string) error {
+	token, err := u.tokenRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return domain.ErrNotFound("personal access token")
+	}
+	return u.tokenRepo.Delete(ctx, id)
+}
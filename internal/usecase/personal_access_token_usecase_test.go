@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupPersonalAccessTokenTest() (*usecase.PersonalAccessTokenUsecase, *mockPersonalAccessTokenRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice"}
+	userRepo.users["user-2"] = &domain.User{ID: "user-2", Name: "Bob"}
+	tokenRepo := newMockPersonalAccessTokenRepository()
+	uc := usecase.NewPersonalAccessTokenUsecase(tokenRepo, userRepo, &mockOpaqueTokenGenerator{})
+	return uc, tokenRepo
+}
+
+func TestCreateToken_Success(t *testing.T) {
+	uc, tokenRepo := setupPersonalAccessTokenTest()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	token, plaintext, err := uc.CreateToken(context.Background(), "user-1", "cli", []string{domain.ScopeStudyLogsWrite}, &expiresAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(plaintext, domain.PersonalAccessTokenPrefix) {
+		t.Errorf("expected plaintext to start with %q, got %q", domain.PersonalAccessTokenPrefix, plaintext)
+	}
+	if token.TokenHash != "hash:"+plaintext {
+		t.Errorf("expected hash of plaintext to be stored, got %q", token.TokenHash)
+	}
+	if _, ok := tokenRepo.tokens[token.ID]; !ok {
+		t.Error("expected token to be saved")
+	}
+}
+
+func TestCreateToken_UnknownScope(t *testing.T) {
+	uc, _ := setupPersonalAccessTokenTest()
+
+	_, _, err := uc.CreateToken(context.Background(), "user-1", "cli", []string{"admin"}, nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	uc, _ := setupPersonalAccessTokenTest()
+	ctx := context.Background()
+
+	_, _, _ = uc.CreateToken(ctx, "user-1", "cli", []string{domain.ScopeStatsRead}, nil)
+	_, _, _ = uc.CreateToken(ctx, "user-2", "other", []string{domain.ScopeStatsRead}, nil)
+
+	tokens, err := uc.ListTokens(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("expected 1 token, got %d", len(tokens))
+	}
+}
+
+func TestRevokeToken_Success(t *testing.T) {
+	uc, tokenRepo := setupPersonalAccessTokenTest()
+	ctx := context.Background()
+
+	token, _, _ := uc.CreateToken(ctx, "user-1", "cli", []string{domain.ScopeStatsRead}, nil)
+
+	if err := uc.RevokeToken(ctx, "user-1", token.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := tokenRepo.tokens[token.ID]; ok {
+		t.Error("expected token to be deleted")
+	}
+}
+
+func TestRevokeToken_OtherUser(t *testing.T) {
+	uc, tokenRepo := setupPersonalAccessTokenTest()
+	ctx := context.Background()
+
+	token, _, _ := uc.CreateToken(ctx, "user-1", "cli", []string{domain.ScopeStatsRead}, nil)
+
+	err := uc.RevokeToken(ctx, "user-2", token.ID)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if _, ok := tokenRepo.tokens[token.ID]; !ok {
+		t.Error("expected token to remain")
+	}
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// PomodoroUsecase provides methods for running pomodoro sessions. Each completed work
+// phase is recorded as a study log counting one pomodoro.
+type PomodoroUsecase struct {
+	pomodoroRepo port.PomodoroRepository
+	userRepo     port.UserRepository
+	projectRepo  port.ProjectRepository
+	studyLogRepo port.StudyLogRepository
+	memberRepo   port.ProjectMemberRepository
+}
+
+// NewPomodoroUsecase creates a new PomodoroUsecase.
+func NewPomodoroUsecase(
+	pomodoroRepo port.PomodoroRepository,
+	userRepo port.UserRepository,
+	projectRepo port.ProjectRepository,
+	studyLogRepo port.StudyLogRepository,
+	memberRepo port.ProjectMemberRepository,
+) *PomodoroUsecase {
+	return &PomodoroUsecase{
+		pomodoroRepo: pomodoroRepo,
+		userRepo:     userRepo,
+		projectRepo:  projectRepo,
+		studyLogRepo: studyLogRepo,
+		memberRepo:   memberRepo,
+	}
+}
+
+// StartPomodoro starts a pomodoro session for a project the user owns or has joined as an editor. A user can have only one session at a time.
+func (u *PomodoroUsecase) StartPomodoro(ctx context.Context, userID, projectID, note string, settings domain.PomodoroSettings) (*domain.PomodoroSession, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.CheckRecordable(); err != nil {
+		return nil, err
+	}
+	current, err := u.GetPomodoro(ctx, userID)
+	if err != nil && !domain.IsNotFound(err) {
+		return nil, err
+	}
+	if current != nil && !current.IsFinished() {
+		return nil, domain.ErrConflict("a pomodoro session is already active; stop it first")
+	}
+
+	session, err := domain.NewPomodoroSession(userID, projectID, note, settings, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.pomodoroRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetPomodoro returns the user's pomodoro session as of now, recording any work phases
+// that have ended since it was last read. The returned session is finished if its last
+// work phase has ended; it is then no longer stored.
+func (u *PomodoroUsecase) GetPomodoro(ctx context.Context, userID string) (*domain.PomodoroSession, error) {
+	session, err := u.pomodoroRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.save(ctx, session, time.Now(), nil); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// SkipBreak ends the current break of the user's pomodoro session and starts the next work phase.
+func (u *PomodoroUsecase) SkipBreak(ctx context.Context, userID string) (*domain.PomodoroSession, error) {
+	session, err := u.pomodoroRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err = u.save(ctx, session, now, func(s *domain.PomodoroSession) (*domain.PomodoroInterval, error) {
+		return nil, s.SkipBreak(now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// StopPomodoro finishes the user's pomodoro session. A work phase in progress is recorded
+// as a study log without counting as a pomodoro.
+func (u *PomodoroUsecase) StopPomodoro(ctx context.Context, userID string) (*domain.PomodoroSession, error) {
+	session, err := u.pomodoroRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err = u.save(ctx, session, now, func(s *domain.PomodoroSession) (*domain.PomodoroInterval, error) {
+		if s.IsFinished() {
+			return nil, nil
+		}
+		return s.Stop(now), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// AdvancePomodoros records the work phases that have ended in every session, so they are
+// logged even if their users do not check back. It returns how many sessions were advanced.
+func (u *PomodoroUsecase) AdvancePomodoros(ctx context.Context) (int, error) {
+	now := time.Now()
+	sessions, err := u.pomodoroRepo.FindDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	advanced := 0
+	var errs []error
+	for _, session := range sessions {
+		if err := u.save(ctx, session, now, nil); err != nil {
+			// Advanced concurrently by its user, or stopped because its project no longer takes study time.
+			if !domain.IsConflict(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		advanced++
+	}
+	return advanced, errors.Join(errs...)
+}
+
+// save advances the session to now, applies change if given, and stores the result together
+// with a study log for each work phase that ended. Nothing is stored if the session is unchanged.
+// Like a stopped timer, a work phase is only recorded for the time the user has free: its log is cut
+// short where another log of the user starts and at the daily limit, and left out if another log
+// covers its start.
+// If the user can no longer record study time in the project, because it was archived, trashed or
+// left, the session is stopped without recording its work and a conflict error is returned.
+func (u *PomodoroUsecase) save(ctx context.Context, session *domain.PomodoroSession, now time.Time, change func(*domain.PomodoroSession) (*domain.PomodoroInterval, error)) error {
+	previous := session.UpdatedAt
+	intervals := session.Advance(now)
+	if change != nil {
+		partial, err := change(session)
+		if err != nil {
+			return err
+		}
+		if partial != nil {
+			intervals = append(intervals, *partial)
+		}
+	}
+	if session.UpdatedAt.Equal(previous) {
+		return nil
+	}
+
+	logs := make([]*domain.StudyLog, 0, len(intervals))
+	for _, interval := range intervals {
+		log, err := domain.NewStudyLog(uuid.New().String(), session.UserID, session.ProjectID, interval.StartedAt, interval.Minutes, session.Note)
+		if err != nil {
+			return err
+		}
+		if interval.Completed {
+			log.Pomodoros = 1
+		}
+		logs = append(logs, log)
+	}
+	if len(logs) > 0 {
+		if err := u.checkRecordable(ctx, session); err != nil {
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) {
+				return err
+			}
+			session.Stop(now)
+			if saveErr := u.pomodoroRepo.Save(ctx, session, previous, nil); saveErr != nil {
+				return saveErr
+			}
+			return domain.ErrConflict("pomodoro session was stopped without recording it: " + domainErr.Error())
+		}
+		var err error
+		if logs, err = u.fitLogs(ctx, session.UserID, logs); err != nil {
+			return err
+		}
+	}
+	return u.pomodoroRepo.Save(ctx, session, previous, logs)
+}
+
+// fitLogs returns the logs shortened to the time the user has free, leaving out those without any.
+func (u *PomodoroUsecase) fitLogs(ctx context.Context, userID string, logs []*domain.StudyLog) ([]*domain.StudyLog, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := loadStudyLogSchedule(ctx, u.studyLogRepo, userID, logs, loc)
+	if err != nil {
+		return nil, err
+	}
+	fitted := make([]*domain.StudyLog, 0, len(logs))
+	for _, log := range logs {
+		minutes := schedule.fit(log, loc)
+		if minutes < 1 {
+			continue
+		}
+		log.Minutes = minutes
+		schedule.add(log)
+		fitted = append(fitted, log)
+	}
+	return fitted, nil
+}
+
+// checkRecordable returns an error if the session's user can no longer record study time in its project.
+func (u *PomodoroUsecase) checkRecordable(ctx context.Context, session *domain.PomodoroSession) error {
+	project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, session.UserID, session.ProjectID)
+	if err != nil {
+		return err
+	}
+	return project.CheckRecordable()
+}
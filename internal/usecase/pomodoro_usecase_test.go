@@ -0,0 +1,234 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupPomodoroTest() (*usecase.PomodoroUsecase, *mockPomodoroRepository, *mockStudyLogRepository, *mockProjectRepository) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	studyLogRepo := newMockStudyLogRepository()
+	pomodoroRepo := newMockPomodoroRepository(studyLogRepo)
+	return usecase.NewPomodoroUsecase(pomodoroRepo, userRepo, projectRepo, studyLogRepo, newMockProjectMemberRepository()), pomodoroRepo, studyLogRepo, projectRepo
+}
+
+// startedPomodoro stores a 25/5 minute, two-cycle session for user-1 that was started the given time ago.
+func startedPomodoro(pomodoroRepo *mockPomodoroRepository, ago time.Duration) *domain.PomodoroSession {
+	settings := domain.PomodoroSettings{WorkMinutes: 25, BreakMinutes: 5, Cycles: 2}
+	session, _ := domain.NewPomodoroSession("user-1", "proj-1", "drills", settings, time.Now().Add(-ago))
+	pomodoroRepo.sessions["user-1"] = session
+	return session
+}
+
+func TestStartPomodoro_Success(t *testing.T) {
+	uc, pomodoroRepo, _, _ := setupPomodoroTest()
+
+	session, err := uc.StartPomodoro(context.Background(), "user-1", "proj-1", "", domain.DefaultPomodoroSettings())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Phase != domain.PomodoroPhaseWork || session.Settings.WorkMinutes != 25 {
+		t.Errorf("unexpected session: %+v", session)
+	}
+	if _, ok := pomodoroRepo.sessions["user-1"]; !ok {
+		t.Error("expected the session to be saved")
+	}
+}
+
+func TestStartPomodoro_AlreadyActive(t *testing.T) {
+	uc, pomodoroRepo, _, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 10*time.Minute)
+
+	_, err := uc.StartPomodoro(context.Background(), "user-1", "proj-1", "", domain.DefaultPomodoroSettings())
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestStartPomodoro_InvalidSettings(t *testing.T) {
+	uc, _, _, _ := setupPomodoroTest()
+
+	_, err := uc.StartPomodoro(context.Background(), "user-1", "proj-1", "", domain.PomodoroSettings{WorkMinutes: 25, BreakMinutes: 5})
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestGetPomodoro_RecordsCompletedWork(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 27*time.Minute)
+
+	session, err := uc.GetPomodoro(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Phase != domain.PomodoroPhaseBreak || session.CompletedPomodoros != 1 {
+		t.Errorf("expected a break after 1 pomodoro, got %s after %d", session.Phase, session.CompletedPomodoros)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Fatalf("expected 1 study log, got %d", len(studyLogRepo.logs))
+	}
+	log := studyLogRepo.logs[0]
+	if log.Minutes != 25 || log.Pomodoros != 1 || log.Note != "drills" {
+		t.Errorf("unexpected study log: %+v", log)
+	}
+
+	// Reading again records nothing new.
+	if _, err := uc.GetPomodoro(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected the work phase to be recorded once, got %d logs", len(studyLogRepo.logs))
+	}
+}
+
+func TestGetPomodoro_Finished(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 2*time.Hour)
+
+	session, err := uc.GetPomodoro(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.IsFinished() || session.CompletedPomodoros != 2 {
+		t.Errorf("expected a finished session with 2 pomodoros, got %+v", session)
+	}
+	if len(studyLogRepo.logs) != 2 || len(pomodoroRepo.sessions) != 0 {
+		t.Errorf("expected 2 study logs and the session to be removed, got %d logs", len(studyLogRepo.logs))
+	}
+	if _, err := uc.GetPomodoro(context.Background(), "user-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestSkipBreak(t *testing.T) {
+	uc, pomodoroRepo, _, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 10*time.Minute)
+
+	if _, err := uc.SkipBreak(context.Background(), "user-1"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error during work, got: %v", err)
+	}
+
+	startedPomodoro(pomodoroRepo, 26*time.Minute)
+	session, err := uc.SkipBreak(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Phase != domain.PomodoroPhaseWork || session.CompletedPomodoros != 1 {
+		t.Errorf("expected the second work phase, got %s after %d", session.Phase, session.CompletedPomodoros)
+	}
+}
+
+func TestStopPomodoro_RecordsPartialWork(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 40*time.Minute)
+
+	session, err := uc.StopPomodoro(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.IsFinished() || session.CompletedPomodoros != 1 {
+		t.Errorf("expected a finished session with 1 pomodoro, got %+v", session)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Fatalf("expected 2 study logs, got %d", len(studyLogRepo.logs))
+	}
+	partial := studyLogRepo.logs[1]
+	if partial.Minutes != 10 || partial.Pomodoros != 0 {
+		t.Errorf("expected a 10 minute log without a pomodoro, got %+v", partial)
+	}
+	if len(pomodoroRepo.sessions) != 0 {
+		t.Error("expected the session to be removed")
+	}
+}
+
+func TestAdvancePomodoros(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	startedPomodoro(pomodoroRepo, 26*time.Minute)
+
+	advanced, err := uc.AdvancePomodoros(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if advanced != 1 || len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 session advanced and 1 log, got %d and %d", advanced, len(studyLogRepo.logs))
+	}
+	if pomodoroRepo.sessions["user-1"].Phase != domain.PomodoroPhaseBreak {
+		t.Error("expected the saved session to be on a break")
+	}
+}
+
+func TestGetPomodoro_ProjectNoLongerRecordable(t *testing.T) {
+	for name, leave := range map[string]func(*mockProjectRepository){
+		"archived": func(r *mockProjectRepository) {
+			now := time.Now()
+			r.projects["proj-1"].ArchivedAt = &now
+		},
+		"trashed": func(r *mockProjectRepository) {
+			r.trashed["proj-1"] = r.projects["proj-1"]
+			delete(r.projects, "proj-1")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			uc, pomodoroRepo, studyLogRepo, projectRepo := setupPomodoroTest()
+			startedPomodoro(pomodoroRepo, 26*time.Minute)
+			leave(projectRepo)
+
+			if _, err := uc.GetPomodoro(context.Background(), "user-1"); !domain.IsConflict(err) {
+				t.Errorf("expected conflict error, got: %v", err)
+			}
+			if len(studyLogRepo.logs) != 0 {
+				t.Errorf("expected no study log, got %d", len(studyLogRepo.logs))
+			}
+			if len(pomodoroRepo.sessions) != 0 {
+				t.Error("expected the session to be stopped")
+			}
+		})
+	}
+}
+
+func TestGetPomodoro_ShortenedBeforeNextStudyLog(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	session := startedPomodoro(pomodoroRepo, 27*time.Minute)
+	// Logged by hand during the first work phase.
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: session.StartedAt.Add(10 * time.Minute), Minutes: 5,
+	})
+
+	if _, err := uc.GetPomodoro(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Fatalf("expected 2 study logs, got %d", len(studyLogRepo.logs))
+	}
+	log := studyLogRepo.logs[1]
+	if log.Minutes != 10 || !log.StudiedAt.Equal(session.StartedAt) || log.Pomodoros != 1 {
+		t.Errorf("expected a 10 minute pomodoro log from the session's start, got %+v", log)
+	}
+}
+
+func TestGetPomodoro_StartCovered(t *testing.T) {
+	uc, pomodoroRepo, studyLogRepo, _ := setupPomodoroTest()
+	session := startedPomodoro(pomodoroRepo, 27*time.Minute)
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: session.StartedAt.Add(-10 * time.Minute), Minutes: 60,
+	})
+
+	got, err := uc.GetPomodoro(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Phase != domain.PomodoroPhaseBreak || got.CompletedPomodoros != 1 {
+		t.Errorf("expected a break after 1 pomodoro, got %s after %d", got.Phase, got.CompletedPomodoros)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected the work phase not to be recorded, got %d logs", len(studyLogRepo.logs))
+	}
+}
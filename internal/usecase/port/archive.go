@@ -0,0 +1,9 @@
+package port
+
+import "io"
+
+// ArchiveWriter defines the interface for writing files into an archive, one at a time.
+// Writing to a file must be finished before the next one is created. *zip.Writer satisfies it.
+type ArchiveWriter interface {
+	Create(name string) (io.Writer, error)
+}
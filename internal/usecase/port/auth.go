@@ -0,0 +1,52 @@
+package port
+
+import "time"
+
+// PasswordHasher defines the interface for hashing and verifying passwords.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare returns nil when password matches hash.
+	Compare(hash, password string) error
+}
+
+// TokenClaims holds the verified contents of an access token.
+type TokenClaims struct {
+	UserID    string
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// TokenManager defines the interface for issuing and verifying signed access tokens.
+type TokenManager interface {
+	Issue(userID, sessionID string) (token string, expiresAt time.Time, err error)
+	Verify(token string) (*TokenClaims, error)
+	// IssueMFAChallenge issues a short-lived token proving the password step of a login succeeded,
+	// for the challenge with the given ID. It is not accepted by Verify.
+	IssueMFAChallenge(userID, challengeID string) (token string, expiresAt time.Time, err error)
+	VerifyMFAChallenge(token string) (userID, challengeID string, err error)
+	// IssueDeletionConfirmation issues a short-lived token with which the user confirms deleting the
+	// project or user with the given ID, as long as what the deletion removes still has the given
+	// digest. It is not accepted by Verify.
+	IssueDeletionConfirmation(userID, targetID, digest string) (token string, expiresAt time.Time, err error)
+	// VerifyDeletionConfirmation returns the target and digest of a confirmation token issued to the user.
+	VerifyDeletionConfirmation(token, userID string) (targetID, digest string, err error)
+}
+
+// OpaqueTokenGenerator defines the interface for generating random opaque tokens
+// and deriving the hash under which they are stored.
+type OpaqueTokenGenerator interface {
+	Generate() (string, error)
+	Hash(token string) string
+}
+
+// TOTPProvider defines the interface for time-based one-time passwords (RFC 6238)
+// and the recovery codes that back them up.
+type TOTPProvider interface {
+	GenerateSecret() (string, error)
+	// ProvisioningURI returns the otpauth:// URI that authenticator apps import, usually as a QR code.
+	ProvisioningURI(secret, accountName string) string
+	// Validate reports whether code is valid for secret around the given time and returns
+	// the time step it belongs to.
+	Validate(secret, code string, at time.Time) (step int64, ok bool)
+	GenerateRecoveryCodes(n int) ([]string, error)
+}
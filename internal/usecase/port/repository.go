@@ -11,43 +11,235 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	FindByID(ctx context.Context, id string) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	// Delete removes the user and everything they own in a single transaction.
+	Delete(ctx context.Context, id string) (*domain.UserDeletionSummary, error)
+	// DeletionImpact counts what Delete would remove together with the user.
+	DeletionImpact(ctx context.Context, id string) (*domain.DeletionImpact, error)
+}
+
+// PageRequest selects one page of a list in keyset order: the items that sort after the cursor.
+type PageRequest struct {
+	// Limit is the maximum number of items to return; zero returns every item.
+	Limit int
+	// Sort is the order of the list; the zero value selects the list's default order.
+	Sort domain.SortOrder
+	// After is the cursor of the last item of the previous page; nil starts from the first item.
+	After *domain.Cursor
+}
+
+// ProjectFilter defines filters for project queries.
+type ProjectFilter struct {
+	// Archived selects only archived projects when true and only unarchived ones when false; nil selects both.
+	Archived *bool
+	// Status selects only the projects with the given lifecycle status when set.
+	Status domain.ProjectStatus
 }
 
 // ProjectRepository defines the interface for project persistence.
 type ProjectRepository interface {
 	Create(ctx context.Context, project *domain.Project) error
 	FindByID(ctx context.Context, id string) (*domain.Project, error)
-	FindByUserID(ctx context.Context, userID string) ([]*domain.Project, error)
+	// FindByUserID orders projects by name or creation time, by name by default.
+	FindByUserID(ctx context.Context, userID string, filter ProjectFilter, page PageRequest) ([]*domain.Project, error)
 	Update(ctx context.Context, project *domain.Project) error
+	// CreateCopy creates a copied project together with its notes, goal and milestones in a single
+	// transaction. It returns a conflict error if the user already has a project with the same name.
+	CreateCopy(ctx context.Context, c *domain.ProjectCopy) error
+	// Merge moves the study logs, notes, milestones and running timers of the source project into the
+	// target project, replaces the goals of both with goal, if it is not nil, and deletes the source
+	// project, in a single transaction. Study logs and notes of other members and in the trash are
+	// moved too. The returned summary holds the counts of moved rows.
+	Merge(ctx context.Context, sourceID, targetID string, goal *domain.Goal) (*domain.ProjectMergeSummary, error)
+	// Trash moves the project to the trash together with its notes, study logs and goals, and
+	// deletes the timers and pomodoro sessions running in it, in a single transaction.
+	Trash(ctx context.Context, id string, at time.Time) error
+	// DeletionImpact counts what Trash would move to the trash together with the project.
+	DeletionImpact(ctx context.Context, id string) (*domain.DeletionImpact, error)
+	FindTrashedByID(ctx context.Context, id string) (*domain.Project, error)
+	// FindTrashedByUserID orders trashed projects by deletion time, most recent first.
+	FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.Project, error)
+	// Restore takes the project out of the trash together with the items that were trashed with it.
+	// It returns a conflict error if the user has since created another project with the same name.
+	Restore(ctx context.Context, id string) error
+	// PurgeTrashed permanently deletes the projects trashed before the given time, with everything in them.
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ProjectMemberRepository defines the interface for persisting the members invited to projects.
+type ProjectMemberRepository interface {
+	// Create returns a conflict error if the user is already a member of the project or invited to it.
+	Create(ctx context.Context, member *domain.ProjectMember) error
+	// Find returns a not found error if the user is not a member of the project and not invited to it.
+	Find(ctx context.Context, projectID, userID string) (*domain.ProjectMember, error)
+	// FindByProjectID orders members by invitation time, oldest first.
+	FindByProjectID(ctx context.Context, projectID string) ([]*domain.ProjectMember, error)
+	// FindByUserID returns the user's memberships and pending invitations, leaving out trashed projects,
+	// ordered by invitation time.
+	FindByUserID(ctx context.Context, userID string) ([]*domain.ProjectMember, error)
+	Update(ctx context.Context, member *domain.ProjectMember) error
+	Delete(ctx context.Context, projectID, userID string) error
+}
+
+// MilestoneRepository defines the interface for milestone persistence. A milestone is saved and
+// loaded together with its checklist items.
+type MilestoneRepository interface {
+	Create(ctx context.Context, milestone *domain.Milestone) error
+	FindByID(ctx context.Context, id string) (*domain.Milestone, error)
+	// FindByProjectIDs returns the milestones of the projects, each project's in position order.
+	FindByProjectIDs(ctx context.Context, projectIDs []string) ([]*domain.Milestone, error)
+	// Update saves the title, due date and completion of the milestone and replaces its checklist items.
+	Update(ctx context.Context, milestone *domain.Milestone) error
+	Delete(ctx context.Context, id string) error
+	// Reorder sets the position of each milestone to its index in ids.
+	Reorder(ctx context.Context, ids []string) error
+}
+
+// ActivityTypeRepository defines the interface for activity type persistence.
+type ActivityTypeRepository interface {
+	// Create returns a conflict error if the user already has an activity type with the same name.
+	Create(ctx context.Context, activityType *domain.ActivityType) error
+	FindByID(ctx context.Context, id string) (*domain.ActivityType, error)
+	// FindByUserID orders activity types by name.
+	FindByUserID(ctx context.Context, userID string) ([]*domain.ActivityType, error)
+	// Update returns a conflict error if the user already has an activity type with the new name.
+	Update(ctx context.Context, activityType *domain.ActivityType) error
+	// Delete removes the activity type from the study logs tagged with it.
 	Delete(ctx context.Context, id string) error
 }
 
 // StudyLogFilter defines filters for study log queries.
 type StudyLogFilter struct {
-	From      *time.Time
-	To        *time.Time
-	ProjectID *string
+	From           *time.Time
+	To             *time.Time
+	ProjectID      *string
+	ActivityTypeID *string
 }
 
 // StudyLogRepository defines the interface for study log persistence.
 type StudyLogRepository interface {
 	Create(ctx context.Context, log *domain.StudyLog) error
+	// CreateBatch creates every log in one transaction; if any of them cannot be created, none are.
+	CreateBatch(ctx context.Context, logs []*domain.StudyLog) error
 	FindByID(ctx context.Context, id string) (*domain.StudyLog, error)
-	FindByUserID(ctx context.Context, userID string, filter StudyLogFilter) ([]*domain.StudyLog, error)
-	Delete(ctx context.Context, id string) error
+	// FindByUserID orders study logs by study time, newest first by default.
+	FindByUserID(ctx context.Context, userID string, filter StudyLogFilter, page PageRequest) ([]*domain.StudyLog, error)
+	// FindImportKeys returns which of the given import keys the user's study logs already have.
+	FindImportKeys(ctx context.Context, userID string, keys []string) ([]string, error)
+	// Update saves the edited log together with the revision holding its previous values.
+	Update(ctx context.Context, log *domain.StudyLog, revision *domain.StudyLogRevision) error
+	FindRevisionByID(ctx context.Context, id string) (*domain.StudyLogRevision, error)
+	// FindRevisions returns the revisions of a study log, newest first.
+	FindRevisions(ctx context.Context, studyLogID string) ([]*domain.StudyLogRevision, error)
+	Trash(ctx context.Context, id string, at time.Time) error
+	FindTrashedByID(ctx context.Context, id string) (*domain.StudyLog, error)
+	// FindTrashedByUserID returns the trashed logs whose project is not in the trash,
+	// most recently deleted first.
+	FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.StudyLog, error)
+	Restore(ctx context.Context, id string) error
+	// PurgeTrashed permanently deletes the logs trashed before the given time.
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
 }
 
 // GoalRepository defines the interface for goal persistence.
 type GoalRepository interface {
 	Upsert(ctx context.Context, goal *domain.Goal) error
-	FindByUserID(ctx context.Context, userID string) ([]*domain.Goal, error)
+	// FindByUserID orders goals by creation time, oldest first by default.
+	FindByUserID(ctx context.Context, userID string, page PageRequest) ([]*domain.Goal, error)
 }
 
 // NoteRepository defines the interface for note persistence.
 type NoteRepository interface {
 	Create(ctx context.Context, note *domain.Note) error
 	FindByID(ctx context.Context, id string) (*domain.Note, error)
-	FindByProjectID(ctx context.Context, projectID string) ([]*domain.Note, error)
+	// FindByProjectID orders notes by update or creation time, most recently updated first by default.
+	FindByProjectID(ctx context.Context, projectID string, page PageRequest) ([]*domain.Note, error)
 	Update(ctx context.Context, note *domain.Note) error
+	Trash(ctx context.Context, id string, at time.Time) error
+	FindTrashedByID(ctx context.Context, id string) (*domain.Note, error)
+	// FindTrashedByUserID returns the trashed notes whose project is not in the trash,
+	// most recently deleted first.
+	FindTrashedByUserID(ctx context.Context, userID string) ([]*domain.Note, error)
+	Restore(ctx context.Context, id string) error
+	// PurgeTrashed permanently deletes the notes trashed before the given time.
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NoteLinkRepository defines the interface for persisting the links between notes and the study logs
+// they were written in.
+type NoteLinkRepository interface {
+	// Link links a note to a study log; linking a pair that is already linked does nothing.
+	Link(ctx context.Context, noteID, studyLogID string) error
+	// Unlink returns a not found error if the note is not linked to the study log.
+	Unlink(ctx context.Context, noteID, studyLogID string) error
+	// FindStudyLogsByNoteIDs returns the study logs linked to each note, ordered by start time.
+	// Notes without links have no entry.
+	FindStudyLogsByNoteIDs(ctx context.Context, noteIDs []string) (map[string][]domain.LinkedStudyLog, error)
+	// FindNotesByStudyLogIDs returns the notes linked to each study log, ordered by title.
+	// Study logs without links have no entry.
+	FindNotesByStudyLogIDs(ctx context.Context, studyLogIDs []string) (map[string][]domain.LinkedNote, error)
+}
+
+// PersonalAccessTokenRepository defines the interface for personal access token persistence.
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *domain.PersonalAccessToken) error
+	FindByID(ctx context.Context, id string) (*domain.PersonalAccessToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error)
+	FindByUserID(ctx context.Context, userID string) ([]*domain.PersonalAccessToken, error)
+	UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error
 	Delete(ctx context.Context, id string) error
 }
+
+// SessionRepository defines the interface for session persistence.
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	FindByID(ctx context.Context, id string) (*domain.Session, error)
+	FindByUserID(ctx context.Context, userID string) ([]*domain.Session, error)
+	// Rotate saves a rotated session and records previousHash as rotated out. It returns a conflict
+	// error if the stored refresh token hash no longer equals previousHash, i.e. the token was rotated
+	// concurrently.
+	Rotate(ctx context.Context, session *domain.Session, previousHash string) error
+	// IsRotatedToken reports whether a refresh token with the given hash was once issued for the
+	// session and has since been rotated out.
+	IsRotatedToken(ctx context.Context, sessionID, tokenHash string) (bool, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+// MFAChallengeRepository defines the interface for persisting the second-factor challenges of logins
+// in progress, so the codes tried against each one can be counted.
+type MFAChallengeRepository interface {
+	// Create starts a challenge for the user, replacing any challenge the user already has.
+	Create(ctx context.Context, id, userID string, expiresAt time.Time) error
+	// RecordAttempt counts a code tried against the challenge and returns how many have been tried.
+	// It returns a not found error if the challenge does not exist or has expired at now.
+	RecordAttempt(ctx context.Context, id string, now time.Time) (int, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// TimerRepository defines the interface for live study timer persistence.
+// A timer is identified by its user and start time, so a timer that was stopped and
+// started again in the meantime is not mistaken for the one that was read.
+type TimerRepository interface {
+	// Create returns a conflict error if the user already has a timer.
+	Create(ctx context.Context, timer *domain.Timer) error
+	FindByUserID(ctx context.Context, userID string) (*domain.Timer, error)
+	// FindExpired returns the running timers that have reached domain.MaxTimerDuration at the given time.
+	FindExpired(ctx context.Context, now time.Time) ([]*domain.Timer, error)
+	Update(ctx context.Context, timer *domain.Timer) error
+	Delete(ctx context.Context, timer *domain.Timer) error
+}
+
+// PomodoroRepository defines the interface for pomodoro session persistence.
+type PomodoroRepository interface {
+	// Create returns a conflict error if the user already has a pomodoro session.
+	Create(ctx context.Context, session *domain.PomodoroSession) error
+	FindByUserID(ctx context.Context, userID string) (*domain.PomodoroSession, error)
+	// FindDue returns the sessions whose current phase has ended at the given time.
+	FindDue(ctx context.Context, now time.Time) ([]*domain.PomodoroSession, error)
+	// Save stores the session's progress, or deletes it once finished, together with the study logs
+	// for the work phases it completed, in a single transaction. It returns a conflict error if the
+	// session has changed since it was read with previousUpdatedAt.
+	Save(ctx context.Context, session *domain.PomodoroSession, previousUpdatedAt time.Time, logs []*domain.StudyLog) error
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// maxCopyNameAttempts limits how many numbered names are tried for a clone before giving up.
+const maxCopyNameAttempts = 100
+
+// ProjectCloneUsecase provides methods for cloning projects as templates.
+type ProjectCloneUsecase struct {
+	projectRepo   port.ProjectRepository
+	memberRepo    port.ProjectMemberRepository
+	userRepo      port.UserRepository
+	noteRepo      port.NoteRepository
+	goalRepo      port.GoalRepository
+	milestoneRepo port.MilestoneRepository
+}
+
+// NewProjectCloneUsecase creates a new ProjectCloneUsecase.
+func NewProjectCloneUsecase(
+	projectRepo port.ProjectRepository,
+	memberRepo port.ProjectMemberRepository,
+	userRepo port.UserRepository,
+	noteRepo port.NoteRepository,
+	goalRepo port.GoalRepository,
+	milestoneRepo port.MilestoneRepository,
+) *ProjectCloneUsecase {
+	return &ProjectCloneUsecase{
+		projectRepo:   projectRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		noteRepo:      noteRepo,
+		goalRepo:      goalRepo,
+		milestoneRepo: milestoneRepo,
+	}
+}
+
+// ProjectCloneOptions chooses who a clone is for and what is copied into it besides the project.
+type ProjectCloneOptions struct {
+	// TargetUserID is the user to create the clone for; empty creates it for the caller.
+	TargetUserID string
+	// Name is the name of the clone; empty uses the name of the source project.
+	Name       string
+	Notes      bool
+	Goal       bool
+	Milestones bool
+}
+
+// CloneProject copies a project the user can read as a new top-level project, with the chosen
+// contents but never its study logs. Dates move along so that the goal starts today in the target
+// user's timezone, or, without a goal, so that the project starts today. If the target user already
+// has a project with the name, a number is added to it, as in "Math (2)".
+//
+// A project can be cloned for another user only by its owner, and only for one of its members.
+func (u *ProjectCloneUsecase) CloneProject(ctx context.Context, userID, projectID string, opts ProjectCloneOptions) (*domain.ProjectCopy, error) {
+	source, role, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID := opts.TargetUserID
+	if targetUserID == "" {
+		targetUserID = userID
+	}
+	if targetUserID != userID {
+		if err := u.checkCloneTarget(ctx, role, projectID, targetUserID); err != nil {
+			return nil, err
+		}
+	}
+	loc, err := userLocation(ctx, u.userRepo, targetUserID, "")
+	if err != nil {
+		return nil, err
+	}
+	name := opts.Name
+	if name == "" {
+		name = source.Name
+	}
+	project, err := domain.NewProject(uuid.New().String(), targetUserID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := u.findGoal(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	start := domain.DateOf(source.CreatedAt, loc)
+	if goal != nil {
+		start = domain.DateOf(goal.StartDate, time.UTC)
+	}
+	shift := start.DaysUntil(domain.DateOf(time.Now(), loc))
+
+	details := domain.ProjectDetails{
+		Description: source.Description,
+		Color:       source.Color,
+		Icon:        source.Icon,
+		TargetDate:  shiftDate(source.TargetDate, shift),
+	}
+	if err := project.UpdateDetails(details); err != nil {
+		return nil, err
+	}
+	clone := &domain.ProjectCopy{Project: project, Notes: []*domain.Note{}, Milestones: []*domain.Milestone{}}
+	if opts.Notes {
+		if clone.Notes, err = u.copyNotes(ctx, source.ID, project); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Goal && goal != nil {
+		startDate := goal.StartDate.AddDate(0, 0, shift)
+		var endDate *time.Time
+		if goal.EndDate != nil {
+			end := goal.EndDate.AddDate(0, 0, shift)
+			endDate = &end
+		}
+		if clone.Goal, err = domain.NewGoal(uuid.New().String(), targetUserID, project.ID, goal.TargetMinutesPerWeek, startDate, endDate); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Milestones {
+		if clone.Milestones, err = u.copyMilestones(ctx, source.ID, project, shift); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		project.Name = domain.CopyName(name, attempt)
+		err := u.projectRepo.CreateCopy(ctx, clone)
+		if err == nil {
+			return clone, nil
+		}
+		if !domain.IsConflict(err) || attempt == maxCopyNameAttempts {
+			return nil, err
+		}
+	}
+}
+
+// checkCloneTarget allows the owner of a project to clone it for the members who have joined it.
+func (u *ProjectCloneUsecase) checkCloneTarget(ctx context.Context, role domain.ProjectRole, projectID, targetUserID string) error {
+	if role != domain.ProjectRoleOwner {
+		return domain.ErrForbidden("only the owner can clone the project for another user")
+	}
+	member, err := u.memberRepo.Find(ctx, projectID, targetUserID)
+	if err != nil && !domain.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !member.IsAccepted() {
+		return domain.ErrForbidden("the project can only be cloned for its members")
+	}
+	return nil
+}
+
+// findGoal returns the owner's goal for the project, or nil if there is none.
+func (u *ProjectCloneUsecase) findGoal(ctx context.Context, project *domain.Project) (*domain.Goal, error) {
+	goals, err := u.goalRepo.FindByUserID(ctx, project.UserID, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range goals {
+		if g.ProjectID == project.ID {
+			return g, nil
+		}
+	}
+	return nil, nil
+}
+
+// copyNotes copies the titles, contents and tags of the source project's notes into the clone.
+func (u *ProjectCloneUsecase) copyNotes(ctx context.Context, sourceID string, project *domain.Project) ([]*domain.Note, error) {
+	notes, err := u.noteRepo.FindByProjectID(ctx, sourceID, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	copies := make([]*domain.Note, 0, len(notes))
+	for _, n := range notes {
+		note, err := domain.NewNote(uuid.New().String(), project.ID, project.UserID, n.Title, n.Content, n.Tags)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, note)
+	}
+	return copies, nil
+}
+
+// copyMilestones copies the source project's milestones in order, with their checklists unchecked
+// and their due dates moved by shift days.
+func (u *ProjectCloneUsecase) copyMilestones(ctx context.Context, sourceID string, project *domain.Project, shift int) ([]*domain.Milestone, error) {
+	milestones, err := u.milestoneRepo.FindByProjectIDs(ctx, []string{sourceID})
+	if err != nil {
+		return nil, err
+	}
+	copies := make([]*domain.Milestone, 0, len(milestones))
+	for _, m := range milestones {
+		milestone, err := domain.NewMilestone(uuid.New().String(), project.UserID, project.ID, m.Title, shiftDate(m.DueDate, shift), m.Position)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range m.Items {
+			if _, err := milestone.AddItem(uuid.New().String(), item.Title); err != nil {
+				return nil, err
+			}
+		}
+		copies = append(copies, milestone)
+	}
+	return copies, nil
+}
+
+// shiftDate moves an optional date by the given number of days.
+func shiftDate(d *domain.Date, days int) *domain.Date {
+	if d == nil {
+		return nil
+	}
+	shifted := d.AddDays(days)
+	return &shifted
+}
@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type projectCloneTest struct {
+	uc            *usecase.ProjectCloneUsecase
+	projectRepo   *mockProjectRepository
+	memberRepo    *mockProjectMemberRepository
+	noteRepo      *mockNoteRepository
+	goalRepo      *mockGoalRepository
+	milestoneRepo *mockMilestoneRepository
+}
+
+// setupProjectCloneTest creates Alice's project proj-1 and the users Bob and Carol.
+func setupProjectCloneTest() projectCloneTest {
+	userRepo := newMockUserRepository()
+	s := projectCloneTest{
+		projectRepo:   newMockProjectRepository(),
+		memberRepo:    newMockProjectMemberRepository(),
+		noteRepo:      newMockNoteRepository(),
+		goalRepo:      newMockGoalRepository(),
+		milestoneRepo: newMockMilestoneRepository(),
+	}
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestUser(userRepo, "user-2", "Bob")
+	createTestUser(userRepo, "user-3", "Carol")
+	createTestProject(s.projectRepo, "proj-1", "user-1", "Math")
+	s.uc = usecase.NewProjectCloneUsecase(s.projectRepo, s.memberRepo, userRepo, s.noteRepo, s.goalRepo, s.milestoneRepo)
+	return s
+}
+
+func today() domain.Date {
+	return domain.DateOf(time.Now(), time.UTC)
+}
+
+func TestCloneProject_CopiesContentsAndShiftsDates(t *testing.T) {
+	s := setupProjectCloneTest()
+	ctx := context.Background()
+	start := today().AddDays(-30)
+	end := start.AddDays(60).StartIn(time.UTC)
+	goal, _ := domain.NewGoal("goal-1", "user-1", "proj-1", 300, start.StartIn(time.UTC), &end)
+	_ = s.goalRepo.Upsert(ctx, goal)
+	note, _ := domain.NewNote("note-1", "proj-1", "user-1", "Formulas", "a^2 + b^2", []string{"algebra"})
+	_ = s.noteRepo.Create(ctx, note)
+	due := start.AddDays(14)
+	milestone, _ := domain.NewMilestone("ms-1", "user-1", "proj-1", "Chapter 1", &due, 0)
+	_, _ = milestone.AddItem("item-1", "Exercises")
+	milestone.Items[0].Done = true
+	milestone.CompletedAt = &end
+	_ = s.milestoneRepo.Create(ctx, milestone)
+
+	clone, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{Notes: true, Goal: true, Milestones: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Project.Name != "Math (2)" || clone.Project.UserID != "user-1" || clone.Project.ID == "proj-1" {
+		t.Errorf("unexpected project: %+v", clone.Project)
+	}
+	if len(clone.Notes) != 1 || clone.Notes[0].ProjectID != clone.Project.ID || clone.Notes[0].Tags[0] != "algebra" {
+		t.Errorf("unexpected notes: %+v", clone.Notes)
+	}
+	if clone.Goal == nil || domain.DateOf(clone.Goal.StartDate, time.UTC) != today() || domain.DateOf(*clone.Goal.EndDate, time.UTC) != today().AddDays(60) {
+		t.Errorf("expected the goal to start today, got: %+v", clone.Goal)
+	}
+	if len(clone.Milestones) != 1 {
+		t.Fatalf("expected 1 milestone, got %d", len(clone.Milestones))
+	}
+	copied := clone.Milestones[0]
+	if *copied.DueDate != today().AddDays(14) || copied.IsCompleted() || len(copied.Items) != 1 || copied.Items[0].Done {
+		t.Errorf("unexpected milestone: %+v", copied)
+	}
+	if _, ok := s.projectRepo.copies[clone.Project.ID]; !ok {
+		t.Error("expected the copy to be saved")
+	}
+	if len(s.goalRepo.goals) != 1 || s.goalRepo.goals[0].ProjectID != "proj-1" {
+		t.Error("expected the source goal to be left alone")
+	}
+}
+
+func TestCloneProject_OnlyProject(t *testing.T) {
+	s := setupProjectCloneTest()
+	ctx := context.Background()
+	note, _ := domain.NewNote("note-1", "proj-1", "user-1", "Formulas", "", nil)
+	_ = s.noteRepo.Create(ctx, note)
+
+	clone, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{Name: "Math 2025"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Project.Name != "Math 2025" || len(clone.Notes) != 0 || clone.Goal != nil || len(clone.Milestones) != 0 {
+		t.Errorf("unexpected clone: %+v", clone)
+	}
+}
+
+func TestCloneProject_NumbersTakenNames(t *testing.T) {
+	s := setupProjectCloneTest()
+	ctx := context.Background()
+	createTestProject(s.projectRepo, "proj-2", "user-1", "Math (2)")
+
+	clone, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Project.Name != "Math (3)" {
+		t.Errorf("expected 'Math (3)', got '%s'", clone.Project.Name)
+	}
+}
+
+func TestCloneProject_ForMember(t *testing.T) {
+	s := setupProjectCloneTest()
+	ctx := context.Background()
+	bob := &domain.ProjectMember{ProjectID: "proj-1", UserID: "user-2", Role: domain.ProjectRoleViewer}
+	_ = s.memberRepo.Create(ctx, bob)
+
+	if _, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{TargetUserID: "user-2"}); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a pending invitation, got: %v", err)
+	}
+	_ = bob.Accept(time.Now())
+	if _, err := s.uc.CloneProject(ctx, "user-2", "proj-1", usecase.ProjectCloneOptions{TargetUserID: "user-1"}); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a member cloning for another user, got: %v", err)
+	}
+	if _, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{TargetUserID: "user-3"}); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a user who is not a member, got: %v", err)
+	}
+
+	clone, err := s.uc.CloneProject(ctx, "user-1", "proj-1", usecase.ProjectCloneOptions{TargetUserID: "user-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Project.UserID != "user-2" || clone.Project.Name != "Math" {
+		t.Errorf("unexpected project: %+v", clone.Project)
+	}
+	if _, err := s.uc.CloneProject(ctx, "user-2", "proj-1", usecase.ProjectCloneOptions{}); err != nil {
+		t.Errorf("expected a member to clone the project for themselves, got: %v", err)
+	}
+}
+
+func TestCloneProject_NotFound(t *testing.T) {
+	s := setupProjectCloneTest()
+	if _, err := s.uc.CloneProject(context.Background(), "user-2", "proj-1", usecase.ProjectCloneOptions{}); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
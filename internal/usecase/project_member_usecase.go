@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// ProjectMemberUsecase provides methods for sharing projects with study groups.
+type ProjectMemberUsecase struct {
+	memberRepo   port.ProjectMemberRepository
+	projectRepo  port.ProjectRepository
+	userRepo     port.UserRepository
+	studyLogRepo port.StudyLogRepository
+}
+
+// NewProjectMemberUsecase creates a new ProjectMemberUsecase.
+func NewProjectMemberUsecase(
+	memberRepo port.ProjectMemberRepository,
+	projectRepo port.ProjectRepository,
+	userRepo port.UserRepository,
+	studyLogRepo port.StudyLogRepository,
+) *ProjectMemberUsecase {
+	return &ProjectMemberUsecase{
+		memberRepo:   memberRepo,
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		studyLogRepo: studyLogRepo,
+	}
+}
+
+// InviteMember invites the user with the given email to a project owned by the user. The invitation
+// is pending until the invited user accepts it. So that invitations cannot tell whether an address has
+// an account, inviting an unknown address or a user who is already invited succeeds without effect.
+func (u *ProjectMemberUsecase) InviteMember(ctx context.Context, userID, projectID, email, role string) error {
+	project, err := u.findManagedProject(ctx, userID, projectID)
+	if err != nil {
+		return err
+	}
+	memberRole, err := domain.ParseMemberRole(role)
+	if err != nil {
+		return err
+	}
+	invitee, err := u.userRepo.FindByEmail(ctx, domain.NormalizeEmail(email))
+	if domain.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	member, err := domain.NewProjectInvitation(project, invitee.ID, memberRole, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := u.memberRepo.Create(ctx, member); err != nil && !domain.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+// ListMembers returns the owner of a project the user can read, followed by its members and pending
+// invitations in the order they were invited.
+func (u *ProjectMemberUsecase) ListMembers(ctx context.Context, userID, projectID string) ([]*domain.ProjectMember, error) {
+	project, _, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := u.memberRepo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members = append([]*domain.ProjectMember{projectOwner(project)}, members...)
+	for _, m := range members {
+		user, err := u.userRepo.FindByID(ctx, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		m.UserName = user.Name
+		m.ProjectName = project.Name
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes the role of a member of a project owned by the user.
+func (u *ProjectMemberUsecase) UpdateMemberRole(ctx context.Context, userID, projectID, memberID, role string) (*domain.ProjectMember, error) {
+	project, err := u.findManagedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	memberRole, err := domain.ParseMemberRole(role)
+	if err != nil {
+		return nil, err
+	}
+	member, err := u.memberRepo.Find(ctx, projectID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	member.Role = memberRole
+	if err := u.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.FindByID(ctx, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+	member.UserName = user.Name
+	member.ProjectName = project.Name
+	return member, nil
+}
+
+// RemoveMember removes a member, or withdraws an invitation, from a project owned by the user.
+// The study logs and notes the member wrote in the project are kept.
+func (u *ProjectMemberUsecase) RemoveMember(ctx context.Context, userID, projectID, memberID string) error {
+	if _, err := u.findManagedProject(ctx, userID, projectID); err != nil {
+		return err
+	}
+	return u.memberRepo.Delete(ctx, projectID, memberID)
+}
+
+// AcceptInvitation joins the user to a project they were invited to.
+func (u *ProjectMemberUsecase) AcceptInvitation(ctx context.Context, userID, projectID string) (*domain.ProjectMember, error) {
+	member, project, err := u.findInvitation(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := member.Accept(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	member.UserName = user.Name
+	member.ProjectName = project.Name
+	return member, nil
+}
+
+// LeaveProject declines an invitation to a project, or leaves a project the user has joined.
+// The study logs and notes the user wrote in the project are kept. The owner cannot leave.
+func (u *ProjectMemberUsecase) LeaveProject(ctx context.Context, userID, projectID string) error {
+	project, err := u.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if project.UserID == userID {
+		return domain.ErrConflict("the owner cannot leave the project; delete it instead")
+	}
+	if _, _, err := u.findInvitation(ctx, userID, projectID); err != nil {
+		return err
+	}
+	return u.memberRepo.Delete(ctx, projectID, userID)
+}
+
+// ListMemberships returns the projects of other users the user has joined or been invited to.
+func (u *ProjectMemberUsecase) ListMemberships(ctx context.Context, userID string) ([]*domain.ProjectMember, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := u.memberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		project, err := u.projectRepo.FindByID(ctx, m.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		m.UserName = user.Name
+		m.ProjectName = project.Name
+	}
+	return memberships, nil
+}
+
+// GetMemberStats breaks the time studied in a project the user can read down by member. from and to
+// are optional and inclusive; their day boundaries are midnight in the given timezone, or in the
+// user's preferred timezone when timezone is empty.
+func (u *ProjectMemberUsecase) GetMemberStats(ctx context.Context, userID, projectID string, from, to *domain.Date, timezone string) (*domain.ProjectMemberStats, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	filter := port.StudyLogFilter{ProjectID: &projectID}
+	if from != nil {
+		start := from.StartIn(loc)
+		filter.From = &start
+	}
+	if to != nil {
+		end := to.AddDays(1).StartIn(loc)
+		filter.To = &end
+	}
+
+	members, err := u.memberRepo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members = append([]*domain.ProjectMember{projectOwner(project)}, members...)
+	stats := &domain.ProjectMemberStats{
+		ProjectID: projectID,
+		Timezone:  loc.String(),
+		Members:   []domain.MemberStudyStats{},
+	}
+	for _, m := range members {
+		if !m.IsAccepted() {
+			continue
+		}
+		user, err := u.userRepo.FindByID(ctx, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := u.studyLogRepo.FindByUserID(ctx, m.UserID, filter, port.PageRequest{})
+		if err != nil {
+			return nil, err
+		}
+		ms := domain.MemberStudyStats{UserID: m.UserID, UserName: user.Name, Role: m.Role}
+		for _, log := range logs {
+			ms.TotalMinutes += log.Minutes
+			ms.Pomodoros += log.Pomodoros
+		}
+		stats.Members = append(stats.Members, ms)
+		stats.TotalMinutes += ms.TotalMinutes
+		stats.TotalPomodoros += ms.Pomodoros
+	}
+	return stats, nil
+}
+
+// findManagedProject returns a project whose members the user may manage. Only the owner may; other
+// members are forbidden, and everyone else is told the project does not exist.
+func (u *ProjectMemberUsecase) findManagedProject(ctx context.Context, userID, projectID string) (*domain.Project, error) {
+	project, role, err := findReadableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if role != domain.ProjectRoleOwner {
+		return nil, domain.ErrForbidden("only the owner can manage the members of the project")
+	}
+	return project, nil
+}
+
+// findInvitation returns the user's membership of, or pending invitation to, a project that is not
+// in the trash.
+func (u *ProjectMemberUsecase) findInvitation(ctx context.Context, userID, projectID string) (*domain.ProjectMember, *domain.Project, error) {
+	member, err := u.memberRepo.Find(ctx, projectID, userID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, nil, domain.ErrNotFound("invitation")
+		}
+		return nil, nil, err
+	}
+	project, err := u.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, nil, domain.ErrNotFound("invitation")
+		}
+		return nil, nil, err
+	}
+	return member, project, nil
+}
+
+// projectOwner describes the owner of a project as its first member.
+func projectOwner(project *domain.Project) *domain.ProjectMember {
+	createdAt := project.CreatedAt
+	return &domain.ProjectMember{
+		ProjectID:  project.ID,
+		UserID:     project.UserID,
+		Role:       domain.ProjectRoleOwner,
+		InvitedAt:  createdAt,
+		AcceptedAt: &createdAt,
+	}
+}
+
+// findReadableProject returns a project the user owns or has joined, with the user's role in it.
+// Projects the user has not joined, including those they are only invited to, are reported as not
+// found so their existence is not leaked.
+func findReadableProject(ctx context.Context, projectRepo port.ProjectRepository, memberRepo port.ProjectMemberRepository, userID, projectID string) (*domain.Project, domain.ProjectRole, error) {
+	project, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, "", err
+	}
+	if project.UserID == userID {
+		return project, domain.ProjectRoleOwner, nil
+	}
+	member, err := memberRepo.Find(ctx, projectID, userID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, "", domain.ErrNotFound("project")
+		}
+		return nil, "", err
+	}
+	if !member.IsAccepted() {
+		return nil, "", domain.ErrNotFound("project")
+	}
+	return project, member.Role, nil
+}
+
+// findWritableProject returns a project the user may record study time and write notes in: one they
+// own or have joined as an editor. Viewers are forbidden.
+func findWritableProject(ctx context.Context, projectRepo port.ProjectRepository, memberRepo port.ProjectMemberRepository, userID, projectID string) (*domain.Project, error) {
+	project, role, err := findReadableProject(ctx, projectRepo, memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanWrite() {
+		return nil, domain.ErrForbidden("viewers cannot record study time or write notes in the project")
+	}
+	return project, nil
+}
@@ -0,0 +1,317 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+type projectMemberTest struct {
+	uc           *usecase.ProjectMemberUsecase
+	studyLogUC   *usecase.StudyLogUsecase
+	noteUC       *usecase.NoteUsecase
+	memberRepo   *mockProjectMemberRepository
+	studyLogRepo *mockStudyLogRepository
+}
+
+// setupProjectMemberTest creates Alice's project proj-1 and the users Bob, Carol and Dave.
+func setupProjectMemberTest() projectMemberTest {
+	userRepo := newMockUserRepository()
+	projectRepo := newMockProjectRepository()
+	memberRepo := newMockProjectMemberRepository()
+	studyLogRepo := newMockStudyLogRepository()
+	noteRepo := newMockNoteRepository()
+	noteLinkRepo := newMockNoteLinkRepository(noteRepo, studyLogRepo)
+	for id, name := range map[string]string{"user-1": "Alice", "user-2": "Bob", "user-3": "Carol", "user-4": "Dave"} {
+		createTestUser(userRepo, id, name)
+		userRepo.users[id].Email = strings.ToLower(name) + "@example.com"
+	}
+	createTestProject(projectRepo, "proj-1", "user-1", "Math")
+	return projectMemberTest{
+		uc:           usecase.NewProjectMemberUsecase(memberRepo, projectRepo, userRepo, studyLogRepo),
+		studyLogUC:   usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, newMockActivityTypeRepository(), noteLinkRepo, memberRepo),
+		noteUC:       usecase.NewNoteUsecase(noteRepo, projectRepo, userRepo, studyLogRepo, noteLinkRepo, memberRepo),
+		memberRepo:   memberRepo,
+		studyLogRepo: studyLogRepo,
+	}
+}
+
+// join invites a user to proj-1 and accepts the invitation.
+func (s projectMemberTest) join(t *testing.T, email, userID, role string) {
+	t.Helper()
+	ctx := context.Background()
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", email, role); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.uc.AcceptInvitation(ctx, userID, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInviteMember_AcceptAndList(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+
+	// Email addresses are matched regardless of case.
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", " Bob@Example.com", "editor"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	invitation, err := s.memberRepo.Find(ctx, "proj-1", "user-2")
+	if err != nil {
+		t.Fatalf("expected Bob to be invited, got: %v", err)
+	}
+	if invitation.Role != domain.ProjectRoleEditor || invitation.IsAccepted() {
+		t.Errorf("unexpected invitation: %+v", invitation)
+	}
+	if _, err := s.uc.ListMembers(ctx, "user-2", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error before accepting, got: %v", err)
+	}
+
+	member, err := s.uc.AcceptInvitation(ctx, "user-2", "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !member.IsAccepted() || member.ProjectName != "Math" {
+		t.Errorf("unexpected member: %+v", member)
+	}
+	if _, err := s.uc.AcceptInvitation(ctx, "user-2", "proj-1"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict accepting twice, got: %v", err)
+	}
+	if _, err := s.uc.AcceptInvitation(ctx, "user-3", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error without an invitation, got: %v", err)
+	}
+
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Carol@example.com", "viewer"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	members, err := s.uc.ListMembers(ctx, "user-2", "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 3 {
+		t.Fatalf("expected the owner and 2 members, got %d", len(members))
+	}
+	if members[0].UserName != "Alice" || members[0].Role != domain.ProjectRoleOwner {
+		t.Errorf("expected the owner first, got %+v", members[0])
+	}
+	if members[1].UserName != "Bob" || !members[1].IsAccepted() || members[2].UserName != "Carol" || members[2].IsAccepted() {
+		t.Errorf("unexpected members: %+v, %+v", members[1], members[2])
+	}
+
+	memberships, err := s.uc.ListMemberships(ctx, "user-3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(memberships) != 1 || memberships[0].ProjectName != "Math" || memberships[0].IsAccepted() {
+		t.Errorf("expected Carol's pending invitation to Math, got %+v", memberships)
+	}
+}
+
+func TestInviteMember_Errors(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+
+	if err := s.uc.InviteMember(ctx, "user-2", "proj-1", "Carol@example.com", "editor"); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a member who is not the owner, got: %v", err)
+	}
+	if err := s.uc.InviteMember(ctx, "user-3", "proj-1", "Dave@example.com", "editor"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a user who is not a member, got: %v", err)
+	}
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Alice@example.com", "editor"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict inviting the owner, got: %v", err)
+	}
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Carol@example.com", "owner"); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for the owner role, got: %v", err)
+	}
+}
+
+func TestInviteMember_SameResultForEveryAddress(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "nobody@example.com", "editor"); err != nil {
+		t.Errorf("expected an unknown email to be accepted, got: %v", err)
+	}
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Bob@example.com", "viewer"); err != nil {
+		t.Errorf("expected inviting a member again to be accepted, got: %v", err)
+	}
+	if len(s.memberRepo.members) != 1 {
+		t.Errorf("expected only Bob's membership, got %d", len(s.memberRepo.members))
+	}
+	if member, _ := s.memberRepo.Find(ctx, "proj-1", "user-2"); member.Role != domain.ProjectRoleEditor || !member.IsAccepted() {
+		t.Errorf("expected Bob's membership to be unchanged, got %+v", member)
+	}
+}
+
+func TestUpdateMemberRoleAndRemoveMember(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+
+	member, err := s.uc.UpdateMemberRole(ctx, "user-1", "proj-1", "user-2", "viewer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.Role != domain.ProjectRoleViewer || !member.IsAccepted() {
+		t.Errorf("expected an accepted viewer, got %+v", member)
+	}
+	if _, err := s.uc.UpdateMemberRole(ctx, "user-1", "proj-1", "user-3", "viewer"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a user who is not a member, got: %v", err)
+	}
+	if err := s.uc.RemoveMember(ctx, "user-2", "proj-1", "user-2"); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a member who is not the owner, got: %v", err)
+	}
+	if err := s.uc.RemoveMember(ctx, "user-1", "proj-1", "user-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.uc.ListMembers(ctx, "user-2", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error after being removed, got: %v", err)
+	}
+}
+
+func TestLeaveProject(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+	if _, err := s.studyLogUC.CreateStudyLog(ctx, "user-2", "proj-1", "", time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC), 30, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.uc.LeaveProject(ctx, "user-1", "proj-1"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict when the owner leaves, got: %v", err)
+	}
+	if err := s.uc.LeaveProject(ctx, "user-2", "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.uc.LeaveProject(ctx, "user-2", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error leaving twice, got: %v", err)
+	}
+	if len(s.studyLogRepo.logs) != 1 {
+		t.Errorf("expected the member's study log to be kept, got %d logs", len(s.studyLogRepo.logs))
+	}
+	if _, err := s.studyLogUC.CreateStudyLog(ctx, "user-2", "proj-1", "", time.Date(2024, 3, 21, 9, 0, 0, 0, time.UTC), 30, ""); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error after leaving, got: %v", err)
+	}
+
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Carol@example.com", "viewer"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.uc.LeaveProject(ctx, "user-3", "proj-1"); err != nil {
+		t.Fatalf("expected the invitation to be declined, got: %v", err)
+	}
+	if _, err := s.uc.AcceptInvitation(ctx, "user-3", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error accepting a declined invitation, got: %v", err)
+	}
+}
+
+func TestSharedProject_StudyLogsByRole(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+	s.join(t, "Carol@example.com", "user-3", "viewer")
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Dave@example.com", "editor"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	at := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	log, err := s.studyLogUC.CreateStudyLog(ctx, "user-2", "proj-1", "", at, 30, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.UserID != "user-2" || log.ProjectID != "proj-1" {
+		t.Errorf("expected the editor's own log in the shared project, got %+v", log)
+	}
+	if _, err := s.studyLogUC.CreateStudyLog(ctx, "user-3", "proj-1", "", at, 30, ""); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a viewer, got: %v", err)
+	}
+	if _, err := s.studyLogUC.CreateStudyLog(ctx, "user-4", "proj-1", "", at, 30, ""); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a pending invitation, got: %v", err)
+	}
+
+	results, err := s.studyLogUC.CreateStudyLogs(ctx, "user-3", []usecase.StudyLogInput{{ProjectID: "proj-1", StudiedAt: at, Minutes: 30}}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Err == nil || results[0].Err.Type != domain.ErrorTypeForbidden {
+		t.Errorf("expected a forbidden result for a viewer's batch, got %+v", results[0])
+	}
+}
+
+func TestSharedProject_Notes(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+	s.join(t, "Carol@example.com", "user-3", "viewer")
+
+	note, err := s.noteUC.CreateNote(ctx, "user-2", "proj-1", "Chapter 1", "summary", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.noteUC.CreateNote(ctx, "user-3", "proj-1", "Chapter 2", "summary", nil); !domain.IsForbidden(err) {
+		t.Errorf("expected forbidden error for a viewer, got: %v", err)
+	}
+
+	notes, _, err := s.noteUC.ListNotes(ctx, "user-3", "proj-1", usecase.PageOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != note.ID {
+		t.Errorf("expected the viewer to read the editor's note, got %+v", notes)
+	}
+	if _, err := s.noteUC.GetNote(ctx, "user-1", note.ID); err != nil {
+		t.Errorf("expected the owner to read the editor's note, got: %v", err)
+	}
+	if _, err := s.noteUC.GetNote(ctx, "user-4", note.ID); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a user who is not a member, got: %v", err)
+	}
+	if _, err := s.noteUC.UpdateNote(ctx, "user-1", note.ID, "Edited", "", nil); !domain.IsNotFound(err) {
+		t.Errorf("expected only the author to edit the note, got: %v", err)
+	}
+}
+
+func TestGetMemberStats(t *testing.T) {
+	s := setupProjectMemberTest()
+	ctx := context.Background()
+	s.join(t, "Bob@example.com", "user-2", "editor")
+	if err := s.uc.InviteMember(ctx, "user-1", "proj-1", "Carol@example.com", "editor"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	day := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+	s.studyLogRepo.logs = []*domain.StudyLog{
+		{ID: "l1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: day, Minutes: 60, Pomodoros: 2},
+		{ID: "l2", UserID: "user-2", ProjectID: "proj-1", StudiedAt: day, Minutes: 45},
+		{ID: "l3", UserID: "user-2", ProjectID: "proj-1", StudiedAt: day.AddDate(0, 0, 1), Minutes: 15},
+		{ID: "l4", UserID: "user-2", ProjectID: "proj-2", StudiedAt: day, Minutes: 90},
+		{ID: "l5", UserID: "user-2", ProjectID: "proj-1", StudiedAt: day.AddDate(0, 0, -7), Minutes: 30},
+	}
+	from := domain.Date{Year: 2024, Month: time.March, Day: 20}
+	to := domain.Date{Year: 2024, Month: time.March, Day: 21}
+
+	stats, err := s.uc.GetMemberStats(ctx, "user-2", "proj-1", &from, &to, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats.Members) != 2 {
+		t.Fatalf("expected the owner and the accepted member, got %+v", stats.Members)
+	}
+	alice, bob := stats.Members[0], stats.Members[1]
+	if alice.UserName != "Alice" || alice.Role != domain.ProjectRoleOwner || alice.TotalMinutes != 60 || alice.Pomodoros != 2 {
+		t.Errorf("unexpected stats for the owner: %+v", alice)
+	}
+	if bob.UserName != "Bob" || bob.TotalMinutes != 60 {
+		t.Errorf("unexpected stats for the member: %+v", bob)
+	}
+	if stats.TotalMinutes != 120 || stats.TotalPomodoros != 2 {
+		t.Errorf("expected totals 120 minutes and 2 pomodoros, got %d and %d", stats.TotalMinutes, stats.TotalPomodoros)
+	}
+
+	if _, err := s.uc.GetMemberStats(ctx, "user-3", "proj-1", nil, nil, ""); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for a pending invitation, got: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,20 +12,27 @@ import (
 
 // ProjectUsecase provides methods for managing projects.
 type ProjectUsecase struct {
-	projectRepo port.ProjectRepository
-	userRepo    port.UserRepository
+	projectRepo   port.ProjectRepository
+	userRepo      port.UserRepository
+	milestoneRepo port.MilestoneRepository
+	goalRepo      port.GoalRepository
+	tokens        port.TokenManager
 }
 
 // NewProjectUsecase creates a new ProjectUsecase.
-func NewProjectUsecase(projectRepo port.ProjectRepository, userRepo port.UserRepository) *ProjectUsecase {
+func NewProjectUsecase(projectRepo port.ProjectRepository, userRepo port.UserRepository, milestoneRepo port.MilestoneRepository, goalRepo port.GoalRepository, tokens port.TokenManager) *ProjectUsecase {
 	return &ProjectUsecase{
-		projectRepo: projectRepo,
-		userRepo:    userRepo,
+		projectRepo:   projectRepo,
+		userRepo:      userRepo,
+		milestoneRepo: milestoneRepo,
+		goalRepo:      goalRepo,
+		tokens:        tokens,
 	}
 }
 
-// CreateProject creates a new project for a user.
-func (u *ProjectUsecase) CreateProject(ctx context.Context, userID, name string) (*domain.Project, error) {
+// CreateProject creates a new project for a user. parentID may be empty; otherwise the project
+// is created as a sub-topic of that project of the user.
+func (u *ProjectUsecase) CreateProject(ctx context.Context, userID, name, parentID string, details domain.ProjectDetails) (*domain.Project, error) {
 	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
 		return nil, err
 	}
@@ -33,39 +41,340 @@ func (u *ProjectUsecase) CreateProject(ctx context.Context, userID, name string)
 	if err != nil {
 		return nil, err
 	}
+	if err := project.UpdateDetails(details); err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		tree, err := u.projectTree(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := tree.CheckParent(id, parentID); err != nil {
+			return nil, err
+		}
+		project.ParentID = parentID
+	}
 	if err := u.projectRepo.Create(ctx, project); err != nil {
 		return nil, err
 	}
 	return project, nil
 }
 
-// ListProjects returns all projects for a user.
-func (u *ProjectUsecase) ListProjects(ctx context.Context, userID string) ([]*domain.Project, error) {
+// projectSortKeys are the keys projects can be sorted by; they are sorted by name by default.
+var projectSortKeys = []string{domain.SortByName, domain.SortByCreatedAt}
+
+// ProjectListFilter selects projects by whether they are archived and by lifecycle status.
+type ProjectListFilter struct {
+	// Archived selects only archived projects when true and only unarchived ones when false; nil selects both.
+	Archived *bool
+	// Status selects only the projects with the given lifecycle status when set.
+	Status string
+}
+
+// ListProjects returns a page of a user's projects that match the filter and the cursor of the next page.
+func (u *ProjectUsecase) ListProjects(ctx context.Context, userID string, filter ProjectListFilter, opts PageOptions) ([]*domain.Project, string, error) {
+	repoFilter := port.ProjectFilter{Archived: filter.Archived}
+	if filter.Status != "" {
+		status, err := domain.ParseProjectStatus(filter.Status)
+		if err != nil {
+			return nil, "", err
+		}
+		repoFilter.Status = status
+	}
+	page, err := pageRequest(opts, projectSortKeys, domain.SortOrder{Key: domain.SortByName})
+	if err != nil {
+		return nil, "", err
+	}
 	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return u.projectRepo.FindByUserID(ctx, userID)
+	projects, err := u.projectRepo.FindByUserID(ctx, userID, repoFilter, page)
+	if err != nil {
+		return nil, "", err
+	}
+	projects, next := nextPage(projects, page, func(p *domain.Project) domain.Cursor {
+		if page.Sort.Key == domain.SortByName {
+			return domain.Cursor{Text: p.Name, ID: p.ID}
+		}
+		return domain.Cursor{Time: p.CreatedAt, ID: p.ID}
+	})
+	if err := u.attachProgress(ctx, projects...); err != nil {
+		return nil, "", err
+	}
+	return projects, next, nil
 }
 
-// UpdateProject updates an existing project.
-func (u *ProjectUsecase) UpdateProject(ctx context.Context, id, name string) (*domain.Project, error) {
-	project, err := u.projectRepo.FindByID(ctx, id)
+// UpdateProject replaces the name and details of an existing project owned by the user.
+func (u *ProjectUsecase) UpdateProject(ctx context.Context, userID, id, name string, details domain.ProjectDetails) (*domain.Project, error) {
+	project, err := u.findOwnedProject(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
 	if err := project.UpdateName(name); err != nil {
 		return nil, err
 	}
+	if err := project.UpdateDetails(details); err != nil {
+		return nil, err
+	}
+	if err := u.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := u.attachProgress(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// MoveProject places a project owned by the user under another of the user's projects,
+// or at the top level when parentID is empty. Its sub-projects move with it.
+func (u *ProjectUsecase) MoveProject(ctx context.Context, userID, id, parentID string) (*domain.Project, error) {
+	project, err := u.findOwnedProject(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if parentID == project.ParentID {
+		if err := u.attachProgress(ctx, project); err != nil {
+			return nil, err
+		}
+		return project, nil
+	}
+	tree, err := u.projectTree(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckParent(id, parentID); err != nil {
+		return nil, err
+	}
+	project.ParentID = parentID
+	project.UpdatedAt = time.Now()
+	if err := u.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := u.attachProgress(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// ArchiveProject archives a project owned by the user. It is hidden from the project list and
+// from weekly stats, and no new study time can be recorded in it until it is unarchived.
+func (u *ProjectUsecase) ArchiveProject(ctx context.Context, userID, id string) (*domain.Project, error) {
+	project, err := u.findOwnedProject(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Archive(time.Now()); err != nil {
+		return nil, err
+	}
 	if err := u.projectRepo.Update(ctx, project); err != nil {
 		return nil, err
 	}
+	if err := u.attachProgress(ctx, project); err != nil {
+		return nil, err
+	}
 	return project, nil
 }
 
-// DeleteProject deletes a project by ID.
-func (u *ProjectUsecase) DeleteProject(ctx context.Context, id string) error {
-	if _, err := u.projectRepo.FindByID(ctx, id); err != nil {
+// UnarchiveProject makes an archived project owned by the user active again.
+func (u *ProjectUsecase) UnarchiveProject(ctx context.Context, userID, id string) (*domain.Project, error) {
+	project, err := u.findOwnedProject(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Unarchive(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	if err := u.attachProgress(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// PreviewProjectDeletion reports what deleting a project owned by the user would move to the trash
+// with it. The deletion is confirmed with the impact's confirmation token, which only the user can
+// use and only until it expires.
+func (u *ProjectUsecase) PreviewProjectDeletion(ctx context.Context, userID, id string) (*domain.DeletionImpact, error) {
+	if _, err := u.findOwnedProject(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	impact, err := u.projectRepo.DeletionImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	goals, err := u.goalRepo.FindByUserID(ctx, userID, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range goals {
+		if g.ProjectID == id {
+			impact.Goal = g
+		}
+	}
+	if err := issueDeletionConfirmation(u.tokens, userID, id, impact); err != nil {
+		return nil, err
+	}
+	return impact, nil
+}
+
+// DeleteProject moves a project owned by the user to the trash, together with its notes,
+// study logs and goal. A project that still has sub-projects cannot be deleted. The deletion must be
+// confirmed with the token of a preview that is still current, or forced.
+func (u *ProjectUsecase) DeleteProject(ctx context.Context, userID, id, confirmationToken string, force bool) error {
+	if _, err := u.findOwnedProject(ctx, userID, id); err != nil {
 		return err
 	}
-	return u.projectRepo.Delete(ctx, id)
+	tree, err := u.projectTree(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(tree.Children(id)) > 0 {
+		return domain.ErrConflict("the project has sub-projects; move or delete them first")
+	}
+	impact, err := u.projectRepo.DeletionImpact(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkDeletionConfirmation(u.tokens, userID, id, confirmationToken, impact, force); err != nil {
+		return err
+	}
+	return u.projectRepo.Trash(ctx, id, time.Now())
+}
+
+// RestoreProject takes a trashed project owned by the user out of the trash, together with the
+// notes, study logs and goal that were trashed with it.
+func (u *ProjectUsecase) RestoreProject(ctx context.Context, userID, id string) (*domain.Project, error) {
+	project, err := u.projectRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID != userID {
+		return nil, domain.ErrNotFound("project")
+	}
+	if project.ParentID != "" {
+		tree, err := u.projectTree(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := tree.CheckParent(id, project.ParentID); err != nil {
+			if domain.IsNotFound(err) {
+				return nil, domain.ErrConflict("the parent project is in the trash; restore the parent first")
+			}
+			return nil, err
+		}
+	}
+	if err := u.projectRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	project.DeletedAt = nil
+	if err := u.attachProgress(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// MergeProject merges a project owned by the user into another of the user's projects. Its study logs,
+// notes, milestones and running timers move to the target project, its goal is merged with the
+// target's by the given strategy (see domain.MergeGoals), and the project is then deleted. The study
+// logs and notes of its other members and those in the trash move too, so none are deleted with it.
+// A project with sub-projects cannot be merged, and nothing can be merged into an archived project.
+func (u *ProjectUsecase) MergeProject(ctx context.Context, userID, id, targetID, goalStrategy string) (*domain.ProjectMergeSummary, error) {
+	strategy, err := domain.ParseGoalMergeStrategy(goalStrategy)
+	if err != nil {
+		return nil, err
+	}
+	if id == targetID {
+		return nil, domain.ErrValidation("a project cannot be merged into itself")
+	}
+	if _, err := u.findOwnedProject(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	target, err := u.findOwnedProject(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.CheckRecordable(); err != nil {
+		return nil, err
+	}
+	tree, err := u.projectTree(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tree.Children(id)) > 0 {
+		return nil, domain.ErrConflict("the project has sub-projects; move or delete them first")
+	}
+
+	goals, err := u.goalRepo.FindByUserID(ctx, userID, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var targetGoal, sourceGoal *domain.Goal
+	for _, g := range goals {
+		switch g.ProjectID {
+		case targetID:
+			targetGoal = g
+		case id:
+			sourceGoal = g
+		}
+	}
+	goal, err := domain.MergeGoals(targetID, targetGoal, sourceGoal, strategy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	summary, err := u.projectRepo.Merge(ctx, id, targetID, goal)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachProgress(ctx, target); err != nil {
+		return nil, err
+	}
+	summary.Target = target
+	summary.Goal = goal
+	return summary, nil
+}
+
+// attachProgress fills in the completion percentage of each of the projects from its milestones.
+func (u *ProjectUsecase) attachProgress(ctx context.Context, projects ...*domain.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	milestones, err := u.milestoneRepo.FindByProjectIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byProject := make(map[string][]*domain.Milestone)
+	for _, m := range milestones {
+		byProject[m.ProjectID] = append(byProject[m.ProjectID], m)
+	}
+	for _, p := range projects {
+		p.Progress = domain.ProjectProgress(byProject[p.ID])
+	}
+	return nil
+}
+
+// projectTree returns the hierarchy of all of the user's projects, archived ones included.
+func (u *ProjectUsecase) projectTree(ctx context.Context, userID string) (*domain.ProjectTree, error) {
+	projects, err := u.projectRepo.FindByUserID(ctx, userID, port.ProjectFilter{}, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewProjectTree(projects), nil
+}
+
+// findOwnedProject reports projects of other users as not found so their existence is not leaked.
+func (u *ProjectUsecase) findOwnedProject(ctx context.Context, userID, id string) (*domain.Project, error) {
+	project, err := u.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID != userID {
+		return nil, domain.ErrNotFound("project")
+	}
+	return project, nil
 }
@@ -2,7 +2,9 @@ package usecase_test
 
 import (
 	"context"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
@@ -11,7 +13,7 @@ import (
 func setupProjectTest() (*usecase.ProjectUsecase, *mockUserRepository, *mockProjectRepository) {
 	userRepo := newMockUserRepository()
 	projectRepo := newMockProjectRepository()
-	uc := usecase.NewProjectUsecase(projectRepo, userRepo)
+	uc := usecase.NewProjectUsecase(projectRepo, userRepo, newMockMilestoneRepository(), newMockGoalRepository(), mockTokenManager{})
 	return uc, userRepo, projectRepo
 }
 
@@ -23,7 +25,7 @@ func TestCreateProject_Success(t *testing.T) {
 	uc, userRepo, _ := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	project, err := uc.CreateProject(context.Background(), "user-1", "Mathematics")
+	project, err := uc.CreateProject(context.Background(), "user-1", "Mathematics", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -41,7 +43,7 @@ func TestCreateProject_Success(t *testing.T) {
 func TestCreateProject_UserNotFound(t *testing.T) {
 	uc, _, _ := setupProjectTest()
 
-	_, err := uc.CreateProject(context.Background(), "nonexistent", "Math")
+	_, err := uc.CreateProject(context.Background(), "nonexistent", "Math", "", domain.ProjectDetails{})
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -54,7 +56,7 @@ func TestCreateProject_EmptyName(t *testing.T) {
 	uc, userRepo, _ := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	_, err := uc.CreateProject(context.Background(), "user-1", "")
+	_, err := uc.CreateProject(context.Background(), "user-1", "", "", domain.ProjectDetails{})
 	if err == nil {
 		t.Fatal("expected error for empty name")
 	}
@@ -67,12 +69,12 @@ func TestCreateProject_DuplicateName(t *testing.T) {
 	uc, userRepo, _ := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	_, err := uc.CreateProject(context.Background(), "user-1", "Math")
+	_, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error creating first project: %v", err)
 	}
 
-	_, err = uc.CreateProject(context.Background(), "user-1", "Math")
+	_, err = uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
 	if err == nil {
 		t.Fatal("expected error for duplicate project name")
 	}
@@ -85,16 +87,16 @@ func TestListProjects_Success(t *testing.T) {
 	uc, userRepo, _ := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	_, err := uc.CreateProject(context.Background(), "user-1", "Math")
+	_, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	_, err = uc.CreateProject(context.Background(), "user-1", "English")
+	_, err = uc.CreateProject(context.Background(), "user-1", "English", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	projects, err := uc.ListProjects(context.Background(), "user-1")
+	projects, _, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{}, usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,10 +105,59 @@ func TestListProjects_Success(t *testing.T) {
 	}
 }
 
+func TestListProjects_Paginated(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	for _, name := range []string{"Math", "English", "History"} {
+		if _, err := uc.CreateProject(context.Background(), "user-1", name, "", domain.ProjectDetails{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	first, next, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{}, usecase.PageOptions{Limit: 2, Sort: "-name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first) != 2 || first[0].Name != "Math" || first[1].Name != "History" {
+		t.Fatalf("unexpected first page: %v", first)
+	}
+	if next == "" {
+		t.Fatal("expected a cursor for the next page")
+	}
+
+	second, next, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{}, usecase.PageOptions{Limit: 2, Cursor: next, Sort: "-name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second) != 1 || second[0].Name != "English" {
+		t.Fatalf("unexpected second page: %v", second)
+	}
+	if next != "" {
+		t.Errorf("expected no cursor on the last page, got %q", next)
+	}
+}
+
+func TestListProjects_InvalidPage(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	tests := []usecase.PageOptions{
+		{Limit: domain.MaxPageLimit + 1},
+		{Sort: "studiedAt"},
+		{Cursor: "not a cursor"},
+		{Cursor: domain.Cursor{Sort: "name", ID: "proj-1"}.Encode(), Sort: "-createdAt"},
+	}
+	for _, opts := range tests {
+		if _, _, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{}, opts); !domain.IsValidation(err) {
+			t.Errorf("expected validation error for %+v, got: %v", opts, err)
+		}
+	}
+}
+
 func TestListProjects_UserNotFound(t *testing.T) {
 	uc, _, _ := setupProjectTest()
 
-	_, err := uc.ListProjects(context.Background(), "nonexistent")
+	_, _, err := uc.ListProjects(context.Background(), "nonexistent", usecase.ProjectListFilter{}, usecase.PageOptions{})
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -115,16 +166,89 @@ func TestListProjects_UserNotFound(t *testing.T) {
 	}
 }
 
+func TestListProjects_Filter(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	archivedAt := time.Now()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math", Status: domain.ProjectStatusActive}
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-1", Name: "History", Status: domain.ProjectStatusDone, ArchivedAt: &archivedAt}
+	projectRepo.projects["proj-3"] = &domain.Project{ID: "proj-3", UserID: "user-1", Name: "Art", Status: domain.ProjectStatusPlanned}
+
+	unarchived, archived := false, true
+	tests := []struct {
+		name   string
+		filter usecase.ProjectListFilter
+		want   []string
+	}{
+		{"all", usecase.ProjectListFilter{}, []string{"Art", "History", "Math"}},
+		{"unarchived", usecase.ProjectListFilter{Archived: &unarchived}, []string{"Art", "Math"}},
+		{"archived", usecase.ProjectListFilter{Archived: &archived}, []string{"History"}},
+		{"planned", usecase.ProjectListFilter{Status: "planned"}, []string{"Art"}},
+		{"unarchived and done", usecase.ProjectListFilter{Archived: &unarchived, Status: "done"}, nil},
+	}
+	for _, tt := range tests {
+		projects, _, err := uc.ListProjects(context.Background(), "user-1", tt.filter, usecase.PageOptions{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		var names []string
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, names)
+		}
+	}
+
+	if _, _, err := uc.ListProjects(context.Background(), "user-1", usecase.ProjectListFilter{Status: "finished"}, usecase.PageOptions{}); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for an unknown status, got: %v", err)
+	}
+}
+
+func TestUpdateProject_Details(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	target := domain.Date{Year: 2025, Month: time.March, Day: 31}
+	created, err := uc.CreateProject(context.Background(), "user-1", "AWS certification", "", domain.ProjectDetails{
+		Description: "Solutions Architect Associate",
+		Color:       "#ff9900",
+		Icon:        "☁️",
+		Status:      domain.ProjectStatusPlanned,
+		TargetDate:  &target,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Color != "#ff9900" || created.Status != domain.ProjectStatusPlanned || *created.TargetDate != target {
+		t.Errorf("unexpected details: %+v", created)
+	}
+
+	updated, err := uc.UpdateProject(context.Background(), "user-1", created.ID, "AWS certification", domain.ProjectDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Description != "" || updated.Color != "" || updated.TargetDate != nil {
+		t.Errorf("expected details to be cleared, got %+v", updated)
+	}
+	if updated.Status != domain.ProjectStatusActive {
+		t.Errorf("expected the status to default to active, got %s", updated.Status)
+	}
+
+	if _, err := uc.UpdateProject(context.Background(), "user-1", created.ID, "AWS", domain.ProjectDetails{Color: "orange"}); !domain.IsValidation(err) {
+		t.Errorf("expected validation error for an invalid color, got: %v", err)
+	}
+}
+
 func TestUpdateProject_Success(t *testing.T) {
 	uc, userRepo, _ := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	created, err := uc.CreateProject(context.Background(), "user-1", "Math")
+	created, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	updated, err := uc.UpdateProject(context.Background(), created.ID, "Mathematics")
+	updated, err := uc.UpdateProject(context.Background(), "user-1", created.ID, "Mathematics", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -136,7 +260,7 @@ func TestUpdateProject_Success(t *testing.T) {
 func TestUpdateProject_NotFound(t *testing.T) {
 	uc, _, _ := setupProjectTest()
 
-	_, err := uc.UpdateProject(context.Background(), "nonexistent", "NewName")
+	_, err := uc.UpdateProject(context.Background(), "user-1", "nonexistent", "NewName", domain.ProjectDetails{})
 	if err == nil {
 		t.Fatal("expected error for nonexistent project")
 	}
@@ -145,30 +269,189 @@ func TestUpdateProject_NotFound(t *testing.T) {
 	}
 }
 
+func TestCreateProject_SubProject(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["aws"] = &domain.Project{ID: "aws", UserID: "user-1", Name: "AWS certification"}
+	projectRepo.projects["other"] = &domain.Project{ID: "other", UserID: "user-2", Name: "Other"}
+
+	iam, err := uc.CreateProject(context.Background(), "user-1", "IAM", "aws", domain.ProjectDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if iam.ParentID != "aws" {
+		t.Errorf("expected ParentID 'aws', got '%s'", iam.ParentID)
+	}
+	if _, err := uc.CreateProject(context.Background(), "user-1", "VPC", "other", domain.ProjectDetails{}); !domain.IsNotFound(err) {
+		t.Errorf("expected not found for another user's parent, got: %v", err)
+	}
+}
+
+func TestMoveProject(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	aws, _ := uc.CreateProject(context.Background(), "user-1", "AWS certification", "", domain.ProjectDetails{})
+	iam, _ := uc.CreateProject(context.Background(), "user-1", "IAM", aws.ID, domain.ProjectDetails{})
+	golang, _ := uc.CreateProject(context.Background(), "user-1", "Go", "", domain.ProjectDetails{})
+
+	if _, err := uc.MoveProject(context.Background(), "user-1", aws.ID, iam.ID); !domain.IsValidation(err) {
+		t.Errorf("expected validation error moving a project under its sub-project, got: %v", err)
+	}
+	moved, err := uc.MoveProject(context.Background(), "user-1", aws.ID, golang.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if moved.ParentID != golang.ID || projectRepo.projects[aws.ID].ParentID != golang.ID {
+		t.Errorf("expected the project to be under Go, got parent '%s'", moved.ParentID)
+	}
+	moved, err = uc.MoveProject(context.Background(), "user-1", aws.ID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if moved.ParentID != "" {
+		t.Errorf("expected a top-level project, got parent '%s'", moved.ParentID)
+	}
+	if _, err := uc.MoveProject(context.Background(), "user-2", aws.ID, ""); !domain.IsNotFound(err) {
+		t.Errorf("expected not found for another user's project, got: %v", err)
+	}
+}
+
+func TestDeleteProject_WithSubProjects(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	aws, _ := uc.CreateProject(context.Background(), "user-1", "AWS certification", "", domain.ProjectDetails{})
+	iam, _ := uc.CreateProject(context.Background(), "user-1", "IAM", aws.ID, domain.ProjectDetails{})
+
+	if err := uc.DeleteProject(context.Background(), "user-1", aws.ID, "", true); !domain.IsConflict(err) {
+		t.Errorf("expected conflict deleting a project with sub-projects, got: %v", err)
+	}
+	if err := uc.DeleteProject(context.Background(), "user-1", iam.ID, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.DeleteProject(context.Background(), "user-1", aws.ID, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.RestoreProject(context.Background(), "user-1", iam.ID); !domain.IsConflict(err) {
+		t.Errorf("expected conflict restoring a sub-project of a trashed project, got: %v", err)
+	}
+	if _, err := uc.RestoreProject(context.Background(), "user-1", aws.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RestoreProject(context.Background(), "user-1", iam.ID); err != nil {
+		t.Errorf("expected the sub-project to be restorable after its parent, got: %v", err)
+	}
+}
+
+func TestArchiveProject(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	created, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	archived, err := uc.ArchiveProject(context.Background(), "user-1", created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if archived.ArchivedAt == nil || !projectRepo.projects[created.ID].IsArchived() {
+		t.Error("expected project to be archived")
+	}
+	if _, err := uc.ArchiveProject(context.Background(), "user-1", created.ID); !domain.IsConflict(err) {
+		t.Errorf("expected conflict archiving twice, got: %v", err)
+	}
+	if _, err := uc.ArchiveProject(context.Background(), "user-2", created.ID); !domain.IsNotFound(err) {
+		t.Errorf("expected not found for another user's project, got: %v", err)
+	}
+
+	unarchived, err := uc.UnarchiveProject(context.Background(), "user-1", created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unarchived.IsArchived() {
+		t.Error("expected project to be active after unarchiving")
+	}
+	if _, err := uc.UnarchiveProject(context.Background(), "user-1", created.ID); !domain.IsConflict(err) {
+		t.Errorf("expected conflict unarchiving an active project, got: %v", err)
+	}
+}
+
 func TestDeleteProject_Success(t *testing.T) {
 	uc, userRepo, projectRepo := setupProjectTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	created, err := uc.CreateProject(context.Background(), "user-1", "Math")
+	created, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	err = uc.DeleteProject(context.Background(), created.ID)
+	err = uc.DeleteProject(context.Background(), "user-1", created.ID, "", true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Verify deleted
+	// Verify moved to the trash
 	if _, ok := projectRepo.projects[created.ID]; ok {
 		t.Error("expected project to be deleted from repository")
 	}
+	if trashed, ok := projectRepo.trashed[created.ID]; !ok || trashed.DeletedAt == nil {
+		t.Error("expected project to be in the trash")
+	}
+}
+
+func TestRestoreProject_Success(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	created, _ := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	_ = uc.DeleteProject(context.Background(), "user-1", created.ID, "", true)
+
+	restored, err := uc.RestoreProject(context.Background(), "user-1", created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("expected restored project not to have a deletion time")
+	}
+	if _, ok := projectRepo.projects[created.ID]; !ok {
+		t.Error("expected project to be back in repository")
+	}
+}
+
+func TestRestoreProject_NameTaken(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	created, _ := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	_ = uc.DeleteProject(context.Background(), "user-1", created.ID, "", true)
+	if _, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{}); err != nil {
+		t.Fatalf("expected the name of a trashed project to be free, got: %v", err)
+	}
+
+	_, err := uc.RestoreProject(context.Background(), "user-1", created.ID)
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if _, ok := projectRepo.trashed[created.ID]; !ok {
+		t.Error("expected project to stay in the trash")
+	}
+}
+
+func TestRestoreProject_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	created, _ := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	_ = uc.DeleteProject(context.Background(), "user-1", created.ID, "", true)
+
+	_, err := uc.RestoreProject(context.Background(), "user-2", created.ID)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's project, got: %v", err)
+	}
 }
 
 func TestDeleteProject_NotFound(t *testing.T) {
 	uc, _, _ := setupProjectTest()
 
-	err := uc.DeleteProject(context.Background(), "nonexistent")
+	err := uc.DeleteProject(context.Background(), "user-1", "nonexistent", "", true)
 	if err == nil {
 		t.Fatal("expected error for nonexistent project")
 	}
@@ -176,3 +459,169 @@ func TestDeleteProject_NotFound(t *testing.T) {
 		t.Errorf("expected not found error, got: %v", err)
 	}
 }
+
+func TestUpdateProject_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, _ := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	created, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = uc.UpdateProject(context.Background(), "user-2", created.ID, "Stolen", domain.ProjectDetails{})
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's project, got: %v", err)
+	}
+	if created.Name != "Math" {
+		t.Errorf("expected name to remain 'Math', got '%s'", created.Name)
+	}
+}
+
+func TestDeleteProject_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, projectRepo := setupProjectTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	created, err := uc.CreateProject(context.Background(), "user-1", "Math", "", domain.ProjectDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = uc.DeleteProject(context.Background(), "user-2", created.ID, "", true)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's project, got: %v", err)
+	}
+	if _, ok := projectRepo.projects[created.ID]; !ok {
+		t.Error("expected project to remain in repository")
+	}
+}
+
+// setupProjectMergeTest creates Alice's projects Go (proj-1) and Golang (proj-2).
+func setupProjectMergeTest() (*usecase.ProjectUsecase, *mockProjectRepository, *mockGoalRepository) {
+	userRepo := newMockUserRepository()
+	projectRepo := newMockProjectRepository()
+	goalRepo := newMockGoalRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	createTestProject(projectRepo, "proj-1", "user-1", "Go")
+	createTestProject(projectRepo, "proj-2", "user-1", "Golang")
+	uc := usecase.NewProjectUsecase(projectRepo, userRepo, newMockMilestoneRepository(), goalRepo, mockTokenManager{})
+	return uc, projectRepo, goalRepo
+}
+
+func TestMergeProject_Success(t *testing.T) {
+	uc, projectRepo, goalRepo := setupProjectMergeTest()
+	ctx := context.Background()
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sourceGoal, _ := domain.NewGoal("goal-2", "user-1", "proj-2", 120, start, nil)
+	_ = goalRepo.Upsert(ctx, sourceGoal)
+
+	summary, err := uc.MergeProject(ctx, "user-1", "proj-2", "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Target.ID != "proj-1" || summary.Goal == nil || summary.Goal.ProjectID != "proj-1" || summary.Goal.TargetMinutesPerWeek != 120 {
+		t.Errorf("expected the source's goal to move to the target, got: %+v", summary)
+	}
+	if _, ok := projectRepo.projects["proj-2"]; ok {
+		t.Error("expected the source project to be deleted")
+	}
+}
+
+func TestMergeProject_GoalConflict(t *testing.T) {
+	uc, _, goalRepo := setupProjectMergeTest()
+	ctx := context.Background()
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	targetGoal, _ := domain.NewGoal("goal-1", "user-1", "proj-1", 300, start, nil)
+	sourceGoal, _ := domain.NewGoal("goal-2", "user-1", "proj-2", 120, start, nil)
+	_ = goalRepo.Upsert(ctx, targetGoal)
+	_ = goalRepo.Upsert(ctx, sourceGoal)
+
+	if _, err := uc.MergeProject(ctx, "user-1", "proj-2", "proj-1", ""); !domain.IsValidation(err) {
+		t.Errorf("expected validation error without a goal strategy, got: %v", err)
+	}
+	summary, err := uc.MergeProject(ctx, "user-1", "proj-2", "proj-1", "sum")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Goal.ID != "goal-1" || summary.Goal.TargetMinutesPerWeek != 420 {
+		t.Errorf("expected the summed goal, got: %+v", summary.Goal)
+	}
+}
+
+func TestMergeProject_Rejected(t *testing.T) {
+	uc, projectRepo, _ := setupProjectMergeTest()
+	ctx := context.Background()
+	createTestProject(projectRepo, "proj-3", "user-2", "Rust")
+	projectRepo.projects["proj-4"] = &domain.Project{ID: "proj-4", UserID: "user-1", Name: "Go basics", ParentID: "proj-2"}
+
+	for _, tc := range []struct {
+		name           string
+		source, target string
+		strategy       string
+		check          func(error) bool
+	}{
+		{"into itself", "proj-1", "proj-1", "", domain.IsValidation},
+		{"unknown strategy", "proj-1", "proj-2", "average", domain.IsValidation},
+		{"another user's source", "proj-3", "proj-1", "", domain.IsNotFound},
+		{"into another user's project", "proj-1", "proj-3", "", domain.IsNotFound},
+		{"with sub-projects", "proj-2", "proj-1", "", domain.IsConflict},
+	} {
+		if _, err := uc.MergeProject(ctx, "user-1", tc.source, tc.target, tc.strategy); !tc.check(err) {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+	}
+
+	now := time.Now()
+	projectRepo.projects["proj-1"].ArchivedAt = &now
+	if _, err := uc.MergeProject(ctx, "user-1", "proj-4", "proj-1", ""); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error merging into an archived project, got: %v", err)
+	}
+	if len(projectRepo.projects) != 4 {
+		t.Errorf("expected no project to be deleted, got %d projects", len(projectRepo.projects))
+	}
+}
+
+func TestDeleteProject_RequiresConfirmation(t *testing.T) {
+	uc, projectRepo, goalRepo := setupProjectMergeTest()
+	ctx := context.Background()
+	goal, _ := domain.NewGoal("goal-1", "user-1", "proj-1", 300, time.Now(), nil)
+	_ = goalRepo.Upsert(ctx, goal)
+	projectRepo.impacts["proj-1"] = domain.DeletionImpact{StudyLogs: 12, TotalMinutes: 540, Notes: 3, Goals: 1}
+
+	impact, err := uc.PreviewProjectDeletion(ctx, "user-1", "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if impact.StudyLogs != 12 || impact.TotalMinutes != 540 || impact.Goal == nil || impact.Goal.ID != "goal-1" {
+		t.Errorf("unexpected impact: %+v", impact)
+	}
+	if _, err := uc.PreviewProjectDeletion(ctx, "user-2", "proj-1"); !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's project, got: %v", err)
+	}
+
+	token := impact.ConfirmationToken
+	if err := uc.DeleteProject(ctx, "user-1", "proj-1", "", false); !domain.IsValidation(err) {
+		t.Errorf("expected validation error without confirmation, got: %v", err)
+	}
+	if err := uc.DeleteProject(ctx, "user-1", "proj-2", token, false); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error with another project's token, got: %v", err)
+	}
+	if err := uc.DeleteProject(ctx, "user-1", "proj-1", "delete:user-2:proj-1:"+impact.Digest("proj-1"), false); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error with a token issued to another user, got: %v", err)
+	}
+	projectRepo.impacts["proj-1"] = domain.DeletionImpact{StudyLogs: 13, TotalMinutes: 570, Notes: 3, Goals: 1}
+	if err := uc.DeleteProject(ctx, "user-1", "proj-1", token, false); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error after a study log was added, got: %v", err)
+	}
+	if _, ok := projectRepo.trashed["proj-1"]; ok {
+		t.Fatal("expected the project not to be deleted")
+	}
+
+	impact, _ = uc.PreviewProjectDeletion(ctx, "user-1", "proj-1")
+	if err := uc.DeleteProject(ctx, "user-1", "proj-1", impact.ConfirmationToken, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := projectRepo.trashed["proj-1"]; !ok {
+		t.Error("expected the project to be in the trash")
+	}
+}
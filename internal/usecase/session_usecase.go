@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// SessionUsecase provides methods for managing the login sessions of a user.
+type SessionUsecase struct {
+	sessionRepo port.SessionRepository
+	userRepo    port.UserRepository
+}
+
+// NewSessionUsecase creates a new SessionUsecase.
+func NewSessionUsecase(sessionRepo port.SessionRepository, userRepo port.UserRepository) *SessionUsecase {
+	return &SessionUsecase{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// ListSessions returns the sessions of a user that have not expired.
+func (u *SessionUsecase) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	sessions, err := u.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]*domain.Session, 0, len(sessions))
+	for _, s := range sessions {
+		if !s.IsExpired(now) {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
+
+// RevokeSession ends a session by ID if it belongs to the user.
+func (u *SessionUsecase) RevokeSession(ctx context.Context, userID, id string) error {
+	session, err := u.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return domain.ErrNotFound("session")
+	}
+	return u.sessionRepo.Delete(ctx, id)
+}
+
+// RevokeAllSessions ends every session of a user, signing them out on all devices.
+func (u *SessionUsecase) RevokeAllSessions(ctx context.Context, userID string) error {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	return u.sessionRepo.DeleteByUserID(ctx, userID)
+}
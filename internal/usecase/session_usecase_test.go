@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupSessionTest() (*usecase.SessionUsecase, *mockSessionRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice"}
+	userRepo.users["user-2"] = &domain.User{ID: "user-2", Name: "Bob"}
+	sessionRepo := newMockSessionRepository()
+	now := time.Now()
+	sessionRepo.sessions["s1"] = &domain.Session{ID: "s1", UserID: "user-1", ExpiresAt: now.Add(time.Hour)}
+	sessionRepo.sessions["s2"] = &domain.Session{ID: "s2", UserID: "user-1", ExpiresAt: now.Add(time.Hour)}
+	sessionRepo.sessions["expired"] = &domain.Session{ID: "expired", UserID: "user-1", ExpiresAt: now.Add(-time.Hour)}
+	sessionRepo.sessions["s3"] = &domain.Session{ID: "s3", UserID: "user-2", ExpiresAt: now.Add(time.Hour)}
+	return usecase.NewSessionUsecase(sessionRepo, userRepo), sessionRepo
+}
+
+func TestListSessions_ExcludesExpired(t *testing.T) {
+	uc, _ := setupSessionTest()
+
+	sessions, err := uc.ListSessions(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("expected 2 active sessions, got %d", len(sessions))
+	}
+}
+
+func TestRevokeSession_Success(t *testing.T) {
+	uc, sessionRepo := setupSessionTest()
+
+	if err := uc.RevokeSession(context.Background(), "user-1", "s1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := sessionRepo.sessions["s1"]; ok {
+		t.Error("expected session to be deleted")
+	}
+}
+
+func TestRevokeSession_OtherUser(t *testing.T) {
+	uc, sessionRepo := setupSessionTest()
+
+	err := uc.RevokeSession(context.Background(), "user-2", "s1")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if _, ok := sessionRepo.sessions["s1"]; !ok {
+		t.Error("expected session to remain")
+	}
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	uc, sessionRepo := setupSessionTest()
+
+	if err := uc.RevokeAllSessions(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessionRepo.sessions) != 1 {
+		t.Errorf("expected only the other user's session to remain, got %d", len(sessionRepo.sessions))
+	}
+	if _, ok := sessionRepo.sessions["s3"]; !ok {
+		t.Error("expected other user's session to remain")
+	}
+}
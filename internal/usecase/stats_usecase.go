@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
@@ -10,9 +11,13 @@ import (
 
 // StatsUsecase provides methods for calculating study statistics.
 type StatsUsecase struct {
-	studyLogRepo port.StudyLogRepository
-	goalRepo     port.GoalRepository
-	projectRepo  port.ProjectRepository
+	studyLogRepo     port.StudyLogRepository
+	goalRepo         port.GoalRepository
+	projectRepo      port.ProjectRepository
+	userRepo         port.UserRepository
+	activityTypeRepo port.ActivityTypeRepository
+	milestoneRepo    port.MilestoneRepository
+	memberRepo       port.ProjectMemberRepository
 }
 
 // NewStatsUsecase creates a new StatsUsecase.
@@ -20,40 +25,74 @@ func NewStatsUsecase(
 	studyLogRepo port.StudyLogRepository,
 	goalRepo port.GoalRepository,
 	projectRepo port.ProjectRepository,
+	userRepo port.UserRepository,
+	activityTypeRepo port.ActivityTypeRepository,
+	milestoneRepo port.MilestoneRepository,
+	memberRepo port.ProjectMemberRepository,
 ) *StatsUsecase {
 	return &StatsUsecase{
-		studyLogRepo: studyLogRepo,
-		goalRepo:     goalRepo,
-		projectRepo:  projectRepo,
+		studyLogRepo:     studyLogRepo,
+		goalRepo:         goalRepo,
+		projectRepo:      projectRepo,
+		userRepo:         userRepo,
+		activityTypeRepo: activityTypeRepo,
+		milestoneRepo:    milestoneRepo,
+		memberRepo:       memberRepo,
 	}
 }
 
-// GetWeeklyStats calculates study statistics for a specific week.
-func (u *StatsUsecase) GetWeeklyStats(ctx context.Context, userID string, weekStart time.Time) (*domain.WeeklyStats, error) {
-	weekEnd := weekStart.AddDate(0, 0, 7)
+// GetWeeklyStats calculates study statistics for the seven days starting at weekStart.
+// Day boundaries are midnight in the given timezone, or in the user's preferred timezone
+// when timezone is empty, so a week spanning a DST change is 167 or 169 hours long.
+// Projects shared with the user count only the user's own study logs.
+func (u *StatsUsecase) GetWeeklyStats(ctx context.Context, userID string, weekStart domain.Date, timezone string) (*domain.WeeklyStats, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	from := weekStart.StartIn(loc)
+	to := weekStart.AddDays(7).StartIn(loc)
 
-	projects, err := u.projectRepo.FindByUserID(ctx, userID)
+	projects, err := u.projectRepo.FindByUserID(ctx, userID, port.ProjectFilter{}, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	shared, err := u.sharedProjects(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	projects = append(projects, shared...)
 
 	filter := port.StudyLogFilter{
-		From: &weekStart,
-		To:   &weekEnd,
+		From: &from,
+		To:   &to,
 	}
-	logs, err := u.studyLogRepo.FindByUserID(ctx, userID, filter)
+	logs, err := u.studyLogRepo.FindByUserID(ctx, userID, filter, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := u.goalRepo.FindByUserID(ctx, userID, port.PageRequest{})
 	if err != nil {
 		return nil, err
 	}
 
-	goals, err := u.goalRepo.FindByUserID(ctx, userID)
+	activityTypes, err := u.activityTypeRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	minutesByProject := make(map[string]int)
+	pomodorosByProject := make(map[string]int)
+	// Minutes by activity type ID within each project; logs without an activity type are under "".
+	activityMinutesByProject := make(map[string]map[string]int)
 	for _, log := range logs {
 		minutesByProject[log.ProjectID] += log.Minutes
+		pomodorosByProject[log.ProjectID] += log.Pomodoros
+		if activityMinutesByProject[log.ProjectID] == nil {
+			activityMinutesByProject[log.ProjectID] = make(map[string]int)
+		}
+		activityMinutesByProject[log.ProjectID][log.ActivityTypeID] += log.Minutes
 	}
 
 	goalByProject := make(map[string]*domain.Goal)
@@ -63,23 +102,41 @@ func (u *StatsUsecase) GetWeeklyStats(ctx context.Context, userID string, weekSt
 
 	stats := &domain.WeeklyStats{
 		WeekStart: weekStart,
+		Timezone:  loc.String(),
 	}
-	var totalMinutes int
+	var totalMinutes, totalPomodoros int
 
+	tree := domain.NewProjectTree(projects)
 	for _, project := range projects {
+		var subtreeMinutes, subtreePomodoros int
+		for _, id := range tree.Subtree(project.ID) {
+			subtreeMinutes += minutesByProject[id]
+			subtreePomodoros += pomodorosByProject[id]
+		}
+		// Archived projects only show up in the weeks they or their sub-projects were studied in.
+		if project.IsArchived() && subtreeMinutes == 0 {
+			continue
+		}
 		minutes := minutesByProject[project.ID]
 		totalMinutes += minutes
+		totalPomodoros += pomodorosByProject[project.ID]
 
 		ps := domain.ProjectWeeklyStats{
-			ProjectID:    project.ID,
-			ProjectName:  project.Name,
-			TotalMinutes: minutes,
+			ProjectID:        project.ID,
+			ProjectName:      project.Name,
+			ParentID:         project.ParentID,
+			TotalMinutes:     minutes,
+			Pomodoros:        pomodorosByProject[project.ID],
+			SubtreeMinutes:   subtreeMinutes,
+			SubtreePomodoros: subtreePomodoros,
+			Activities:       activityWeeklyStats(activityTypes, activityMinutesByProject[project.ID]),
 		}
 
+		// A goal counts the time studied in the project's sub-projects too.
 		if goal, ok := goalByProject[project.ID]; ok {
 			ps.TargetMinutesPerWeek = goal.TargetMinutesPerWeek
 			if goal.TargetMinutesPerWeek > 0 {
-				ps.AchievementRate = float64(minutes) / float64(goal.TargetMinutesPerWeek) * 100
+				ps.AchievementRate = float64(subtreeMinutes) / float64(goal.TargetMinutesPerWeek) * 100
 			}
 		}
 
@@ -87,5 +144,92 @@ func (u *StatsUsecase) GetWeeklyStats(ctx context.Context, userID string, weekSt
 	}
 
 	stats.TotalMinutes = totalMinutes
+	stats.TotalPomodoros = totalPomodoros
+
+	// A week that has not ended yet only lists the milestones that are overdue already.
+	at := to
+	if now := time.Now(); now.Before(at) {
+		at = now
+	}
+	stats.OverdueMilestones, err = u.overdueMilestones(ctx, stats.Projects, at, loc)
+	if err != nil {
+		return nil, err
+	}
 	return stats, nil
 }
+
+// sharedProjects returns the projects of other users the user has joined. They are listed as
+// top-level projects, since the user cannot see the rest of the owner's project tree.
+func (u *StatsUsecase) sharedProjects(ctx context.Context, userID string) ([]*domain.Project, error) {
+	memberships, err := u.memberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var projects []*domain.Project
+	for _, m := range memberships {
+		if !m.IsAccepted() {
+			continue
+		}
+		project, err := u.projectRepo.FindByID(ctx, m.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		shared := *project
+		shared.ParentID = ""
+		projects = append(projects, &shared)
+	}
+	return projects, nil
+}
+
+// overdueMilestones lists the milestones of the projects that are overdue at t, ordered by due date.
+func (u *StatsUsecase) overdueMilestones(ctx context.Context, projects []domain.ProjectWeeklyStats, t time.Time, loc *time.Location) ([]domain.OverdueMilestone, error) {
+	overdue := []domain.OverdueMilestone{}
+	if len(projects) == 0 {
+		return overdue, nil
+	}
+	ids := make([]string, len(projects))
+	names := make(map[string]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ProjectID
+		names[p.ProjectID] = p.ProjectName
+	}
+	milestones, err := u.milestoneRepo.FindByProjectIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range milestones {
+		if !m.IsOverdueAt(t, loc) {
+			continue
+		}
+		overdue = append(overdue, domain.OverdueMilestone{
+			MilestoneID: m.ID,
+			ProjectID:   m.ProjectID,
+			ProjectName: names[m.ProjectID],
+			Title:       m.Title,
+			DueDate:     *m.DueDate,
+		})
+	}
+	slices.SortStableFunc(overdue, func(a, b domain.OverdueMilestone) int {
+		return a.DueDate.StartIn(time.UTC).Compare(b.DueDate.StartIn(time.UTC))
+	})
+	return overdue, nil
+}
+
+// activityWeeklyStats lists the activity types that have minutes, in the catalogue's order,
+// followed by the minutes of the logs without an activity type.
+func activityWeeklyStats(activityTypes []*domain.ActivityType, minutesByActivityType map[string]int) []domain.ActivityWeeklyStats {
+	activities := []domain.ActivityWeeklyStats{}
+	for _, activityType := range activityTypes {
+		if minutes := minutesByActivityType[activityType.ID]; minutes > 0 {
+			activities = append(activities, domain.ActivityWeeklyStats{
+				ActivityTypeID:   activityType.ID,
+				ActivityTypeName: activityType.Name,
+				TotalMinutes:     minutes,
+			})
+		}
+	}
+	if minutes := minutesByActivityType[""]; minutes > 0 {
+		activities = append(activities, domain.ActivityWeeklyStats{TotalMinutes: minutes})
+	}
+	return activities
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -30,8 +31,11 @@ func TestGetWeeklyStats(t *testing.T) {
 		},
 	}
 
-	uc := usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo)
-	stats, err := uc.GetWeeklyStats(context.Background(), "u1", weekStart)
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+
+	uc := usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -79,3 +83,300 @@ func TestGetWeeklyStats(t *testing.T) {
 		}
 	}
 }
+
+func TestGetWeeklyStats_ArchivedProjects(t *testing.T) {
+	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	archivedAt := weekStart.Add(48 * time.Hour)
+
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+	projectRepo.projects["s2"] = &domain.Project{ID: "s2", UserID: "u1", Name: "English", ArchivedAt: &archivedAt}
+	projectRepo.projects["s3"] = &domain.Project{ID: "s3", UserID: "u1", Name: "History", ArchivedAt: &archivedAt}
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			{ID: "l1", UserID: "u1", ProjectID: "s2", StudiedAt: weekStart.Add(time.Hour), Minutes: 30},
+		},
+	}
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+	uc := usecase.NewStatsUsecase(studyLogRepo, &mockGoalRepository{}, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, ps := range stats.Projects {
+		names = append(names, ps.ProjectName)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"English", "Math"}) {
+		t.Errorf("expected the archived project without logs to be excluded, got %v", names)
+	}
+	if stats.TotalMinutes != 30 {
+		t.Errorf("expected total 30, got %d", stats.TotalMinutes)
+	}
+}
+
+func TestGetWeeklyStats_SubProjects(t *testing.T) {
+	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["aws"] = &domain.Project{ID: "aws", UserID: "u1", Name: "AWS certification"}
+	projectRepo.projects["iam"] = &domain.Project{ID: "iam", UserID: "u1", Name: "IAM", ParentID: "aws"}
+	projectRepo.projects["policies"] = &domain.Project{ID: "policies", UserID: "u1", Name: "Policies", ParentID: "iam"}
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			{ID: "l1", UserID: "u1", ProjectID: "aws", StudiedAt: weekStart.Add(time.Hour), Minutes: 30},
+			{ID: "l2", UserID: "u1", ProjectID: "iam", StudiedAt: weekStart.Add(3 * time.Hour), Minutes: 40, Pomodoros: 1},
+			{ID: "l3", UserID: "u1", ProjectID: "policies", StudiedAt: weekStart.Add(5 * time.Hour), Minutes: 50},
+		},
+	}
+	goalRepo := &mockGoalRepository{
+		goals: []*domain.Goal{{ID: "g1", UserID: "u1", ProjectID: "aws", TargetMinutesPerWeek: 240}},
+	}
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+	uc := usecase.NewStatsUsecase(studyLogRepo, goalRepo, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalMinutes != 120 {
+		t.Errorf("expected sub-project minutes to be counted once in the total, got %d", stats.TotalMinutes)
+	}
+	byID := make(map[string]domain.ProjectWeeklyStats)
+	for _, ps := range stats.Projects {
+		byID[ps.ProjectID] = ps
+	}
+	tests := []struct {
+		id                 string
+		own, subtree, poms int
+	}{
+		{"aws", 30, 120, 1},
+		{"iam", 40, 90, 1},
+		{"policies", 50, 50, 0},
+	}
+	for _, tt := range tests {
+		ps := byID[tt.id]
+		if ps.TotalMinutes != tt.own || ps.SubtreeMinutes != tt.subtree || ps.SubtreePomodoros != tt.poms {
+			t.Errorf("%s: expected own %d, subtree %d and %d subtree pomodoros, got %+v", tt.id, tt.own, tt.subtree, tt.poms, ps)
+		}
+	}
+	if byID["iam"].ParentID != "aws" {
+		t.Errorf("expected IAM's parent to be reported, got '%s'", byID["iam"].ParentID)
+	}
+	if byID["aws"].AchievementRate != 50.0 {
+		t.Errorf("expected the goal to count the subtree minutes (50%%), got %.1f%%", byID["aws"].AchievementRate)
+	}
+}
+
+func TestGetWeeklyStats_UserTimezone(t *testing.T) {
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			// Sunday 23:30 in Tokyo: belongs to the previous week there.
+			{ID: "l1", UserID: "u1", ProjectID: "s1", StudiedAt: time.Date(2024, 1, 7, 14, 30, 0, 0, time.UTC), Minutes: 60},
+			// Monday 00:30 in Tokyo, but still Sunday in UTC.
+			{ID: "l2", UserID: "u1", ProjectID: "s1", StudiedAt: time.Date(2024, 1, 7, 15, 30, 0, 0, time.UTC), Minutes: 30},
+		},
+	}
+	userRepo := newMockUserRepository()
+	userRepo.users["u1"] = &domain.User{ID: "u1", Preferences: domain.UserPreferences{Timezone: "Asia/Tokyo"}}
+	uc := usecase.NewStatsUsecase(studyLogRepo, &mockGoalRepository{}, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+
+	weekStart := domain.Date{Year: 2024, Month: time.January, Day: 8}
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", weekStart, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalMinutes != 30 {
+		t.Errorf("expected 30 minutes in the Tokyo week, got %d", stats.TotalMinutes)
+	}
+	if stats.Timezone != "Asia/Tokyo" {
+		t.Errorf("expected timezone Asia/Tokyo, got %s", stats.Timezone)
+	}
+
+	stats, err = uc.GetWeeklyStats(context.Background(), "u1", weekStart.AddDays(-1), "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalMinutes != 90 {
+		t.Errorf("expected 90 minutes when overridden to UTC, got %d", stats.TotalMinutes)
+	}
+}
+
+func TestGetWeeklyStats_DSTTransition(t *testing.T) {
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+	ny, _ := time.LoadLocation("America/New_York")
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			// DST starts on 2024-03-10, so the week is 167 hours long.
+			{ID: "l1", UserID: "u1", ProjectID: "s1", StudiedAt: time.Date(2024, 3, 16, 23, 30, 0, 0, ny), Minutes: 45},
+			{ID: "l2", UserID: "u1", ProjectID: "s1", StudiedAt: time.Date(2024, 3, 17, 0, 30, 0, 0, ny), Minutes: 20},
+		},
+	}
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+	uc := usecase.NewStatsUsecase(studyLogRepo, &mockGoalRepository{}, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.Date{Year: 2024, Month: time.March, Day: 10}, "America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalMinutes != 45 {
+		t.Errorf("expected only the Saturday log in the week, got %d minutes", stats.TotalMinutes)
+	}
+}
+
+func TestGetWeeklyStats_UnknownTimezone(t *testing.T) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+	uc := usecase.NewStatsUsecase(&mockStudyLogRepository{}, &mockGoalRepository{}, newMockProjectRepository(), userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), newMockProjectMemberRepository())
+
+	_, err := uc.GetWeeklyStats(context.Background(), "u1", domain.Date{Year: 2024, Month: time.January, Day: 1}, "Mars/Base")
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestGetWeeklyStats_Activities(t *testing.T) {
+	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
+
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+
+	activityTypeRepo := newMockActivityTypeRepository()
+	activityTypeRepo.activityTypes["a1"] = &domain.ActivityType{ID: "a1", UserID: "u1", Name: "Reading"}
+	activityTypeRepo.activityTypes["a2"] = &domain.ActivityType{ID: "a2", UserID: "u1", Name: "Exercises"}
+
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			{ID: "l1", UserID: "u1", ProjectID: "s1", ActivityTypeID: "a1", StudiedAt: weekStart.Add(1 * time.Hour), Minutes: 60},
+			{ID: "l2", UserID: "u1", ProjectID: "s1", ActivityTypeID: "a1", StudiedAt: weekStart.Add(25 * time.Hour), Minutes: 30},
+			{ID: "l3", UserID: "u1", ProjectID: "s1", ActivityTypeID: "a2", StudiedAt: weekStart.Add(49 * time.Hour), Minutes: 45},
+			{ID: "l4", UserID: "u1", ProjectID: "s1", StudiedAt: weekStart.Add(73 * time.Hour), Minutes: 15},
+		},
+	}
+
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+
+	uc := usecase.NewStatsUsecase(studyLogRepo, &mockGoalRepository{}, projectRepo, userRepo, activityTypeRepo, newMockMilestoneRepository(), newMockProjectMemberRepository())
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats.Projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(stats.Projects))
+	}
+
+	// Activity types come in catalogue (name) order, followed by the untyped minutes.
+	want := []domain.ActivityWeeklyStats{
+		{ActivityTypeID: "a2", ActivityTypeName: "Exercises", TotalMinutes: 45},
+		{ActivityTypeID: "a1", ActivityTypeName: "Reading", TotalMinutes: 90},
+		{TotalMinutes: 15},
+	}
+	got := stats.Projects[0].Activities
+	if len(got) != len(want) {
+		t.Fatalf("expected %d activities, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("activity %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestGetWeeklyStats_OverdueMilestones(t *testing.T) {
+	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+
+	date := func(day int) *domain.Date {
+		return &domain.Date{Year: 2024, Month: time.January, Day: day}
+	}
+	at := func(day int) *time.Time {
+		t := time.Date(2024, 1, day, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+	milestoneRepo := newMockMilestoneRepository()
+	for _, m := range []*domain.Milestone{
+		{ID: "late", Title: "Completed late", DueDate: date(5), CompletedAt: at(10)},
+		{ID: "open", Title: "Still open", DueDate: date(3), Position: 1},
+		{ID: "on-time", Title: "Completed on time", DueDate: date(3), CompletedAt: at(2), Position: 2},
+		{ID: "next-week", Title: "Due next week", DueDate: date(9), Position: 3},
+		{ID: "undated", Title: "No due date", Position: 4},
+	} {
+		m.ProjectID = "s1"
+		m.UserID = "u1"
+		milestoneRepo.milestones[m.ID] = m
+	}
+
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+
+	uc := usecase.NewStatsUsecase(&mockStudyLogRepository{}, &mockGoalRepository{}, projectRepo, userRepo, newMockActivityTypeRepository(), milestoneRepo, newMockProjectMemberRepository())
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, m := range stats.OverdueMilestones {
+		ids = append(ids, m.MilestoneID)
+	}
+	if !slices.Equal(ids, []string{"open", "late"}) {
+		t.Fatalf("expected overdue milestones [open late], got %v", ids)
+	}
+	if got := stats.OverdueMilestones[0]; got.ProjectName != "Math" || got.DueDate != *date(3) {
+		t.Errorf("unexpected overdue milestone: %+v", got)
+	}
+}
+
+func TestGetWeeklyStats_SharedProjects(t *testing.T) {
+	weekStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	acceptedAt := weekStart.Add(-time.Hour)
+
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["s1"] = &domain.Project{ID: "s1", UserID: "u1", Name: "Math"}
+	projectRepo.projects["s2"] = &domain.Project{ID: "s2", UserID: "u2", Name: "Study group", ParentID: "s0"}
+	projectRepo.projects["s3"] = &domain.Project{ID: "s3", UserID: "u2", Name: "Invited"}
+	memberRepo := newMockProjectMemberRepository()
+	memberRepo.members[projectMemberKey("s2", "u1")] = &domain.ProjectMember{ProjectID: "s2", UserID: "u1", Role: domain.ProjectRoleEditor, AcceptedAt: &acceptedAt}
+	memberRepo.members[projectMemberKey("s3", "u1")] = &domain.ProjectMember{ProjectID: "s3", UserID: "u1", Role: domain.ProjectRoleEditor}
+	studyLogRepo := &mockStudyLogRepository{
+		logs: []*domain.StudyLog{
+			{ID: "l1", UserID: "u1", ProjectID: "s1", StudiedAt: weekStart.Add(time.Hour), Minutes: 30},
+			{ID: "l2", UserID: "u1", ProjectID: "s2", StudiedAt: weekStart.Add(2 * time.Hour), Minutes: 45},
+			{ID: "l3", UserID: "u2", ProjectID: "s2", StudiedAt: weekStart.Add(3 * time.Hour), Minutes: 60},
+		},
+	}
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "u1", "Alice")
+
+	uc := usecase.NewStatsUsecase(studyLogRepo, &mockGoalRepository{}, projectRepo, userRepo, newMockActivityTypeRepository(), newMockMilestoneRepository(), memberRepo)
+	stats, err := uc.GetWeeklyStats(context.Background(), "u1", domain.DateOf(weekStart, time.UTC), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.TotalMinutes != 75 {
+		t.Errorf("expected total 75 counting only the user's own logs, got %d", stats.TotalMinutes)
+	}
+	if len(stats.Projects) != 2 {
+		t.Fatalf("expected the own and the joined project, got %+v", stats.Projects)
+	}
+	for _, p := range stats.Projects {
+		if p.ProjectID == "s2" && (p.TotalMinutes != 45 || p.ParentID != "") {
+			t.Errorf("expected the joined project at the top level with 45 minutes, got %+v", p)
+		}
+	}
+	if projectRepo.projects["s2"].ParentID != "s0" {
+		t.Error("expected the stored project to be left as it is")
+	}
+}
@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// MaxStudyLogImportRows limits how many data rows a single import may contain.
+const MaxStudyLogImportRows = 10000
+
+// StudyLogImportMapping names the CSV columns to read each field from. Column names are
+// matched against the header row without regard to case or surrounding spaces.
+type StudyLogImportMapping struct {
+	// Date holds the date, or the date and time, the session started.
+	Date string
+	// Time optionally holds the time of day when Date holds only a date.
+	Time string
+	// Duration holds whole minutes or hours and minutes (H:MM or HH:MM:SS).
+	Duration string
+	Project  string
+	Note     string
+	// ExternalID optionally holds a stable ID from the source system used to skip duplicates.
+	ExternalID string
+}
+
+// StudyLogImportOptions configures a CSV import.
+type StudyLogImportOptions struct {
+	Mapping StudyLogImportMapping
+	// Timezone applies to dates and times without a UTC offset; defaults to the user's timezone.
+	Timezone string
+	// DryRun validates every row and reports the result without saving anything.
+	DryRun bool
+}
+
+// StudyLogImportUsecase provides methods for importing study logs from other time trackers.
+type StudyLogImportUsecase struct {
+	studyLogRepo   port.StudyLogRepository
+	userRepo       port.UserRepository
+	projectUsecase *ProjectUsecase
+}
+
+// NewStudyLogImportUsecase creates a new StudyLogImportUsecase.
+func NewStudyLogImportUsecase(
+	studyLogRepo port.StudyLogRepository,
+	userRepo port.UserRepository,
+	projectUsecase *ProjectUsecase,
+) *StudyLogImportUsecase {
+	return &StudyLogImportUsecase{
+		studyLogRepo:   studyLogRepo,
+		userRepo:       userRepo,
+		projectUsecase: projectUsecase,
+	}
+}
+
+// importRow is a CSV row that has been parsed but not yet checked against existing data.
+type importRow struct {
+	line        int
+	studiedAt   time.Time
+	minutes     int
+	projectName string
+	note        string
+	importKey   string
+}
+
+// ImportCSV imports study logs from CSV data. Rows that fail to parse or validate are
+// reported individually and do not stop the import; rows that were already imported are skipped.
+func (u *StudyLogImportUsecase) ImportCSV(ctx context.Context, userID string, r io.Reader, opts StudyLogImportOptions) (*domain.StudyLogImportResult, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.StudyLogImportResult{DryRun: opts.DryRun, CreatedProjects: []string{}, Errors: []domain.StudyLogImportError{}}
+
+	rows, err := readImportRows(r, opts.Mapping, loc, result)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.importKey
+	}
+	existing, err := u.studyLogRepo.FindImportKeys(ctx, userID, keys)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, key := range existing {
+		seen[key] = true
+	}
+
+	projects, _, err := u.projectUsecase.ListProjects(ctx, userID, ProjectListFilter{}, PageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	index := newImportProjectIndex()
+	// Rows naming an archived project are reported as errors rather than creating a new project.
+	notRecordable := make(map[string]error)
+	for _, p := range projects {
+		// Logs are only imported into the user's own projects, never into projects shared with them.
+		if p.UserID != userID {
+			continue
+		}
+		index.add(p.Name, p.ID)
+		if err := p.CheckRecordable(); err != nil {
+			notRecordable[p.ID] = err
+		}
+	}
+
+	schedule, err := u.loadImportSchedule(ctx, userID, rows, loc)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if seen[row.importKey] {
+			result.Duplicates++
+			continue
+		}
+		projectID, err := u.importProjectID(ctx, userID, row.projectName, index, result)
+		if err == nil {
+			err = notRecordable[projectID]
+		}
+		if err != nil {
+			if !domain.IsValidation(err) && !domain.IsConflict(err) {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, domain.StudyLogImportError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		log, err := domain.NewStudyLog(uuid.New().String(), userID, projectID, row.studiedAt, row.minutes, row.note)
+		if err != nil {
+			result.Errors = append(result.Errors, domain.StudyLogImportError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		log.ImportKey = row.importKey
+		if err := schedule.check(log, loc); err != nil {
+			if !domain.IsConflict(err) {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, domain.StudyLogImportError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		seen[row.importKey] = true
+		if !opts.DryRun {
+			if err := u.studyLogRepo.Create(ctx, log); err != nil {
+				if domain.IsConflict(err) {
+					// Imported concurrently since the keys were checked.
+					result.Duplicates++
+					continue
+				}
+				return nil, err
+			}
+		}
+		schedule.add(log)
+		result.Imported++
+	}
+	slices.SortStableFunc(result.Errors, func(a, b domain.StudyLogImportError) int { return a.Line - b.Line })
+	return result, nil
+}
+
+// loadImportSchedule loads the user's logs that the rows must be checked against, in one query
+// covering the windows of all rows.
+func (u *StudyLogImportUsecase) loadImportSchedule(ctx context.Context, userID string, rows []importRow, loc *time.Location) (*studyLogSchedule, error) {
+	logs := make([]*domain.StudyLog, 0, len(rows))
+	for _, row := range rows {
+		logs = append(logs, &domain.StudyLog{StudiedAt: row.studiedAt, Minutes: row.minutes})
+	}
+	return loadStudyLogSchedule(ctx, u.studyLogRepo, userID, logs, loc)
+}
+
+// importProjectIndex finds projects by the names used in an import. Project names are unique per
+// user only as written, so "Go" and "go" can both exist.
+type importProjectIndex struct {
+	byName map[string]string
+	// byFoldedName holds the IDs of the projects whose names are equal when case is ignored.
+	byFoldedName map[string][]string
+}
+
+func newImportProjectIndex() *importProjectIndex {
+	return &importProjectIndex{byName: make(map[string]string), byFoldedName: make(map[string][]string)}
+}
+
+func (x *importProjectIndex) add(name, id string) {
+	x.byName[name] = id
+	folded := strings.ToLower(name)
+	x.byFoldedName[folded] = append(x.byFoldedName[folded], id)
+}
+
+// find returns the project with exactly the given name, or else the only project whose name
+// differs from it just in case. ok is false if no project matches.
+func (x *importProjectIndex) find(name string) (id string, ok bool, err error) {
+	if id, ok := x.byName[name]; ok {
+		return id, true, nil
+	}
+	switch ids := x.byFoldedName[strings.ToLower(name)]; len(ids) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return ids[0], true, nil
+	default:
+		return "", false, domain.ErrValidation("project " + strconv.Quote(name) + " matches more than one project when case is ignored; use the exact name")
+	}
+}
+
+// importProjectID finds the project with the given name, creating it if needed.
+// In a dry run the project is only recorded as one that would be created.
+func (u *StudyLogImportUsecase) importProjectID(ctx context.Context, userID, name string, index *importProjectIndex, result *domain.StudyLogImportResult) (string, error) {
+	id, ok, err := index.find(name)
+	if ok || err != nil {
+		return id, err
+	}
+	if result.DryRun {
+		if _, err := domain.NewProject("", userID, name); err != nil {
+			return "", err
+		}
+		id = "dry-run:" + name
+	} else {
+		project, err := u.projectUsecase.CreateProject(ctx, userID, name, "", domain.ProjectDetails{})
+		if err != nil {
+			return "", err
+		}
+		id = project.ID
+	}
+	index.add(name, id)
+	result.CreatedProjects = append(result.CreatedProjects, name)
+	return id, nil
+}
+
+// readImportRows parses and validates the CSV rows. Rows with errors are added to result.
+func readImportRows(r io.Reader, mapping StudyLogImportMapping, loc *time.Location, result *domain.StudyLogImportResult) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.ErrValidation("CSV file is empty")
+	}
+	if err != nil {
+		return nil, domain.ErrValidation("invalid CSV header: " + err.Error())
+	}
+	columns, err := mapImportColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		result.Rows++
+		if result.Rows > MaxStudyLogImportRows {
+			return nil, domain.ErrValidation(fmt.Sprintf("CSV file has more than %d rows", MaxStudyLogImportRows))
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Errors = append(result.Errors, domain.StudyLogImportError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		row, err := parseImportRow(record, columns, loc)
+		if err != nil {
+			result.Errors = append(result.Errors, domain.StudyLogImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		row.line = line
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// importColumns holds the index of each mapped column, or -1 when it is not mapped.
+type importColumns struct {
+	date, time, duration, project, note, externalID int
+}
+
+func mapImportColumns(header []string, mapping StudyLogImportMapping) (importColumns, error) {
+	normalized := make([]string, len(header))
+	for i, h := range header {
+		normalized[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+	find := func(name string, required bool) (int, error) {
+		if name == "" {
+			if required {
+				return -1, domain.ErrValidation("column mapping is incomplete")
+			}
+			return -1, nil
+		}
+		i := slices.Index(normalized, strings.ToLower(strings.TrimSpace(name)))
+		if i < 0 {
+			return -1, domain.ErrValidation("column not found in CSV header: " + name)
+		}
+		return i, nil
+	}
+
+	var c importColumns
+	var err error
+	if c.date, err = find(mapping.Date, true); err != nil {
+		return c, err
+	}
+	if c.time, err = find(mapping.Time, false); err != nil {
+		return c, err
+	}
+	if c.duration, err = find(mapping.Duration, true); err != nil {
+		return c, err
+	}
+	if c.project, err = find(mapping.Project, true); err != nil {
+		return c, err
+	}
+	if c.note, err = find(mapping.Note, false); err != nil {
+		return c, err
+	}
+	if c.externalID, err = find(mapping.ExternalID, false); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+func parseImportRow(record []string, c importColumns, loc *time.Location) (importRow, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	value := field(c.date)
+	if t := field(c.time); t != "" {
+		value += " " + t
+	}
+	studiedAt, err := parseImportTime(value, loc)
+	if err != nil {
+		return importRow{}, err
+	}
+	minutes, err := domain.ParseDurationMinutes(field(c.duration))
+	if err != nil {
+		return importRow{}, err
+	}
+	projectName := field(c.project)
+	if projectName == "" {
+		return importRow{}, domain.ErrValidation("project is required")
+	}
+	note := field(c.note)
+	return importRow{
+		studiedAt:   studiedAt,
+		minutes:     minutes,
+		projectName: projectName,
+		note:        note,
+		importKey:   domain.StudyLogImportKey(field(c.externalID), studiedAt, minutes, projectName, note),
+	}, nil
+}
+
+// importTimeLayouts are tried in order; the first group carries a UTC offset.
+var (
+	importTimeLayoutsWithOffset = []string{time.RFC3339, "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04Z07:00"}
+	importTimeLayoutsLocal      = []string{
+		"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04",
+		"2006/01/02 15:04:05", "2006/01/02 15:04", "2006-01-02", "2006/01/02",
+	}
+)
+
+func parseImportTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, domain.ErrValidation("date is required")
+	}
+	for _, layout := range importTimeLayoutsWithOffset {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range importTimeLayoutsLocal {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, domain.ErrValidation("invalid date: " + value)
+}
@@ -0,0 +1,276 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupStudyLogImportTest() (*usecase.StudyLogImportUsecase, *mockProjectRepository, *mockStudyLogRepository) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	studyLogRepo := newMockStudyLogRepository()
+	projectUC := usecase.NewProjectUsecase(projectRepo, userRepo, newMockMilestoneRepository(), newMockGoalRepository(), mockTokenManager{})
+	return usecase.NewStudyLogImportUsecase(studyLogRepo, userRepo, projectUC), projectRepo, studyLogRepo
+}
+
+func defaultImportOptions() usecase.StudyLogImportOptions {
+	return usecase.StudyLogImportOptions{
+		Mapping: usecase.StudyLogImportMapping{Date: "date", Duration: "minutes", Project: "project"},
+	}
+}
+
+const importCSV = "date,minutes,project,note\n" +
+	"2024-01-15 09:00,60,Math,limits\n" +
+	"2024-01-16 10:00,30,physics,\n" +
+	"2024-01-17 11:00,45,Physics,waves\n"
+
+func TestStudyLogImport_ImportCSV(t *testing.T) {
+	uc, projectRepo, studyLogRepo := setupStudyLogImportTest()
+	opts := defaultImportOptions()
+	opts.Mapping.Note = "note"
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(importCSV), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Rows != 3 || result.Imported != 3 || result.Duplicates != 0 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.CreatedProjects) != 1 || result.CreatedProjects[0] != "physics" {
+		t.Errorf("expected project 'physics' to be created once, got %v", result.CreatedProjects)
+	}
+	if len(projectRepo.projects) != 2 {
+		t.Errorf("expected 2 projects, got %d", len(projectRepo.projects))
+	}
+	if len(studyLogRepo.logs) != 3 {
+		t.Fatalf("expected 3 study logs, got %d", len(studyLogRepo.logs))
+	}
+	first := studyLogRepo.logs[0]
+	if first.ProjectID != "proj-1" || first.Minutes != 60 || first.Note != "limits" {
+		t.Errorf("unexpected study log: %+v", first)
+	}
+	if !first.StudiedAt.Equal(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the user's timezone (UTC), got %v", first.StudiedAt)
+	}
+	if studyLogRepo.logs[1].ProjectID != studyLogRepo.logs[2].ProjectID {
+		t.Error("expected project names to match without regard to case")
+	}
+}
+
+func TestStudyLogImport_ReimportSkipsDuplicates(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	if _, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(importCSV), defaultImportOptions()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(importCSV), defaultImportOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 0 || result.Duplicates != 3 {
+		t.Errorf("expected 3 duplicates and nothing imported, got %+v", result)
+	}
+	if len(result.CreatedProjects) != 0 {
+		t.Errorf("expected no projects to be created, got %v", result.CreatedProjects)
+	}
+	if len(studyLogRepo.logs) != 3 {
+		t.Errorf("expected 3 study logs, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestStudyLogImport_DuplicateRowsInFile(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	csv := "date,minutes,project\n2024-01-15 09:00,60,Math\n2024-01-15 09:00,60,Math\n"
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), defaultImportOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 1 || result.Duplicates != 1 {
+		t.Errorf("expected 1 imported and 1 duplicate, got %+v", result)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 study log, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestStudyLogImport_ExternalID(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	opts := defaultImportOptions()
+	opts.Mapping.ExternalID = "id"
+	first := "id,date,minutes,project\n1,2024-01-15 09:00,60,Math\n"
+	edited := "id,date,minutes,project\n1,2024-01-15 09:00,75,Math\n2,2024-01-16 09:00,30,Math\n"
+
+	if _, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(first), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(edited), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 1 || result.Duplicates != 1 {
+		t.Errorf("expected 1 imported and 1 duplicate, got %+v", result)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected 2 study logs, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestStudyLogImport_DryRun(t *testing.T) {
+	uc, projectRepo, studyLogRepo := setupStudyLogImportTest()
+	opts := defaultImportOptions()
+	opts.DryRun = true
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(importCSV), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.DryRun || result.Imported != 3 {
+		t.Errorf("expected a dry run reporting 3 imports, got %+v", result)
+	}
+	if len(result.CreatedProjects) != 1 {
+		t.Errorf("expected 1 project to be reported, got %v", result.CreatedProjects)
+	}
+	if len(studyLogRepo.logs) != 0 || len(projectRepo.projects) != 1 {
+		t.Error("expected a dry run to save nothing")
+	}
+}
+
+func TestStudyLogImport_RowErrors(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	csv := "date,minutes,project\n" +
+		"2024-01-15 09:00,60,Math\n" +
+		"yesterday,60,Math\n" +
+		"2024-01-16 09:00,abc,Math\n" +
+		"2024-01-17 09:00,0,Math\n" +
+		"2024-01-18 09:00,30,\n" +
+		"2024-01-19 09:00,30,Math\n"
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), defaultImportOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Rows != 6 || result.Imported != 2 {
+		t.Errorf("expected 6 rows and 2 imported, got %+v", result)
+	}
+	if len(result.Errors) != 4 {
+		t.Fatalf("expected 4 errors, got %v", result.Errors)
+	}
+	for i, want := range []int{3, 4, 5, 6} {
+		if result.Errors[i].Line != want {
+			t.Errorf("expected error %d on line %d, got line %d", i, want, result.Errors[i].Line)
+		}
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected 2 study logs, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestStudyLogImport_SeparateTimeColumnAndTimezone(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	opts := usecase.StudyLogImportOptions{
+		Mapping:  usecase.StudyLogImportMapping{Date: "Start date", Time: "Start time", Duration: "Duration", Project: "Project"},
+		Timezone: "Asia/Tokyo",
+	}
+	csv := "\ufeffProject,Start date,Start time,Duration\nMath,2024-01-15,09:00:00,01:30:00\n"
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Fatalf("expected 1 imported, got %+v", result)
+	}
+	log := studyLogRepo.logs[0]
+	if log.Minutes != 90 {
+		t.Errorf("expected 90 minutes, got %d", log.Minutes)
+	}
+	if !log.StudiedAt.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 09:00 in Tokyo, got %v", log.StudiedAt.UTC())
+	}
+}
+
+func TestStudyLogImport_MissingColumn(t *testing.T) {
+	uc, _, _ := setupStudyLogImportTest()
+	csv := "date,duration,project\n2024-01-15 09:00,60,Math\n"
+
+	_, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), defaultImportOptions())
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestStudyLogImport_UserNotFound(t *testing.T) {
+	uc, _, _ := setupStudyLogImportTest()
+
+	_, err := uc.ImportCSV(context.Background(), "nonexistent", strings.NewReader(importCSV), defaultImportOptions())
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestStudyLogImport_ProjectNameCase(t *testing.T) {
+	uc, projectRepo, studyLogRepo := setupStudyLogImportTest()
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-1", Name: "Go"}
+	projectRepo.projects["proj-3"] = &domain.Project{ID: "proj-3", UserID: "user-1", Name: "go"}
+	csv := "date,minutes,project\n" +
+		"2024-01-15 09:00,60,Go\n" +
+		"2024-01-16 09:00,60,go\n" +
+		"2024-01-17 09:00,60,GO\n" +
+		"2024-01-18 09:00,60,MATH\n"
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), defaultImportOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 3 || len(result.CreatedProjects) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Line != 4 {
+		t.Errorf("expected the ambiguous name on line 4 to be rejected, got %v", result.Errors)
+	}
+	for i, want := range []string{"proj-2", "proj-3", "proj-1"} {
+		if got := studyLogRepo.logs[i].ProjectID; got != want {
+			t.Errorf("log %d: expected project %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestStudyLogImport_ScheduleConflicts(t *testing.T) {
+	uc, _, studyLogRepo := setupStudyLogImportTest()
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Minutes: 60,
+	})
+	csv := "date,minutes,project\n" +
+		"2024-01-15 09:30,30,Math\n" + // overlaps the saved log
+		"2024-01-16 09:00,60,Math\n" +
+		"2024-01-16 09:30,30,Math\n" + // overlaps the row above
+		"2024-01-17 00:00,1000,Math\n" +
+		"2024-01-17 20:00,600,Math\n" // over the daily limit
+
+	result, err := uc.ImportCSV(context.Background(), "user-1", strings.NewReader(csv), defaultImportOptions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 2 {
+		t.Errorf("expected 2 imported, got %+v", result)
+	}
+	if len(result.Errors) != 3 {
+		t.Fatalf("expected 3 errors, got %v", result.Errors)
+	}
+	for i, want := range []int{2, 4, 6} {
+		if result.Errors[i].Line != want {
+			t.Errorf("expected error %d on line %d, got line %d", i, want, result.Errors[i].Line)
+		}
+	}
+	if studyLogRepo.findCalls != 1 {
+		t.Errorf("expected the saved logs to be loaded once, got %d queries", studyLogRepo.findCalls)
+	}
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,9 +15,12 @@ import (
 
 // StudyLogUsecase provides methods for managing study logs.
 type StudyLogUsecase struct {
-	studyLogRepo port.StudyLogRepository
-	userRepo     port.UserRepository
-	projectRepo  port.ProjectRepository
+	studyLogRepo     port.StudyLogRepository
+	userRepo         port.UserRepository
+	projectRepo      port.ProjectRepository
+	activityTypeRepo port.ActivityTypeRepository
+	noteLinkRepo     port.NoteLinkRepository
+	memberRepo       port.ProjectMemberRepository
 }
 
 // NewStudyLogUsecase creates a new StudyLogUsecase.
@@ -22,25 +28,39 @@ func NewStudyLogUsecase(
 	studyLogRepo port.StudyLogRepository,
 	userRepo port.UserRepository,
 	projectRepo port.ProjectRepository,
+	activityTypeRepo port.ActivityTypeRepository,
+	noteLinkRepo port.NoteLinkRepository,
+	memberRepo port.ProjectMemberRepository,
 ) *StudyLogUsecase {
 	return &StudyLogUsecase{
-		studyLogRepo: studyLogRepo,
-		userRepo:     userRepo,
-		projectRepo:  projectRepo,
+		studyLogRepo:     studyLogRepo,
+		userRepo:         userRepo,
+		projectRepo:      projectRepo,
+		activityTypeRepo: activityTypeRepo,
+		noteLinkRepo:     noteLinkRepo,
+		memberRepo:       memberRepo,
 	}
 }
 
-// CreateStudyLog creates a new study log.
-func (u *StudyLogUsecase) CreateStudyLog(ctx context.Context, userID, projectID string, studiedAt time.Time, minutes int, note string) (*domain.StudyLog, error) {
-	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+// CreateStudyLog creates a new study log in a project the user owns or has joined as an editor.
+// The log must not overlap the user's other logs. activityTypeID may be empty; otherwise it must be
+// one of the user's activity types.
+func (u *StudyLogUsecase) CreateStudyLog(ctx context.Context, userID, projectID, activityTypeID string, studiedAt time.Time, minutes int, note string) (*domain.StudyLog, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
 		return nil, err
 	}
-	project, err := u.projectRepo.FindByID(ctx, projectID)
+	project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
 	if err != nil {
 		return nil, err
 	}
-	if project.UserID != userID {
-		return nil, domain.ErrNotFound("project")
+	if err := project.CheckRecordable(); err != nil {
+		return nil, err
+	}
+	if activityTypeID != "" {
+		if _, err := findOwnedActivityType(ctx, u.activityTypeRepo, userID, activityTypeID); err != nil {
+			return nil, err
+		}
 	}
 
 	id := uuid.New().String()
@@ -48,24 +68,517 @@ func (u *StudyLogUsecase) CreateStudyLog(ctx context.Context, userID, projectID
 	if err != nil {
 		return nil, err
 	}
+	log.ActivityTypeID = activityTypeID
+	if err := checkStudyLogSchedule(ctx, u.studyLogRepo, log, loc, nil); err != nil {
+		return nil, err
+	}
 	if err := u.studyLogRepo.Create(ctx, log); err != nil {
 		return nil, err
 	}
 	return log, nil
 }
 
-// ListStudyLogs returns all study logs for a user based on filters.
-func (u *StudyLogUsecase) ListStudyLogs(ctx context.Context, userID string, filter port.StudyLogFilter) ([]*domain.StudyLog, error) {
-	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+// MaxStudyLogBatchSize limits how many study logs a single batch may create.
+const MaxStudyLogBatchSize = 100
+
+// StudyLogInput holds the fields of a study log to create.
+type StudyLogInput struct {
+	ProjectID string
+	// ActivityTypeID may be left empty.
+	ActivityTypeID string
+	StudiedAt      time.Time
+	// Minutes may be left zero when EndedAt is set.
+	Minutes int
+	EndedAt *time.Time
+	Note    string
+}
+
+// CreateStudyLogs creates several study logs at once, returning one result per input in the same order.
+// When atomic is set, the logs are created in one transaction and any invalid input fails the whole batch;
+// otherwise every valid input is created and the others are reported in their results.
+func (u *StudyLogUsecase) CreateStudyLogs(ctx context.Context, userID string, inputs []StudyLogInput, atomic bool) ([]domain.StudyLogBatchResult, error) {
+	if len(inputs) == 0 || len(inputs) > MaxStudyLogBatchSize {
+		return nil, domain.ErrValidation(fmt.Sprintf("a batch must contain between 1 and %d study logs", MaxStudyLogBatchSize))
+	}
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.StudyLogBatchResult, len(inputs))
+	logs := make([]*domain.StudyLog, 0, len(inputs))
+	ownerErrs := make(map[string]error)
+	for i, input := range inputs {
+		log, err := u.newBatchStudyLog(ctx, userID, input, loc, logs, ownerErrs)
+		if err != nil {
+			var domErr *domain.Error
+			if !errors.As(err, &domErr) {
+				return nil, err
+			}
+			if atomic {
+				return nil, &domain.Error{Type: domErr.Type, Message: fmt.Sprintf("logs[%d]: %s", i, domErr.Message)}
+			}
+			results[i].Err = domErr
+			continue
+		}
+		results[i].Log = log
+		logs = append(logs, log)
+	}
+
+	if atomic {
+		if err := u.studyLogRepo.CreateBatch(ctx, logs); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	for i, result := range results {
+		if result.Log == nil {
+			continue
+		}
+		if err := u.studyLogRepo.Create(ctx, result.Log); err != nil {
+			var domErr *domain.Error
+			if !errors.As(err, &domErr) {
+				return nil, err
+			}
+			results[i] = domain.StudyLogBatchResult{Err: domErr}
+		}
+	}
+	return results, nil
+}
+
+// newBatchStudyLog validates one input of a batch against the saved logs and the valid inputs before it.
+// The outcome of each ownership check is kept in ownerErrs by project or activity type ID, so a batch
+// looks up every project and activity type only once.
+func (u *StudyLogUsecase) newBatchStudyLog(ctx context.Context, userID string, input StudyLogInput, loc *time.Location, pending []*domain.StudyLog, ownerErrs map[string]error) (*domain.StudyLog, error) {
+	minutes, err := domain.StudyLogMinutes(input.StudiedAt, input.Minutes, input.EndedAt)
+	if err != nil {
+		return nil, err
+	}
+	log, err := domain.NewStudyLog(uuid.New().String(), userID, input.ProjectID, input.StudiedAt, minutes, input.Note)
+	if err != nil {
+		return nil, err
+	}
+	log.ActivityTypeID = input.ActivityTypeID
+	projectErr, checked := ownerErrs[input.ProjectID]
+	if !checked {
+		project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, input.ProjectID)
+		switch {
+		case err == nil:
+			projectErr = project.CheckRecordable()
+		case domain.IsNotFound(err) || domain.IsForbidden(err):
+			projectErr = err
+		default:
+			return nil, err
+		}
+		ownerErrs[input.ProjectID] = projectErr
+	}
+	if projectErr != nil {
+		return nil, projectErr
+	}
+	if input.ActivityTypeID != "" {
+		activityTypeErr, checked := ownerErrs[input.ActivityTypeID]
+		if !checked {
+			_, activityTypeErr = findOwnedActivityType(ctx, u.activityTypeRepo, userID, input.ActivityTypeID)
+			if activityTypeErr != nil && !domain.IsNotFound(activityTypeErr) {
+				return nil, activityTypeErr
+			}
+			ownerErrs[input.ActivityTypeID] = activityTypeErr
+		}
+		if activityTypeErr != nil {
+			return nil, activityTypeErr
+		}
+	}
+	if err := checkStudyLogSchedule(ctx, u.studyLogRepo, log, loc, pending); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// StudyLogListFilter selects study logs by calendar dates in the user's timezone.
+type StudyLogListFilter struct {
+	From *domain.Date
+	// To is inclusive: logs on that whole day are returned.
+	To             *domain.Date
+	ProjectID      *string
+	ActivityTypeID *string
+	// Timezone overrides the user's preferred timezone when set.
+	Timezone string
+}
+
+// studyLogSortKeys are the keys study logs can be sorted by; they are sorted newest first by default.
+var studyLogSortKeys = []string{domain.SortByStudiedAt}
+
+// ListStudyLogs returns a page of a user's study logs that match the filter and the cursor of the next page.
+func (u *StudyLogUsecase) ListStudyLogs(ctx context.Context, userID string, filter StudyLogListFilter, opts PageOptions) ([]*domain.StudyLog, string, error) {
+	page, err := pageRequest(opts, studyLogSortKeys, domain.SortOrder{Key: domain.SortByStudiedAt, Descending: true})
+	if err != nil {
+		return nil, "", err
+	}
+	loc, err := userLocation(ctx, u.userRepo, userID, filter.Timezone)
+	if err != nil {
+		return nil, "", err
+	}
+	repoFilter := port.StudyLogFilter{ProjectID: filter.ProjectID, ActivityTypeID: filter.ActivityTypeID}
+	if filter.From != nil {
+		from := filter.From.StartIn(loc)
+		repoFilter.From = &from
+	}
+	if filter.To != nil {
+		to := filter.To.AddDays(1).StartIn(loc)
+		repoFilter.To = &to
+	}
+	logs, err := u.studyLogRepo.FindByUserID(ctx, userID, repoFilter, page)
+	if err != nil {
+		return nil, "", err
+	}
+	logs, next := nextPage(logs, page, func(l *domain.StudyLog) domain.Cursor {
+		return domain.Cursor{Time: l.StudiedAt, ID: l.ID}
+	})
+	if err := u.attachLinkedNotes(ctx, logs...); err != nil {
+		return nil, "", err
+	}
+	return logs, next, nil
+}
+
+// StudyLogChanges holds the fields to change in a study log; nil fields are left as they are.
+type StudyLogChanges struct {
+	ProjectID *string
+	// ActivityTypeID set to an empty string removes the log's activity type.
+	ActivityTypeID *string
+	StudiedAt      *time.Time
+	Minutes        *int
+	// EndedAt sets the minutes to the time between the (changed) start and this end time.
+	EndedAt *time.Time
+	Note    *string
+}
+
+// UpdateStudyLog edits a study log that belongs to the user, keeping a revision with its previous values.
+// Nothing is saved when the changes leave the log as it is.
+func (u *StudyLogUsecase) UpdateStudyLog(ctx context.Context, userID, id string, changes StudyLogChanges) (*domain.StudyLog, error) {
+	log, err := u.findOwnStudyLog(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	projectID, activityTypeID, studiedAt, minutes, note := log.ProjectID, log.ActivityTypeID, log.StudiedAt, log.Minutes, log.Note
+	if changes.ProjectID != nil {
+		projectID = *changes.ProjectID
+	}
+	if changes.ActivityTypeID != nil {
+		activityTypeID = *changes.ActivityTypeID
+	}
+	if changes.StudiedAt != nil {
+		studiedAt = *changes.StudiedAt
+	}
+	if changes.Minutes != nil {
+		minutes = *changes.Minutes
+	}
+	if changes.EndedAt != nil {
+		given := 0
+		if changes.Minutes != nil {
+			given = *changes.Minutes
+		}
+		if minutes, err = domain.StudyLogMinutes(studiedAt, given, changes.EndedAt); err != nil {
+			return nil, err
+		}
+	}
+	if changes.Note != nil {
+		note = *changes.Note
+	}
+	log, err = u.editStudyLog(ctx, userID, log, projectID, activityTypeID, studiedAt, minutes, note)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachLinkedNotes(ctx, log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// ListStudyLogRevisions returns the revisions of a study log that belongs to the user, newest first.
+func (u *StudyLogUsecase) ListStudyLogRevisions(ctx context.Context, userID, id string) ([]*domain.StudyLogRevision, error) {
+	if _, err := u.findOwnStudyLog(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return u.studyLogRepo.FindRevisions(ctx, id)
+}
+
+// RevertStudyLog restores the values a study log had before the given revision's edit.
+// The revert is itself recorded as a revision, so it can be undone.
+func (u *StudyLogUsecase) RevertStudyLog(ctx context.Context, userID, id, revisionID string) (*domain.StudyLog, error) {
+	log, err := u.findOwnStudyLog(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := u.studyLogRepo.FindRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if revision.StudyLogID != log.ID {
+		return nil, domain.ErrNotFound("study log revision")
+	}
+	log, err = u.editStudyLog(ctx, userID, log, revision.ProjectID, revision.ActivityTypeID, revision.StudiedAt, revision.Minutes, revision.Note)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachLinkedNotes(ctx, log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (u *StudyLogUsecase) editStudyLog(ctx context.Context, userID string, log *domain.StudyLog, projectID, activityTypeID string, studiedAt time.Time, minutes int, note string) (*domain.StudyLog, error) {
+	if projectID == log.ProjectID && activityTypeID == log.ActivityTypeID && studiedAt.Equal(log.StudiedAt) && minutes == log.Minutes && note == log.Note {
+		return log, nil
+	}
+	if projectID != log.ProjectID {
+		project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if err := project.CheckRecordable(); err != nil {
+			return nil, err
+		}
+	}
+	if activityTypeID != log.ActivityTypeID && activityTypeID != "" {
+		if _, err := findOwnedActivityType(ctx, u.activityTypeRepo, userID, activityTypeID); err != nil {
+			return nil, err
+		}
+	}
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	revision := domain.NewStudyLogRevision(uuid.New().String(), log, userID)
+	if err := log.Update(projectID, activityTypeID, studiedAt, minutes, note); err != nil {
+		return nil, err
+	}
+	if err := checkStudyLogSchedule(ctx, u.studyLogRepo, log, loc, nil); err != nil {
+		return nil, err
+	}
+	if err := u.studyLogRepo.Update(ctx, log, revision); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (u *StudyLogUsecase) findOwnStudyLog(ctx context.Context, userID, id string) (*domain.StudyLog, error) {
+	log, err := u.studyLogRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if log.UserID != userID {
+		return nil, domain.ErrNotFound("study log")
+	}
+	return log, nil
+}
+
+// attachLinkedNotes fills in the notes linked to each of the logs.
+func (u *StudyLogUsecase) attachLinkedNotes(ctx context.Context, logs ...*domain.StudyLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(logs))
+	for i, l := range logs {
+		ids[i] = l.ID
+	}
+	links, err := u.noteLinkRepo.FindNotesByStudyLogIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, l := range logs {
+		l.Notes = links[l.ID]
+	}
+	return nil
+}
+
+// DeleteStudyLog moves a study log owned by the user to the trash.
+func (u *StudyLogUsecase) DeleteStudyLog(ctx context.Context, userID, id string) error {
+	if _, err := u.findOwnStudyLog(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.studyLogRepo.Trash(ctx, id, time.Now())
+}
+
+// RestoreStudyLog takes a trashed study log owned by the user out of the trash. Like a new log, it
+// must not overlap the user's other logs. A log in a trashed project comes back with the project instead.
+func (u *StudyLogUsecase) RestoreStudyLog(ctx context.Context, userID, id string) (*domain.StudyLog, error) {
+	log, err := u.studyLogRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if log.UserID != userID {
+		return nil, domain.ErrNotFound("study log")
+	}
+	if err := checkProjectNotTrashed(ctx, u.projectRepo, log.ProjectID); err != nil {
+		return nil, err
+	}
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
 		return nil, err
 	}
-	return u.studyLogRepo.FindByUserID(ctx, userID, filter)
+	if err := checkStudyLogSchedule(ctx, u.studyLogRepo, log, loc, nil); err != nil {
+		return nil, err
+	}
+	if err := u.studyLogRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	log.DeletedAt = nil
+	if err := u.attachLinkedNotes(ctx, log); err != nil {
+		return nil, err
+	}
+	return log, nil
 }
 
-// DeleteStudyLog deletes a study log by ID.
-func (u *StudyLogUsecase) DeleteStudyLog(ctx context.Context, id string) error {
-	if _, err := u.studyLogRepo.FindByID(ctx, id); err != nil {
+// checkStudyLogSchedule rejects a log that overlaps another log of the user, or that would bring the
+// minutes of the logs starting on its day in loc over domain.MaxStudyMinutesPerDay. pending holds logs
+// of the same request that may not have been saved yet.
+func checkStudyLogSchedule(ctx context.Context, studyLogRepo port.StudyLogRepository, log *domain.StudyLog, loc *time.Location, pending []*domain.StudyLog) error {
+	from, to := studyLogScheduleWindow(log, loc)
+	saved, err := studyLogRepo.FindByUserID(ctx, log.UserID, port.StudyLogFilter{From: &from, To: &to}, port.PageRequest{})
+	if err != nil {
 		return err
 	}
-	return u.studyLogRepo.Delete(ctx, id)
+	return newStudyLogSchedule(append(saved, pending...)).check(log, loc)
+}
+
+// freeMinutes returns how many of the given minutes from studiedAt the user can record: a log
+// starting then is cut short where the user's next log starts and at the daily limit.
+func (u *StudyLogUsecase) freeMinutes(ctx context.Context, userID string, studiedAt time.Time, minutes int) (int, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID, "")
+	if err != nil {
+		return 0, err
+	}
+	log := &domain.StudyLog{UserID: userID, StudiedAt: studiedAt, Minutes: minutes}
+	from, to := studyLogScheduleWindow(log, loc)
+	saved, err := u.studyLogRepo.FindByUserID(ctx, userID, port.StudyLogFilter{From: &from, To: &to}, port.PageRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return newStudyLogSchedule(saved).fit(log, loc), nil
+}
+
+// loadStudyLogSchedule loads the user's logs that the given logs must be checked against, in one
+// query covering the windows of all of them.
+func loadStudyLogSchedule(ctx context.Context, studyLogRepo port.StudyLogRepository, userID string, logs []*domain.StudyLog, loc *time.Location) (*studyLogSchedule, error) {
+	if len(logs) == 0 {
+		return newStudyLogSchedule(nil), nil
+	}
+	var from, to time.Time
+	for i, log := range logs {
+		logFrom, logTo := studyLogScheduleWindow(log, loc)
+		if i == 0 || logFrom.Before(from) {
+			from = logFrom
+		}
+		if i == 0 || logTo.After(to) {
+			to = logTo
+		}
+	}
+	saved, err := studyLogRepo.FindByUserID(ctx, userID, port.StudyLogFilter{From: &from, To: &to}, port.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return newStudyLogSchedule(saved), nil
+}
+
+// studyLogScheduleWindow returns the range of start times of the logs that log must be checked against.
+func studyLogScheduleWindow(log *domain.StudyLog, loc *time.Location) (from, to time.Time) {
+	day := domain.DateOf(log.StudiedAt, loc)
+	from, to = day.StartIn(loc), day.AddDays(1).StartIn(loc)
+	// Logs are at most 1440 minutes long, so a log that overlaps this one starts less than a day before it.
+	if earliest := log.StudiedAt.Add(-24 * time.Hour); earliest.Before(from) {
+		from = earliest
+	}
+	if end := log.EndedAt(); end.After(to) {
+		to = end
+	}
+	return from, to
+}
+
+// studyLogSchedule holds a user's logs ordered by start time, so that many logs can be checked
+// against logs loaded once.
+type studyLogSchedule struct {
+	logs []*domain.StudyLog
+	ids  map[string]bool
+}
+
+func newStudyLogSchedule(logs []*domain.StudyLog) *studyLogSchedule {
+	s := &studyLogSchedule{ids: make(map[string]bool, len(logs))}
+	for _, log := range logs {
+		if s.ids[log.ID] {
+			continue
+		}
+		s.ids[log.ID] = true
+		s.logs = append(s.logs, log)
+	}
+	slices.SortFunc(s.logs, func(a, b *domain.StudyLog) int { return a.StudiedAt.Compare(b.StudiedAt) })
+	return s
+}
+
+// add records a log that has been accepted, so that later logs are checked against it.
+func (s *studyLogSchedule) add(log *domain.StudyLog) {
+	if s.ids[log.ID] {
+		return
+	}
+	s.ids[log.ID] = true
+	i, _ := slices.BinarySearchFunc(s.logs, log.StudiedAt, func(l *domain.StudyLog, t time.Time) int { return l.StudiedAt.Compare(t) })
+	s.logs = slices.Insert(s.logs, i, log)
+}
+
+// fit returns how many minutes of log, keeping its start, can be recorded without overlapping the
+// logs of the schedule or exceeding the daily limit. It is zero if another log covers the start.
+func (s *studyLogSchedule) fit(log *domain.StudyLog, loc *time.Location) int {
+	day := domain.DateOf(log.StudiedAt, loc)
+	dayStart, dayEnd := day.StartIn(loc), day.AddDays(1).StartIn(loc)
+	from, to := studyLogScheduleWindow(log, loc)
+	i, _ := slices.BinarySearchFunc(s.logs, from, func(l *domain.StudyLog, t time.Time) int { return l.StudiedAt.Compare(t) })
+
+	minutes, dayTotal := log.Minutes, 0
+	for _, other := range s.logs[i:] {
+		if !other.StudiedAt.Before(to) {
+			break
+		}
+		if other.ID == log.ID {
+			continue
+		}
+		if other.StudiedAt.After(log.StudiedAt) {
+			minutes = min(minutes, int(other.StudiedAt.Sub(log.StudiedAt)/time.Minute))
+		} else if other.EndedAt().After(log.StudiedAt) {
+			return 0
+		}
+		if !other.StudiedAt.Before(dayStart) && other.StudiedAt.Before(dayEnd) {
+			dayTotal += other.Minutes
+		}
+	}
+	return max(0, min(minutes, domain.MaxStudyMinutesPerDay-dayTotal))
+}
+
+// check applies the rules of checkStudyLogSchedule against the logs of the schedule. The schedule
+// must hold every log of the user starting in studyLogScheduleWindow(log, loc).
+func (s *studyLogSchedule) check(log *domain.StudyLog, loc *time.Location) error {
+	day := domain.DateOf(log.StudiedAt, loc)
+	dayStart, dayEnd := day.StartIn(loc), day.AddDays(1).StartIn(loc)
+	from, to := studyLogScheduleWindow(log, loc)
+	i, _ := slices.BinarySearchFunc(s.logs, from, func(l *domain.StudyLog, t time.Time) int { return l.StudiedAt.Compare(t) })
+
+	total := log.Minutes
+	for _, other := range s.logs[i:] {
+		if !other.StudiedAt.Before(to) {
+			break
+		}
+		if other.ID == log.ID {
+			continue
+		}
+		if other.Overlaps(log) {
+			return domain.ErrConflict(fmt.Sprintf("study log overlaps another study log from %s to %s",
+				other.StudiedAt.In(loc).Format(time.RFC3339), other.EndedAt().In(loc).Format(time.RFC3339)))
+		}
+		if !other.StudiedAt.Before(dayStart) && other.StudiedAt.Before(dayEnd) {
+			total += other.Minutes
+		}
+	}
+	if total > domain.MaxStudyMinutesPerDay {
+		return domain.ErrConflict(fmt.Sprintf("study logs on %s would total %d minutes, more than the daily limit of %d",
+			day, total, domain.MaxStudyMinutesPerDay))
+	}
+	return nil
 }
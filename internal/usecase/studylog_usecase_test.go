@@ -7,14 +7,13 @@ import (
 
 	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
-	"github.com/shnaki/studytrack-api/internal/usecase/port"
 )
 
 func setupStudyLogTest() (*usecase.StudyLogUsecase, *mockUserRepository, *mockProjectRepository, *mockStudyLogRepository) {
 	userRepo := newMockUserRepository()
 	projectRepo := newMockProjectRepository()
 	studyLogRepo := newMockStudyLogRepository()
-	uc := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo)
+	uc := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, newMockActivityTypeRepository(), newMockNoteLinkRepository(newMockNoteRepository(), studyLogRepo), newMockProjectMemberRepository())
 	return uc, userRepo, projectRepo, studyLogRepo
 }
 
@@ -24,7 +23,7 @@ func TestCreateStudyLog_Success(t *testing.T) {
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
 
 	now := time.Now()
-	log, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", now, 60, "chapter 3")
+	log, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", now, 60, "chapter 3")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,7 +48,7 @@ func TestCreateStudyLog_UserNotFound(t *testing.T) {
 	uc, _, projectRepo, _ := setupStudyLogTest()
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
 
-	_, err := uc.CreateStudyLog(context.Background(), "nonexistent", "proj-1", time.Now(), 60, "")
+	_, err := uc.CreateStudyLog(context.Background(), "nonexistent", "proj-1", "", time.Now(), 60, "")
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -62,7 +61,7 @@ func TestCreateStudyLog_ProjectNotFound(t *testing.T) {
 	uc, userRepo, _, _ := setupStudyLogTest()
 	createTestUser(userRepo, "user-1", "Alice")
 
-	_, err := uc.CreateStudyLog(context.Background(), "user-1", "nonexistent", time.Now(), 60, "")
+	_, err := uc.CreateStudyLog(context.Background(), "user-1", "nonexistent", "", time.Now(), 60, "")
 	if err == nil {
 		t.Fatal("expected error for nonexistent project")
 	}
@@ -77,7 +76,7 @@ func TestCreateStudyLog_ProjectBelongsToDifferentUser(t *testing.T) {
 	createTestUser(userRepo, "user-2", "Bob")
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-2", Name: "Math"}
 
-	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 60, "")
+	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "")
 	if err == nil {
 		t.Fatal("expected error when project belongs to different user")
 	}
@@ -86,13 +85,35 @@ func TestCreateStudyLog_ProjectBelongsToDifferentUser(t *testing.T) {
 	}
 }
 
+func TestCreateStudyLog_ArchivedProject(t *testing.T) {
+	uc, userRepo, projectRepo, _ := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	archivedAt := time.Now()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math", ArchivedAt: &archivedAt}
+
+	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "")
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict for an archived project, got: %v", err)
+	}
+
+	results, err := uc.CreateStudyLogs(context.Background(), "user-1", []usecase.StudyLogInput{
+		{ProjectID: "proj-1", StudiedAt: time.Now(), Minutes: 60},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !domain.IsConflict(results[0].Err) {
+		t.Errorf("expected conflict for an archived project in a batch, got: %v", results[0].Err)
+	}
+}
+
 func TestCreateStudyLog_InvalidMinutes(t *testing.T) {
 	uc, userRepo, projectRepo, _ := setupStudyLogTest()
 	createTestUser(userRepo, "user-1", "Alice")
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
 
 	// Zero minutes
-	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 0, "")
+	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 0, "")
 	if err == nil {
 		t.Fatal("expected error for zero minutes")
 	}
@@ -101,7 +122,7 @@ func TestCreateStudyLog_InvalidMinutes(t *testing.T) {
 	}
 
 	// Negative minutes
-	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), -5, "")
+	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), -5, "")
 	if err == nil {
 		t.Fatal("expected error for negative minutes")
 	}
@@ -110,7 +131,7 @@ func TestCreateStudyLog_InvalidMinutes(t *testing.T) {
 	}
 
 	// Over 1440 minutes
-	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 1441, "")
+	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 1441, "")
 	if err == nil {
 		t.Fatal("expected error for > 1440 minutes")
 	}
@@ -119,21 +140,127 @@ func TestCreateStudyLog_InvalidMinutes(t *testing.T) {
 	}
 }
 
+func TestCreateStudyLog_Overlap(t *testing.T) {
+	uc, userRepo, projectRepo, _ := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", start, 480, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", start.Add(4*time.Hour), 480, ""); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error for an overlapping log, got: %v", err)
+	}
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", start.Add(8*time.Hour), 60, ""); err != nil {
+		t.Errorf("expected a log starting as the other ends to be accepted, got: %v", err)
+	}
+}
+
+func TestCreateStudyLog_DailyLimit(t *testing.T) {
+	uc, userRepo, projectRepo, _ := setupStudyLogTest()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice", Preferences: domain.UserPreferences{Timezone: "Asia/Tokyo"}}
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Date(2024, 1, 15, 0, 0, 0, 0, tokyo), 1000, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Starts on the same day in Tokyo and runs into the next day, so the day would total 1500 minutes.
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Date(2024, 1, 15, 22, 0, 0, 0, tokyo), 500, ""); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error over the daily limit, got: %v", err)
+	}
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Date(2024, 1, 15, 22, 0, 0, 0, tokyo), 440, ""); err != nil {
+		t.Errorf("expected a log up to the daily limit to be accepted, got: %v", err)
+	}
+}
+
+func TestCreateStudyLogs_Atomic(t *testing.T) {
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	now := time.Now()
+
+	results, err := uc.CreateStudyLogs(context.Background(), "user-1", []usecase.StudyLogInput{
+		{ProjectID: "proj-1", StudiedAt: now, Minutes: 30},
+		{ProjectID: "proj-1", StudiedAt: now.Add(time.Hour), Minutes: 45},
+	}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Log == nil || results[1].Log == nil {
+		t.Fatalf("expected 2 created logs, got %+v", results)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected 2 saved logs, got %d", len(studyLogRepo.logs))
+	}
+
+	_, err = uc.CreateStudyLogs(context.Background(), "user-1", []usecase.StudyLogInput{
+		{ProjectID: "proj-1", StudiedAt: now.Add(2 * time.Hour), Minutes: 30},
+		{ProjectID: "proj-1", StudiedAt: now.Add(3 * time.Hour), Minutes: 0},
+	}, true)
+	if !domain.IsValidation(err) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+	if len(studyLogRepo.logs) != 2 {
+		t.Errorf("expected no logs saved from the failed batch, got %d", len(studyLogRepo.logs)-2)
+	}
+}
+
+func TestCreateStudyLogs_BestEffort(t *testing.T) {
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-2", Name: "Other"}
+	now := time.Now()
+
+	results, err := uc.CreateStudyLogs(context.Background(), "user-1", []usecase.StudyLogInput{
+		{ProjectID: "proj-1", StudiedAt: now, Minutes: 30},
+		{ProjectID: "proj-2", StudiedAt: now.Add(time.Hour), Minutes: 30},
+		{ProjectID: "proj-1", StudiedAt: now.Add(2 * time.Hour), Minutes: 2000},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Log == nil || results[0].Err != nil {
+		t.Errorf("expected the first log to be created, got %+v", results[0])
+	}
+	if !domain.IsNotFound(results[1].Err) {
+		t.Errorf("expected not found error for another user's project, got: %v", results[1].Err)
+	}
+	if !domain.IsValidation(results[2].Err) {
+		t.Errorf("expected validation error, got: %v", results[2].Err)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 saved log, got %d", len(studyLogRepo.logs))
+	}
+}
+
+func TestCreateStudyLogs_TooMany(t *testing.T) {
+	uc, userRepo, _, _ := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+
+	inputs := make([]usecase.StudyLogInput, usecase.MaxStudyLogBatchSize+1)
+	if _, err := uc.CreateStudyLogs(context.Background(), "user-1", inputs, false); !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
 func TestListStudyLogs_Success(t *testing.T) {
 	uc, userRepo, projectRepo, _ := setupStudyLogTest()
 	createTestUser(userRepo, "user-1", "Alice")
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
 
-	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 60, "session 1")
+	_, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "session 1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 30, "session 2")
+	_, err = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now().Add(-2*time.Hour), 30, "session 2")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	logs, err := uc.ListStudyLogs(context.Background(), "user-1", port.StudyLogFilter{})
+	logs, _, err := uc.ListStudyLogs(context.Background(), "user-1", usecase.StudyLogListFilter{}, usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -152,17 +279,17 @@ func TestListStudyLogs_WithFilter(t *testing.T) {
 	day2 := time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)
 	day3 := time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)
 
-	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", day1, 60, "day1 math")
-	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-2", day2, 45, "day2 english")
-	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", day3, 30, "day3 math")
+	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", day1, 60, "day1 math")
+	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-2", "", day2, 45, "day2 english")
+	_, _ = uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", day3, 30, "day3 math")
 
-	// Filter by date range: from day2 to day3 (exclusive)
-	from := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
-	to := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
-	logs, err := uc.ListStudyLogs(context.Background(), "user-1", port.StudyLogFilter{
+	// Filter by date range: Jan 4 to Jan 7 (inclusive) contains only day2
+	from := domain.Date{Year: 2024, Month: time.January, Day: 4}
+	to := domain.Date{Year: 2024, Month: time.January, Day: 7}
+	logs, _, err := uc.ListStudyLogs(context.Background(), "user-1", usecase.StudyLogListFilter{
 		From: &from,
 		To:   &to,
-	})
+	}, usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -172,9 +299,9 @@ func TestListStudyLogs_WithFilter(t *testing.T) {
 
 	// Filter by project
 	projectID := "proj-1"
-	logs, err = uc.ListStudyLogs(context.Background(), "user-1", port.StudyLogFilter{
+	logs, _, err = uc.ListStudyLogs(context.Background(), "user-1", usecase.StudyLogListFilter{
 		ProjectID: &projectID,
-	})
+	}, usecase.PageOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -188,26 +315,68 @@ func TestDeleteStudyLog_Success(t *testing.T) {
 	createTestUser(userRepo, "user-1", "Alice")
 	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
 
-	created, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", time.Now(), 60, "")
+	created, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	err = uc.DeleteStudyLog(context.Background(), created.ID)
+	err = uc.DeleteStudyLog(context.Background(), "user-1", created.ID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Verify deleted
+	// Verify moved to the trash
 	if len(studyLogRepo.logs) != 0 {
 		t.Errorf("expected 0 logs after deletion, got %d", len(studyLogRepo.logs))
 	}
+	if len(studyLogRepo.trashed) != 1 {
+		t.Errorf("expected 1 log in the trash, got %d", len(studyLogRepo.trashed))
+	}
+}
+
+func TestRestoreStudyLog_Success(t *testing.T) {
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	created, _ := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "")
+	_ = uc.DeleteStudyLog(context.Background(), "user-1", created.ID)
+
+	restored, err := uc.RestoreStudyLog(context.Background(), "user-1", created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("expected restored log not to have a deletion time")
+	}
+	if len(studyLogRepo.logs) != 1 || len(studyLogRepo.trashed) != 0 {
+		t.Errorf("expected the log to be back, got %d logs and %d trashed", len(studyLogRepo.logs), len(studyLogRepo.trashed))
+	}
+}
+
+func TestRestoreStudyLog_Overlap(t *testing.T) {
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	studiedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	created, _ := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", studiedAt, 60, "")
+	_ = uc.DeleteStudyLog(context.Background(), "user-1", created.ID)
+	if _, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", studiedAt.Add(30*time.Minute), 60, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := uc.RestoreStudyLog(context.Background(), "user-1", created.ID)
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if len(studyLogRepo.trashed) != 1 {
+		t.Error("expected the log to stay in the trash")
+	}
 }
 
 func TestDeleteStudyLog_NotFound(t *testing.T) {
 	uc, _, _, _ := setupStudyLogTest()
 
-	err := uc.DeleteStudyLog(context.Background(), "nonexistent")
+	err := uc.DeleteStudyLog(context.Background(), "user-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent study log")
 	}
@@ -215,3 +384,177 @@ func TestDeleteStudyLog_NotFound(t *testing.T) {
 		t.Errorf("expected not found error, got: %v", err)
 	}
 }
+
+func TestDeleteStudyLog_OwnedByAnotherUser(t *testing.T) {
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+
+	created, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Now(), 60, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = uc.DeleteStudyLog(context.Background(), "user-2", created.ID)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error for another user's log, got: %v", err)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected log to remain, got %d logs", len(studyLogRepo.logs))
+	}
+}
+
+func setupStudyLogUpdateTest(t *testing.T) (*usecase.StudyLogUsecase, *mockProjectRepository, *mockStudyLogRepository, *domain.StudyLog) {
+	t.Helper()
+	uc, userRepo, projectRepo, studyLogRepo := setupStudyLogTest()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	log, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), 60, "chapter 3")
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	return uc, projectRepo, studyLogRepo, log
+}
+
+func TestUpdateStudyLog_Success(t *testing.T) {
+	uc, projectRepo, studyLogRepo, log := setupStudyLogUpdateTest(t)
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-1", Name: "Physics"}
+	createdAt := log.CreatedAt
+	minutes, projectID := 45, "proj-2"
+
+	updated, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{ProjectID: &projectID, Minutes: &minutes})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.ID != log.ID || !updated.CreatedAt.Equal(createdAt) {
+		t.Error("expected ID and CreatedAt to be kept")
+	}
+	if updated.ProjectID != "proj-2" || updated.Minutes != 45 || updated.Note != "chapter 3" {
+		t.Errorf("unexpected study log: %+v", updated)
+	}
+	if len(studyLogRepo.revisions) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(studyLogRepo.revisions))
+	}
+	rev := studyLogRepo.revisions[0]
+	if rev.EditedBy != "user-1" || rev.ProjectID != "proj-1" || rev.Minutes != 60 {
+		t.Errorf("expected the revision to hold the previous values, got %+v", rev)
+	}
+}
+
+func TestUpdateStudyLog_EndedAt(t *testing.T) {
+	uc, _, _, log := setupStudyLogUpdateTest(t)
+	endedAt := log.StudiedAt.Add(150 * time.Minute)
+
+	updated, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{EndedAt: &endedAt})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Minutes != 150 || !updated.EndedAt().Equal(endedAt) {
+		t.Errorf("expected 150 minutes ending at %v, got %d minutes ending at %v", endedAt, updated.Minutes, updated.EndedAt())
+	}
+}
+
+func TestUpdateStudyLog_Overlap(t *testing.T) {
+	uc, _, _, log := setupStudyLogUpdateTest(t)
+	other, err := uc.CreateStudyLog(context.Background(), "user-1", "proj-1", "", log.EndedAt(), 60, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	minutes := 90
+
+	if _, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{Minutes: &minutes}); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	studiedAt := other.StudiedAt.Add(-30 * time.Minute)
+	minutes = 30
+	if _, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{StudiedAt: &studiedAt, Minutes: &minutes}); err != nil {
+		t.Errorf("expected a log not to conflict with its own previous time, got: %v", err)
+	}
+}
+
+func TestUpdateStudyLog_NoChanges(t *testing.T) {
+	uc, _, studyLogRepo, log := setupStudyLogUpdateTest(t)
+	minutes := 60
+
+	if _, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{Minutes: &minutes}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(studyLogRepo.revisions) != 0 {
+		t.Errorf("expected no revision for an unchanged log, got %d", len(studyLogRepo.revisions))
+	}
+}
+
+func TestUpdateStudyLog_Invalid(t *testing.T) {
+	uc, _, studyLogRepo, log := setupStudyLogUpdateTest(t)
+	minutes := 0
+
+	_, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{Minutes: &minutes})
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+	if len(studyLogRepo.revisions) != 0 {
+		t.Error("expected no revision for a rejected edit")
+	}
+}
+
+func TestUpdateStudyLog_OtherUsersProject(t *testing.T) {
+	uc, projectRepo, _, log := setupStudyLogUpdateTest(t)
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-2", Name: "Physics"}
+	projectID := "proj-2"
+
+	_, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{ProjectID: &projectID})
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestUpdateStudyLog_OtherUser(t *testing.T) {
+	uc, _, _, log := setupStudyLogUpdateTest(t)
+	note := "hijacked"
+
+	_, err := uc.UpdateStudyLog(context.Background(), "user-2", log.ID, usecase.StudyLogChanges{Note: &note})
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestRevertStudyLog(t *testing.T) {
+	uc, _, _, log := setupStudyLogUpdateTest(t)
+	first, second := "typo", "fixed"
+	if _, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{Note: &first}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.UpdateStudyLog(context.Background(), "user-1", log.ID, usecase.StudyLogChanges{Note: &second}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revisions, err := uc.ListStudyLogRevisions(context.Background(), "user-1", log.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Note != "typo" || revisions[1].Note != "chapter 3" {
+		t.Fatalf("expected revisions newest first, got %+v", revisions)
+	}
+
+	reverted, err := uc.RevertStudyLog(context.Background(), "user-1", log.ID, revisions[1].ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reverted.Note != "chapter 3" {
+		t.Errorf("expected the original note, got '%s'", reverted.Note)
+	}
+	revisions, _ = uc.ListStudyLogRevisions(context.Background(), "user-1", log.ID)
+	if len(revisions) != 3 || revisions[0].Note != "fixed" {
+		t.Errorf("expected the revert to be recorded as a revision, got %+v", revisions)
+	}
+}
+
+func TestRevertStudyLog_RevisionOfOtherLog(t *testing.T) {
+	uc, _, studyLogRepo, log := setupStudyLogUpdateTest(t)
+	studyLogRepo.revisions = append(studyLogRepo.revisions, &domain.StudyLogRevision{ID: "rev-1", StudyLogID: "other-log", ProjectID: "proj-1", Minutes: 30})
+
+	_, err := uc.RevertStudyLog(context.Background(), "user-1", log.ID, "rev-1")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,554 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// TakeoutSchemaVersion is the version of the takeout archive layout recorded in its manifest.
+// Bump it whenever a file is added, removed or changes shape.
+const TakeoutSchemaVersion = 3
+
+// takeoutPageSize is the number of study logs read at a time while they are written to the archive.
+const takeoutPageSize = 500
+
+// TakeoutUsecase provides methods for exporting everything a user owns.
+type TakeoutUsecase struct {
+	userRepo         port.UserRepository
+	projectRepo      port.ProjectRepository
+	studyLogRepo     port.StudyLogRepository
+	goalRepo         port.GoalRepository
+	noteRepo         port.NoteRepository
+	activityTypeRepo port.ActivityTypeRepository
+	noteLinkRepo     port.NoteLinkRepository
+	milestoneRepo    port.MilestoneRepository
+}
+
+// NewTakeoutUsecase creates a new TakeoutUsecase.
+func NewTakeoutUsecase(
+	userRepo port.UserRepository,
+	projectRepo port.ProjectRepository,
+	studyLogRepo port.StudyLogRepository,
+	goalRepo port.GoalRepository,
+	noteRepo port.NoteRepository,
+	activityTypeRepo port.ActivityTypeRepository,
+	noteLinkRepo port.NoteLinkRepository,
+	milestoneRepo port.MilestoneRepository,
+) *TakeoutUsecase {
+	return &TakeoutUsecase{
+		userRepo:         userRepo,
+		projectRepo:      projectRepo,
+		studyLogRepo:     studyLogRepo,
+		goalRepo:         goalRepo,
+		noteRepo:         noteRepo,
+		activityTypeRepo: activityTypeRepo,
+		noteLinkRepo:     noteLinkRepo,
+		milestoneRepo:    milestoneRepo,
+	}
+}
+
+// Takeout is a personal data export for one user that is ready to be written.
+type Takeout struct {
+	uc         *TakeoutUsecase
+	user       *domain.User
+	exportedAt time.Time
+}
+
+// PrepareTakeout checks that the user exists so that errors can be reported before
+// any part of the archive is sent.
+func (u *TakeoutUsecase) PrepareTakeout(ctx context.Context, userID string) (*Takeout, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &Takeout{uc: u, user: user, exportedAt: time.Now()}, nil
+}
+
+// ExportedAt returns when the export was started.
+func (t *Takeout) ExportedAt() time.Time {
+	return t.exportedAt
+}
+
+type takeoutManifest struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	ExportedAt    time.Time             `json:"exportedAt"`
+	UserID        string                `json:"userId"`
+	Timezone      string                `json:"timezone"`
+	Files         []takeoutManifestFile `json:"files"`
+}
+
+type takeoutManifestFile struct {
+	Path    string `json:"path"`
+	Format  string `json:"format"`
+	Records int    `json:"records"`
+}
+
+type takeoutProfile struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Email            string                 `json:"email"`
+	TwoFactorEnabled bool                   `json:"twoFactorEnabled"`
+	Preferences      takeoutUserPreferences `json:"preferences"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+type takeoutUserPreferences struct {
+	Timezone              string `json:"timezone"`
+	WeekStart             string `json:"weekStart"`
+	Locale                string `json:"locale"`
+	DefaultSessionMinutes int    `json:"defaultSessionMinutes"`
+}
+
+type takeoutProject struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	ParentID    string     `json:"parentId,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Color       string     `json:"color,omitempty"`
+	Icon        string     `json:"icon,omitempty"`
+	Status      string     `json:"status"`
+	TargetDate  string     `json:"targetDate,omitempty"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type takeoutActivityType struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type takeoutStudyLog struct {
+	ID             string    `json:"id"`
+	ProjectID      string    `json:"projectId"`
+	ActivityTypeID *string   `json:"activityTypeId"`
+	StudiedAt      time.Time `json:"studiedAt"`
+	Minutes        int       `json:"minutes"`
+	Note           string    `json:"note"`
+	Pomodoros      int       `json:"pomodoros"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type takeoutGoal struct {
+	ID                   string    `json:"id"`
+	ProjectID            string    `json:"projectId"`
+	TargetMinutesPerWeek int       `json:"targetMinutesPerWeek"`
+	StartDate            string    `json:"startDate"`
+	EndDate              *string   `json:"endDate"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+type takeoutMilestone struct {
+	ID          string                 `json:"id"`
+	ProjectID   string                 `json:"projectId"`
+	Title       string                 `json:"title"`
+	DueDate     *string                `json:"dueDate"`
+	Position    int                    `json:"position"`
+	CompletedAt *time.Time             `json:"completedAt"`
+	Items       []takeoutChecklistItem `json:"items"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+type takeoutChecklistItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+type takeoutNote struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"projectId"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Tags        []string  `json:"tags"`
+	StudyLogIDs []string  `json:"studyLogIds"`
+	Path        string    `json:"path"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WriteTo writes the archive one file at a time. Study logs are read a page at a time and each
+// page is encoded before the next is read, and notes are loaded one project at a time, so the
+// archive is never held in memory.
+// The manifest is written last because it records how many records each file holds.
+func (t *Takeout) WriteTo(ctx context.Context, archive port.ArchiveWriter) error {
+	user := t.user
+	loc := user.Preferences.Location()
+	manifest := takeoutManifest{
+		SchemaVersion: TakeoutSchemaVersion,
+		ExportedAt:    t.exportedAt,
+		UserID:        user.ID,
+		Timezone:      loc.String(),
+	}
+	addFile := func(path, format string, records int) {
+		manifest.Files = append(manifest.Files, takeoutManifestFile{Path: path, Format: format, Records: records})
+	}
+
+	profile := takeoutProfile{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		TwoFactorEnabled: user.TwoFactor.TOTPEnabled,
+		Preferences: takeoutUserPreferences{
+			Timezone:              user.Preferences.Timezone,
+			WeekStart:             strings.ToLower(user.Preferences.WeekStart.String()),
+			Locale:                user.Preferences.Locale,
+			DefaultSessionMinutes: user.Preferences.DefaultSessionMinutes,
+		},
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	if err := writeTakeoutJSON(archive, "profile.json", profile); err != nil {
+		return err
+	}
+	addFile("profile.json", "json", 1)
+
+	projects, err := t.uc.projectRepo.FindByUserID(ctx, user.ID, port.ProjectFilter{}, port.PageRequest{})
+	if err != nil {
+		return err
+	}
+	projectNames := make(map[string]string, len(projects))
+	projectRecords := make([]takeoutProject, len(projects))
+	for i, p := range projects {
+		projectNames[p.ID] = p.Name
+		projectRecords[i] = takeoutProject{
+			ID:          p.ID,
+			Name:        p.Name,
+			ParentID:    p.ParentID,
+			Description: p.Description,
+			Color:       p.Color,
+			Icon:        p.Icon,
+			Status:      string(p.Status),
+			ArchivedAt:  p.ArchivedAt,
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
+		}
+		if p.TargetDate != nil {
+			projectRecords[i].TargetDate = p.TargetDate.String()
+		}
+	}
+	if err := writeTakeoutJSON(archive, "projects.json", projectRecords); err != nil {
+		return err
+	}
+	addFile("projects.json", "json", len(projectRecords))
+
+	activityTypes, err := t.uc.activityTypeRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	activityTypeNames := make(map[string]string, len(activityTypes))
+	activityTypeRecords := make([]takeoutActivityType, len(activityTypes))
+	for i, a := range activityTypes {
+		activityTypeNames[a.ID] = a.Name
+		activityTypeRecords[i] = takeoutActivityType{ID: a.ID, Name: a.Name, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt}
+	}
+	if err := writeTakeoutJSON(archive, "activity_types.json", activityTypeRecords); err != nil {
+		return err
+	}
+	addFile("activity_types.json", "json", len(activityTypeRecords))
+
+	records, err := t.writeStudyLogsJSON(ctx, archive, "study_logs.json")
+	if err != nil {
+		return err
+	}
+	addFile("study_logs.json", "json", records)
+	records, err = t.writeStudyLogsCSV(ctx, archive, "study_logs.csv", projectNames, activityTypeNames, loc)
+	if err != nil {
+		return err
+	}
+	addFile("study_logs.csv", "csv", records)
+
+	goals, err := t.uc.goalRepo.FindByUserID(ctx, user.ID, port.PageRequest{})
+	if err != nil {
+		return err
+	}
+	goalRecords := make([]takeoutGoal, len(goals))
+	for i, g := range goals {
+		goalRecords[i] = takeoutGoal{
+			ID:                   g.ID,
+			ProjectID:            g.ProjectID,
+			TargetMinutesPerWeek: g.TargetMinutesPerWeek,
+			StartDate:            g.StartDate.Format(domain.DateLayout),
+			CreatedAt:            g.CreatedAt,
+			UpdatedAt:            g.UpdatedAt,
+		}
+		if g.EndDate != nil {
+			endDate := g.EndDate.Format(domain.DateLayout)
+			goalRecords[i].EndDate = &endDate
+		}
+	}
+	if err := writeTakeoutJSON(archive, "goals.json", goalRecords); err != nil {
+		return err
+	}
+	addFile("goals.json", "json", len(goalRecords))
+
+	projectIDs := make([]string, len(projects))
+	for i, p := range projects {
+		projectIDs[i] = p.ID
+	}
+	milestones, err := t.uc.milestoneRepo.FindByProjectIDs(ctx, projectIDs)
+	if err != nil {
+		return err
+	}
+	milestoneRecords := make([]takeoutMilestone, len(milestones))
+	for i, m := range milestones {
+		items := make([]takeoutChecklistItem, len(m.Items))
+		for j, item := range m.Items {
+			items[j] = takeoutChecklistItem{ID: item.ID, Title: item.Title, Done: item.Done}
+		}
+		milestoneRecords[i] = takeoutMilestone{
+			ID:          m.ID,
+			ProjectID:   m.ProjectID,
+			Title:       m.Title,
+			Position:    m.Position,
+			CompletedAt: m.CompletedAt,
+			Items:       items,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+		}
+		if m.DueDate != nil {
+			dueDate := m.DueDate.String()
+			milestoneRecords[i].DueDate = &dueDate
+		}
+	}
+	if err := writeTakeoutJSON(archive, "milestones.json", milestoneRecords); err != nil {
+		return err
+	}
+	addFile("milestones.json", "json", len(milestoneRecords))
+
+	var noteRecords []takeoutNote
+	for _, p := range projects {
+		notes, err := t.uc.noteRepo.FindByProjectID(ctx, p.ID, port.PageRequest{})
+		if err != nil {
+			return err
+		}
+		if err := attachLinkedStudyLogs(ctx, t.uc.noteLinkRepo, notes...); err != nil {
+			return err
+		}
+		for _, n := range notes {
+			path := "notes/" + takeoutFileName(p.Name) + "/" + takeoutFileName(n.Title) + "-" + shortID(n.ID) + ".md"
+			if err := writeTakeoutNoteMarkdown(archive, path, n, p.Name); err != nil {
+				return err
+			}
+			addFile(path, "markdown", 1)
+			studyLogIDs := make([]string, len(n.StudyLogs))
+			for i, l := range n.StudyLogs {
+				studyLogIDs[i] = l.ID
+			}
+			noteRecords = append(noteRecords, takeoutNote{
+				ID:          n.ID,
+				ProjectID:   n.ProjectID,
+				Title:       n.Title,
+				Content:     n.Content,
+				Tags:        n.Tags,
+				StudyLogIDs: studyLogIDs,
+				Path:        path,
+				CreatedAt:   n.CreatedAt,
+				UpdatedAt:   n.UpdatedAt,
+			})
+		}
+	}
+	if noteRecords == nil {
+		noteRecords = []takeoutNote{}
+	}
+	if err := writeTakeoutJSON(archive, "notes.json", noteRecords); err != nil {
+		return err
+	}
+	addFile("notes.json", "json", len(noteRecords))
+
+	return writeTakeoutJSON(archive, "manifest.json", manifest)
+}
+
+func writeTakeoutJSON(archive port.ArchiveWriter, path string, v any) error {
+	w, err := archive.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// forEachStudyLogPage reads the user's study logs a page at a time, newest first, and passes
+// each page to fn before the next one is read.
+func (t *Takeout) forEachStudyLogPage(ctx context.Context, fn func([]*domain.StudyLog) error) error {
+	page := port.PageRequest{Limit: takeoutPageSize, Sort: domain.SortOrder{Key: domain.SortByStudiedAt, Descending: true}}
+	for {
+		logs, err := t.uc.studyLogRepo.FindByUserID(ctx, t.user.ID, port.StudyLogFilter{}, page)
+		if err != nil {
+			return err
+		}
+		if err := fn(logs); err != nil {
+			return err
+		}
+		if len(logs) < takeoutPageSize {
+			return nil
+		}
+		last := logs[len(logs)-1]
+		page.After = &domain.Cursor{Sort: page.Sort.String(), Time: last.StudiedAt, ID: last.ID}
+	}
+}
+
+// writeStudyLogsJSON encodes the logs one by one instead of marshaling a single slice,
+// and returns how many were written.
+func (t *Takeout) writeStudyLogsJSON(ctx context.Context, archive port.ArchiveWriter, path string) (int, error) {
+	w, err := archive.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("create %s: %w", path, err)
+	}
+	if _, err := io.WriteString(w, "["); err != nil {
+		return 0, fmt.Errorf("write %s: %w", path, err)
+	}
+	records := 0
+	err = t.forEachStudyLogPage(ctx, func(logs []*domain.StudyLog) error {
+		for _, l := range logs {
+			sep := ",\n"
+			if records == 0 {
+				sep = "\n"
+			}
+			record := takeoutStudyLog{
+				ID:        l.ID,
+				ProjectID: l.ProjectID,
+				StudiedAt: l.StudiedAt,
+				Minutes:   l.Minutes,
+				Note:      l.Note,
+				Pomodoros: l.Pomodoros,
+				CreatedAt: l.CreatedAt,
+				UpdatedAt: l.UpdatedAt,
+			}
+			if l.ActivityTypeID != "" {
+				record.ActivityTypeID = &l.ActivityTypeID
+			}
+			b, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+			if _, err := io.WriteString(w, sep+"  "+string(b)); err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+			records++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.WriteString(w, "\n]\n"); err != nil {
+		return 0, fmt.Errorf("write %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// writeStudyLogsCSV writes the logs with times in the user's timezone, for spreadsheets,
+// and returns how many were written.
+func (t *Takeout) writeStudyLogsCSV(ctx context.Context, archive port.ArchiveWriter, path string, projectNames, activityTypeNames map[string]string, loc *time.Location) (int, error) {
+	w, err := archive.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("create %s: %w", path, err)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "project_id", "project_name", "studied_at", "minutes", "note", "created_at", "activity_type"}); err != nil {
+		return 0, fmt.Errorf("write %s: %w", path, err)
+	}
+	records := 0
+	err = t.forEachStudyLogPage(ctx, func(logs []*domain.StudyLog) error {
+		for _, l := range logs {
+			record := []string{
+				l.ID,
+				l.ProjectID,
+				projectNames[l.ProjectID],
+				l.StudiedAt.In(loc).Format(time.RFC3339),
+				strconv.Itoa(l.Minutes),
+				l.Note,
+				l.CreatedAt.In(loc).Format(time.RFC3339),
+				activityTypeNames[l.ActivityTypeID],
+			}
+			if err := cw.Write(record); err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+			records++
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return records, nil
+}
+
+// writeTakeoutNoteMarkdown writes a note as Markdown with its metadata in YAML front matter.
+func writeTakeoutNoteMarkdown(archive port.ArchiveWriter, path string, n *domain.Note, projectName string) error {
+	w, err := archive.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	tags := make([]string, len(n.Tags))
+	for i, tag := range n.Tags {
+		tags[i] = strconv.Quote(tag)
+	}
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %s\n", n.ID)
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(n.Title))
+	fmt.Fprintf(&b, "project: %s\n", strconv.Quote(projectName))
+	fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(tags, ", "))
+	fmt.Fprintf(&b, "createdAt: %s\n", n.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "updatedAt: %s\n", n.UpdatedAt.Format(time.RFC3339))
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "# %s\n\n", n.Title)
+	b.WriteString(n.Content)
+	if !strings.HasSuffix(n.Content, "\n") {
+		b.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// takeoutFileName makes a name safe to use as a single path element on common file systems.
+func takeoutFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return "untitled"
+	}
+	if r := []rune(name); len(r) > 80 {
+		name = string(r[:80])
+	}
+	return name
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupTakeoutTest() *usecase.TakeoutUsecase {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{
+		ID:          "user-1",
+		Name:        "Alice",
+		Email:       "alice@example.com",
+		Preferences: domain.UserPreferences{Timezone: "Asia/Tokyo", WeekStart: time.Monday, Locale: "ja", DefaultSessionMinutes: 60},
+	}
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math/Physics"}
+	studyLogRepo := newMockStudyLogRepository()
+	studyLogRepo.logs = append(studyLogRepo.logs,
+		&domain.StudyLog{ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC), Minutes: 60, Note: "chapter 1, part 2"},
+		&domain.StudyLog{ID: "log-2", UserID: "user-2", ProjectID: "proj-2", StudiedAt: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC), Minutes: 30},
+	)
+	goalRepo := newMockGoalRepository()
+	goalRepo.goals = append(goalRepo.goals, &domain.Goal{ID: "goal-1", UserID: "user-1", ProjectID: "proj-1", TargetMinutesPerWeek: 300, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	noteRepo := newMockNoteRepository()
+	noteRepo.notes["note-1"] = &domain.Note{ID: "note-1", ProjectID: "proj-1", UserID: "user-1", Title: "Limits", Content: "epsilon-delta", Tags: []string{"calculus"}}
+	noteLinkRepo := newMockNoteLinkRepository(noteRepo, studyLogRepo)
+	noteLinkRepo.links[[2]string{"note-1", "log-1"}] = true
+	return usecase.NewTakeoutUsecase(userRepo, projectRepo, studyLogRepo, goalRepo, noteRepo, newMockActivityTypeRepository(), noteLinkRepo, newMockMilestoneRepository())
+}
+
+func readTakeout(t *testing.T, uc *usecase.TakeoutUsecase, userID string) map[string]string {
+	t.Helper()
+	takeout, err := uc.PrepareTakeout(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := takeout.WriteTo(context.Background(), zw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func TestTakeout_Manifest(t *testing.T) {
+	files := readTakeout(t, setupTakeoutTest(), "user-1")
+
+	var manifest struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		UserID        string `json:"userId"`
+		Files         []struct {
+			Path    string `json:"path"`
+			Records int    `json:"records"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	if manifest.SchemaVersion != usecase.TakeoutSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", usecase.TakeoutSchemaVersion, manifest.SchemaVersion)
+	}
+	if manifest.UserID != "user-1" {
+		t.Errorf("expected userId 'user-1', got '%s'", manifest.UserID)
+	}
+	for _, f := range manifest.Files {
+		if _, ok := files[f.Path]; !ok {
+			t.Errorf("manifest lists missing file %s", f.Path)
+		}
+	}
+	if len(manifest.Files) != len(files)-1 {
+		t.Errorf("expected manifest to list %d files, got %d", len(files)-1, len(manifest.Files))
+	}
+}
+
+func TestTakeout_OnlyContainsUsersData(t *testing.T) {
+	files := readTakeout(t, setupTakeoutTest(), "user-1")
+
+	var logs []map[string]any
+	if err := json.Unmarshal([]byte(files["study_logs.json"]), &logs); err != nil {
+		t.Fatalf("failed to parse study_logs.json: %v", err)
+	}
+	if len(logs) != 1 || logs[0]["id"] != "log-1" {
+		t.Errorf("expected only log-1, got %v", logs)
+	}
+	if strings.Contains(files["profile.json"], "password") {
+		t.Error("expected profile not to contain credentials")
+	}
+}
+
+func TestTakeout_StudyLogsCSV(t *testing.T) {
+	files := readTakeout(t, setupTakeoutTest(), "user-1")
+
+	records, err := csv.NewReader(strings.NewReader(files["study_logs.csv"])).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse study_logs.csv: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d rows", len(records))
+	}
+	row := records[1]
+	if row[2] != "Math/Physics" {
+		t.Errorf("expected project name, got '%s'", row[2])
+	}
+	if row[3] != "2024-01-15T23:30:00+09:00" {
+		t.Errorf("expected studied_at in the user's timezone, got '%s'", row[3])
+	}
+	if row[5] != "chapter 1, part 2" {
+		t.Errorf("expected note to survive CSV quoting, got '%s'", row[5])
+	}
+}
+
+func TestTakeout_StudyLogsAcrossPages(t *testing.T) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}
+	studyLogRepo := newMockStudyLogRepository()
+	// More logs than fit in two pages, some sharing a study time so the cursor has to break ties by ID.
+	const count = 1201
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range count {
+		studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+			ID:        fmt.Sprintf("log-%04d", i),
+			UserID:    "user-1",
+			ProjectID: "proj-1",
+			StudiedAt: start.Add(time.Duration(i/2) * time.Hour),
+			Minutes:   30,
+		})
+	}
+	uc := usecase.NewTakeoutUsecase(userRepo, newMockProjectRepository(), studyLogRepo, newMockGoalRepository(), newMockNoteRepository(),
+		newMockActivityTypeRepository(), newMockNoteLinkRepository(newMockNoteRepository(), studyLogRepo), newMockMilestoneRepository())
+	files := readTakeout(t, uc, "user-1")
+
+	var logs []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(files["study_logs.json"]), &logs); err != nil {
+		t.Fatalf("failed to parse study_logs.json: %v", err)
+	}
+	seen := make(map[string]bool, len(logs))
+	for _, l := range logs {
+		seen[l.ID] = true
+	}
+	if len(logs) != count || len(seen) != count {
+		t.Errorf("expected %d distinct logs in study_logs.json, got %d (%d distinct)", count, len(logs), len(seen))
+	}
+	records, err := csv.NewReader(strings.NewReader(files["study_logs.csv"])).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse study_logs.csv: %v", err)
+	}
+	if len(records) != count+1 {
+		t.Errorf("expected header and %d rows in study_logs.csv, got %d rows", count, len(records))
+	}
+}
+
+func TestTakeout_NotesAsMarkdown(t *testing.T) {
+	files := readTakeout(t, setupTakeoutTest(), "user-1")
+
+	md, ok := files["notes/Math_Physics/Limits-note-1.md"]
+	if !ok {
+		t.Fatalf("expected note Markdown file, got files %v", keys(files))
+	}
+	if !strings.HasPrefix(md, "---\n") || !strings.Contains(md, "# Limits\n\nepsilon-delta\n") {
+		t.Errorf("unexpected Markdown:\n%s", md)
+	}
+	if !strings.Contains(md, `tags: ["calculus"]`) {
+		t.Errorf("expected tags in front matter:\n%s", md)
+	}
+}
+
+func TestTakeout_NoteStudyLogLinks(t *testing.T) {
+	files := readTakeout(t, setupTakeoutTest(), "user-1")
+
+	var notes []struct {
+		ID          string   `json:"id"`
+		StudyLogIDs []string `json:"studyLogIds"`
+	}
+	if err := json.Unmarshal([]byte(files["notes.json"]), &notes); err != nil {
+		t.Fatalf("failed to parse notes.json: %v", err)
+	}
+	if len(notes) != 1 || len(notes[0].StudyLogIDs) != 1 || notes[0].StudyLogIDs[0] != "log-1" {
+		t.Errorf("expected note-1 to be linked to log-1, got %+v", notes)
+	}
+}
+
+func TestTakeout_UserNotFound(t *testing.T) {
+	_, err := setupTakeoutTest().PrepareTakeout(context.Background(), "missing")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func keys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
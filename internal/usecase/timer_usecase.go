@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// TimerUsecase provides methods for timing study sessions live on the server.
+type TimerUsecase struct {
+	timerRepo       port.TimerRepository
+	userRepo        port.UserRepository
+	projectRepo     port.ProjectRepository
+	studyLogUsecase *StudyLogUsecase
+	memberRepo      port.ProjectMemberRepository
+}
+
+// NewTimerUsecase creates a new TimerUsecase.
+func NewTimerUsecase(
+	timerRepo port.TimerRepository,
+	userRepo port.UserRepository,
+	projectRepo port.ProjectRepository,
+	studyLogUsecase *StudyLogUsecase,
+	memberRepo port.ProjectMemberRepository,
+) *TimerUsecase {
+	return &TimerUsecase{
+		timerRepo:       timerRepo,
+		userRepo:        userRepo,
+		projectRepo:     projectRepo,
+		studyLogUsecase: studyLogUsecase,
+		memberRepo:      memberRepo,
+	}
+}
+
+// StartTimer starts a timer for a project the user owns or has joined as an editor. A user can have only one timer at a time.
+func (u *TimerUsecase) StartTimer(ctx context.Context, userID, projectID, note string) (*domain.Timer, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	project, err := findWritableProject(ctx, u.projectRepo, u.memberRepo, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.CheckRecordable(); err != nil {
+		return nil, err
+	}
+	if _, err := u.activeTimer(ctx, userID); err == nil {
+		return nil, domain.ErrConflict("a timer is already active; stop it first")
+	} else if !domain.IsNotFound(err) {
+		return nil, err
+	}
+
+	timer, err := domain.NewTimer(userID, projectID, note, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.timerRepo.Create(ctx, timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// GetTimer returns the user's active timer.
+func (u *TimerUsecase) GetTimer(ctx context.Context, userID string) (*domain.Timer, error) {
+	return u.activeTimer(ctx, userID)
+}
+
+// PauseTimer pauses the user's running timer.
+func (u *TimerUsecase) PauseTimer(ctx context.Context, userID string) (*domain.Timer, error) {
+	timer, err := u.activeTimer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := timer.Pause(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.timerRepo.Update(ctx, timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// ResumeTimer resumes the user's paused timer.
+func (u *TimerUsecase) ResumeTimer(ctx context.Context, userID string) (*domain.Timer, error) {
+	timer, err := u.activeTimer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := timer.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.timerRepo.Update(ctx, timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// StopTimer stops the user's timer and records the time it ran as a study log.
+// A non-nil note replaces the note given when the timer was started.
+func (u *TimerUsecase) StopTimer(ctx context.Context, userID string, note *string) (*domain.StudyLog, error) {
+	timer, err := u.timerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if note != nil {
+		timer.Note = *note
+	}
+	log, discarded, err := u.stop(ctx, timer, time.Now())
+	if discarded {
+		return nil, domain.ErrConflict("timer expired and could not be recorded, so it was discarded: " + err.Error())
+	}
+	return log, err
+}
+
+// DiscardTimer deletes the user's timer without recording a study log.
+func (u *TimerUsecase) DiscardTimer(ctx context.Context, userID string) error {
+	timer, err := u.timerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return u.timerRepo.Delete(ctx, timer)
+}
+
+// DiscardedTimer is an expired timer that could not be recorded as a study log and was deleted.
+type DiscardedTimer struct {
+	UserID    string
+	ProjectID string
+	// Reason says why the timer could not be recorded.
+	Reason string
+}
+
+// StopExpiredTimers stops every timer that has run for domain.MaxTimerDuration, recording
+// a study log for each. Timers that cannot be recorded are discarded and returned with the reason.
+func (u *TimerUsecase) StopExpiredTimers(ctx context.Context) (int, []DiscardedTimer, error) {
+	now := time.Now()
+	timers, err := u.timerRepo.FindExpired(ctx, now)
+	if err != nil {
+		return 0, nil, err
+	}
+	stopped := 0
+	var discarded []DiscardedTimer
+	var errs []error
+	for _, timer := range timers {
+		_, wasDiscarded, err := u.stop(ctx, timer, now)
+		switch {
+		case wasDiscarded:
+			discarded = append(discarded, DiscardedTimer{UserID: timer.UserID, ProjectID: timer.ProjectID, Reason: err.Error()})
+		case err == nil:
+			stopped++
+		case !domain.IsNotFound(err):
+			// A not-found error means it was stopped concurrently by its user.
+			errs = append(errs, err)
+		}
+	}
+	return stopped, discarded, errors.Join(errs...)
+}
+
+// activeTimer returns the user's timer, first stopping it if it has expired,
+// in which case a not-found error is returned.
+func (u *TimerUsecase) activeTimer(ctx context.Context, userID string) (*domain.Timer, error) {
+	timer, err := u.timerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !timer.IsExpired(now) {
+		return timer, nil
+	}
+	if _, discarded, err := u.stop(ctx, timer, now); err != nil && !discarded && !domain.IsNotFound(err) {
+		return nil, err
+	}
+	return nil, domain.ErrNotFound("timer")
+}
+
+// stop removes the timer and records it as a study log, which starts when the timer started and is
+// cut short where the user's next log starts and at the daily limit. If the log cannot be recorded,
+// the timer is put back so the timed session is not lost. An expired timer could then never be
+// stopped, so it stays deleted instead and discarded is set, with err saying why.
+func (u *TimerUsecase) stop(ctx context.Context, timer *domain.Timer, now time.Time) (log *domain.StudyLog, discarded bool, err error) {
+	minutes := timer.MinutesAt(now)
+	if minutes < 1 {
+		return nil, false, domain.ErrValidation("timer has run for less than a minute; discard it instead")
+	}
+	if err := u.timerRepo.Delete(ctx, timer); err != nil {
+		return nil, false, err
+	}
+	log, err = u.record(ctx, timer, minutes)
+	if err == nil {
+		return log, false, nil
+	}
+	var domainErr *domain.Error
+	if timer.IsExpired(now) && errors.As(err, &domainErr) {
+		return nil, true, err
+	}
+	if restoreErr := u.timerRepo.Create(ctx, timer); restoreErr != nil {
+		return nil, false, errors.Join(err, restoreErr)
+	}
+	return nil, false, err
+}
+
+// record creates the study log of a stopped timer that ran for the given minutes.
+func (u *TimerUsecase) record(ctx context.Context, timer *domain.Timer, minutes int) (*domain.StudyLog, error) {
+	free, err := u.studyLogUsecase.freeMinutes(ctx, timer.UserID, timer.StartedAt, minutes)
+	if err != nil {
+		return nil, err
+	}
+	if free < 1 {
+		return nil, domain.ErrConflict("another study log covers the time the timer started, or the daily limit is reached")
+	}
+	return u.studyLogUsecase.CreateStudyLog(ctx, timer.UserID, timer.ProjectID, "", timer.StartedAt, free, timer.Note)
+}
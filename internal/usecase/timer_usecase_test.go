@@ -0,0 +1,275 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupTimerTest() (*usecase.TimerUsecase, *mockTimerRepository, *mockStudyLogRepository) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo := newMockProjectRepository()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-2", Name: "Physics"}
+	studyLogRepo := newMockStudyLogRepository()
+	timerRepo := newMockTimerRepository()
+	studyLogUC := usecase.NewStudyLogUsecase(studyLogRepo, userRepo, projectRepo, newMockActivityTypeRepository(), newMockNoteLinkRepository(newMockNoteRepository(), studyLogRepo), newMockProjectMemberRepository())
+	return usecase.NewTimerUsecase(timerRepo, userRepo, projectRepo, studyLogUC, newMockProjectMemberRepository()), timerRepo, studyLogRepo
+}
+
+// startedTimer stores a running timer for user-1 that was started the given time ago.
+func startedTimer(timerRepo *mockTimerRepository, ago time.Duration) *domain.Timer {
+	startedAt := time.Now().Add(-ago)
+	timer, _ := domain.NewTimer("user-1", "proj-1", "chapter 1", startedAt)
+	timerRepo.timers["user-1"] = timer
+	return timer
+}
+
+func TestStartTimer_Success(t *testing.T) {
+	uc, timerRepo, _ := setupTimerTest()
+
+	timer, err := uc.StartTimer(context.Background(), "user-1", "proj-1", "chapter 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !timer.IsRunning() || timer.ProjectID != "proj-1" {
+		t.Errorf("unexpected timer: %+v", timer)
+	}
+	if _, ok := timerRepo.timers["user-1"]; !ok {
+		t.Error("expected the timer to be saved")
+	}
+}
+
+func TestStartTimer_AlreadyActive(t *testing.T) {
+	uc, timerRepo, _ := setupTimerTest()
+	startedTimer(timerRepo, 10*time.Minute)
+
+	_, err := uc.StartTimer(context.Background(), "user-1", "proj-1", "")
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestStartTimer_OtherUsersProject(t *testing.T) {
+	uc, _, _ := setupTimerTest()
+
+	_, err := uc.StartTimer(context.Background(), "user-1", "proj-2", "")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPauseAndResumeTimer(t *testing.T) {
+	uc, timerRepo, _ := setupTimerTest()
+	startedTimer(timerRepo, 10*time.Minute)
+
+	paused, err := uc.PauseTimer(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if paused.IsRunning() || timerRepo.timers["user-1"].IsRunning() {
+		t.Error("expected the saved timer to be paused")
+	}
+	if _, err := uc.PauseTimer(context.Background(), "user-1"); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error when pausing twice, got: %v", err)
+	}
+
+	resumed, err := uc.ResumeTimer(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resumed.IsRunning() {
+		t.Error("expected the timer to be running")
+	}
+}
+
+func TestStopTimer_CreatesStudyLog(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	timer := startedTimer(timerRepo, 25*time.Minute)
+	note := "chapters 1-2"
+
+	log, err := uc.StopTimer(context.Background(), "user-1", &note)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Minutes != 25 || log.ProjectID != "proj-1" || log.Note != "chapters 1-2" {
+		t.Errorf("unexpected study log: %+v", log)
+	}
+	if !log.StudiedAt.Equal(timer.StartedAt) {
+		t.Errorf("expected the log to start when the timer started, got %v", log.StudiedAt)
+	}
+	if len(studyLogRepo.logs) != 1 {
+		t.Errorf("expected 1 study log, got %d", len(studyLogRepo.logs))
+	}
+	if len(timerRepo.timers) != 0 {
+		t.Error("expected the timer to be removed")
+	}
+}
+
+func TestStopTimer_LessThanAMinute(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	startedTimer(timerRepo, 10*time.Second)
+
+	_, err := uc.StopTimer(context.Background(), "user-1", nil)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+	if len(timerRepo.timers) != 1 || len(studyLogRepo.logs) != 0 {
+		t.Error("expected the timer to be kept and no log to be created")
+	}
+}
+
+func TestStopTimer_NoTimer(t *testing.T) {
+	uc, _, _ := setupTimerTest()
+
+	_, err := uc.StopTimer(context.Background(), "user-1", nil)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestDiscardTimer(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	startedTimer(timerRepo, 30*time.Minute)
+
+	if err := uc.DiscardTimer(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(timerRepo.timers) != 0 || len(studyLogRepo.logs) != 0 {
+		t.Error("expected the timer to be removed without a study log")
+	}
+}
+
+func TestGetTimer_ExpiredIsStopped(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	startedTimer(timerRepo, 30*time.Hour)
+
+	_, err := uc.GetTimer(context.Background(), "user-1")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	if len(studyLogRepo.logs) != 1 || studyLogRepo.logs[0].Minutes != 1440 {
+		t.Fatalf("expected a 1440 minute study log, got %+v", studyLogRepo.logs)
+	}
+
+	if _, err := uc.StartTimer(context.Background(), "user-1", "proj-1", ""); err != nil {
+		t.Errorf("expected a new timer to start, got: %v", err)
+	}
+}
+
+func TestStopExpiredTimers(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	startedTimer(timerRepo, 25*time.Hour)
+	paused, _ := domain.NewTimer("user-2", "proj-2", "", time.Now().Add(-48*time.Hour))
+	_ = paused.Pause(paused.StartedAt.Add(time.Hour))
+	timerRepo.timers["user-2"] = paused
+
+	stopped, discarded, err := uc.StopExpiredTimers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stopped != 1 || len(discarded) != 0 {
+		t.Errorf("expected 1 timer to be stopped, got %d stopped and %v discarded", stopped, discarded)
+	}
+	if _, ok := timerRepo.timers["user-2"]; !ok {
+		t.Error("expected a paused timer under the limit to be kept")
+	}
+	if len(studyLogRepo.logs) != 1 || studyLogRepo.logs[0].Minutes != 1440 {
+		t.Errorf("expected a 1440 minute study log, got %+v", studyLogRepo.logs)
+	}
+}
+
+func TestStopTimer_ShortenedBeforeNextStudyLog(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	timer := startedTimer(timerRepo, time.Hour)
+	// Logged by hand while the timer was running.
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: timer.StartedAt.Add(30 * time.Minute), Minutes: 15,
+	})
+
+	log, err := uc.StopTimer(context.Background(), "user-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Minutes != 30 || !log.StudiedAt.Equal(timer.StartedAt) {
+		t.Errorf("expected a 30 minute log from the timer's start, got %+v", log)
+	}
+	if len(timerRepo.timers) != 0 {
+		t.Error("expected the timer to be removed")
+	}
+}
+
+func TestStopTimer_StartCovered(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	timer := startedTimer(timerRepo, time.Hour)
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: timer.StartedAt.Add(-10 * time.Minute), Minutes: 30,
+	})
+
+	_, err := uc.StopTimer(context.Background(), "user-1", nil)
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if len(timerRepo.timers) != 1 || len(studyLogRepo.logs) != 1 {
+		t.Error("expected the timer to be kept and no log to be created")
+	}
+}
+
+func TestStopTimer_ExpiredAndStartCovered(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	timer := startedTimer(timerRepo, 25*time.Hour)
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: timer.StartedAt, Minutes: 30,
+	})
+
+	_, err := uc.StopTimer(context.Background(), "user-1", nil)
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if len(timerRepo.timers) != 0 || len(studyLogRepo.logs) != 1 {
+		t.Error("expected the expired timer to be discarded without a log")
+	}
+	if _, err := uc.StartTimer(context.Background(), "user-1", "proj-1", ""); err != nil {
+		t.Errorf("expected a new timer to start, got: %v", err)
+	}
+}
+
+func TestStopExpiredTimers_UnrecordableTimers(t *testing.T) {
+	uc, timerRepo, studyLogRepo := setupTimerTest()
+	// user-1 studied for an hour earlier on the day the timer started, so only 1380 minutes are left.
+	startedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	timer, _ := domain.NewTimer("user-1", "proj-1", "", startedAt)
+	timerRepo.timers["user-1"] = timer
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{
+		ID: "log-1", UserID: "user-1", ProjectID: "proj-1", StudiedAt: startedAt.Add(-2 * time.Hour), Minutes: 60,
+	})
+	// user-2's timer cannot be recorded at all.
+	other, _ := domain.NewTimer("user-2", "proj-1", "", startedAt)
+	timerRepo.timers["user-2"] = other
+
+	stopped, discarded, err := uc.StopExpiredTimers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stopped != 1 {
+		t.Errorf("expected 1 timer to be stopped, got %d", stopped)
+	}
+	if len(discarded) != 1 || discarded[0].UserID != "user-2" || discarded[0].Reason == "" {
+		t.Errorf("expected user-2's timer to be discarded with a reason, got %+v", discarded)
+	}
+	if len(timerRepo.timers) != 0 {
+		t.Error("expected both timers to be removed")
+	}
+	if len(studyLogRepo.logs) != 2 || studyLogRepo.logs[1].Minutes != 1380 {
+		t.Errorf("expected a 1380 minute study log, got %+v", studyLogRepo.logs)
+	}
+
+	// Nothing is left to retry.
+	if stopped, discarded, err := uc.StopExpiredTimers(context.Background()); stopped != 0 || len(discarded) != 0 || err != nil {
+		t.Errorf("expected nothing to do, got %d, %v, %v", stopped, discarded, err)
+	}
+}
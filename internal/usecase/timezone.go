@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// userLocation returns the time zone used to interpret calendar dates for a user:
+// the explicit timezone when given, otherwise the user's preferred timezone.
+func userLocation(ctx context.Context, userRepo port.UserRepository, userID, timezone string) (*time.Location, error) {
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if timezone != "" {
+		return domain.LoadLocation(timezone)
+	}
+	return user.Preferences.Location(), nil
+}
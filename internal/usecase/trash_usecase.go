@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// TrashUsecase provides methods for the trash that deleted projects, notes and study logs are moved to.
+// Items are restored through the usecase of their kind.
+type TrashUsecase struct {
+	projectRepo  port.ProjectRepository
+	noteRepo     port.NoteRepository
+	studyLogRepo port.StudyLogRepository
+	userRepo     port.UserRepository
+	retention    time.Duration
+}
+
+// NewTrashUsecase creates a new TrashUsecase. Trashed items are purged once they have been in the
+// trash for longer than retention.
+func NewTrashUsecase(
+	projectRepo port.ProjectRepository,
+	noteRepo port.NoteRepository,
+	studyLogRepo port.StudyLogRepository,
+	userRepo port.UserRepository,
+	retention time.Duration,
+) *TrashUsecase {
+	return &TrashUsecase{
+		projectRepo:  projectRepo,
+		noteRepo:     noteRepo,
+		studyLogRepo: studyLogRepo,
+		userRepo:     userRepo,
+		retention:    retention,
+	}
+}
+
+// ListTrash returns the user's trashed items, most recently deleted first.
+func (u *TrashUsecase) ListTrash(ctx context.Context, userID string) (*domain.Trash, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	projects, err := u.projectRepo.FindTrashedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := u.noteRepo.FindTrashedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := u.studyLogRepo.FindTrashedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Trash{Projects: projects, Notes: notes, StudyLogs: logs}, nil
+}
+
+// PurgeTrash permanently deletes the items of every user that have been in the trash for longer than
+// the retention period, and returns how many projects, notes and study logs were deleted. The contents
+// of a purged project are deleted with it and not counted.
+func (u *TrashUsecase) PurgeTrash(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-u.retention)
+	projects, err := u.projectRepo.PurgeTrashed(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	notes, err := u.noteRepo.PurgeTrashed(ctx, before)
+	if err != nil {
+		return projects, err
+	}
+	logs, err := u.studyLogRepo.PurgeTrashed(ctx, before)
+	if err != nil {
+		return projects + notes, err
+	}
+	return projects + notes + logs, nil
+}
+
+// checkProjectNotTrashed returns a conflict error if the project is in the trash, since items
+// cannot be restored into it.
+func checkProjectNotTrashed(ctx context.Context, projectRepo port.ProjectRepository, projectID string) error {
+	if _, err := projectRepo.FindByID(ctx, projectID); err != nil {
+		if domain.IsNotFound(err) {
+			return domain.ErrConflict("the project is in the trash; restore the project first")
+		}
+		return err
+	}
+	return nil
+}
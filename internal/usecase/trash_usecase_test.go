@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupTrashTest() (*usecase.TrashUsecase, *mockProjectRepository, *mockNoteRepository, *mockStudyLogRepository) {
+	userRepo := newMockUserRepository()
+	createTestUser(userRepo, "user-1", "Alice")
+	projectRepo := newMockProjectRepository()
+	noteRepo := newMockNoteRepository()
+	studyLogRepo := newMockStudyLogRepository()
+	uc := usecase.NewTrashUsecase(projectRepo, noteRepo, studyLogRepo, userRepo, 30*24*time.Hour)
+	return uc, projectRepo, noteRepo, studyLogRepo
+}
+
+func TestListTrash(t *testing.T) {
+	uc, projectRepo, noteRepo, studyLogRepo := setupTrashTest()
+	now := time.Now()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	projectRepo.projects["proj-2"] = &domain.Project{ID: "proj-2", UserID: "user-2", Name: "Physics"}
+	noteRepo.notes["note-1"] = &domain.Note{ID: "note-1", ProjectID: "proj-3", UserID: "user-1", Title: "Limits"}
+	studyLogRepo.logs = append(studyLogRepo.logs,
+		&domain.StudyLog{ID: "log-1", UserID: "user-1", ProjectID: "proj-3", Minutes: 30},
+		&domain.StudyLog{ID: "log-2", UserID: "user-1", ProjectID: "proj-3", Minutes: 45},
+	)
+	_ = projectRepo.Trash(context.Background(), "proj-1", now)
+	_ = projectRepo.Trash(context.Background(), "proj-2", now)
+	_ = noteRepo.Trash(context.Background(), "note-1", now)
+	_ = studyLogRepo.Trash(context.Background(), "log-1", now.Add(-time.Hour))
+	_ = studyLogRepo.Trash(context.Background(), "log-2", now)
+
+	trash, err := uc.ListTrash(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trash.Projects) != 1 || trash.Projects[0].ID != "proj-1" {
+		t.Errorf("expected only the user's trashed project, got %+v", trash.Projects)
+	}
+	if len(trash.Notes) != 1 {
+		t.Errorf("expected 1 trashed note, got %d", len(trash.Notes))
+	}
+	if len(trash.StudyLogs) != 2 || trash.StudyLogs[0].ID != "log-2" {
+		t.Errorf("expected the most recently trashed log first, got %+v", trash.StudyLogs)
+	}
+}
+
+func TestListTrash_UserNotFound(t *testing.T) {
+	uc, _, _, _ := setupTrashTest()
+
+	_, err := uc.ListTrash(context.Background(), "missing")
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	uc, projectRepo, noteRepo, studyLogRepo := setupTrashTest()
+	now := time.Now()
+	projectRepo.projects["proj-1"] = &domain.Project{ID: "proj-1", UserID: "user-1", Name: "Math"}
+	noteRepo.notes["note-1"] = &domain.Note{ID: "note-1", ProjectID: "proj-2", UserID: "user-1", Title: "Limits"}
+	studyLogRepo.logs = append(studyLogRepo.logs, &domain.StudyLog{ID: "log-1", UserID: "user-1", ProjectID: "proj-2", Minutes: 30})
+	_ = projectRepo.Trash(context.Background(), "proj-1", now.Add(-31*24*time.Hour))
+	_ = noteRepo.Trash(context.Background(), "note-1", now.Add(-31*24*time.Hour))
+	_ = studyLogRepo.Trash(context.Background(), "log-1", now.Add(-29*24*time.Hour))
+
+	purged, err := uc.PurgeTrash(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("expected 2 items to be purged, got %d", purged)
+	}
+	if len(projectRepo.trashed) != 0 || len(noteRepo.trashed) != 0 {
+		t.Error("expected items past the retention period to be purged")
+	}
+	if len(studyLogRepo.trashed) != 1 {
+		t.Error("expected a log within the retention period to be kept")
+	}
+}
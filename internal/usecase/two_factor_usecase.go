@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase/port"
+)
+
+// TwoFactorUsecase provides methods for enrolling in and removing TOTP two-factor authentication.
+type TwoFactorUsecase struct {
+	userRepo  port.UserRepository
+	totp      port.TOTPProvider
+	generator port.OpaqueTokenGenerator
+}
+
+// NewTwoFactorUsecase creates a new TwoFactorUsecase.
+func NewTwoFactorUsecase(userRepo port.UserRepository, totp port.TOTPProvider, generator port.OpaqueTokenGenerator) *TwoFactorUsecase {
+	return &TwoFactorUsecase{
+		userRepo:  userRepo,
+		totp:      totp,
+		generator: generator,
+	}
+}
+
+// StartTOTPEnrollment generates a new TOTP secret for the user. Two-factor authentication
+// stays disabled until ConfirmTOTP succeeds; starting again replaces an unconfirmed secret.
+func (u *TwoFactorUsecase) StartTOTPEnrollment(ctx context.Context, userID string) (*domain.TOTPEnrollment, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := u.totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := user.StartTOTPEnrollment(secret); err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return &domain.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: u.totp.ProvisioningURI(secret, user.Email),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication once the user proves the authenticator app
+// works, and returns one-time recovery codes. The codes are only stored as hashes.
+func (u *TwoFactorUsecase) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor.TOTPEnabled {
+		return nil, domain.ErrConflict("two-factor authentication is already enabled")
+	}
+	if user.TwoFactor.TOTPSecret == "" {
+		return nil, domain.ErrValidation("two-factor enrollment has not been started")
+	}
+	step, ok := u.totp.Validate(user.TwoFactor.TOTPSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, domain.ErrValidation("invalid verification code")
+	}
+
+	codes, err := u.totp.GenerateRecoveryCodes(domain.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = u.generator.Hash(normalizeRecoveryCode(c))
+	}
+	if err := user.EnableTOTP(step, hashes); err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns off two-factor authentication after checking a current TOTP code or a recovery code.
+func (u *TwoFactorUsecase) DisableTOTP(ctx context.Context, userID, code string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactor.TOTPEnabled {
+		return domain.ErrValidation("two-factor authentication is not enabled")
+	}
+	if !verifySecondFactor(user, code, u.totp, u.generator) {
+		return domain.ErrValidation("invalid verification code")
+	}
+	user.DisableTOTP()
+	return u.userRepo.Update(ctx, user)
+}
+
+// verifySecondFactor checks a TOTP code or an unused recovery code and records its use on the user.
+// The caller must save the user afterwards so the code cannot be used again.
+func verifySecondFactor(user *domain.User, code string, totp port.TOTPProvider, generator port.OpaqueTokenGenerator) bool {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(user.TwoFactor.TOTPSecret, code, time.Now()); ok {
+		return user.AcceptTOTPStep(step)
+	}
+	return user.UseRecoveryCode(generator.Hash(normalizeRecoveryCode(code)))
+}
+
+// normalizeRecoveryCode lets users type recovery codes in any case and with or without separators.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shnaki/studytrack-api/internal/domain"
+	"github.com/shnaki/studytrack-api/internal/usecase"
+)
+
+func setupTwoFactorTest() (*usecase.TwoFactorUsecase, *mockUserRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}
+	uc := usecase.NewTwoFactorUsecase(userRepo, mockTOTPProvider{}, &mockOpaqueTokenGenerator{})
+	return uc, userRepo
+}
+
+func TestStartTOTPEnrollment(t *testing.T) {
+	uc, userRepo := setupTwoFactorTest()
+
+	enrollment, err := uc.StartTOTPEnrollment(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enrollment.Secret != "SECRET" {
+		t.Errorf("expected secret 'SECRET', got '%s'", enrollment.Secret)
+	}
+	if enrollment.ProvisioningURI != "otpauth://totp/Test:alice@example.com?secret=SECRET" {
+		t.Errorf("unexpected provisioning URI: %s", enrollment.ProvisioningURI)
+	}
+	if userRepo.users["user-1"].TwoFactor.TOTPEnabled {
+		t.Error("expected TOTP to stay disabled until confirmed")
+	}
+}
+
+func TestConfirmTOTP_Success(t *testing.T) {
+	uc, userRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	_, _ = uc.StartTOTPEnrollment(ctx, "user-1")
+
+	codes, err := uc.ConfirmTOTP(ctx, "user-1", "000042")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != domain.RecoveryCodeCount {
+		t.Errorf("expected %d recovery codes, got %d", domain.RecoveryCodeCount, len(codes))
+	}
+	tf := userRepo.users["user-1"].TwoFactor
+	if !tf.TOTPEnabled {
+		t.Error("expected TOTP to be enabled")
+	}
+	if tf.TOTPLastStep != 42 {
+		t.Errorf("expected last step 42, got %d", tf.TOTPLastStep)
+	}
+	if tf.RecoveryCodeHashes[0] != "hash:code0" {
+		t.Errorf("expected recovery codes to be stored hashed, got '%s'", tf.RecoveryCodeHashes[0])
+	}
+}
+
+func TestConfirmTOTP_InvalidCode(t *testing.T) {
+	uc, _ := setupTwoFactorTest()
+	ctx := context.Background()
+	_, _ = uc.StartTOTPEnrollment(ctx, "user-1")
+
+	_, err := uc.ConfirmTOTP(ctx, "user-1", "bad")
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestConfirmTOTP_NotStarted(t *testing.T) {
+	uc, _ := setupTwoFactorTest()
+
+	_, err := uc.ConfirmTOTP(context.Background(), "user-1", "000042")
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func TestStartTOTPEnrollment_AlreadyEnabled(t *testing.T) {
+	uc, _ := setupTwoFactorTest()
+	ctx := context.Background()
+	_, _ = uc.StartTOTPEnrollment(ctx, "user-1")
+	_, _ = uc.ConfirmTOTP(ctx, "user-1", "000042")
+
+	_, err := uc.StartTOTPEnrollment(ctx, "user-1")
+	if !domain.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	uc, userRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	_, _ = uc.StartTOTPEnrollment(ctx, "user-1")
+	_, _ = uc.ConfirmTOTP(ctx, "user-1", "000042")
+
+	if err := uc.DisableTOTP(ctx, "user-1", "000042"); !domain.IsValidation(err) {
+		t.Errorf("expected replayed code to be rejected, got: %v", err)
+	}
+	if err := uc.DisableTOTP(ctx, "user-1", "000043"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userRepo.users["user-1"].TwoFactor.TOTPEnabled || userRepo.users["user-1"].TwoFactor.TOTPSecret != "" {
+		t.Error("expected TOTP to be removed")
+	}
+}
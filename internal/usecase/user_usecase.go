@@ -11,15 +11,24 @@ import (
 
 type UserUsecase struct {
 	userRepo port.UserRepository
+	hasher   port.PasswordHasher
+	tokens   port.TokenManager
 }
 
-func NewUserUsecase(userRepo port.UserRepository) *UserUsecase {
-	return &UserUsecase{userRepo: userRepo}
+func NewUserUsecase(userRepo port.UserRepository, hasher port.PasswordHasher, tokens port.TokenManager) *UserUsecase {
+	return &UserUsecase{userRepo: userRepo, hasher: hasher, tokens: tokens}
 }
 
-func (u *UserUsecase) CreateUser(ctx context.Context, name string) (*domain.User, error) {
+func (u *UserUsecase) CreateUser(ctx context.Context, name, email, password string) (*domain.User, error) {
+	if err := domain.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+	hash, err := u.hasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
 	id := uuid.New().String()
-	user, err := domain.NewUser(id, name)
+	user, err := domain.NewUser(id, name, email, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -32,3 +41,46 @@ func (u *UserUsecase) CreateUser(ctx context.Context, name string) (*domain.User
 func (u *UserUsecase) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	return u.userRepo.FindByID(ctx, id)
 }
+
+func (u *UserUsecase) UpdateUser(ctx context.Context, id, name string, preferences domain.UserPreferences) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.Update(name, preferences); err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *UserUsecase) PreviewUserDeletion(ctx context.Context, id string) (*domain.DeletionImpact, error) {
+	impact, err := u.deletionImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := issueDeletionConfirmation(u.tokens, id, id, impact); err != nil {
+		return nil, err
+	}
+	return impact, nil
+}
+
+func (u *UserUsecase) DeleteUser(ctx context.Context, id, confirmationToken string, force bool) (*domain.UserDeletionSummary, error) {
+	impact, err := u.deletionImpact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDeletionConfirmation(u.tokens, id, id, confirmationToken, impact, force); err != nil {
+		return nil, err
+	}
+	return u.userRepo.Delete(ctx, id)
+}
+
+func (u *UserUsecase) deletionImpact(ctx context.Context, id string) (*domain.DeletionImpact, error) {
+	if _, err := u.userRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.userRepo.DeletionImpact(ctx, id)
+}
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shnaki/studytrack-api/internal/domain"
 	"github.com/shnaki/studytrack-api/internal/usecase"
@@ -10,9 +11,9 @@ import (
 
 func TestCreateUser_Success(t *testing.T) {
 	repo := newMockUserRepository()
-	uc := usecase.NewUserUsecase(repo)
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
 
-	user, err := uc.CreateUser(context.Background(), "Alice")
+	user, err := uc.CreateUser(context.Background(), "Alice", "alice@example.com", "password123")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -31,13 +32,32 @@ func TestCreateUser_Success(t *testing.T) {
 	if stored.Name != "Alice" {
 		t.Errorf("stored name mismatch")
 	}
+	if stored.PasswordHash != "hashed:password123" {
+		t.Errorf("expected password to be stored hashed, got '%s'", stored.PasswordHash)
+	}
+}
+
+func TestCreateUser_ShortPassword(t *testing.T) {
+	repo := newMockUserRepository()
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
+
+	_, err := uc.CreateUser(context.Background(), "Alice", "alice@example.com", "short")
+	if err == nil {
+		t.Fatal("expected error for short password")
+	}
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+	if len(repo.users) != 0 {
+		t.Errorf("expected no user to be stored, got %d", len(repo.users))
+	}
 }
 
 func TestCreateUser_EmptyName(t *testing.T) {
 	repo := newMockUserRepository()
-	uc := usecase.NewUserUsecase(repo)
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
 
-	_, err := uc.CreateUser(context.Background(), "")
+	_, err := uc.CreateUser(context.Background(), "", "alice@example.com", "password123")
 	if err == nil {
 		t.Fatal("expected error for empty name")
 	}
@@ -48,9 +68,9 @@ func TestCreateUser_EmptyName(t *testing.T) {
 
 func TestGetUser_Found(t *testing.T) {
 	repo := newMockUserRepository()
-	uc := usecase.NewUserUsecase(repo)
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
 
-	created, _ := uc.CreateUser(context.Background(), "Bob")
+	created, _ := uc.CreateUser(context.Background(), "Bob", "bob@example.com", "password123")
 	found, err := uc.GetUser(context.Background(), created.ID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -62,7 +82,7 @@ func TestGetUser_Found(t *testing.T) {
 
 func TestGetUser_NotFound(t *testing.T) {
 	repo := newMockUserRepository()
-	uc := usecase.NewUserUsecase(repo)
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
 
 	_, err := uc.GetUser(context.Background(), "nonexistent")
 	if err == nil {
@@ -72,3 +92,73 @@ func TestGetUser_NotFound(t *testing.T) {
 		t.Errorf("expected not found error, got: %v", err)
 	}
 }
+
+func TestUpdateUser_Success(t *testing.T) {
+	repo := newMockUserRepository()
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
+	user, _ := uc.CreateUser(context.Background(), "Alice", "alice@example.com", "password123")
+
+	prefs := domain.UserPreferences{Timezone: "Asia/Tokyo", WeekStart: time.Sunday, Locale: "ja-JP", DefaultSessionMinutes: 25}
+	updated, err := uc.UpdateUser(context.Background(), user.ID, "Alice Smith", prefs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "Alice Smith" {
+		t.Errorf("expected name 'Alice Smith', got '%s'", updated.Name)
+	}
+	if repo.users[user.ID].Preferences != prefs {
+		t.Errorf("expected preferences to be stored, got %+v", repo.users[user.ID].Preferences)
+	}
+}
+
+func TestUpdateUser_InvalidTimezone(t *testing.T) {
+	repo := newMockUserRepository()
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
+	user, _ := uc.CreateUser(context.Background(), "Alice", "alice@example.com", "password123")
+
+	prefs := domain.DefaultUserPreferences()
+	prefs.Timezone = "Mars/Olympus"
+	_, err := uc.UpdateUser(context.Background(), user.ID, "Alice", prefs)
+	if !domain.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+	if repo.users[user.ID].Preferences.Timezone != "UTC" {
+		t.Error("expected preferences to be unchanged")
+	}
+}
+
+func TestDeleteUser_NotFound(t *testing.T) {
+	uc := usecase.NewUserUsecase(newMockUserRepository(), mockPasswordHasher{}, mockTokenManager{})
+
+	_, err := uc.DeleteUser(context.Background(), "missing", "", true)
+	if !domain.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestDeleteUser_RequiresConfirmation(t *testing.T) {
+	repo := newMockUserRepository()
+	createTestUser(repo, "user-1", "Alice")
+	uc := usecase.NewUserUsecase(repo, mockPasswordHasher{}, mockTokenManager{})
+	ctx := context.Background()
+
+	if _, err := uc.DeleteUser(ctx, "user-1", "", false); !domain.IsValidation(err) {
+		t.Errorf("expected validation error without confirmation, got: %v", err)
+	}
+	if _, err := uc.DeleteUser(ctx, "user-1", "stale", false); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error with an outdated token, got: %v", err)
+	}
+	impact, err := uc.PreviewUserDeletion(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.DeleteUser(ctx, "user-1", "delete:user-2:user-1:"+impact.Digest("user-1"), false); !domain.IsConflict(err) {
+		t.Errorf("expected conflict error with a token issued to another user, got: %v", err)
+	}
+	if _, err := uc.DeleteUser(ctx, "user-1", impact.ConfirmationToken, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.users["user-1"]; ok {
+		t.Error("expected user to be deleted")
+	}
+}